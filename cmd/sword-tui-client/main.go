@@ -0,0 +1,49 @@
+// sword-tui-client is the reference client for the editor plugin protocol.
+// It sends one request to a running `sword-tui -listen` and prints the
+// reply, so editor plugins can shell out to it instead of speaking the
+// socket protocol themselves:
+//
+//	sword-tui-client goto John 3:16
+//	sword-tui-client get Rom 8:28-30
+//	sword-tui-client search "living water"
+//	sword-tui-client play John 3 42.5
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"sword-tui/internal/remote"
+)
+
+func main() {
+	socket := flag.String("socket", remote.DefaultSocket(), "Socket of the running sword-tui")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: sword-tui-client [-socket path] goto|get|search|play <argument>")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() < 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	c, err := remote.Dial(*socket)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "sword-tui-client: %v (is sword-tui running with -listen?)\n", err)
+		os.Exit(1)
+	}
+	defer c.Close()
+
+	lines, err := c.Do(strings.ToUpper(flag.Arg(0)), strings.Join(flag.Args()[1:], " "))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "sword-tui-client: %v\n", err)
+		c.Close()
+		os.Exit(1)
+	}
+	for _, l := range lines {
+		fmt.Println(l)
+	}
+}
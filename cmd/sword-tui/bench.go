@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"runtime"
+	"sort"
+	"time"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/cache"
+	"sword-tui/internal/ui"
+)
+
+// benchResult is what `sword-tui bench -json` prints, for CI to track.
+type benchResult struct {
+	Translation   string  `json:"translation"`
+	Chapters      int     `json:"chapters"`
+	Verses        int     `json:"verses"`
+	Width         int     `json:"width"`
+	TotalMS       float64 `json:"total_ms"`
+	MeanMS        float64 `json:"mean_ms"`
+	P50MS         float64 `json:"p50_ms"`
+	P95MS         float64 `json:"p95_ms"`
+	MaxMS         float64 `json:"max_ms"`
+	AllocsPerChap uint64  `json:"allocs_per_chapter"`
+	BytesPerChap  uint64  `json:"bytes_per_chapter"`
+}
+
+// runBench implements
+//
+//	sword-tui bench [-translation KJV] [-n 200] [-width 100] [-seed 1] [-json]
+//
+// which formats random chapters of a cached translation the way the
+// reader does and reports timing and allocations. Loading the translation
+// is not part of the measurement.
+func runBench(args []string) int {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	translation := fs.String("translation", "", "cached translation to use (default: first cached)")
+	n := fs.Int("n", 200, "number of chapters to format")
+	width := fs.Int("width", 100, "content width in columns")
+	seed := fs.Int64("seed", 1, "random seed for chapter selection")
+	asJSON := fs.Bool("json", false, "print results as JSON")
+	fs.Parse(args)
+
+	if *n < 1 || *width < 20 {
+		fmt.Fprintln(os.Stderr, "Error: -n must be at least 1 and -width at least 20")
+		return 2
+	}
+
+	c, err := cache.NewCache()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if *translation == "" {
+		cached, err := c.ListCached()
+		if err != nil || len(cached) == 0 {
+			fmt.Fprintln(os.Stderr, "Error: no cached translations — download one in the cache manager (x) first")
+			return 1
+		}
+		*translation = cached[0]
+	}
+
+	all, err := c.LoadAll(*translation)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	type key struct{ book, chapter int }
+	chapters := map[key][]api.Verse{}
+	for _, v := range all {
+		k := key{v.Book, v.Chapter}
+		chapters[k] = append(chapters[k], v)
+	}
+	keys := make([]key, 0, len(chapters))
+	for k := range chapters {
+		keys = append(keys, k)
+	}
+	if len(keys) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: %s has no verses\n", *translation)
+		return 1
+	}
+	// Sort before sampling so a given seed picks the same chapters.
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].book != keys[j].book {
+			return keys[i].book < keys[j].book
+		}
+		return keys[i].chapter < keys[j].chapter
+	})
+
+	rng := rand.New(rand.NewSource(*seed))
+	type job struct {
+		verses    []api.Verse
+		name      string
+		chapter   int
+		highlight int
+	}
+	jobs := make([]job, *n)
+	verses := 0
+	for i := range jobs {
+		k := keys[rng.Intn(len(keys))]
+		vs := chapters[k]
+		name := fmt.Sprintf("Book %d", k.book)
+		if b, ok := api.CanonicalBook(k.book); ok {
+			name = b.Name
+		}
+		// Highlight one verse, as the reader usually has a selection.
+		jobs[i] = job{vs, name, k.chapter, vs[rng.Intn(len(vs))].Verse}
+		verses += len(vs)
+	}
+
+	durations := make([]time.Duration, len(jobs))
+	runtime.GC()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	for i, j := range jobs {
+		t := time.Now()
+		ui.RenderChapter(j.verses, j.name, j.chapter, *width, j.highlight, j.highlight)
+		durations[i] = time.Since(t)
+	}
+	total := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	ms := func(d time.Duration) float64 { return float64(d.Microseconds()) / 1000 }
+	pct := func(p float64) time.Duration { return durations[int(p*float64(len(durations)-1))] }
+
+	res := benchResult{
+		Translation:   *translation,
+		Chapters:      len(jobs),
+		Verses:        verses,
+		Width:         *width,
+		TotalMS:       ms(total),
+		MeanMS:        ms(total / time.Duration(len(jobs))),
+		P50MS:         ms(pct(0.50)),
+		P95MS:         ms(pct(0.95)),
+		MaxMS:         ms(durations[len(durations)-1]),
+		AllocsPerChap: (after.Mallocs - before.Mallocs) / uint64(len(jobs)),
+		BytesPerChap:  (after.TotalAlloc - before.TotalAlloc) / uint64(len(jobs)),
+	}
+
+	if *asJSON {
+		out, _ := json.MarshalIndent(res, "", "  ")
+		fmt.Println(string(out))
+		return 0
+	}
+	fmt.Printf("translation  %s (%d chapters, %d verses, width %d)\n", res.Translation, res.Chapters, res.Verses, res.Width)
+	fmt.Printf("total        %.1f ms\n", res.TotalMS)
+	fmt.Printf("per chapter  mean %.3f ms  p50 %.3f ms  p95 %.3f ms  max %.3f ms\n", res.MeanMS, res.P50MS, res.P95MS, res.MaxMS)
+	fmt.Printf("allocations  %d allocs, %d KiB per chapter\n", res.AllocsPerChap, res.BytesPerChap/1024)
+	return 0
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/cache"
+	"sword-tui/internal/settings"
+	"sword-tui/internal/ui"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/colorprofile"
+)
+
+// Results of a doctor check, most serious last.
+const (
+	checkOK = iota
+	checkWarn
+	checkFail
+)
+
+// checkMarks are printed before each check's result.
+var checkMarks = [...]string{checkOK: "✓", checkWarn: "!", checkFail: "✗"}
+
+// doctor prints check results and remembers the worst.
+type doctor struct {
+	worst int
+}
+
+func (d *doctor) section(title string) {
+	fmt.Println(title)
+}
+
+// report prints one result; advice, if any, says what to do about it.
+func (d *doctor) report(level int, what, advice string) {
+	fmt.Printf("  %s %s\n", checkMarks[level], what)
+	if advice != "" {
+		fmt.Printf("      %s\n", advice)
+	}
+	d.worst = max(d.worst, level)
+}
+
+// runDoctor implements
+//
+//	sword-tui doctor
+//
+// which checks what sword-tui depends on — the terminal, the clipboard,
+// the Bible provider, the cache directory and the config — and says how
+// to fix what isn't right. It exits 1 if anything failed outright.
+func runDoctor(args []string) int {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	fs.Parse(args)
+
+	var d doctor
+	d.section("Terminal")
+	d.checkColors()
+	d.checkMouse()
+	d.checkClipboard()
+
+	d.section("Network")
+	d.checkNetwork()
+
+	d.section("Cache")
+	d.checkCache()
+
+	d.section("Config")
+	d.checkConfig()
+
+	if d.worst == checkFail {
+		return 1
+	}
+	return 0
+}
+
+func (d *doctor) checkColors() {
+	if cfg, err := settings.Load(); err == nil && cfg.Colors != "" {
+		d.report(checkOK, "colors: "+cfg.Colors+", set by \"colors\" in config.json", "")
+		return
+	}
+	switch p := colorprofile.Detect(os.Stdout, os.Environ()); p {
+	case colorprofile.TrueColor:
+		d.report(checkOK, "colors: truecolor", "")
+	case colorprofile.ANSI256:
+		d.report(checkWarn, "colors: 256 only, themes use their 256-color palettes",
+			"if your terminal supports truecolor, set COLORTERM=truecolor or \"colors\": \"truecolor\"")
+	case colorprofile.ANSI:
+		d.report(checkWarn, "colors: 16 only, themes use their 16-color palettes",
+			"use a terminal with 256-color or truecolor support, or set TERM (e.g. xterm-256color)")
+	case colorprofile.NoTTY:
+		d.report(checkWarn, "colors: stdout is not a terminal",
+			"run doctor in the terminal you read in")
+	default:
+		d.report(checkWarn, "colors: "+p.String()+" only, themes will look flat",
+			"use a terminal with 256-color or truecolor support, or set TERM (e.g. xterm-256color)")
+	}
+}
+
+func (d *doctor) checkMouse() {
+	term := os.Getenv("TERM")
+	switch {
+	case term == "" || term == "dumb" || term == "linux":
+		d.report(checkWarn, fmt.Sprintf("mouse: TERM=%q is unlikely to report clicks", term),
+			"use a terminal emulator; the keyboard works everywhere")
+	case os.Getenv("TMUX") != "":
+		out, err := exec.Command("tmux", "show-options", "-gv", "mouse").Output()
+		if err == nil && strings.TrimSpace(string(out)) == "off" {
+			d.report(checkWarn, "mouse: off in tmux",
+				`add "set -g mouse on" to ~/.tmux.conf to click and scroll`)
+			return
+		}
+		d.report(checkOK, "mouse: on (in tmux)", "")
+	default:
+		d.report(checkOK, "mouse: "+term+" reports clicks and the wheel", "")
+	}
+}
+
+func (d *doctor) checkClipboard() {
+	if clipboard.Unsupported {
+		d.report(checkWarn, "clipboard: no tool found, y and V won't work",
+			"install xclip, xsel or wl-clipboard (Wayland)")
+		return
+	}
+	tool := "system"
+	if runtime.GOOS != "darwin" && runtime.GOOS != "windows" {
+		// The order atotto/clipboard picks them in.
+		candidates := []string{"xclip", "xsel", "termux-clipboard-set", "clip.exe"}
+		if os.Getenv("WAYLAND_DISPLAY") != "" {
+			candidates = append([]string{"wl-copy"}, candidates...)
+		}
+		for _, c := range candidates {
+			if _, err := exec.LookPath(c); err == nil {
+				tool = c
+				break
+			}
+		}
+		if (tool == "xclip" || tool == "xsel") && os.Getenv("DISPLAY") == "" {
+			d.report(checkWarn, "clipboard: "+tool+", but there is no display to own the clipboard",
+				"over SSH, forward X (ssh -X) or send verses to tmux with Y instead")
+			return
+		}
+	}
+	d.report(checkOK, "clipboard: "+tool, "")
+}
+
+func (d *doctor) checkNetwork() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client := newClient()
+	service := client.Provider().Name()
+	status, latency, err := client.Ping(ctx)
+	switch {
+	case errors.Is(err, api.ErrOffline):
+		d.report(checkOK, service+": offline, nothing to reach", "")
+	case err != nil:
+		d.report(checkFail, service+": unreachable: "+err.Error(),
+			"check your connection, DNS or HTTPS_PROXY; downloaded translations still work offline (d)")
+	case status == 401 || status == 403:
+		d.report(checkFail, fmt.Sprintf("%s: refused with %d", service, status),
+			"check provider_key in config.json")
+	case status >= 500 || status == 429:
+		d.report(checkWarn, fmt.Sprintf("%s: answered %d in %s", service, status, latency.Round(time.Millisecond)),
+			"the service is struggling; try again later")
+	case latency > 2*time.Second:
+		d.report(checkWarn, fmt.Sprintf("%s: slow, %s", service, latency.Round(time.Millisecond)),
+			"download the translations you read with d to read offline")
+	default:
+		d.report(checkOK, fmt.Sprintf("%s: %d in %s", service, status, latency.Round(time.Millisecond)), "")
+	}
+}
+
+func (d *doctor) checkCache() {
+	c, err := cache.NewCache()
+	if err != nil {
+		d.report(checkFail, "translations: "+err.Error(),
+			"make sure the user cache directory (~/.cache, ~/Library/Caches or %LocalAppData%) is writable; a damaged cache.json in its sword-tui directory can be deleted")
+		return
+	}
+	probe, err := os.CreateTemp(c.Dir(), ".doctor-*")
+	if err != nil {
+		d.report(checkFail, c.Dir()+": not writable: "+err.Error(),
+			"fix its permissions (chmod u+w) or free some disk space")
+		return
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+
+	names, _ := c.ListCached()
+	size, _ := c.GetCacheSize()
+	d.report(checkOK, fmt.Sprintf("%s: writable, %d translations, %.2f MB", c.Dir(), len(names), float64(size)/(1024*1024)), "")
+	if v, err := c.Version(); err == nil && v > cache.FormatVersion {
+		d.report(checkWarn, fmt.Sprintf("cache format %d is newer than this build's (%d)", v, cache.FormatVersion),
+			"upgrade sword-tui, or clear the cache to download translations afresh")
+	}
+}
+
+func (d *doctor) checkConfig() {
+	dir, err := settings.Dir()
+	if err != nil {
+		d.report(checkFail, "config directory: "+err.Error(),
+			"make sure the user config directory (~/.config, ~/Library/Application Support or %AppData%) is writable")
+		return
+	}
+	path := filepath.Join(dir, "config.json")
+	cfg, err := settings.Load()
+	if err != nil {
+		d.report(checkFail, path+": "+err.Error(),
+			"fix the JSON, or move the file away to start from the defaults")
+		return
+	}
+	problems := ui.CheckSettings(cfg)
+	if len(problems) == 0 {
+		d.report(checkOK, path, "")
+		return
+	}
+	for _, p := range problems {
+		d.report(checkWarn, p.Error(), "edit "+path)
+	}
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"bufio"
+	"io"
+
+	"sword-tui/internal/ui"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// followReferences reads one reference per line from r and makes the
+// running program jump to each, until r is closed.
+func followReferences(p *tea.Program, r io.Reader) {
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		if line := sc.Text(); line != "" {
+			p.Send(ui.Goto(line))
+		}
+	}
+}
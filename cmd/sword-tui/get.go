@@ -0,0 +1,120 @@
+package main
+
+import (
+	"cmp"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/settings"
+)
+
+// parseInterspersed parses args with fs, letting flags come after the
+// arguments too, as in `get "John 3:16" -t KJV`. It returns the
+// arguments.
+func parseInterspersed(fs *flag.FlagSet, args []string) []string {
+	var rest []string
+	for {
+		fs.Parse(args)
+		args = fs.Args()
+		if len(args) == 0 {
+			return rest
+		}
+		rest = append(rest, args[0])
+		args = args[1:]
+	}
+}
+
+// defaultTranslation is the one the reader was last on.
+func defaultTranslation() string {
+	cfg, _ := settings.Load()
+	return cmp.Or(cfg.SelectedTranslation, "NLT")
+}
+
+// runGet implements
+//
+//	sword-tui get [-t KJV] [-text] REFERENCE[; REFERENCE...]
+//
+// which prints passages without starting the reader, a
+// "reference<TAB>text" line per verse, for scripts and pipes. -text
+// prints only the words, a line per passage. Passages come from
+// downloaded, personal and SWORD translations when they can.
+func runGet(args []string) int {
+	fs := flag.NewFlagSet("get", flag.ExitOnError)
+	translation := fs.String("t", defaultTranslation(), "translation to read")
+	textOnly := fs.Bool("text", false, "print only the text, a line per passage")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, `usage: sword-tui get [-t KJV] [-text] REFERENCE[; REFERENCE...]`)
+		fmt.Fprintln(os.Stderr, "\nREFERENCE is a book, chapter and optional verses: \"John 3:16-18\", \"Ps 23\".")
+		fs.PrintDefaults()
+	}
+	refs := strings.Join(parseInterspersed(fs, args), " ")
+	if strings.TrimSpace(refs) == "" {
+		fs.Usage()
+		return 2
+	}
+
+	client := readingClient()
+	status := 0
+	for _, ref := range strings.Split(refs, ";") {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			continue
+		}
+		lines, err := passageLines(client, *translation, ref)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			status = 1
+			continue
+		}
+		if !*textOnly {
+			fmt.Println(strings.Join(lines, "\n"))
+			continue
+		}
+		for i, l := range lines {
+			_, lines[i], _ = strings.Cut(l, "\t")
+		}
+		fmt.Println(strings.Join(lines, " "))
+	}
+	return status
+}
+
+// runSearch implements
+//
+//	sword-tui search [-t KJV] [-page N] WORDS...
+//
+// which prints the verses a search finds, a "reference<TAB>text" line
+// each, without starting the reader. How many there are in all goes to
+// stderr when they don't fit on one page.
+func runSearch(args []string) int {
+	fs := flag.NewFlagSet("search", flag.ExitOnError)
+	translation := fs.String("t", defaultTranslation(), "translation to search")
+	page := fs.Int("page", 1, fmt.Sprintf("page of results to print, %d to a page", api.SearchPageSize))
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: sword-tui search [-t KJV] [-page N] WORDS...")
+		fs.PrintDefaults()
+	}
+	query := strings.Join(parseInterspersed(fs, args), " ")
+	if strings.TrimSpace(query) == "" || *page < 1 {
+		fs.Usage()
+		return 2
+	}
+
+	resp, err := readingClient().SearchVersesPage(*translation, query, *page)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	for _, l := range searchLines(resp) {
+		fmt.Println(l)
+	}
+	if pages := (resp.Total + api.SearchPageSize - 1) / api.SearchPageSize; pages > 1 {
+		fmt.Fprintf(os.Stderr, "page %d of %d, %d verses in all\n", *page, pages, resp.Total)
+	}
+	if len(resp.Results) == 0 {
+		return 1
+	}
+	return 0
+}
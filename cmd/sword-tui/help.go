@@ -0,0 +1,176 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"sword-tui/internal/ui"
+	"sword-tui/internal/version"
+)
+
+// command is a subcommand, run instead of the TUI.
+type command struct {
+	name    string
+	args    string // synopsis after the name
+	summary string
+	run     func(args []string) int
+}
+
+// commands returns every subcommand. main dispatches on it, and --help
+// and the man page list it, so they can't drift apart. (A function, not
+// a var, because man refers back to it.)
+func commands() []command {
+	return []command{
+		{"get", "[-t KJV] [-text] REFERENCE[; REFERENCE...]", "print passages as plain text, for scripts and pipes", runGet},
+		{"search", "[-t KJV] [-page N] WORDS...", "print the verses a search finds as plain text", runSearch},
+		{"import-translation", "-name NAME [-title TITLE] FILE", "store a personal translation from CSV or JSON for reading and comparison", runImportTranslation},
+		{"sword-modules", "[DIR...]", "list the SWORD modules installed and whether they can be read", runSwordModules},
+		{"import", "[-collection NAME] [-format csv|json] FILE", "bulk-add a list of references as bookmarks", runImport},
+		{"import-highlights", "[-format kindle|youversion] [-translation KJV] [-color yellow] [-dry-run] FILE", "bring highlights and notes over from Kindle clippings or a YouVersion export", runImportHighlights},
+		{"bench", "[-translation KJV] [-n 200] [-width 100] [-seed 1] [-json]", "time chapter formatting on a cached translation", runBench},
+		{"plan", "-books BOOKS [-days 365] [-on daily] [-start DATE] [-remind HH:MM] [-reader NAME] NAME | -catch-up HOW NAME | [-delete] [NAME]", "build a reading plan, catch up on one, or list, show or delete plans", runPlan},
+		{"report", "-week [-ago N] [-reader NAME] [-o FILE]", "print a Markdown summary of a week's reading", runReport},
+		{"wallpaper", "[-size 1920x1080] [-theme NAME] [-translation KJV] [-date DATE] [-o FILE] [-set]", "draw the verse of the day onto a desktop wallpaper, and set it", runWallpaper},
+		{"doctor", "", "check the terminal, clipboard, network, cache and config, and suggest fixes", runDoctor},
+		{"man", "", "print this manual page in roff, for man(1)", runMan},
+	}
+}
+
+// findCommand returns the subcommand called name.
+func findCommand(name string) (command, bool) {
+	for _, c := range commands() {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return command{}, false
+}
+
+// keyName spells a key for the terminal and the man page.
+func keyName(k string) string {
+	return strings.ReplaceAll(k, "⏎", "Enter")
+}
+
+// usage is the --help text: the synopsis, the subcommands, the flags of
+// fs and the keys of the reader.
+func usage(w io.Writer, fs *flag.FlagSet) {
+	fmt.Fprintf(w, "sword-tui %s - read the Bible in the terminal\n\n", version.Version)
+	fmt.Fprintln(w, "Usage:")
+	fmt.Fprintln(w, "  sword-tui [flags]")
+	fmt.Fprintln(w, "  sword-tui COMMAND [args]")
+
+	fmt.Fprintln(w, "\nCommands:")
+	for _, c := range commands() {
+		fmt.Fprintf(w, "  %-20s %s\n", c.name, c.summary)
+	}
+
+	fmt.Fprintln(w, "\nFlags:")
+	fs.VisitAll(func(f *flag.Flag) {
+		name, help := flag.UnquoteUsage(f)
+		fmt.Fprintf(w, "  -%s", f.Name)
+		if name != "" {
+			fmt.Fprintf(w, " %s", name)
+		}
+		fmt.Fprintf(w, "\n      %s\n", help)
+	})
+
+	fmt.Fprintln(w, "\nKeys:")
+	keyW := ui.KeyWidth()
+	for _, g := range ui.Keymap() {
+		fmt.Fprintf(w, "  %s\n", g.Title)
+		for _, k := range g.Keys {
+			fmt.Fprintf(w, "    %-*s %s\n", keyW, keyName(k.Keys), k.Desc)
+		}
+	}
+	fmt.Fprintln(w, "\nRun 'sword-tui COMMAND -h' for a command's flags, or 'sword-tui man' for the manual.")
+}
+
+// roff escapes s for a man page line.
+func roff(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\e`)
+	s = strings.ReplaceAll(s, "-", `\-`)
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+	return s
+}
+
+// writeMan writes sword-tui(1) in roff, from the same commands, flags and
+// keymap as --help.
+func writeMan(w io.Writer, fs *flag.FlagSet) {
+	date := time.Now().Format("2006-01-02")
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		// Reproducible builds pin the date.
+		var secs int64
+		if _, err := fmt.Sscan(epoch, &secs); err == nil {
+			date = time.Unix(secs, 0).UTC().Format("2006-01-02")
+		}
+	}
+	fmt.Fprintf(w, ".TH SWORD\\-TUI 1 %s \"sword-tui %s\" \"User Commands\"\n", date, roff(version.Version))
+	fmt.Fprintln(w, ".SH NAME")
+	fmt.Fprintln(w, `sword\-tui \- read the Bible in the terminal`)
+	fmt.Fprintln(w, ".SH SYNOPSIS")
+	fmt.Fprintln(w, `.B sword\-tui`)
+	fmt.Fprintln(w, `[\fIflags\fR]`)
+	fmt.Fprintln(w, ".br")
+	fmt.Fprintln(w, `.B sword\-tui`)
+	fmt.Fprintln(w, `\fICOMMAND\fR [\fIargs\fR]`)
+	fmt.Fprintln(w, ".SH DESCRIPTION")
+	fmt.Fprintln(w, "A terminal Bible reader backed by bolls.life, with offline downloads,")
+	fmt.Fprintln(w, "side-by-side comparison, bookmarks, notes and highlights.")
+
+	fmt.Fprintln(w, ".SH OPTIONS")
+	fs.VisitAll(func(f *flag.Flag) {
+		name, help := flag.UnquoteUsage(f)
+		fmt.Fprintln(w, ".TP")
+		if name != "" {
+			fmt.Fprintf(w, ".BI \\-%s \" %s\"\n", roff(f.Name), roff(name))
+		} else {
+			fmt.Fprintf(w, ".B \\-%s\n", roff(f.Name))
+		}
+		fmt.Fprintln(w, roff(help))
+	})
+
+	fmt.Fprintln(w, ".SH COMMANDS")
+	for _, c := range commands() {
+		fmt.Fprintln(w, ".TP")
+		fmt.Fprintf(w, ".B %s\n", roff(c.name))
+		if c.args != "" {
+			fmt.Fprintf(w, ".I %s\n", roff(c.args))
+		}
+		fmt.Fprintln(w, ".br")
+		fmt.Fprintln(w, roff(c.summary))
+	}
+
+	fmt.Fprintln(w, ".SH KEYS")
+	for _, g := range ui.Keymap() {
+		fmt.Fprintf(w, ".SS %s\n", roff(g.Title))
+		for _, k := range g.Keys {
+			fmt.Fprintln(w, ".TP")
+			fmt.Fprintf(w, ".B %s\n", roff(keyName(k.Keys)))
+			fmt.Fprintln(w, roff(k.Desc))
+		}
+	}
+
+	fmt.Fprintln(w, ".SH FILES")
+	fmt.Fprintln(w, ".TP")
+	fmt.Fprintln(w, `.I ~/.config/sword\-tui/config.json`)
+	fmt.Fprintln(w, "Settings, including the translation, theme and display options.")
+	fmt.Fprintln(w, ".TP")
+	fmt.Fprintln(w, `.I ~/.config/sword\-tui/annotations/`)
+	fmt.Fprintln(w, "Bookmarks, notes and highlights; this is what sync copies.")
+}
+
+// runMan implements
+//
+//	sword-tui man
+//
+// which prints the manual page, e.g. to install as sword-tui.1.
+func runMan(args []string) int {
+	writeMan(os.Stdout, flag.CommandLine)
+	return 0
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/cache"
+	"sword-tui/internal/clippings"
+	"sword-tui/internal/highlights"
+	"sword-tui/internal/notes"
+	"sword-tui/internal/ui"
+)
+
+// runImportHighlights implements
+//
+//	sword-tui import-highlights [-format kindle|youversion] [-translation ESV] [-color yellow] [-dry-run] FILE
+//
+// which brings highlights and notes over from Kindle's "My Clippings.txt"
+// or a YouVersion CSV export into the annotations store.
+func runImportHighlights(args []string) int {
+	fs := flag.NewFlagSet("import-highlights", flag.ExitOnError)
+	format := fs.String("format", "", "input format: kindle or youversion (default: from file extension, .txt or .csv)")
+	translation := fs.String("translation", "KJV", "downloaded translation the Kindle book is, to find highlights in")
+	color := fs.String("color", highlights.Colors[0], "highlight color where the export has none: "+strings.Join(highlights.Colors, ", "))
+	dryRun := fs.Bool("dry-run", false, "list what would be imported without saving anything")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: sword-tui import-highlights [-format kindle|youversion] [-translation ESV] [-color yellow] [-dry-run] FILE")
+		fmt.Fprintln(os.Stderr, "\nKindle clippings hold no references, so their text is looked up in -translation,")
+		fmt.Fprintln(os.Stderr, "which must be downloaded (d in the reader).")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	if clippings.Color(*color) != *color {
+		fmt.Fprintf(os.Stderr, "Error: unknown color %q (want %s)\n", *color, strings.Join(highlights.Colors, ", "))
+		return 2
+	}
+	path := fs.Arg(0)
+	if *format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".txt":
+			*format = "kindle"
+		case ".csv":
+			*format = "youversion"
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	defer f.Close()
+
+	var res clippings.Result
+	switch *format {
+	case "kindle":
+		verses, err := loadTranslation(*translation)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		res, err = clippings.Kindle(f, verses, *color)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+	case "youversion":
+		res, err = clippings.YouVersion(f, *color)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unsupported format %q (want kindle or youversion)\n", *format)
+		return 2
+	}
+
+	for _, s := range res.Skipped {
+		fmt.Fprintf(os.Stderr, "skipped %s\n", s)
+	}
+	if *dryRun {
+		for _, it := range res.Items {
+			detail := it.Color
+			if it.Kind == clippings.Note {
+				detail = it.Text
+			}
+			fmt.Printf("%-9s  %-22s  %s\n", it.Kind, it.Reference(), detail)
+		}
+		fmt.Printf("Would import %s\n", summary(res))
+		return 0
+	}
+
+	hl, err := highlights.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	var verses, added, present int
+	for _, it := range res.Items {
+		switch it.Kind {
+		case clippings.Highlight:
+			for v := it.VerseStart; v <= it.VerseEnd; v++ {
+				if hl.Restore(it.Book, it.Chapter, v, it.Color, it.Added) {
+					verses++
+				}
+			}
+		case clippings.Note:
+			ok, err := addNote(it)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				return 1
+			}
+			if ok {
+				added++
+			} else {
+				present++
+			}
+		}
+	}
+	if verses > 0 {
+		if err := hl.Save(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+	}
+
+	fmt.Printf("Imported %s: %d verses newly highlighted, %d notes added", summary(res), verses, added)
+	if present > 0 {
+		fmt.Printf(" (%d already present)", present)
+	}
+	fmt.Println()
+	return 0
+}
+
+// summary counts what res holds, e.g. "12 highlights and 3 notes,
+// skipped 2".
+func summary(res clippings.Result) string {
+	var nh, nn int
+	for _, it := range res.Items {
+		if it.Kind == clippings.Note {
+			nn++
+		} else {
+			nh++
+		}
+	}
+	s := fmt.Sprintf("%d highlights and %d notes", nh, nn)
+	if len(res.Skipped) > 0 {
+		s += fmt.Sprintf(", skipped %d", len(res.Skipped))
+	}
+	return s
+}
+
+// loadTranslation reads every verse of a downloaded translation as plain
+// text.
+func loadTranslation(name string) ([]api.Verse, error) {
+	c, err := cache.NewCache()
+	if err != nil {
+		return nil, err
+	}
+	if !c.IsCached(name) {
+		return nil, fmt.Errorf("%s is not downloaded; download it in the reader (d) or pick another with -translation", name)
+	}
+	verses, err := c.LoadAll(name)
+	if err != nil {
+		return nil, err
+	}
+	for i := range verses {
+		verses[i].Text = ui.PlainText(verses[i].Text)
+	}
+	return verses, nil
+}
+
+// addNote appends the note it unless the verse already has one with the
+// same text. It reports whether it added it.
+func addNote(it clippings.Item) (bool, error) {
+	existing, err := notes.ForChapter(it.Book, it.Chapter)
+	if err != nil {
+		return false, err
+	}
+	text := strings.Join(strings.Fields(it.Text), " ")
+	for _, n := range existing[it.VerseStart] {
+		if n.Text == text {
+			return false, nil
+		}
+	}
+	name := fmt.Sprint(it.Book)
+	if b, ok := api.CanonicalBook(it.Book); ok {
+		name = b.Name
+	}
+	at := it.Added
+	if at.IsZero() {
+		at = time.Now()
+	}
+	return true, notes.Append(name, it.Book, it.Chapter, it.VerseStart, text, at)
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sword-tui/internal/bookmarks"
+)
+
+// runImport implements
+//
+//	sword-tui import [-collection NAME] file.csv|file.json
+//
+// which bulk-adds a list of references as bookmarks.
+func runImport(args []string) int {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	collection := fs.String("collection", "", "collection to file the bookmarks under (default: unfiled)")
+	format := fs.String("format", "", "input format: csv or json (default: from file extension)")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: sword-tui import [-collection NAME] [-format csv|json] FILE")
+		fmt.Fprintln(os.Stderr, "\nFILE lists references such as \"John 3:16\" or \"Rom 8:28-30\", one per row.")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	path := fs.Arg(0)
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(path), ".")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	defer f.Close()
+
+	store, err := bookmarks.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	res, err := store.Import(f, *format, *collection)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	for _, s := range res.Skipped {
+		fmt.Fprintf(os.Stderr, "skipped %s\n", s)
+	}
+	if res.Added > 0 {
+		if err := store.Save(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+	}
+
+	fmt.Printf("Imported %d bookmarks", res.Added)
+	if res.Duplicates > 0 {
+		fmt.Printf(" (%d already present)", res.Duplicates)
+	}
+	if len(res.Skipped) > 0 {
+		fmt.Printf(", skipped %d unparseable rows", len(res.Skipped))
+	}
+	fmt.Println()
+	return 0
+}
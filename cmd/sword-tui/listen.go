@@ -0,0 +1,108 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/reference"
+	"sword-tui/internal/ui"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// editorBackend answers editor plugin requests (see internal/remote) on
+// behalf of the running program. GET and SEARCH use whichever translation
+// the reader is showing.
+type editorBackend struct {
+	p      *tea.Program
+	client *api.Client
+}
+
+func (b editorBackend) Goto(ref string) error {
+	if _, _, _, _, err := reference.Parse(ref, api.CanonicalBooks()); err != nil {
+		return err
+	}
+	b.p.Send(ui.Goto(ref))
+	return nil
+}
+
+func (b editorBackend) Get(ref string) ([]string, error) {
+	translation, err := b.translation()
+	if err != nil {
+		return nil, err
+	}
+	return passageLines(b.client, translation, ref)
+}
+
+func (b editorBackend) Search(query string) ([]string, error) {
+	translation, err := b.translation()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := b.client.SearchVerses(translation, query)
+	if err != nil {
+		return nil, err
+	}
+	return searchLines(resp), nil
+}
+
+func (b editorBackend) Play(ref string, pos time.Duration) error {
+	book, chapter, _, _, err := reference.Parse(ref, api.CanonicalBooks())
+	if err != nil {
+		return err
+	}
+	b.p.Send(ui.PlaybackPosition(book, chapter, pos))
+	return nil
+}
+
+func (b editorBackend) translation() (string, error) {
+	t := ui.CurrentTranslation(b.p, time.Second)
+	if t == "" {
+		return "", errors.New("sword-tui is not responding")
+	}
+	return t, nil
+}
+
+// passageLines returns a "reference<TAB>text" line for each verse of ref
+// in translation.
+func passageLines(client *api.Client, translation, ref string) ([]string, error) {
+	book, chapter, verseStart, verseEnd, err := reference.Parse(ref, api.CanonicalBooks())
+	if err != nil {
+		return nil, err
+	}
+	verses, err := client.GetChapter(translation, book, chapter)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for _, v := range verses {
+		if verseStart > 0 && (v.Verse < verseStart || v.Verse > verseEnd) {
+			continue
+		}
+		lines = append(lines, verseLine(book, chapter, v))
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("%s: no such verses in %s", ref, translation)
+	}
+	return lines, nil
+}
+
+// searchLines returns a "reference<TAB>text" line for each result.
+func searchLines(resp *api.SearchResponse) []string {
+	lines := make([]string, len(resp.Results))
+	for i, v := range resp.Results {
+		lines[i] = verseLine(v.Book, v.Chapter, v)
+	}
+	return lines
+}
+
+func verseLine(book, chapter int, v api.Verse) string {
+	name := fmt.Sprint(book)
+	if meta, ok := api.CanonicalBook(book); ok {
+		name = meta.Name
+	}
+	return fmt.Sprintf("%s %d:%d\t%s", name, chapter, v.Verse, ui.PlainText(v.Text))
+}
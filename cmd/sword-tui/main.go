@@ -3,17 +3,37 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io"
+	"log"
 	"os"
+	"sword-tui/internal/api"
 	"sword-tui/internal/cache"
+	"sword-tui/internal/remote"
+	"sword-tui/internal/settings"
 	"sword-tui/internal/ui"
 	"sword-tui/internal/version"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 )
 
 func main() {
-	// Parse command line flags
+	// Parse command line flags. They are defined before any subcommand
+	// runs so that man can document them.
 	versionFlag := flag.Bool("version", false, "Print version information")
+	listen := flag.Bool("listen", false, "Accept editor plugin requests on a Unix socket")
+	socketPath := flag.String("socket", remote.DefaultSocket(), "Socket used by -listen")
+	noMouse := flag.Bool("no-mouse", false, "Leave the mouse to the terminal so text can be selected with it (M toggles)")
+	inline := flag.Bool("inline", false, "Draw in the terminal instead of the alternate screen, leaving the last screen in the scrollback")
+	stdinFollow := flag.Bool("stdin-follow", false, "Jump to each reference written to stdin (one per line); keys are read from the terminal")
+	flag.Usage = func() { usage(flag.CommandLine.Output(), flag.CommandLine) }
+	openDebugLog()
+
+	if len(os.Args) > 1 {
+		if c, ok := findCommand(os.Args[1]); ok {
+			os.Exit(c.run(os.Args[2:]))
+		}
+	}
 	flag.Parse()
 
 	// Handle version flag
@@ -30,12 +50,114 @@ func main() {
 	}
 
 	model := ui.NewModel()
-	model.SetCache(cacheManager)
+	// A nil *cache.Cache would make a non-nil CacheInterface, so the
+	// model is only handed one that exists.
+	if cacheManager != nil {
+		model.SetCache(cacheManager)
+	}
+	if *noMouse {
+		model.DisableMouse()
+	}
+	if *inline {
+		model.SetInline()
+	}
+	local, localErr := localTranslations()
+	if localErr == nil {
+		model.SetLocalTranslations(local)
+	}
 
-	p := tea.NewProgram(model)
+	var opts []tea.ProgramOption
+	if *stdinFollow {
+		// stdin carries references, so the keyboard has to come from the
+		// controlling terminal instead.
+		if fi, err := os.Stdin.Stat(); err == nil && fi.Mode()&os.ModeCharDevice != 0 {
+			fmt.Println("Error: --stdin-follow expects references piped to stdin")
+			os.Exit(1)
+		}
+		tty, err := os.Open("/dev/tty")
+		if err != nil {
+			fmt.Printf("Error: --stdin-follow needs a controlling terminal: %v\n", err)
+			os.Exit(1)
+		}
+		defer tty.Close()
+		opts = append(opts, tea.WithInput(tty))
+	}
+
+	if profile, ok := model.ColorProfile(); ok {
+		opts = append(opts, tea.WithColorProfile(profile))
+	}
+
+	p := tea.NewProgram(model, opts...)
+	if *stdinFollow {
+		go followReferences(p, os.Stdin)
+	}
+	if *listen {
+		l, err := remote.Listen(*socketPath)
+		if err != nil {
+			fmt.Printf("Error: could not listen on %s: %v\n", *socketPath, err)
+			os.Exit(1)
+		}
+		defer l.Close()
+
+		// The backend has its own client so requests never wait on the UI.
+		client := newClient()
+		if cacheManager != nil {
+			client.SetCache(cacheManager)
+		}
+		if localErr == nil {
+			client.SetLocal(local)
+		}
+		go remote.Serve(l, editorBackend{p: p, client: client})
+	}
 
-	if _, err := p.Run(); err != nil {
+	final, err := p.Run()
+	if err != nil {
 		fmt.Printf("Error running program: %v\n", err)
 		os.Exit(1)
 	}
+	if m, ok := final.(ui.Model); ok {
+		log.Printf("requests: %s", m.RequestStats())
+		fmt.Print(m.ExitSummary(time.Now()))
+	}
+}
+
+// openDebugLog sends the log, each request to the provider and how long
+// it took, to the file SWORD_TUI_DEBUG names. Without it the log is
+// discarded, so nothing is written over the reader.
+func openDebugLog() {
+	log.SetOutput(io.Discard)
+	path := os.Getenv("SWORD_TUI_DEBUG")
+	if path == "" {
+		return
+	}
+	if _, err := tea.LogToFile(path, "sword-tui"); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not open the debug log: %v\n", err)
+	}
+}
+
+// newClient returns a client for the provider config.json selects, or
+// for bolls.life when that can't be set up; the reader and doctor say
+// why.
+func newClient() *api.Client {
+	client := api.NewClient()
+	if cfg, err := settings.Load(); err == nil {
+		if p, err := api.NewProvider(cfg.Provider, cfg.ProviderKey); err == nil {
+			client.SetProvider(p)
+		}
+		client.SetMaxRequests(cfg.MaxRequests)
+	}
+	return client
+}
+
+// readingClient is newClient reading downloaded, personal and SWORD
+// translations from disk, as the reader does.
+func readingClient() *api.Client {
+	client := newClient()
+	if c, err := cache.NewCache(); err == nil {
+		client.SetCache(c)
+	}
+	if local, err := localTranslations(); err == nil {
+		client.SetLocal(local)
+	}
+	return client
 }
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"sword-tui/internal/plans"
+	"sword-tui/internal/settings"
+)
+
+// remindRe matches a reminder time, HH:MM.
+var remindRe = regexp.MustCompile(`^([01]?\d|2[0-3]):[0-5]\d$`)
+
+// runPlan implements
+//
+//	sword-tui plan -books "Matt-John, Ps 1-41" [-days 90] [-on mon-fri] [-start 2026-01-05] [-remind 07:00] NAME
+//	sword-tui plan -catch-up spread|double|shift NAME
+//	sword-tui plan [-delete] [NAME]
+//
+// which builds a reading plan, spreading the chosen chapters over the
+// reading days of the period, reschedules one that has fallen behind, or
+// lists the plans, prints one's schedule or deletes one. The reader's R
+// tracks them. Each takes -reader to work on someone else's plans on a
+// shared machine; naming a new reader adds them.
+func runPlan(args []string) int {
+	fs := flag.NewFlagSet("plan", flag.ExitOnError)
+	books := fs.String("books", "", `books to read: books, runs of books, chapters or testaments, e.g. "Gen-Deut, Ps 1-41, NT"`)
+	days := fs.Int("days", 365, "length of the plan in days")
+	on := fs.String("on", "daily", `days to read on: daily, weekdays, weekends, or e.g. "mon-fri" or "mon,wed,fri"`)
+	start := fs.String("start", "", "first day, YYYY-MM-DD (default today)")
+	remind := fs.String("remind", "", "time of day to be reminded, HH:MM")
+	catchUp := fs.String("catch-up", "", "reschedule missed readings: "+strings.Join(plans.CatchUps, ", "))
+	del := fs.Bool("delete", false, "delete the plan")
+	reader := fs.String("reader", currentReader(), `whose plans, on a shared machine ("" is the default reader)`)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, `usage: sword-tui plan -books "Matt-John" [-days 90] [-on mon-fri] [-start DATE] [-remind 07:00] NAME`)
+		fmt.Fprintln(os.Stderr, "       sword-tui plan -catch-up spread|double|shift NAME")
+		fmt.Fprintln(os.Stderr, "       sword-tui plan [-delete] [NAME]")
+		fs.PrintDefaults()
+	}
+	// Let the name come first too: "plan nt90 -books NT -days 90".
+	var name string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+	fs.Parse(args)
+	if err := settings.CheckReader(*reader); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	switch {
+	case name == "" && fs.NArg() == 1:
+		name = fs.Arg(0)
+	case fs.NArg() > 0:
+		fs.Usage()
+		return 2
+	}
+
+	switch {
+	case *del:
+		if name == "" {
+			fs.Usage()
+			return 2
+		}
+		if err := plans.Remove(*reader, name); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Deleted plan %s\n", name)
+		return 0
+	case *catchUp != "":
+		if name == "" {
+			fs.Usage()
+			return 2
+		}
+		p, err := plans.Load(*reader, name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		if !p.CatchUp(*catchUp, time.Now()) {
+			fmt.Fprintf(os.Stderr, "Error: unknown -catch-up %q (want %s)\n", *catchUp, strings.Join(plans.CatchUps, ", "))
+			return 2
+		}
+		if err := p.Save(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		printSchedule(p)
+		return 0
+	case *books != "":
+		if name == "" {
+			fs.Usage()
+			return 2
+		}
+		return buildPlan(*reader, name, *books, *days, *on, *start, *remind)
+	case name != "":
+		p, err := plans.Load(*reader, name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		printSchedule(p)
+		return 0
+	}
+
+	all, err := plans.List(*reader)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if len(all) == 0 {
+		fmt.Println(`No reading plans. Build one with: sword-tui plan -books "Matt-John" -days 90 gospels`)
+		return 0
+	}
+	if *reader != "" {
+		fmt.Printf("Plans of %s:\n", *reader)
+	}
+	for _, p := range all {
+		read, total := p.Progress()
+		fmt.Printf("%-16s %-24s %d/%d chapters, ends %s\n", p.Name, p.Selection, read, total, p.End())
+	}
+	return 0
+}
+
+// currentReader is the reader the app last read as.
+func currentReader() string {
+	cfg, _ := settings.Load()
+	return cfg.Reader
+}
+
+// buildPlan builds and saves a new plan for reader.
+func buildPlan(reader, name, books string, days int, on, start, remind string) int {
+	if err := plans.CheckName(name); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	if _, err := plans.Load(reader, name); err == nil {
+		fmt.Fprintf(os.Stderr, "Error: there is already a plan called %s (-delete it first)\n", name)
+		return 1
+	}
+	if days < 1 {
+		fmt.Fprintln(os.Stderr, "Error: -days must be at least 1")
+		return 2
+	}
+	if remind != "" && !remindRe.MatchString(remind) {
+		fmt.Fprintf(os.Stderr, "Error: -remind %q is not a time like 07:30\n", remind)
+		return 2
+	}
+	readings, err := plans.Select(books)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	weekdays, err := plans.ParseDays(on)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	first := time.Now()
+	if start != "" {
+		if first, err = time.ParseInLocation(plans.DateLayout, start, time.Local); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -start %q is not a date like 2026-01-05\n", start)
+			return 2
+		}
+	}
+	schedule, err := plans.Schedule(readings, first, days, weekdays)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	p := &plans.Plan{
+		Name:      name,
+		Selection: books,
+		ReadOn:    on,
+		Remind:    remind,
+		Created:   time.Now(),
+		Days:      schedule,
+		Reader:    reader,
+	}
+	if err := p.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Plan %s: %d chapters over %d reading days, %s to %s\n",
+		name, len(readings), len(schedule), schedule[0].Date, p.End())
+	return 0
+}
+
+// printSchedule prints a plan's days, ticking the ones read.
+func printSchedule(p *plans.Plan) {
+	read, total := p.Progress()
+	fmt.Printf("%s: %s, %d/%d chapters read", p.Name, p.Selection, read, total)
+	if behind := p.Behind(time.Now()); behind > 0 {
+		fmt.Printf(", %d behind", behind)
+	}
+	fmt.Println()
+	for _, d := range p.Days {
+		mark := " "
+		if d.Done() {
+			mark = "✓"
+		}
+		date, _ := time.ParseInLocation(plans.DateLayout, d.Date, time.Local)
+		fmt.Printf("%s %s %s  %s\n", mark, d.Date, date.Format("Mon"), d.Summary())
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"sword-tui/internal/report"
+	"sword-tui/internal/settings"
+)
+
+// runReport implements
+//
+//	sword-tui report -week [-ago N] [-reader NAME] [-o week.md]
+//
+// which prints a Markdown summary of a week's reading: chapters read,
+// days with reading, time spent and the notes, bookmarks and highlights
+// made. -ago picks an earlier week (1 is last week), -reader someone
+// else's reading on a shared machine.
+func runReport(args []string) int {
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+	week := fs.Bool("week", false, "summarize a week (Monday to Sunday)")
+	ago := fs.Int("ago", 0, "weeks back from the current one")
+	out := fs.String("o", "", "write the report to this file instead of stdout")
+	reader := fs.String("reader", currentReader(), `whose reading, on a shared machine ("" is the default reader)`)
+	fs.Parse(args)
+
+	if !*week {
+		fmt.Fprintln(os.Stderr, "usage: sword-tui report -week [-ago N] [-o file.md]")
+		return 2
+	}
+	if *ago < 0 {
+		fmt.Fprintln(os.Stderr, "Error: -ago must not be negative")
+		return 2
+	}
+
+	if *reader != "" {
+		// Only plan adds readers, so a typo here doesn't.
+		readers, err := settings.Readers()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		if !slices.Contains(readers, *reader) {
+			fmt.Fprintf(os.Stderr, "Error: no reader called %q\n", *reader)
+			return 2
+		}
+	}
+
+	w, err := report.ForWeek(*reader, time.Now().AddDate(0, 0, -7*(*ago)))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if *out == "" {
+		fmt.Print(w.Markdown())
+		return 0
+	}
+	if err := os.WriteFile(*out, []byte(w.Markdown()), 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Wrote %s\n", *out)
+	return 0
+}
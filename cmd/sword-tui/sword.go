@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/personal"
+	"sword-tui/internal/sword"
+)
+
+// localTranslations is what the reader serves from disk: personal
+// translations, then the Bibles of any SWORD library installed.
+func localTranslations() (api.LocalSource, error) {
+	var sources api.LocalSources
+	store, storeErr := personal.NewStore()
+	if storeErr == nil {
+		sources = append(sources, store)
+	}
+	if lib, err := sword.Open(sword.DefaultPaths()...); err == nil {
+		sources = append(sources, lib)
+	}
+	if len(sources) == 0 {
+		return nil, storeErr
+	}
+	return sources, nil
+}
+
+// runSwordModules implements
+//
+//	sword-tui sword-modules [DIR...]
+//
+// which lists the SWORD modules found, in DIRs or the usual places, and
+// whether the reader can read them.
+func runSwordModules(args []string) int {
+	fs := flag.NewFlagSet("sword-modules", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: sword-tui sword-modules [DIR...]")
+		fmt.Fprintf(os.Stderr, "\nDIRs default to $SWORD_PATH, then %s.\n", strings.Join(sword.DefaultPaths(), ", "))
+	}
+	fs.Parse(args)
+
+	paths := fs.Args()
+	if len(paths) == 0 {
+		paths = sword.DefaultPaths()
+	}
+	lib, err := sword.Open(paths...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	mods := lib.Modules()
+	if len(mods) == 0 {
+		fmt.Printf("No SWORD modules in %s\n", strings.Join(paths, ", "))
+		return 0
+	}
+	for _, m := range mods {
+		status := "readable"
+		if err := moduleProblem(lib, m); err != nil {
+			status = err.Error()
+		}
+		fmt.Printf("%-12s %-8s %-40s %s\n", m.Name, m.Driver, m.Description, status)
+	}
+	return 0
+}
+
+// moduleProblem says why the reader can't read m, or nil if it can.
+func moduleProblem(lib *sword.Library, m sword.Module) error {
+	switch {
+	case !m.Bible():
+		return errors.New("not a Bible")
+	case m.Locked:
+		return errors.New("locked")
+	case m.Versification != "KJV":
+		return fmt.Errorf("%s versification not supported", m.Versification)
+	}
+	_, err := lib.Books(m.Name)
+	return err
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sword-tui/internal/personal"
+)
+
+// runImportTranslation implements
+//
+//	sword-tui import-translation -name DRAFT [-title "My Draft"] file.csv|file.json
+//
+// which stores a personal translation that then appears in the pickers and
+// comparison view.
+func runImportTranslation(args []string) int {
+	fs := flag.NewFlagSet("import-translation", flag.ExitOnError)
+	name := fs.String("name", "", "short name for the translation (e.g. DRAFT)")
+	title := fs.String("title", "", "full display name")
+	format := fs.String("format", "", "input format: csv or json (default: from file extension)")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: sword-tui import-translation -name NAME [-title TITLE] [-format csv|json] FILE")
+		fmt.Fprintln(os.Stderr, "\nFILE holds one verse per row: book,chapter,verse,text (book as number or name).")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 1 || *name == "" {
+		fs.Usage()
+		return 2
+	}
+	path := fs.Arg(0)
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(path), ".")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	defer f.Close()
+
+	n, skipped, err := personal.Import(f, *format, *name, *title)
+	for _, s := range skipped {
+		fmt.Fprintf(os.Stderr, "skipped %s\n", s)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Imported %d verses as %s\n", n, *name)
+	return 0
+}
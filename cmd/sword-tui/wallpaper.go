@@ -0,0 +1,160 @@
+package main
+
+import (
+	"cmp"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"sword-tui/internal/settings"
+	"sword-tui/internal/theme"
+	"sword-tui/internal/ui"
+	"sword-tui/internal/votd"
+	"sword-tui/internal/wallpaper"
+)
+
+// runWallpaper implements
+//
+//	sword-tui wallpaper [-size 1920x1080] [-theme NAME] [-translation KJV] [-date DATE] [-o FILE] [-set]
+//
+// which draws the verse of the day onto a PNG in the theme's colors and,
+// with -set, makes it the desktop background with wallpaper_command.
+// Run it from cron or a login script for a new verse every day.
+func runWallpaper(args []string) int {
+	cfg, _ := settings.Load()
+	fs := flag.NewFlagSet("wallpaper", flag.ExitOnError)
+	size := fs.String("size", "1920x1080", "image size in pixels, WIDTHxHEIGHT")
+	themeName := fs.String("theme", cmp.Or(cfg.CurrentTheme, theme.CatppuccinMocha.Name), "theme to take the colors from")
+	translation := fs.String("translation", cmp.Or(cfg.SelectedTranslation, "NLT"), "translation to quote")
+	date := fs.String("date", "", "day whose verse to draw, YYYY-MM-DD (default today)")
+	out := fs.String("o", "", "file to write (default wallpaper.png in the cache directory)")
+	set := fs.Bool("set", false, "set it as the desktop background with wallpaper_command")
+	fs.Parse(args)
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return 2
+	}
+
+	var width, height int
+	if _, err := fmt.Sscanf(*size, "%dx%d", &width, &height); err != nil || width < 100 || height < 100 {
+		fmt.Fprintf(os.Stderr, "Error: -size %q is not a size like 1920x1080\n", *size)
+		return 2
+	}
+	th, ok := findTheme(*themeName)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: no theme called %q\n", *themeName)
+		return 2
+	}
+	day := time.Now()
+	if *date != "" {
+		var err error
+		if day, err = time.ParseInLocation("2006-01-02", *date, time.Local); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -date %q is not a date like 2026-01-05\n", *date)
+			return 2
+		}
+	}
+	if *set && cfg.WallpaperCommand == "" {
+		fmt.Fprintln(os.Stderr, `Error: -set needs "wallpaper_command" in config.json, e.g. "feh --bg-fill {}"`)
+		return 2
+	}
+	if *out == "" {
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		*out = filepath.Join(dir, "sword-tui", "wallpaper.png")
+	}
+
+	v := votd.For(day)
+	quote, err := verseText(*translation, v)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if err := os.MkdirAll(filepath.Dir(*out), 0o755); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	f, err := os.Create(*out)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	err = wallpaper.Render(f, wallpaper.Options{
+		Width:    width,
+		Height:   height,
+		Theme:    th,
+		Quote:    quote,
+		Citation: fmt.Sprintf("%s (%s)", v.Ref, *translation),
+	})
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Printf("%s: %s\n", *out, v.Ref)
+
+	if *set {
+		if err := setWallpaper(cfg.WallpaperCommand, *out); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: wallpaper_command: %v\n", err)
+			return 1
+		}
+	}
+	return 0
+}
+
+// findTheme returns the theme with the display name name.
+func findTheme(name string) (theme.Theme, bool) {
+	for _, th := range theme.AllThemes() {
+		if strings.EqualFold(th.Name, name) {
+			return th, true
+		}
+	}
+	return theme.Theme{}, false
+}
+
+// verseText fetches the text of v in translation, from a downloaded,
+// personal or SWORD translation if there is one.
+func verseText(translation string, v votd.Verse) (string, error) {
+	verses, err := readingClient().GetChapter(translation, v.Book, v.Chapter)
+	if err != nil {
+		return "", err
+	}
+	var parts []string
+	for _, vs := range verses {
+		if vs.Verse >= v.VerseStart && vs.Verse <= v.VerseEnd {
+			parts = append(parts, ui.PlainText(vs.Text))
+		}
+	}
+	if len(parts) == 0 {
+		return "", fmt.Errorf("%s is not in %s", v.Ref, translation)
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// setWallpaper runs command with path in place of {}, or after it.
+func setWallpaper(command, path string) error {
+	argv := strings.Fields(command)
+	found := false
+	for i, a := range argv {
+		if strings.Contains(a, "{}") {
+			argv[i] = strings.ReplaceAll(a, "{}", path)
+			found = true
+		}
+	}
+	if !found {
+		argv = append(argv, path)
+	}
+	out, err := exec.Command(argv[0], argv[1:]...).CombinedOutput()
+	if err != nil && len(out) > 0 {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return err
+}
@@ -0,0 +1,216 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const apiBibleURL = "https://api.scripture.api.bible/v1"
+
+// apiBibleSearchPage is the most results api.bible sends at once.
+const apiBibleSearchPage = 100
+
+// usfmBooks are the USFM codes api.bible names the 66 books by, in
+// canonical order.
+var usfmBooks = []string{
+	"GEN", "EXO", "LEV", "NUM", "DEU", "JOS", "JDG", "RUT", "1SA", "2SA",
+	"1KI", "2KI", "1CH", "2CH", "EZR", "NEH", "EST", "JOB", "PSA", "PRO",
+	"ECC", "SNG", "ISA", "JER", "LAM", "EZK", "DAN", "HOS", "JOL", "AMO",
+	"OBA", "JON", "MIC", "NAM", "HAB", "ZEP", "HAG", "ZEC", "MAL", "MAT",
+	"MRK", "LUK", "JHN", "ACT", "ROM", "1CO", "2CO", "GAL", "EPH", "PHP",
+	"COL", "1TH", "2TH", "1TI", "2TI", "TIT", "PHM", "HEB", "JAS", "1PE",
+	"2PE", "1JN", "2JN", "3JN", "JUD", "REV",
+}
+
+// usfmBook returns the book id of a USFM code, or 0.
+func usfmBook(code string) int {
+	return slices.Index(usfmBooks, code) + 1
+}
+
+// apiBibleProvider is api.bible (American Bible Society), which needs a
+// key. Its Bibles are known by opaque ids; they are shown under their
+// abbreviations, and the ids looked up from the list when needed.
+type apiBibleProvider struct {
+	key string
+
+	mu  sync.Mutex
+	ids map[string]string // abbreviation to Bible id
+}
+
+func newAPIBibleProvider(key string) *apiBibleProvider {
+	return &apiBibleProvider{key: key}
+}
+
+func (*apiBibleProvider) Name() string    { return "api.bible" }
+func (*apiBibleProvider) PingURL() string { return apiBibleURL + "/bibles" }
+
+func (p *apiBibleProvider) header() http.Header {
+	return http.Header{"Api-Key": {p.key}}
+}
+
+func (p *apiBibleProvider) Translations(f Fetcher) ([]Translation, error) {
+	var resp struct {
+		Data []struct {
+			ID           string `json:"id"`
+			Abbreviation string `json:"abbreviationLocal"`
+			Name         string `json:"nameLocal"`
+			Language     struct {
+				Name            string `json:"name"`
+				ScriptDirection string `json:"scriptDirection"`
+			} `json:"language"`
+		} `json:"data"`
+	}
+	if err := get(f, apiBibleURL+"/bibles", p.header(), &resp); err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ids = map[string]string{}
+	var out []Translation
+	for _, b := range resp.Data {
+		short := strings.ToUpper(b.Abbreviation)
+		if _, dup := p.ids[short]; dup || short == "" {
+			continue
+		}
+		p.ids[short] = b.ID
+		out = append(out, Translation{ShortName: short, FullName: b.Name, Dir: strings.ToLower(b.Language.ScriptDirection), Language: b.Language.Name})
+	}
+	return out, nil
+}
+
+// bibleID looks up the id of translation, fetching the list the first
+// time.
+func (p *apiBibleProvider) bibleID(f Fetcher, translation string) (string, error) {
+	p.mu.Lock()
+	loaded := p.ids != nil
+	p.mu.Unlock()
+	if !loaded {
+		if _, err := p.Translations(f); err != nil {
+			return "", err
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	id, ok := p.ids[strings.ToUpper(translation)]
+	if !ok {
+		return "", unsupported(p, "serve "+translation)
+	}
+	return id, nil
+}
+
+func (p *apiBibleProvider) Books(f Fetcher, translation string) ([]Book, error) {
+	id, err := p.bibleID(f, translation)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Data []struct {
+			ID       string `json:"id"`
+			Name     string `json:"name"`
+			Chapters []struct {
+				Number string `json:"number"`
+			} `json:"chapters"`
+		} `json:"data"`
+	}
+	if err := get(f, fmt.Sprintf("%s/bibles/%s/books?include-chapters=true", apiBibleURL, id), p.header(), &resp); err != nil {
+		return nil, err
+	}
+	var books []Book
+	for _, b := range resp.Data {
+		n := usfmBook(b.ID)
+		if n == 0 {
+			continue // deuterocanonical books have no place in the canon
+		}
+		chapters := 0
+		for _, c := range b.Chapters {
+			if _, err := strconv.Atoi(c.Number); err == nil {
+				chapters++ // not "intro"
+			}
+		}
+		books = append(books, Book{BookID: n, ChronOrder: n, Name: b.Name, Chapters: chapters})
+	}
+	return books, nil
+}
+
+func (p *apiBibleProvider) Chapter(f Fetcher, translation string, book, chapter int) ([]Verse, error) {
+	id, err := p.bibleID(f, translation)
+	if err != nil {
+		return nil, err
+	}
+	if book < 1 || book > len(usfmBooks) {
+		return nil, fmt.Errorf("no book %d", book)
+	}
+	params := url.Values{}
+	params.Set("content-type", "text")
+	params.Set("include-notes", "false")
+	params.Set("include-titles", "false")
+	params.Set("include-chapter-numbers", "false")
+	params.Set("include-verse-numbers", "true")
+	var resp struct {
+		Data struct {
+			Content string `json:"content"`
+		} `json:"data"`
+	}
+	u := fmt.Sprintf("%s/bibles/%s/chapters/%s.%d?%s", apiBibleURL, id, usfmBooks[book-1], chapter, params.Encode())
+	if err := get(f, u, p.header(), &resp); err != nil {
+		return nil, err
+	}
+	return splitNumberedVerses(resp.Data.Content, translation, book, chapter), nil
+}
+
+func (p *apiBibleProvider) Verse(f Fetcher, translation string, book, chapter, verse int) (*Verse, error) {
+	return verseOfChapter(p, f, translation, book, chapter, verse)
+}
+
+func (p *apiBibleProvider) Parallel(f Fetcher, req ParallelVerseRequest) (map[string][]Verse, error) {
+	return parallelOfChapters(p, f, req)
+}
+
+// Search gathers SearchPageSize results from the smaller pages api.bible
+// sends. Verses are named like "JHN.3.16".
+func (p *apiBibleProvider) Search(f Fetcher, translation, query string, page int) (*SearchResponse, error) {
+	id, err := p.bibleID(f, translation)
+	if err != nil {
+		return nil, err
+	}
+	out := &SearchResponse{}
+	for offset := (page - 1) * SearchPageSize; offset < page*SearchPageSize; offset += apiBibleSearchPage {
+		params := url.Values{}
+		params.Set("query", query)
+		params.Set("limit", strconv.Itoa(apiBibleSearchPage))
+		params.Set("offset", strconv.Itoa(offset))
+		var resp struct {
+			Data struct {
+				Total  int `json:"total"`
+				Verses []struct {
+					ID   string `json:"id"`
+					Text string `json:"text"`
+				} `json:"verses"`
+			} `json:"data"`
+		}
+		if err := get(f, fmt.Sprintf("%s/bibles/%s/search?%s", apiBibleURL, id, params.Encode()), p.header(), &resp); err != nil {
+			return nil, err
+		}
+		out.Total = resp.Data.Total
+		for _, v := range resp.Data.Verses {
+			parts := strings.Split(v.ID, ".")
+			if len(parts) != 3 {
+				continue
+			}
+			c, err1 := strconv.Atoi(parts[1])
+			n, err2 := strconv.Atoi(parts[2])
+			if b := usfmBook(parts[0]); b > 0 && err1 == nil && err2 == nil {
+				out.Results = append(out.Results, Verse{Verse: n, Text: v.Text, Translation: translation, Book: b, Chapter: c})
+			}
+		}
+		if offset+apiBibleSearchPage >= resp.Data.Total {
+			break
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const bollsURL = "https://bolls.life"
+
+// bollsProvider is bolls.life, the default provider and the one
+// translations are downloaded from.
+type bollsProvider struct{}
+
+func (bollsProvider) Name() string    { return "bolls.life" }
+func (bollsProvider) PingURL() string { return bollsURL + "/" }
+
+// languagesURL lists bolls.life's translations by language.
+const languagesURL = bollsURL + "/static/bolls/app/views/languages.json"
+
+// Translations returns the translations bolls.life offers, in every
+// language.
+func (bollsProvider) Translations(f Fetcher) ([]Translation, error) {
+	var languageGroups []LanguageGroup
+	if err := get(f, languagesURL, nil, &languageGroups); err != nil {
+		return nil, err
+	}
+	return ungroup(languageGroups), nil
+}
+
+// ungroup flattens the language list, noting each translation's
+// language on it.
+func ungroup(languageGroups []LanguageGroup) []Translation {
+	var out []Translation
+	for _, group := range languageGroups {
+		for _, t := range group.Translations {
+			t.Language = group.Language
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func (bollsProvider) Books(f Fetcher, translation string) ([]Book, error) {
+	var books []Book
+	url := fmt.Sprintf("%s/get-books/%s/", bollsURL, translation)
+	if err := get(f, url, nil, &books); err != nil {
+		return nil, err
+	}
+	return books, nil
+}
+
+func (bollsProvider) Chapter(f Fetcher, translation string, book, chapter int) ([]Verse, error) {
+	var verses []Verse
+	url := fmt.Sprintf("%s/get-text/%s/%d/%d/", bollsURL, translation, book, chapter)
+	if err := get(f, url, nil, &verses); err != nil {
+		return nil, err
+	}
+	return verses, nil
+}
+
+func (bollsProvider) Verse(f Fetcher, translation string, book, chapter, verse int) (*Verse, error) {
+	var v Verse
+	url := fmt.Sprintf("%s/get-verse/%s/%d/%d/%d/", bollsURL, translation, book, chapter, verse)
+	if err := get(f, url, nil, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (bollsProvider) Parallel(f Fetcher, req ParallelVerseRequest) (map[string][]Verse, error) {
+	jsonData, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequest(http.MethodPost, bollsURL+"/get-parallel-verses/", strings.NewReader(string(jsonData)))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	// Response is a nested array structure
+	var rawResponse [][]Verse
+	if err := f.Fetch(httpReq, &rawResponse); err != nil {
+		return nil, err
+	}
+
+	// Convert to map for easier access
+	result := make(map[string][]Verse, len(req.Translations))
+	for i, translation := range req.Translations {
+		if i < len(rawResponse) {
+			result[translation] = rawResponse[i]
+		}
+	}
+	return result, nil
+}
+
+func (bollsProvider) Search(f Fetcher, translation, query string, page int) (*SearchResponse, error) {
+	// Build URL with query parameters
+	params := url.Values{}
+	params.Set("search", query)
+	params.Set("limit", strconv.Itoa(SearchPageSize))
+	if page > 1 {
+		params.Set("page", strconv.Itoa(page))
+	}
+	searchURL := fmt.Sprintf("%s/v2/find/%s?%s", bollsURL, translation, params.Encode())
+
+	var searchResp SearchResponse
+	if err := get(f, searchURL, nil, &searchResp); err != nil {
+		return nil, err
+	}
+	return &searchResp, nil
+}
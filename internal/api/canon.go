@@ -0,0 +1,48 @@
+package api
+
+// canonicalBooks is the Protestant 66-book canon in KJV versification. It is
+// used whenever book metadata is needed without asking bolls.life (local
+// translations, offline startup, reference parsing in the CLI).
+var canonicalBooks = []Book{
+	canon(1, "Genesis", 50), canon(2, "Exodus", 40), canon(3, "Leviticus", 27),
+	canon(4, "Numbers", 36), canon(5, "Deuteronomy", 34), canon(6, "Joshua", 24),
+	canon(7, "Judges", 21), canon(8, "Ruth", 4), canon(9, "1 Samuel", 31),
+	canon(10, "2 Samuel", 24), canon(11, "1 Kings", 22), canon(12, "2 Kings", 25),
+	canon(13, "1 Chronicles", 29), canon(14, "2 Chronicles", 36), canon(15, "Ezra", 10),
+	canon(16, "Nehemiah", 13), canon(17, "Esther", 10), canon(18, "Job", 42),
+	canon(19, "Psalms", 150), canon(20, "Proverbs", 31), canon(21, "Ecclesiastes", 12),
+	canon(22, "Song of Solomon", 8), canon(23, "Isaiah", 66), canon(24, "Jeremiah", 52),
+	canon(25, "Lamentations", 5), canon(26, "Ezekiel", 48), canon(27, "Daniel", 12),
+	canon(28, "Hosea", 14), canon(29, "Joel", 3), canon(30, "Amos", 9),
+	canon(31, "Obadiah", 1), canon(32, "Jonah", 4), canon(33, "Micah", 7),
+	canon(34, "Nahum", 3), canon(35, "Habakkuk", 3), canon(36, "Zephaniah", 3),
+	canon(37, "Haggai", 2), canon(38, "Zechariah", 14), canon(39, "Malachi", 4),
+	canon(40, "Matthew", 28), canon(41, "Mark", 16), canon(42, "Luke", 24),
+	canon(43, "John", 21), canon(44, "Acts", 28), canon(45, "Romans", 16),
+	canon(46, "1 Corinthians", 16), canon(47, "2 Corinthians", 13), canon(48, "Galatians", 6),
+	canon(49, "Ephesians", 6), canon(50, "Philippians", 4), canon(51, "Colossians", 4),
+	canon(52, "1 Thessalonians", 5), canon(53, "2 Thessalonians", 3), canon(54, "1 Timothy", 6),
+	canon(55, "2 Timothy", 4), canon(56, "Titus", 3), canon(57, "Philemon", 1),
+	canon(58, "Hebrews", 13), canon(59, "James", 5), canon(60, "1 Peter", 5),
+	canon(61, "2 Peter", 3), canon(62, "1 John", 5), canon(63, "2 John", 1),
+	canon(64, "3 John", 1), canon(65, "Jude", 1), canon(66, "Revelation", 22),
+}
+
+func canon(id int, name string, chapters int) Book {
+	return Book{BookID: id, ChronOrder: id, Name: name, Chapters: chapters}
+}
+
+// CanonicalBooks returns a copy of the built-in 66-book list.
+func CanonicalBooks() []Book {
+	out := make([]Book, len(canonicalBooks))
+	copy(out, canonicalBooks)
+	return out
+}
+
+// CanonicalBook looks up a book of the built-in list by id.
+func CanonicalBook(id int) (Book, bool) {
+	if id >= 1 && id <= len(canonicalBooks) {
+		return canonicalBooks[id-1], true
+	}
+	return Book{}, false
+}
@@ -1,42 +1,247 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
-	"net/url"
-	"strings"
+	"sync"
+	"time"
 )
 
-const baseURL = "https://bolls.life"
-
 type CacheInterface interface {
 	IsCached(translation string) bool
 	GetChapter(translation string, book, chapter int) ([]Verse, error)
 	GetVerse(translation string, book, chapter, verse int) (*Verse, error)
 }
 
+// VerseCountSource is implemented by caches that know how many verses each
+// chapter has without loading it. GetBooks uses it to fill Book.Verses.
+type VerseCountSource interface {
+	// VerseCounts returns the verses per chapter for each book id.
+	VerseCounts(translation string) (map[int][]int, error)
+	// RecordVerseCount remembers the size of a chapter fetched from the
+	// API, so the count is known next time.
+	RecordVerseCount(translation string, book, chapter, verses int)
+}
+
+// LocalSource serves translations that only exist on this machine (the
+// user's own imported drafts, SWORD modules). They take precedence over
+// the cache and the provider and are never requested from it.
+type LocalSource interface {
+	CacheInterface
+	Translations() []Translation
+	Books(translation string) ([]Book, error)
+}
+
+// LocalSources serves the translations of several sources as one. A
+// translation two of them have comes from the first.
+type LocalSources []LocalSource
+
+func (ls LocalSources) source(translation string) (LocalSource, bool) {
+	for _, l := range ls {
+		if l.IsCached(translation) {
+			return l, true
+		}
+	}
+	return nil, false
+}
+
+func (ls LocalSources) IsCached(translation string) bool {
+	_, ok := ls.source(translation)
+	return ok
+}
+
+func (ls LocalSources) GetChapter(translation string, book, chapter int) ([]Verse, error) {
+	l, ok := ls.source(translation)
+	if !ok {
+		return nil, fmt.Errorf("no local translation %q", translation)
+	}
+	return l.GetChapter(translation, book, chapter)
+}
+
+func (ls LocalSources) GetVerse(translation string, book, chapter, verse int) (*Verse, error) {
+	l, ok := ls.source(translation)
+	if !ok {
+		return nil, fmt.Errorf("no local translation %q", translation)
+	}
+	return l.GetVerse(translation, book, chapter, verse)
+}
+
+func (ls LocalSources) Translations() []Translation {
+	var out []Translation
+	seen := map[string]bool{}
+	for _, l := range ls {
+		for _, t := range l.Translations() {
+			if !seen[t.ShortName] {
+				seen[t.ShortName] = true
+				out = append(out, t)
+			}
+		}
+	}
+	return out
+}
+
+func (ls LocalSources) Books(translation string) ([]Book, error) {
+	l, ok := ls.source(translation)
+	if !ok {
+		return nil, fmt.Errorf("no local translation %q", translation)
+	}
+	return l.Books(translation)
+}
+
+// ResponseStore is implemented by caches that can keep raw API responses
+// on disk. Chapters and comparisons of translations that aren't
+// downloaded and search results are then answered from it while younger
+// than the client's TTL, so flipping between the same chapters doesn't
+// refetch them.
+type ResponseStore interface {
+	// CachedResponse returns the response stored under key if it was
+	// saved less than ttl ago.
+	CachedResponse(key string, ttl time.Duration) ([]byte, bool)
+	StoreResponse(key string, body []byte)
+}
+
+// DefaultResponseTTL is how long stored API responses are reused. Bible
+// text rarely changes, so it is generous.
+const DefaultResponseTTL = 7 * 24 * time.Hour
+
+// Client answers from local translations, then downloaded ones, then
+// its provider, bolls.life unless SetProvider picks another.
 type Client struct {
-	httpClient *http.Client
-	cache      CacheInterface
+	httpClient  *http.Client
+	provider    BibleProvider
+	cache       CacheInterface
+	local       LocalSource
+	responseTTL time.Duration
+	statsMu     sync.Mutex // guards stats
+	stats       RequestStats
+	recent      chapterMemo
 }
 
 func NewClient() *Client {
 	return &Client{
-		httpClient: &http.Client{},
+		httpClient:  &http.Client{Transport: newPoliteTransport(DefaultMaxRequests)},
+		provider:    bollsProvider{},
+		responseTTL: DefaultResponseTTL,
+	}
+}
+
+// SetProvider sets the backend translations that aren't local or
+// downloaded are fetched from.
+func (c *Client) SetProvider(p BibleProvider) {
+	c.provider = p
+}
+
+// Provider returns the backend the client fetches from.
+func (c *Client) Provider() BibleProvider {
+	return c.provider
+}
+
+// SetResponseTTL sets how long stored API responses are reused; 0 turns
+// the response cache off.
+func (c *Client) SetResponseTTL(ttl time.Duration) {
+	c.responseTTL = ttl
+}
+
+// ResponseTTL returns how long stored API responses are reused.
+func (c *Client) ResponseTTL() time.Duration {
+	return c.responseTTL
+}
+
+// Fetch sends req and decodes the JSON body into v. GETs are answered
+// from the response store when it holds a fresh copy, keyed by URL; only
+// responses that decode are stored.
+func (c *Client) Fetch(req *http.Request, v any) error {
+	store, _ := c.cache.(ResponseStore)
+	if c.responseTTL <= 0 || req.Method != http.MethodGet {
+		store = nil
+	}
+	url := req.URL.String()
+	if store != nil {
+		if body, ok := store.CachedResponse(url, c.responseTTL); ok && json.Unmarshal(body, v) == nil {
+			return nil
+		}
+	}
+
+	start := time.Now()
+	body, err := c.do(req)
+	c.record(req, time.Since(start), err)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return err
+	}
+	if store != nil {
+		store.StoreResponse(url, body)
+	}
+	return nil
+}
+
+// do sends req and reads the reply.
+func (c *Client) do(req *http.Request) ([]byte, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, statusError(resp, body)
 	}
+	return io.ReadAll(resp.Body)
+}
+
+// errNotStored is what storedFetcher answers for responses it doesn't
+// have.
+var errNotStored = errors.New("no stored response")
+
+// storedFetcher answers a provider's GETs from the stored responses
+// however old, without going to the network. It lets the reader show the
+// last answer at once while a fresh one is fetched.
+type storedFetcher struct{ c *Client }
+
+func (s storedFetcher) Fetch(req *http.Request, v any) error {
+	store, ok := s.c.cache.(ResponseStore)
+	if !ok || req.Method != http.MethodGet {
+		return errNotStored
+	}
+	body, ok := store.CachedResponse(req.URL.String(), time.Duration(math.MaxInt64))
+	if !ok || json.Unmarshal(body, v) != nil {
+		return errNotStored
+	}
+	return nil
 }
 
 func (c *Client) SetCache(cache CacheInterface) {
 	c.cache = cache
 }
 
+func (c *Client) SetLocal(local LocalSource) {
+	c.local = local
+}
+
+func (c *Client) isLocal(translation string) bool {
+	return c.local != nil && c.local.IsCached(translation)
+}
+
 type Translation struct {
 	ShortName string `json:"short_name"`
 	FullName  string `json:"full_name"`
 	Updated   int64  `json:"updated"`
-	Dir       string `json:"dir,omitempty"`
+	// Dir is "rtl" for translations written right to left.
+	Dir string `json:"dir,omitempty"`
+	// Language is the English name of the translation's language, when
+	// the provider says.
+	Language string `json:"language,omitempty"`
+	// Local marks a personal or SWORD translation that the provider
+	// knows nothing about.
+	Local bool `json:"-"`
 }
 
 type LanguageGroup struct {
@@ -49,6 +254,18 @@ type Book struct {
 	ChronOrder int    `json:"chronorder"`
 	Name       string `json:"name"`
 	Chapters   int    `json:"chapters"`
+	// Verses holds the verse count of each chapter (index 0 is chapter 1)
+	// where known. Providers needn't send it; GetBooks fills it in from
+	// cached data and chapters fetched earlier.
+	Verses []int `json:"verses,omitempty"`
+}
+
+// VerseCount returns the number of verses in chapter, or 0 if unknown.
+func (b Book) VerseCount(chapter int) int {
+	if chapter < 1 || chapter > len(b.Verses) {
+		return 0
+	}
+	return b.Verses[chapter-1]
 }
 
 type Verse struct {
@@ -73,168 +290,206 @@ type SearchResponse struct {
 	Results      []Verse `json:"results"`
 }
 
+// GetTranslations returns the translations offered by the provider, in
+// every language, followed by any local translations. Local translations are
+// returned even when the provider fails, alongside the error.
 func (c *Client) GetTranslations() ([]Translation, error) {
-	url := fmt.Sprintf("%s/static/bolls/app/views/languages.json", baseURL)
-	resp, err := c.httpClient.Get(url)
-	if err != nil {
-		return nil, err
+	var local []Translation
+	if c.local != nil {
+		local = c.local.Translations()
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+	remote, err := c.provider.Translations(c)
+	if err != nil {
+		return local, err
 	}
+	return append(remote, local...), nil
+}
 
-	var languageGroups []LanguageGroup
-	if err := json.NewDecoder(resp.Body).Decode(&languageGroups); err != nil {
-		return nil, err
+// StoredTranslations is GetTranslations answered from the last stored
+// list, however old, or false if there is none.
+func (c *Client) StoredTranslations() ([]Translation, bool) {
+	remote, err := c.provider.Translations(storedFetcher{c})
+	if err != nil {
+		return nil, false
 	}
-
-	// Filter for English translations only
-	var englishTranslations []Translation
-	for _, group := range languageGroups {
-		if group.Language == "English" {
-			englishTranslations = group.Translations
-			break
-		}
+	var local []Translation
+	if c.local != nil {
+		local = c.local.Translations()
 	}
-
-	return englishTranslations, nil
+	return append(remote, local...), true
 }
 
 func (c *Client) GetBooks(translation string) ([]Book, error) {
-	url := fmt.Sprintf("%s/get-books/%s/", baseURL, translation)
-	resp, err := c.httpClient.Get(url)
+	if c.isLocal(translation) {
+		return c.local.Books(translation)
+	}
+
+	books, err := c.provider.Books(c, translation)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	c.addVerseCounts(translation, books)
+	return books, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+// StoredBooks is GetBooks answered from the last stored list, however
+// old, or false if there is none.
+func (c *Client) StoredBooks(translation string) ([]Book, bool) {
+	if c.isLocal(translation) {
+		books, err := c.local.Books(translation)
+		return books, err == nil
 	}
-
-	var books []Book
-	if err := json.NewDecoder(resp.Body).Decode(&books); err != nil {
-		return nil, err
+	books, err := c.provider.Books(storedFetcher{c}, translation)
+	if err != nil {
+		return nil, false
 	}
+	c.addVerseCounts(translation, books)
+	return books, true
+}
 
-	return books, nil
+// addVerseCounts fills in the verse counts the cache knows of.
+func (c *Client) addVerseCounts(translation string, books []Book) {
+	if src, ok := c.cache.(VerseCountSource); ok {
+		if counts, err := src.VerseCounts(translation); err == nil {
+			for i := range books {
+				books[i].Verses = counts[books[i].BookID]
+			}
+		}
+	}
 }
 
 func (c *Client) GetChapter(translation string, book, chapter int) ([]Verse, error) {
+	if c.isLocal(translation) {
+		return c.local.GetChapter(translation, book, chapter)
+	}
+
 	// Try cache first if available
 	if c.cache != nil && c.cache.IsCached(translation) {
 		return c.cache.GetChapter(translation, book, chapter)
 	}
 
-	// Fall back to API
-	url := fmt.Sprintf("%s/get-text/%s/%d/%d/", baseURL, translation, book, chapter)
-	resp, err := c.httpClient.Get(url)
+	// Fall back to the provider, unless the chapter was fetched lately
+	verses, err := c.recent.get(c.chapterKey(translation, book, chapter), func() ([]Verse, error) {
+		return c.provider.Chapter(c, translation, book, chapter)
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
-	}
-
-	var verses []Verse
-	if err := json.NewDecoder(resp.Body).Decode(&verses); err != nil {
-		return nil, err
+	if src, ok := c.cache.(VerseCountSource); ok && len(verses) > 0 {
+		last := 0
+		for _, v := range verses {
+			last = max(last, v.Verse)
+		}
+		src.RecordVerseCount(translation, book, chapter, last)
 	}
 
 	return verses, nil
 }
 
 func (c *Client) GetVerse(translation string, book, chapter, verse int) (*Verse, error) {
+	if c.isLocal(translation) {
+		return c.local.GetVerse(translation, book, chapter, verse)
+	}
+
 	// Try cache first if available
 	if c.cache != nil && c.cache.IsCached(translation) {
 		return c.cache.GetVerse(translation, book, chapter, verse)
 	}
 
-	// Fall back to API
-	url := fmt.Sprintf("%s/get-verse/%s/%d/%d/%d/", baseURL, translation, book, chapter, verse)
-	resp, err := c.httpClient.Get(url)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
-	}
-
-	var v Verse
-	if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
-		return nil, err
-	}
-
-	return &v, nil
+	return c.provider.Verse(c, translation, book, chapter, verse)
 }
 
 func (c *Client) GetParallelVerses(req ParallelVerseRequest) (map[string][]Verse, error) {
-	url := fmt.Sprintf("%s/get-parallel-verses/", baseURL)
-
-	jsonData, err := json.Marshal(req)
-	if err != nil {
-		return nil, err
+	// Local and downloaded translations are answered from disk, and
+	// others from earlier answers while they last; only the rest go to
+	// the provider.
+	result := make(map[string][]Verse)
+	var remote []string
+	for _, t := range req.Translations {
+		var source CacheInterface
+		switch {
+		case c.isLocal(t):
+			source = c.local
+		case c.cache != nil && c.cache.IsCached(t):
+			source = c.cache
+		default:
+			if verses, ok := c.storedParallelVerses(t, req); ok {
+				result[t] = verses
+			} else {
+				remote = append(remote, t)
+			}
+			continue
+		}
+		verses, err := source.GetChapter(t, req.Book, req.Chapter)
+		if err != nil {
+			return nil, err
+		}
+		result[t] = filterVerses(verses, req.Verses)
+	}
+	if len(remote) == 0 {
+		return result, nil
 	}
+	req.Translations = remote
 
-	resp, err := c.httpClient.Post(url, "application/json", strings.NewReader(string(jsonData)))
+	fetched, err := c.provider.Parallel(c, req)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	for t, verses := range fetched {
+		result[t] = verses
+		c.storeParallelVerses(t, req, verses)
 	}
+	return result, nil
+}
 
-	// Response is a nested array structure
-	var rawResponse [][]Verse
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return nil, err
+// filterVerses keeps the verses whose numbers appear in wanted.
+func filterVerses(verses []Verse, wanted []int) []Verse {
+	keep := make(map[int]bool, len(wanted))
+	for _, n := range wanted {
+		keep[n] = true
 	}
-
-	// Convert to map for easier access
-	result := make(map[string][]Verse)
-	for i, translation := range req.Translations {
-		if i < len(rawResponse) {
-			result[translation] = rawResponse[i]
+	var out []Verse
+	for _, v := range verses {
+		if keep[v.Verse] {
+			out = append(out, v)
 		}
 	}
-
-	return result, nil
+	return out
 }
 
+// SearchPageSize is how many verses a page of search results holds.
+const SearchPageSize = 500
+
 func (c *Client) SearchVerses(translation, query string) (*SearchResponse, error) {
-	// Build URL with query parameters
-	searchURL := fmt.Sprintf("%s/v2/find/%s", baseURL, translation)
-	params := url.Values{}
-	params.Set("search", query)
-	params.Set("limit", "500") // Get more results
+	return c.SearchVersesPage(translation, query, 1)
+}
 
-	fullURL := searchURL + "?" + params.Encode()
+// SearchVersesPage returns page (from 1) of the verses matching query,
+// SearchPageSize to a page. Total counts them all.
+func (c *Client) SearchVersesPage(translation, query string, page int) (*SearchResponse, error) {
+	return c.provider.Search(c, translation, query, page)
+}
 
-	resp, err := c.httpClient.Get(fullURL)
-	if err != nil {
-		return nil, err
+// Ping checks that the provider answers, returning the HTTP status and
+// how long the request took. The error is only set when no reply
+// arrived.
+func (c *Client) Ping(ctx context.Context) (int, time.Duration, error) {
+	url := c.provider.PingURL()
+	if url == "" {
+		return 0, 0, fmt.Errorf("%s: %w", c.provider.Name(), ErrOffline)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return 0, 0, err
 	}
-
-	var searchResp SearchResponse
-	if err := json.NewDecoder(resp.Body).Decode(&searchResp); err != nil {
-		return nil, err
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	latency := time.Since(start)
+	if err != nil {
+		return 0, latency, err
 	}
-
-	return &searchResp, nil
+	resp.Body.Close()
+	return resp.StatusCode, latency, nil
 }
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// StatusError is returned when bolls.life answers with anything other
+// than 200 OK. Body holds the start of the response, for diagnostics.
+type StatusError struct {
+	Code int
+	Body string
+	// RetryAfter is how long the server asked us to wait before trying
+	// again (the Retry-After header), or 0 if it didn't say.
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("API returned status %d", e.Code)
+	}
+	return fmt.Sprintf("API returned status %d: %s", e.Code, e.Body)
+}
+
+// Temporary reports whether the request may succeed if repeated later:
+// the server is rate limiting or failing.
+func (e *StatusError) Temporary() bool {
+	return e.Code == http.StatusTooManyRequests || e.Code >= 500
+}
+
+// statusError builds a StatusError from resp, keeping at most a line of
+// body.
+func statusError(resp *http.Response, body []byte) error {
+	const maxBody = 200
+	s := strings.TrimSpace(string(body))
+	if len(s) > maxBody {
+		// Cut on a rune boundary, so the status line gets valid UTF-8.
+		n := maxBody
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+		s = s[:n] + "…"
+	}
+	return &StatusError{
+		Code:       resp.StatusCode,
+		Body:       s,
+		RetryAfter: retryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+}
+
+// retryAfter parses a Retry-After value, given either in seconds or as
+// an HTTP date.
+func retryAfter(v string, now time.Time) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+		return max(time.Duration(secs)*time.Second, 0)
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0)
+	}
+	return 0
+}
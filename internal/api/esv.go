@@ -0,0 +1,115 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const esvURL = "https://api.esv.org/v3"
+
+// esvSearchPage is the most results the ESV API sends at once.
+const esvSearchPage = 100
+
+// esvProvider is Crossway's ESV API, which serves the one translation and
+// needs a key.
+type esvProvider struct{ key string }
+
+func (esvProvider) Name() string    { return "the ESV API" }
+func (esvProvider) PingURL() string { return esvURL + "/" }
+
+func (p esvProvider) header() http.Header {
+	return http.Header{"Authorization": {"Token " + p.key}}
+}
+
+func (esvProvider) Translations(Fetcher) ([]Translation, error) {
+	return []Translation{{ShortName: "ESV", FullName: "English Standard Version", Language: "English"}}, nil
+}
+
+// only refuses translations other than the ESV.
+func (p esvProvider) only(translation string) error {
+	if !strings.EqualFold(translation, "ESV") {
+		return unsupported(p, "serve "+translation)
+	}
+	return nil
+}
+
+func (p esvProvider) Books(_ Fetcher, translation string) ([]Book, error) {
+	if err := p.only(translation); err != nil {
+		return nil, err
+	}
+	return CanonicalBooks(), nil
+}
+
+func (p esvProvider) Chapter(f Fetcher, translation string, book, chapter int) ([]Verse, error) {
+	if err := p.only(translation); err != nil {
+		return nil, err
+	}
+	b, ok := CanonicalBook(book)
+	if !ok {
+		return nil, fmt.Errorf("no book %d", book)
+	}
+	params := url.Values{}
+	params.Set("q", fmt.Sprintf("%s %d", b.Name, chapter))
+	for _, off := range []string{"include-passage-references", "include-footnotes", "include-headings", "include-short-copyright"} {
+		params.Set(off, "false")
+	}
+	params.Set("include-first-verse-numbers", "true")
+	params.Set("indent-poetry", "false")
+	params.Set("indent-paragraphs", "0")
+
+	var resp struct {
+		Passages []string `json:"passages"`
+	}
+	if err := get(f, esvURL+"/passage/text/?"+params.Encode(), p.header(), &resp); err != nil {
+		return nil, err
+	}
+	return splitNumberedVerses(strings.Join(resp.Passages, " "), translation, book, chapter), nil
+}
+
+func (p esvProvider) Verse(f Fetcher, translation string, book, chapter, verse int) (*Verse, error) {
+	return verseOfChapter(p, f, translation, book, chapter, verse)
+}
+
+func (p esvProvider) Parallel(f Fetcher, req ParallelVerseRequest) (map[string][]Verse, error) {
+	return parallelOfChapters(p, f, req)
+}
+
+// Search gathers SearchPageSize results from the smaller pages the ESV
+// API sends.
+func (p esvProvider) Search(f Fetcher, translation, query string, page int) (*SearchResponse, error) {
+	if err := p.only(translation); err != nil {
+		return nil, err
+	}
+	per := SearchPageSize / esvSearchPage
+	out := &SearchResponse{}
+	for n := (page-1)*per + 1; n <= page*per; n++ {
+		params := url.Values{}
+		params.Set("q", query)
+		params.Set("page-size", strconv.Itoa(esvSearchPage))
+		params.Set("page", strconv.Itoa(n))
+		var resp struct {
+			TotalResults int `json:"total_results"`
+			TotalPages   int `json:"total_pages"`
+			Results      []struct {
+				Reference string `json:"reference"`
+				Content   string `json:"content"`
+			} `json:"results"`
+		}
+		if err := get(f, esvURL+"/passage/search/?"+params.Encode(), p.header(), &resp); err != nil {
+			return nil, err
+		}
+		out.Total = resp.TotalResults
+		for _, r := range resp.Results {
+			if b, c, v, ok := parseReference(r.Reference); ok {
+				out.Results = append(out.Results, Verse{Verse: v, Text: r.Content, Translation: translation, Book: b, Chapter: c})
+			}
+		}
+		if n >= resp.TotalPages {
+			break
+		}
+	}
+	return out, nil
+}
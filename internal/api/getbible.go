@@ -0,0 +1,92 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const getBibleURL = "https://api.getbible.net/v2"
+
+// getBibleProvider is getbible.net, which serves whole translations as
+// static JSON and needs no key. It has no search. Its abbreviations are
+// lower case; they are shown in upper case like everyone else's.
+type getBibleProvider struct{}
+
+func (getBibleProvider) Name() string    { return "getbible.net" }
+func (getBibleProvider) PingURL() string { return getBibleURL + "/translations.json" }
+
+func (getBibleProvider) Translations(f Fetcher) ([]Translation, error) {
+	var all map[string]struct {
+		Translation  string `json:"translation"`
+		Abbreviation string `json:"abbreviation"`
+		Language     string `json:"language"`
+		Direction    string `json:"direction"`
+	}
+	if err := get(f, getBibleURL+"/translations.json", nil, &all); err != nil {
+		return nil, err
+	}
+	var out []Translation
+	for _, t := range all {
+		out = append(out, Translation{
+			ShortName: strings.ToUpper(t.Abbreviation),
+			FullName:  t.Translation,
+			Dir:       strings.ToLower(t.Direction),
+			Language:  t.Language,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ShortName < out[j].ShortName })
+	return out, nil
+}
+
+// Books lists the translation's books with the canonical chapter counts,
+// which getbible.net only gives a request per book.
+func (getBibleProvider) Books(f Fetcher, translation string) ([]Book, error) {
+	var all map[string]struct {
+		Nr   int    `json:"nr"`
+		Name string `json:"name"`
+	}
+	url := fmt.Sprintf("%s/%s/books.json", getBibleURL, strings.ToLower(translation))
+	if err := get(f, url, nil, &all); err != nil {
+		return nil, err
+	}
+	var books []Book
+	for _, b := range all {
+		if c, ok := CanonicalBook(b.Nr); ok {
+			c.Name = b.Name
+			books = append(books, c)
+		}
+	}
+	sort.Slice(books, func(i, j int) bool { return books[i].BookID < books[j].BookID })
+	return books, nil
+}
+
+func (getBibleProvider) Chapter(f Fetcher, translation string, book, chapter int) ([]Verse, error) {
+	var resp struct {
+		Verses []struct {
+			Verse int    `json:"verse"`
+			Text  string `json:"text"`
+		} `json:"verses"`
+	}
+	url := fmt.Sprintf("%s/%s/%d/%d.json", getBibleURL, strings.ToLower(translation), book, chapter)
+	if err := get(f, url, nil, &resp); err != nil {
+		return nil, err
+	}
+	verses := make([]Verse, 0, len(resp.Verses))
+	for _, v := range resp.Verses {
+		verses = append(verses, Verse{Verse: v.Verse, Text: strings.TrimSpace(v.Text), Translation: translation, Book: book, Chapter: chapter})
+	}
+	return verses, nil
+}
+
+func (p getBibleProvider) Verse(f Fetcher, translation string, book, chapter, verse int) (*Verse, error) {
+	return verseOfChapter(p, f, translation, book, chapter, verse)
+}
+
+func (p getBibleProvider) Parallel(f Fetcher, req ParallelVerseRequest) (map[string][]Verse, error) {
+	return parallelOfChapters(p, f, req)
+}
+
+func (p getBibleProvider) Search(Fetcher, string, string, int) (*SearchResponse, error) {
+	return nil, unsupported(p, "search")
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// Comparisons ask for the same chapter in the same translations again and
+// again, so each translation's part of a parallel request is kept in the
+// response store on its own. Opening a comparison then only asks the
+// provider for the translations it hasn't answered for that chapter yet.
+
+// storedParallel is what the response store keeps of one translation's
+// part of a parallel request: the verses asked for and those returned,
+// which may be fewer.
+type storedParallel struct {
+	Asked  []int   `json:"asked"`
+	Verses []Verse `json:"verses"`
+}
+
+// parallelKey is the response store key of translation's part of the
+// parallel verses of a chapter.
+func (c *Client) parallelKey(translation string, book, chapter int) string {
+	return fmt.Sprintf("parallel:%s/%s/%d/%d", c.provider.Name(), translation, book, chapter)
+}
+
+// parallelStore returns the response store, or false when responses
+// aren't being kept.
+func (c *Client) parallelStore() (ResponseStore, bool) {
+	store, ok := c.cache.(ResponseStore)
+	return store, ok && c.responseTTL > 0
+}
+
+// storedParallelVerses returns translation's part of req from an earlier
+// request that asked for all of its verses.
+func (c *Client) storedParallelVerses(translation string, req ParallelVerseRequest) ([]Verse, bool) {
+	store, ok := c.parallelStore()
+	if !ok {
+		return nil, false
+	}
+	body, ok := store.CachedResponse(c.parallelKey(translation, req.Book, req.Chapter), c.responseTTL)
+	if !ok {
+		return nil, false
+	}
+	var stored storedParallel
+	if json.Unmarshal(body, &stored) != nil {
+		return nil, false
+	}
+	for _, n := range req.Verses {
+		if !slices.Contains(stored.Asked, n) {
+			return nil, false
+		}
+	}
+	return filterVerses(stored.Verses, req.Verses), true
+}
+
+// storeParallelVerses keeps translation's part of req's answer.
+func (c *Client) storeParallelVerses(translation string, req ParallelVerseRequest, verses []Verse) {
+	store, ok := c.parallelStore()
+	if !ok {
+		return
+	}
+	body, err := json.Marshal(storedParallel{Asked: req.Verses, Verses: verses})
+	if err != nil {
+		return
+	}
+	store.StoreResponse(c.parallelKey(translation, req.Book, req.Chapter), body)
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// recentChapters is how many chapters fetched from the provider are kept
+// in memory.
+const recentChapters = 16
+
+// chapterMemo keeps the chapters last fetched from the provider in
+// memory, so stepping back and forth between them (and to those fetched
+// ahead by Prefetch) needs no request or disk read. A request for a
+// chapter already being fetched waits for that fetch instead of sending
+// another. The zero value is ready to use.
+type chapterMemo struct {
+	mu      sync.Mutex
+	order   []string // least recently used first
+	entries map[string][]Verse
+	pending map[string]*pendingChapter
+}
+
+// pendingChapter is a fetch in progress; done is closed when verses and
+// err are set.
+type pendingChapter struct {
+	done   chan struct{}
+	verses []Verse
+	err    error
+}
+
+// get returns the chapter under key, calling fetch for it unless it is
+// kept or already being fetched.
+func (m *chapterMemo) get(key string, fetch func() ([]Verse, error)) ([]Verse, error) {
+	m.mu.Lock()
+	if verses, ok := m.entries[key]; ok {
+		m.use(key)
+		m.mu.Unlock()
+		return verses, nil
+	}
+	if p, ok := m.pending[key]; ok {
+		m.mu.Unlock()
+		<-p.done
+		return p.verses, p.err
+	}
+	if m.pending == nil {
+		m.pending = map[string]*pendingChapter{}
+		m.entries = map[string][]Verse{}
+	}
+	p := &pendingChapter{done: make(chan struct{})}
+	m.pending[key] = p
+	m.mu.Unlock()
+
+	p.verses, p.err = fetch()
+
+	m.mu.Lock()
+	delete(m.pending, key)
+	if p.err == nil {
+		m.entries[key] = p.verses
+		m.use(key)
+		if len(m.order) > recentChapters {
+			delete(m.entries, m.order[0])
+			m.order = m.order[1:]
+		}
+	}
+	m.mu.Unlock()
+	close(p.done)
+	return p.verses, p.err
+}
+
+// use moves key to the most recently used end.
+func (m *chapterMemo) use(key string) {
+	m.order = slices.DeleteFunc(m.order, func(k string) bool { return k == key })
+	m.order = append(m.order, key)
+}
+
+// chapterKey names a chapter of the provider's in the memo.
+func (c *Client) chapterKey(translation string, book, chapter int) string {
+	return fmt.Sprintf("%s/%s/%d/%d", c.provider.Name(), translation, book, chapter)
+}
+
+// Prefetch fetches a chapter ahead of its being read, keeping it in
+// memory and the response store. Local and downloaded translations are
+// on disk already and are left alone. Errors are left for the read.
+func (c *Client) Prefetch(translation string, book, chapter int) {
+	if c.isLocal(translation) || c.cache != nil && c.cache.IsCached(translation) {
+		return
+	}
+	c.GetChapter(translation, book, chapter)
+}
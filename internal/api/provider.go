@@ -0,0 +1,206 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// BibleProvider is a backend Bible text is fetched from. The Client puts
+// the downloaded and local translations in front of it and keeps its
+// responses, so a provider only has to speak its service's API. Requests
+// go through the Fetcher it is handed, which answers from the response
+// store where it can.
+type BibleProvider interface {
+	// Name is how the service is called in messages, e.g. "bolls.life".
+	Name() string
+	Translations(f Fetcher) ([]Translation, error)
+	Books(f Fetcher, translation string) ([]Book, error)
+	Chapter(f Fetcher, translation string, book, chapter int) ([]Verse, error)
+	Verse(f Fetcher, translation string, book, chapter, verse int) (*Verse, error)
+	Parallel(f Fetcher, req ParallelVerseRequest) (map[string][]Verse, error)
+	// Search returns page (from 1) of the verses matching query,
+	// SearchPageSize to a page.
+	Search(f Fetcher, translation, query string, page int) (*SearchResponse, error)
+	// PingURL is requested to check that the service answers; "" when
+	// there is no service to reach.
+	PingURL() string
+}
+
+// Fetcher sends a provider's requests.
+type Fetcher interface {
+	// Fetch sends req and decodes the JSON reply into v. A reply other
+	// than 200 OK is a *StatusError.
+	Fetch(req *http.Request, v any) error
+}
+
+// Providers lists the names NewProvider accepts, the default first.
+func Providers() []string {
+	return []string{"bolls", "getbible", "esv", "api.bible", "sword"}
+}
+
+// NewProvider returns the provider called name, which is one of
+// Providers; "" is bolls.life. key is the API key of the services that
+// need one (esv, api.bible).
+func NewProvider(name, key string) (BibleProvider, error) {
+	switch strings.ToLower(name) {
+	case "", "bolls", "bolls.life":
+		return bollsProvider{}, nil
+	case "getbible", "getbible.net":
+		return getBibleProvider{}, nil
+	case "esv":
+		if key == "" {
+			return nil, errors.New("the ESV API needs an API key (provider_key), from https://api.esv.org")
+		}
+		return esvProvider{key: key}, nil
+	case "api.bible", "apibible":
+		if key == "" {
+			return nil, errors.New("api.bible needs an API key (provider_key), from https://scripture.api.bible")
+		}
+		return newAPIBibleProvider(key), nil
+	case "sword", "offline", "none":
+		return offlineProvider{}, nil
+	}
+	return nil, fmt.Errorf("unknown provider %q (want one of %s)", name, strings.Join(Providers(), ", "))
+}
+
+// ErrOffline is returned by the sword provider, which has no service to
+// ask, for anything that isn't downloaded or local.
+var ErrOffline = errors.New("not available offline")
+
+// offlineProvider serves nothing itself: only downloaded, personal and
+// SWORD translations are read, and nothing goes online.
+type offlineProvider struct{}
+
+func (offlineProvider) Name() string                                { return "local modules" }
+func (offlineProvider) PingURL() string                             { return "" }
+func (offlineProvider) Translations(Fetcher) ([]Translation, error) { return nil, nil }
+
+func (offlineProvider) Books(_ Fetcher, translation string) ([]Book, error) {
+	return nil, fmt.Errorf("%s: %w", translation, ErrOffline)
+}
+
+func (offlineProvider) Chapter(_ Fetcher, translation string, _, _ int) ([]Verse, error) {
+	return nil, fmt.Errorf("%s: %w", translation, ErrOffline)
+}
+
+func (offlineProvider) Verse(_ Fetcher, translation string, _, _, _ int) (*Verse, error) {
+	return nil, fmt.Errorf("%s: %w", translation, ErrOffline)
+}
+
+func (offlineProvider) Parallel(_ Fetcher, req ParallelVerseRequest) (map[string][]Verse, error) {
+	return nil, fmt.Errorf("%s: %w", strings.Join(req.Translations, ", "), ErrOffline)
+}
+
+func (offlineProvider) Search(_ Fetcher, translation, _ string, _ int) (*SearchResponse, error) {
+	return nil, fmt.Errorf("searching %s: %w", translation, ErrOffline)
+}
+
+// unsupported is the error of a provider whose service can't do what.
+func unsupported(p BibleProvider, what string) error {
+	return fmt.Errorf("%s can't %s: %w", p.Name(), what, errors.ErrUnsupported)
+}
+
+// verseOfChapter answers Verse from the whole chapter, for services that
+// have no call for a single verse.
+func verseOfChapter(p BibleProvider, f Fetcher, translation string, book, chapter, verse int) (*Verse, error) {
+	verses, err := p.Chapter(f, translation, book, chapter)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range verses {
+		if v.Verse == verse {
+			return &v, nil
+		}
+	}
+	return nil, fmt.Errorf("%s has no verse %d:%d of book %d", translation, chapter, verse, book)
+}
+
+// parallelOfChapters answers Parallel a chapter per translation, for
+// services that can't send several at once.
+func parallelOfChapters(p BibleProvider, f Fetcher, req ParallelVerseRequest) (map[string][]Verse, error) {
+	result := make(map[string][]Verse, len(req.Translations))
+	for _, t := range req.Translations {
+		verses, err := p.Chapter(f, t, req.Book, req.Chapter)
+		if err != nil {
+			return nil, err
+		}
+		result[t] = filterVerses(verses, req.Verses)
+	}
+	return result, nil
+}
+
+// verseMarker is a verse number in running text, as "[16]".
+var verseMarker = regexp.MustCompile(`\[(\d+)\]`)
+
+// splitNumberedVerses cuts a chapter sent as one text with "[n]" before
+// each verse into its verses.
+func splitNumberedVerses(text, translation string, book, chapter int) []Verse {
+	marks := verseMarker.FindAllStringSubmatchIndex(text, -1)
+	var verses []Verse
+	for i, mk := range marks {
+		end := len(text)
+		if i+1 < len(marks) {
+			end = marks[i+1][0]
+		}
+		n, _ := strconv.Atoi(text[mk[2]:mk[3]])
+		body := strings.Join(strings.Fields(text[mk[1]:end]), " ")
+		if n == 0 || body == "" {
+			continue
+		}
+		verses = append(verses, Verse{Verse: n, Text: body, Translation: translation, Book: book, Chapter: chapter})
+	}
+	return verses
+}
+
+// canonicalBookNamed finds a book of the built-in list by its English
+// name, allowing the singular "Psalm".
+func canonicalBookNamed(name string) (Book, bool) {
+	i := slices.IndexFunc(canonicalBooks, func(b Book) bool {
+		return strings.EqualFold(b.Name, name) || strings.EqualFold(strings.TrimSuffix(b.Name, "s"), name)
+	})
+	if i < 0 {
+		return Book{}, false
+	}
+	return canonicalBooks[i], true
+}
+
+// parseReference splits a reference like "1 John 3:16" into book,
+// chapter and verse. Books of one chapter may leave it out ("Jude 3").
+func parseReference(ref string) (book, chapter, verse int, ok bool) {
+	sp := strings.LastIndexByte(ref, ' ')
+	if sp < 0 {
+		return 0, 0, 0, false
+	}
+	b, found := canonicalBookNamed(ref[:sp])
+	c, v, colon := strings.Cut(ref[sp+1:], ":")
+	if found && !colon && b.Chapters == 1 {
+		c, v, colon = "1", c, true
+	}
+	if !found || !colon {
+		return 0, 0, 0, false
+	}
+	chapter, err1 := strconv.Atoi(c)
+	verse, err2 := strconv.Atoi(strings.SplitN(v, "-", 2)[0])
+	if err1 != nil || err2 != nil {
+		return 0, 0, 0, false
+	}
+	return b.BookID, chapter, verse, true
+}
+
+// get fetches url through f with header added, decoding the reply into
+// v.
+func get(f Fetcher, url string, header http.Header, v any) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	for k, vs := range header {
+		req.Header[k] = vs
+	}
+	return f.Fetch(req, v)
+}
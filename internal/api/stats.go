@@ -0,0 +1,70 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RequestStats sums up the requests a client has sent to its provider,
+// leaving out those answered from the response store.
+type RequestStats struct {
+	Requests int
+	Failed   int           // no reply, or a reply other than 200 OK
+	Total    time.Duration // spent waiting, all told
+	Slowest  time.Duration
+	// Last is how long the latest request took, and LastAt when it
+	// finished.
+	Last   time.Duration
+	LastAt time.Time
+}
+
+// Mean is the average time a request took.
+func (s RequestStats) Mean() time.Duration {
+	if s.Requests == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Requests)
+}
+
+func (s RequestStats) String() string {
+	return fmt.Sprintf("%d requests, %d failed, mean %s, slowest %s",
+		s.Requests, s.Failed, s.Mean().Round(time.Millisecond), s.Slowest.Round(time.Millisecond))
+}
+
+// Stats returns the client's request statistics so far. Safe to call
+// from any goroutine.
+func (c *Client) Stats() RequestStats {
+	c.statsMu.Lock()
+	defer c.statsMu.Unlock()
+	return c.stats
+}
+
+// record notes a request that took latency and ended in err, and writes
+// it to the log.
+func (c *Client) record(req *http.Request, latency time.Duration, err error) {
+	c.statsMu.Lock()
+	s := &c.stats
+	s.Requests++
+	if err != nil {
+		s.Failed++
+	}
+	s.Total += latency
+	s.Slowest = max(s.Slowest, latency)
+	s.Last, s.LastAt = latency, time.Now()
+	c.statsMu.Unlock()
+
+	// Transport errors name the request themselves.
+	var uerr *url.Error
+	if errors.As(err, &uerr) {
+		err = uerr.Err
+	}
+	if err != nil {
+		log.Printf("%s %s: %v after %s", req.Method, req.URL, err, latency.Round(time.Millisecond))
+	} else {
+		log.Printf("%s %s: %s", req.Method, req.URL, latency.Round(time.Millisecond))
+	}
+}
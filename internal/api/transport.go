@@ -0,0 +1,65 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"sync"
+
+	"sword-tui/internal/version"
+)
+
+// DefaultMaxRequests is how many requests a client keeps in flight to its
+// provider at once unless SetMaxRequests says otherwise. Comparisons and
+// prefetching can ask for several things together; the rest wait.
+const DefaultMaxRequests = 4
+
+// politeTransport sends requests with sword-tui's User-Agent and no more
+// than cap(slots) at a time. A slot is held until the reply's body is
+// closed.
+type politeTransport struct {
+	base  http.RoundTripper
+	slots chan struct{}
+}
+
+func newPoliteTransport(maxRequests int) *politeTransport {
+	if maxRequests <= 0 {
+		maxRequests = DefaultMaxRequests
+	}
+	return &politeTransport{base: http.DefaultTransport, slots: make(chan struct{}, maxRequests)}
+}
+
+func (t *politeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("User-Agent") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", version.UserAgent())
+	}
+	select {
+	case t.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		<-t.slots
+		return nil, err
+	}
+	resp.Body = &slotBody{ReadCloser: resp.Body, release: sync.OnceFunc(func() { <-t.slots })}
+	return resp, nil
+}
+
+// slotBody gives back its request's slot when closed.
+type slotBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *slotBody) Close() error {
+	b.release()
+	return b.ReadCloser.Close()
+}
+
+// SetMaxRequests sets how many requests may be in flight to the provider
+// at once; 0 means DefaultMaxRequests.
+func (c *Client) SetMaxRequests(n int) {
+	c.httpClient.Transport = newPoliteTransport(n)
+}
@@ -0,0 +1,165 @@
+// Package bookmarks stores bookmarked references, optionally grouped into
+// named collections, in the annotations directory.
+package bookmarks
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"sword-tui/internal/jsonfile"
+	"sword-tui/internal/settings"
+)
+
+// Bookmark marks a verse range (or a whole chapter when VerseStart is 0).
+type Bookmark struct {
+	Book       int       `json:"book"`
+	BookName   string    `json:"book_name"`
+	Chapter    int       `json:"chapter"`
+	VerseStart int       `json:"verse_start,omitempty"`
+	VerseEnd   int       `json:"verse_end,omitempty"`
+	Collection string    `json:"collection,omitempty"` // "" is unfiled
+	Added      time.Time `json:"added"`
+}
+
+// Reference renders the bookmark as "John 3:16-18".
+func (b Bookmark) Reference() string {
+	switch {
+	case b.VerseStart == 0:
+		return fmt.Sprintf("%s %d", b.BookName, b.Chapter)
+	case b.VerseStart == b.VerseEnd:
+		return fmt.Sprintf("%s %d:%d", b.BookName, b.Chapter, b.VerseStart)
+	default:
+		return fmt.Sprintf("%s %d:%d-%d", b.BookName, b.Chapter, b.VerseStart, b.VerseEnd)
+	}
+}
+
+// Contains reports whether the bookmark covers the given verse. A
+// whole-chapter bookmark covers every verse of the chapter.
+func (b Bookmark) Contains(book, chapter, verse int) bool {
+	if b.Book != book || b.Chapter != chapter {
+		return false
+	}
+	return b.VerseStart == 0 || (verse >= b.VerseStart && verse <= b.VerseEnd)
+}
+
+func (b Bookmark) same(o Bookmark) bool {
+	return b.Book == o.Book && b.Chapter == o.Chapter &&
+		b.VerseStart == o.VerseStart && b.VerseEnd == o.VerseEnd &&
+		b.Collection == o.Collection
+}
+
+// Store holds every bookmark, kept grouped by collection (unfiled first)
+// and then in canonical order.
+type Store struct {
+	Bookmarks []Bookmark `json:"bookmarks"`
+
+	file jsonfile.File
+}
+
+func path() (string, error) {
+	dir, err := settings.AnnotationsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "bookmarks.json"), nil
+}
+
+// Load reads the saved bookmarks. A missing file is an empty store; one
+// that can't be read is an empty store that won't be saved.
+func Load() (*Store, error) {
+	s := &Store{}
+	p, err := path()
+	if err != nil {
+		return s, err
+	}
+	if s.file, err = jsonfile.Load(p, s); err != nil {
+		return &Store{file: s.file}, err
+	}
+	return s, nil
+}
+
+func (s *Store) Save() error {
+	p, err := path()
+	if err != nil {
+		return err
+	}
+	return s.file.Save(p, s)
+}
+
+// Add appends b unless an identical bookmark already exists in the same
+// collection. It reports whether anything was added.
+func (s *Store) Add(b Bookmark) bool {
+	for _, o := range s.Bookmarks {
+		if o.same(b) {
+			return false
+		}
+	}
+	if b.Added.IsZero() {
+		b.Added = time.Now()
+	}
+	s.Bookmarks = append(s.Bookmarks, b)
+	s.sort()
+	return true
+}
+
+// Toggle removes b if it exists and adds it otherwise. It reports whether
+// b is bookmarked afterwards.
+func (s *Store) Toggle(b Bookmark) bool {
+	for i, o := range s.Bookmarks {
+		if o.same(b) {
+			s.Remove(i)
+			return false
+		}
+	}
+	s.Add(b)
+	return true
+}
+
+func (s *Store) Remove(i int) {
+	if i < 0 || i >= len(s.Bookmarks) {
+		return
+	}
+	s.Bookmarks = append(s.Bookmarks[:i], s.Bookmarks[i+1:]...)
+}
+
+// Has reports whether any bookmark covers the given verse.
+func (s *Store) Has(book, chapter, verse int) bool {
+	for _, b := range s.Bookmarks {
+		if b.Contains(book, chapter, verse) {
+			return true
+		}
+	}
+	return false
+}
+
+// Collections returns the names of all non-empty collections, sorted.
+func (s *Store) Collections() []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, b := range s.Bookmarks {
+		if b.Collection != "" && !seen[b.Collection] {
+			seen[b.Collection] = true
+			names = append(names, b.Collection)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *Store) sort() {
+	sort.SliceStable(s.Bookmarks, func(i, j int) bool {
+		a, b := s.Bookmarks[i], s.Bookmarks[j]
+		if a.Collection != b.Collection {
+			return a.Collection < b.Collection
+		}
+		if a.Book != b.Book {
+			return a.Book < b.Book
+		}
+		if a.Chapter != b.Chapter {
+			return a.Chapter < b.Chapter
+		}
+		return a.VerseStart < b.VerseStart
+	})
+}
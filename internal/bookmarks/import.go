@@ -0,0 +1,208 @@
+package bookmarks
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/reference"
+)
+
+// ImportResult summarises a bulk import.
+type ImportResult struct {
+	Added      int
+	Duplicates int
+	Skipped    []string // one description per unparseable row
+}
+
+// Import reads a list of references and adds them to the store. CSV input
+// is either a single column of references ("John 3:16", "Rom 8:28-30") or
+// has a header naming any of reference, book, chapter, verse, verse_end and
+// collection. JSON input is an array of reference strings or of objects
+// with the same fields. Rows without a collection of their own go into
+// collection ("" for unfiled).
+func (s *Store) Import(r io.Reader, format, collection string) (ImportResult, error) {
+	var rows []importRow
+	var err error
+	switch strings.ToLower(format) {
+	case "csv":
+		rows, err = readCSV(r)
+	case "json":
+		rows, err = readJSON(r)
+	default:
+		return ImportResult{}, fmt.Errorf("unsupported format %q (want csv or json)", format)
+	}
+	if err != nil {
+		return ImportResult{}, err
+	}
+
+	var res ImportResult
+	books := api.CanonicalBooks()
+	for _, rw := range rows {
+		b, err := rw.bookmark(books)
+		if err != nil {
+			res.Skipped = append(res.Skipped, fmt.Sprintf("row %d: %v", rw.line, err))
+			continue
+		}
+		if b.Collection == "" {
+			b.Collection = collection
+		}
+		if s.Add(b) {
+			res.Added++
+		} else {
+			res.Duplicates++
+		}
+	}
+	return res, nil
+}
+
+// importRow is one input record before validation. Either ref or the
+// numeric fields are set.
+type importRow struct {
+	line                     int
+	ref                      string
+	book                     string
+	chapter, verse, verseEnd int
+	collection               string
+}
+
+func (rw importRow) bookmark(books []api.Book) (Bookmark, error) {
+	var b Bookmark
+	switch {
+	case rw.ref != "":
+		book, ch, vs, ve, err := reference.Parse(rw.ref, books)
+		if err != nil {
+			return b, err
+		}
+		b = Bookmark{Book: book, Chapter: ch, VerseStart: vs, VerseEnd: ve}
+	case rw.book != "":
+		id, err := strconv.Atoi(rw.book)
+		if err != nil {
+			var found bool
+			if id, _, found = reference.MatchBook(rw.book, books); !found {
+				return b, fmt.Errorf("book not found: %s", rw.book)
+			}
+		}
+		b = Bookmark{Book: id, Chapter: rw.chapter, VerseStart: rw.verse, VerseEnd: rw.verseEnd}
+		if b.VerseEnd < b.VerseStart {
+			b.VerseEnd = b.VerseStart
+		}
+	default:
+		return b, fmt.Errorf("no reference")
+	}
+
+	meta, ok := api.CanonicalBook(b.Book)
+	if !ok {
+		return b, fmt.Errorf("unknown book %d", b.Book)
+	}
+	if b.Chapter < 1 || b.Chapter > meta.Chapters {
+		return b, fmt.Errorf("%s has no chapter %d", meta.Name, b.Chapter)
+	}
+	if b.VerseStart < 0 || b.VerseEnd < b.VerseStart {
+		return b, fmt.Errorf("bad verse range %d-%d", b.VerseStart, b.VerseEnd)
+	}
+	b.BookName = meta.Name
+	b.Collection = strings.TrimSpace(rw.collection)
+	return b, nil
+}
+
+func readCSV(r io.Reader) ([]importRow, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	// A header row maps column names to positions; without one the first
+	// column is the reference and an optional second the collection.
+	cols := map[string]int{"reference": 0, "collection": 1}
+	start := 0
+	header := map[string]int{}
+	for i, name := range records[0] {
+		switch n := strings.ToLower(strings.TrimSpace(name)); n {
+		case "ref", "reference", "book", "chapter", "verse", "verse_end", "collection":
+			if n == "ref" {
+				n = "reference"
+			}
+			header[n] = i
+		}
+	}
+	if len(header) > 0 {
+		cols, start = header, 1
+	}
+
+	field := func(rec []string, name string) string {
+		if i, ok := cols[name]; ok && i < len(rec) {
+			return strings.TrimSpace(rec[i])
+		}
+		return ""
+	}
+	num := func(rec []string, name string) int {
+		n, _ := strconv.Atoi(field(rec, name))
+		return n
+	}
+
+	var rows []importRow
+	for i := start; i < len(records); i++ {
+		rec := records[i]
+		if len(rec) == 1 && strings.TrimSpace(rec[0]) == "" {
+			continue
+		}
+		rows = append(rows, importRow{
+			line:       i + 1,
+			ref:        field(rec, "reference"),
+			book:       field(rec, "book"),
+			chapter:    num(rec, "chapter"),
+			verse:      num(rec, "verse"),
+			verseEnd:   num(rec, "verse_end"),
+			collection: field(rec, "collection"),
+		})
+	}
+	return rows, nil
+}
+
+func readJSON(r io.Reader) ([]importRow, error) {
+	var items []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return nil, err
+	}
+	rows := make([]importRow, len(items))
+	for i, raw := range items {
+		rows[i].line = i + 1
+		var ref string
+		if json.Unmarshal(raw, &ref) == nil {
+			rows[i].ref = ref
+			continue
+		}
+		var obj struct {
+			Reference  string          `json:"reference"`
+			Book       json.RawMessage `json:"book"`
+			Chapter    int             `json:"chapter"`
+			Verse      int             `json:"verse"`
+			VerseEnd   int             `json:"verse_end"`
+			Collection string          `json:"collection"`
+		}
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			// Leave the row empty so it is reported as unparseable.
+			continue
+		}
+		rows[i].ref = obj.Reference
+		if len(obj.Book) > 0 && string(obj.Book) != "null" {
+			rows[i].book = strings.Trim(string(obj.Book), `"`)
+		}
+		rows[i].chapter = obj.Chapter
+		rows[i].verse = obj.Verse
+		rows[i].verseEnd = obj.VerseEnd
+		rows[i].collection = obj.Collection
+	}
+	return rows, nil
+}
@@ -2,14 +2,18 @@ package cache
 
 import (
 	"archive/zip"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
 	"sync"
 	"sword-tui/internal/api"
+	"sword-tui/internal/version"
 )
 
 const baseURL = "https://bolls.life/static/translations"
@@ -18,8 +22,19 @@ type Cache struct {
 	cacheDir string
 
 	mu       sync.Mutex
-	progress float64 // [0, 1] for the current download, 0 if idle
-	active   string  // translation short-name being downloaded, or ""
+	progress float64            // [0, 1] for the current download, 0 if idle
+	active   string             // translation short-name being downloaded, or ""
+	read     int64              // bytes of it received so far
+	total    int64              // its size, or -1 if the server didn't say
+	cancel   context.CancelFunc // stops it
+	mirrors  []string           // base URLs tried before bolls.life
+
+	countsMu sync.Mutex // guards the verse-count files
+	metaMu   sync.Mutex // guards the metadata file
+
+	// updates holds the source of each translation downloaded again and
+	// waiting on ApplyUpdate or DiscardUpdate. Guarded by mu.
+	updates map[string]string
 }
 
 // progressReader wraps an io.Reader and reports the byte count consumed so far
@@ -35,9 +50,7 @@ func (p *progressReader) Read(b []byte) (int, error) {
 	n, err := p.r.Read(b)
 	if n > 0 {
 		p.read += int64(n)
-		if p.total > 0 {
-			p.cache.setProgress(float64(p.read) / float64(p.total))
-		}
+		p.cache.setReceived(p.read, p.total)
 	}
 	return n, err
 }
@@ -48,6 +61,17 @@ func (c *Cache) setProgress(p float64) {
 	c.mu.Unlock()
 }
 
+// setReceived records that read of total bytes (-1 if unknown) have
+// arrived, and the progress that makes.
+func (c *Cache) setReceived(read, total int64) {
+	c.mu.Lock()
+	c.read, c.total = read, total
+	if total > 0 {
+		c.progress = float64(read) / float64(total)
+	}
+	c.mu.Unlock()
+}
+
 // DownloadProgress returns the current download's byte progress as a value
 // in [0, 1], plus the translation short-name being downloaded ("" if idle).
 // Safe to call from any goroutine.
@@ -57,21 +81,66 @@ func (c *Cache) DownloadProgress() (float64, string) {
 	return c.progress, c.active
 }
 
+// DownloadBytes returns how many bytes of the current download have
+// arrived and its size, -1 when the server didn't send one. Safe to call
+// from any goroutine.
+func (c *Cache) DownloadBytes() (read, total int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.read, c.total
+}
+
 func NewCache() (*Cache, error) {
-	// Get user's cache directory
-	homeDir, err := os.UserHomeDir()
+	// Get user's cache directory: $XDG_CACHE_HOME or ~/.cache on Linux,
+	// ~/Library/Caches on macOS, %LocalAppData% on Windows.
+	base, err := os.UserCacheDir()
 	if err != nil {
 		return nil, err
 	}
+	dir := filepath.Join(base, "sword-tui")
+	moveLegacyCache(dir)
 
-	cacheDir := filepath.Join(homeDir, ".cache", "sword-tui", "translations")
+	cacheDir := filepath.Join(dir, "translations")
 
 	// Create cache directory if it doesn't exist
 	if err := os.MkdirAll(cacheDir, 0755); err != nil {
 		return nil, err
 	}
 
-	return &Cache{cacheDir: cacheDir}, nil
+	c := &Cache{cacheDir: cacheDir}
+	if err := c.migrate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// moveLegacyCache moves the cache from ~/.cache/sword-tui, where it was
+// kept on every system, to dir, if that is elsewhere and doesn't exist
+// yet. When it can't be moved the cache starts afresh.
+func moveLegacyCache(dir string) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return
+	}
+	legacy := filepath.Join(home, ".cache", "sword-tui")
+	if legacy == dir {
+		return
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		return
+	}
+	if _, err := os.Stat(legacy); err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return
+	}
+	os.Rename(legacy, dir)
+}
+
+// Dir returns the directory downloaded translations are kept in.
+func (c *Cache) Dir() string {
+	return c.cacheDir
 }
 
 // IsCached checks if a translation is already downloaded
@@ -82,23 +151,74 @@ func (c *Cache) IsCached(translation string) bool {
 }
 
 // DownloadTranslation downloads and caches a translation. While the download
-// runs the cache exposes byte-level progress via DownloadProgress().
+// runs the cache exposes byte-level progress via DownloadProgress(), and
+// CancelDownload stops it, when it returns context.Canceled.
 func (c *Cache) DownloadTranslation(translation string) error {
+	return c.download(translation, false)
+}
+
+// download runs a download, of a translation to cache or, when update is
+// set, of a new copy of one to set beside it.
+func (c *Cache) download(translation string, update bool) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 	// Mark this download as active so the UI can poll for progress.
 	c.mu.Lock()
 	c.active = translation
 	c.progress = 0
+	c.cancel = cancel
 	c.mu.Unlock()
 	// Idle-out when the download finishes (either branch).
 	defer func() {
 		c.mu.Lock()
 		c.active = ""
 		c.progress = 0
+		c.read, c.total = 0, 0
+		c.cancel = nil
 		c.mu.Unlock()
 	}()
 
-	url := fmt.Sprintf("%s/%s.zip", baseURL, translation)
-	resp, err := http.Get(url)
+	local, err := localManifest()
+	if err != nil {
+		return fmt.Errorf("%s: %w", manifestName, err)
+	}
+	var errs []error
+	for _, base := range c.sources() {
+		err := c.downloadFrom(ctx, base, translation, local, update)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", base, err))
+	}
+	if len(errs) == 1 {
+		return errors.Unwrap(errs[0])
+	}
+	return errors.Join(errs...)
+}
+
+// CancelDownload stops the running download, if there is one.
+func (c *Cache) CancelDownload() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cancel != nil {
+		c.cancel()
+	}
+}
+
+// downloadFrom downloads a translation from one source, checking it
+// against the manifests, and caches it, or sets it aside as an update.
+func (c *Cache) downloadFrom(ctx context.Context, base, translation string, local map[string]string, update bool) error {
+	c.setProgress(0)
+	file := translation + ".zip"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+"/"+file, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", version.UserAgent())
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to download: %w", err)
 	}
@@ -107,6 +227,7 @@ func (c *Cache) DownloadTranslation(translation string) error {
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("download failed with status %d", resp.StatusCode)
 	}
+	c.setReceived(0, resp.ContentLength)
 
 	tmpFile, err := os.CreateTemp("", translation+"*.zip")
 	if err != nil {
@@ -123,17 +244,41 @@ func (c *Cache) DownloadTranslation(translation string) error {
 	if _, err := io.Copy(tmpFile, pr); err != nil {
 		return err
 	}
+	if want := expectedSum(local, base, file); want != "" {
+		if err := checkSum(tmpFile.Name(), want); err != nil {
+			return err
+		}
+	}
 
 	// Treat unzip as the final stretch (97% → 100%).
 	c.setProgress(0.97)
-	if err := c.extractJSON(tmpFile.Name(), translation); err != nil {
+	if update {
+		if err := c.extractJSON(tmpFile.Name(), translation, c.updatePath(translation)); err != nil {
+			return err
+		}
+		c.mu.Lock()
+		if c.updates == nil {
+			c.updates = map[string]string{}
+		}
+		c.updates[translation] = base + "/" + file
+		c.mu.Unlock()
+		c.setProgress(1.0)
+		return nil
+	}
+	if err := c.extractJSON(tmpFile.Name(), translation, filepath.Join(c.cacheDir, translation+".json")); err != nil {
 		return err
 	}
+	// The translation is cached either way; only its date and source
+	// would be missing.
+	c.recordDownload(translation, base+"/"+file)
 	c.setProgress(1.0)
 	return nil
 }
 
-func (c *Cache) extractJSON(zipPath, translation string) error {
+// extractJSON unpacks the translation's JSON from the ZIP next to
+// outPath, and moves it into place once verifyTranslation passes it. One
+// that fails is deleted, leaving the provider to serve the translation.
+func (c *Cache) extractJSON(zipPath, translation, outPath string) error {
 	r, err := zip.OpenReader(zipPath)
 	if err != nil {
 		return err
@@ -142,31 +287,45 @@ func (c *Cache) extractJSON(zipPath, translation string) error {
 
 	// Find the JSON file in the ZIP
 	for _, f := range r.File {
-		if filepath.Ext(f.Name) == ".json" {
-			rc, err := f.Open()
-			if err != nil {
+		// Names in a ZIP are separated by slashes on every system.
+		if path.Ext(f.Name) == ".json" {
+			partPath := outPath + ".part"
+			if err := extractFile(f, partPath); err != nil {
+				os.Remove(partPath)
 				return err
 			}
-			defer rc.Close()
-
-			// Create output file
-			outPath := filepath.Join(c.cacheDir, translation+".json")
-			outFile, err := os.Create(outPath)
-			if err != nil {
-				return err
+			if err := verifyTranslation(partPath); err != nil {
+				os.Remove(partPath)
+				return fmt.Errorf("%s: %w", translation, err)
 			}
-			defer outFile.Close()
-
-			_, err = io.Copy(outFile, rc)
-			return err
+			return os.Rename(partPath, outPath)
 		}
 	}
 
 	return fmt.Errorf("no JSON file found in ZIP")
 }
 
-// GetChapter retrieves a chapter from cached data
-func (c *Cache) GetChapter(translation string, book, chapter int) ([]api.Verse, error) {
+// extractFile writes f from a ZIP to outPath.
+func extractFile(f *zip.File, outPath string) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	outFile, err := os.Create(outPath)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(outFile, rc); err != nil {
+		outFile.Close()
+		return err
+	}
+	return outFile.Close()
+}
+
+// LoadAll decodes every verse of a cached translation
+func (c *Cache) LoadAll(translation string) ([]api.Verse, error) {
 	if !c.IsCached(translation) {
 		return nil, fmt.Errorf("translation %s not cached", translation)
 	}
@@ -185,6 +344,16 @@ func (c *Cache) GetChapter(translation string, book, chapter int) ([]api.Verse,
 		return nil, err
 	}
 
+	return allVerses, nil
+}
+
+// GetChapter retrieves a chapter from cached data
+func (c *Cache) GetChapter(translation string, book, chapter int) ([]api.Verse, error) {
+	allVerses, err := c.LoadAll(translation)
+	if err != nil {
+		return nil, err
+	}
+
 	// Filter verses for the requested book and chapter
 	var verses []api.Verse
 	for _, v := range allVerses {
@@ -233,13 +402,24 @@ func (c *Cache) ListCached() ([]string, error) {
 
 // ClearCache removes all cached translations
 func (c *Cache) ClearCache() error {
-	return os.RemoveAll(c.cacheDir)
+	if err := os.RemoveAll(c.cacheDir); err != nil {
+		return err
+	}
+	return c.updateMetadata(func(md *metadata) {
+		clear(md.Translations)
+	})
 }
 
 // RemoveTranslation removes a specific cached translation
 func (c *Cache) RemoveTranslation(translation string) error {
 	path := filepath.Join(c.cacheDir, translation+".json")
-	return os.Remove(path)
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	c.DiscardUpdate(translation)
+	return c.updateMetadata(func(md *metadata) {
+		delete(md.Translations, translation)
+	})
 }
 
 // GetCacheSize returns the total size of cached data in bytes
@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// verseCounts is the on-disk record of chapter sizes for one translation.
+// Complete is set once the counts were derived from the full downloaded
+// text; until then they are whatever chapters were fetched from the API.
+type verseCounts struct {
+	Complete bool          `json:"complete"`
+	Books    map[int][]int `json:"books"` // book id -> verses per chapter
+}
+
+func (c *Cache) countsPath(translation string) string {
+	return filepath.Join(filepath.Dir(c.cacheDir), "verse-counts", translation+".json")
+}
+
+func (c *Cache) readCounts(translation string) verseCounts {
+	vc := verseCounts{Books: map[int][]int{}}
+	data, err := os.ReadFile(c.countsPath(translation))
+	if err == nil {
+		json.Unmarshal(data, &vc)
+	}
+	if vc.Books == nil {
+		vc.Books = map[int][]int{}
+	}
+	return vc
+}
+
+func (c *Cache) writeCounts(translation string, vc verseCounts) error {
+	path := c.countsPath(translation)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(vc)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// VerseCounts returns the number of verses in each chapter of each book,
+// keyed by book id (index 0 is chapter 1; 0 means unknown). For a
+// downloaded translation the counts are computed from its text once and
+// remembered; otherwise they cover the chapters seen so far.
+func (c *Cache) VerseCounts(translation string) (map[int][]int, error) {
+	c.countsMu.Lock()
+	defer c.countsMu.Unlock()
+
+	vc := c.readCounts(translation)
+	if vc.Complete || !c.IsCached(translation) {
+		return vc.Books, nil
+	}
+
+	all, err := c.LoadAll(translation)
+	if err != nil {
+		return vc.Books, err
+	}
+	vc = verseCounts{Complete: true, Books: map[int][]int{}}
+	for _, v := range all {
+		setCount(vc.Books, v.Book, v.Chapter, v.Verse)
+	}
+	return vc.Books, c.writeCounts(translation, vc)
+}
+
+// RecordVerseCount remembers the size of a chapter fetched from the API.
+func (c *Cache) RecordVerseCount(translation string, book, chapter, verses int) {
+	c.countsMu.Lock()
+	defer c.countsMu.Unlock()
+
+	vc := c.readCounts(translation)
+	if vc.Complete {
+		return
+	}
+	if chs := vc.Books[book]; chapter <= len(chs) && chs[chapter-1] == verses {
+		return
+	}
+	setCount(vc.Books, book, chapter, verses)
+	c.writeCounts(translation, vc)
+}
+
+// setCount raises the count for book/chapter to at least verse, growing
+// the chapter slice as needed.
+func setCount(books map[int][]int, book, chapter, verse int) {
+	if chapter < 1 {
+		return
+	}
+	chs := books[book]
+	for len(chs) < chapter {
+		chs = append(chs, 0)
+	}
+	if verse > chs[chapter-1] {
+		chs[chapter-1] = verse
+	}
+	books[book] = chs
+}
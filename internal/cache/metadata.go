@@ -0,0 +1,172 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// The cache keeps a metadata file beside the translations: the format
+// the cache is laid out in, and when and where from each translation
+// was downloaded. A build that lays the cache out differently raises
+// FormatVersion and adds a migration, and caches on disk are brought up
+// to date when opened.
+
+// FormatVersion is the layout of the cache this build reads and writes.
+// It is len(migrations).
+const FormatVersion = 1
+
+// metadataName is the name of the metadata file, in the directory above
+// the translations.
+const metadataName = "cache.json"
+
+// TranslationInfo describes a downloaded translation.
+type TranslationInfo struct {
+	Downloaded time.Time `json:"downloaded"`
+	// Source is the URL the ZIP came from, empty for translations
+	// downloaded before the metadata was kept.
+	Source string `json:"source,omitempty"`
+	Size   int64  `json:"size"` // bytes of the unpacked text
+}
+
+// metadata is the contents of the metadata file.
+type metadata struct {
+	Version      int                        `json:"version"`
+	Translations map[string]TranslationInfo `json:"translations"`
+}
+
+// migrations[i] brings a cache in format i up to format i+1. Each runs
+// on the metadata as read, and the cache's files; the metadata is saved
+// once they all have.
+var migrations = []func(c *Cache, md *metadata) error{
+	recordTranslations,
+}
+
+// recordTranslations moves a cache from before the metadata (format 0)
+// to format 1: the translations on disk are recorded with their files'
+// dates and sizes. Where they came from isn't known.
+func recordTranslations(c *Cache, md *metadata) error {
+	names, err := c.ListCached()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		info, err := os.Stat(filepath.Join(c.cacheDir, name+".json"))
+		if err != nil {
+			continue
+		}
+		md.Translations[name] = TranslationInfo{Downloaded: info.ModTime(), Size: info.Size()}
+	}
+	return nil
+}
+
+func (c *Cache) metadataPath() string {
+	return filepath.Join(filepath.Dir(c.cacheDir), metadataName)
+}
+
+// readMetadata reads the metadata file. A cache without one is in
+// format 0.
+func (c *Cache) readMetadata() (metadata, error) {
+	md := metadata{Translations: map[string]TranslationInfo{}}
+	data, err := os.ReadFile(c.metadataPath())
+	if os.IsNotExist(err) {
+		return md, nil
+	}
+	if err != nil {
+		return md, err
+	}
+	if err := json.Unmarshal(data, &md); err != nil {
+		return md, fmt.Errorf("%s: %w", c.metadataPath(), err)
+	}
+	if md.Translations == nil {
+		md.Translations = map[string]TranslationInfo{}
+	}
+	return md, nil
+}
+
+func (c *Cache) writeMetadata(md metadata) error {
+	data, err := json.MarshalIndent(md, "", "  ")
+	if err != nil {
+		return err
+	}
+	// Write then rename so the metadata is never left half written.
+	path := c.metadataPath()
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// migrate brings the cache on disk up to FormatVersion. A cache written
+// by a newer build is left as it is.
+func (c *Cache) migrate() error {
+	c.metaMu.Lock()
+	defer c.metaMu.Unlock()
+
+	md, err := c.readMetadata()
+	if err != nil {
+		return err
+	}
+	if md.Version >= len(migrations) {
+		return nil
+	}
+	for v := md.Version; v < len(migrations); v++ {
+		if err := migrations[v](c, &md); err != nil {
+			return fmt.Errorf("cache format %d to %d: %w", v, v+1, err)
+		}
+	}
+	md.Version = len(migrations)
+	return c.writeMetadata(md)
+}
+
+// updateMetadata applies change to the metadata and saves it.
+func (c *Cache) updateMetadata(change func(md *metadata)) error {
+	c.metaMu.Lock()
+	defer c.metaMu.Unlock()
+
+	md, err := c.readMetadata()
+	if err != nil {
+		return err
+	}
+	change(&md)
+	md.Version = max(md.Version, FormatVersion)
+	return c.writeMetadata(md)
+}
+
+// recordDownload notes a translation just downloaded from source.
+func (c *Cache) recordDownload(translation, source string) error {
+	info, err := os.Stat(filepath.Join(c.cacheDir, translation+".json"))
+	if err != nil {
+		return err
+	}
+	return c.updateMetadata(func(md *metadata) {
+		md.Translations[translation] = TranslationInfo{Downloaded: time.Now(), Source: source, Size: info.Size()}
+	})
+}
+
+// Version returns the format the cache on disk is in.
+func (c *Cache) Version() (int, error) {
+	c.metaMu.Lock()
+	defer c.metaMu.Unlock()
+	md, err := c.readMetadata()
+	return md.Version, err
+}
+
+// Info returns what is known of a downloaded translation.
+func (c *Cache) Info(translation string) (TranslationInfo, bool) {
+	c.metaMu.Lock()
+	defer c.metaMu.Unlock()
+	md, err := c.readMetadata()
+	if err != nil {
+		return TranslationInfo{}, false
+	}
+	info, ok := md.Translations[translation]
+	return info, ok
+}
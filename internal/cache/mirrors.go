@@ -0,0 +1,130 @@
+package cache
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"sword-tui/internal/settings"
+	"sword-tui/internal/version"
+)
+
+// Translations download from the mirrors in download_mirrors, in order,
+// then from bolls.life; each is a base URL serving TRANSLATION.zip the way
+// bolls.life/static/translations does. A download is checked against a
+// sha256sum-style manifest, "HASH  TRANSLATION.zip" per line: the one in
+// <config dir>/sword-tui/SHA256SUMS if it lists the file, else the one
+// the source serves beside the zips, if any. A download that doesn't
+// match is thrown away and the next source tried.
+
+// manifestName is the checksum manifest's file name, locally and on a
+// mirror.
+const manifestName = "SHA256SUMS"
+
+// manifestTimeout bounds fetching a source's manifest, which most don't
+// have.
+const manifestTimeout = 10 * time.Second
+
+// SetMirrors sets the base URLs tried before bolls.life.
+func (c *Cache) SetMirrors(mirrors []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mirrors = nil
+	for _, m := range mirrors {
+		if m = strings.TrimRight(strings.TrimSpace(m), "/"); m != "" {
+			c.mirrors = append(c.mirrors, m)
+		}
+	}
+}
+
+// sources returns the base URLs to download from, in order.
+func (c *Cache) sources() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append(append([]string(nil), c.mirrors...), baseURL)
+}
+
+// parseManifest reads sha256sum output into file name → hex digest.
+func parseManifest(r io.Reader) map[string]string {
+	sums := map[string]string{}
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) != 2 || len(fields[0]) != sha256.Size*2 {
+			continue
+		}
+		// sha256sum marks binary mode with a * before the name.
+		sums[strings.TrimPrefix(fields[1], "*")] = strings.ToLower(fields[0])
+	}
+	return sums
+}
+
+// localManifest reads the manifest in the config directory, if there is
+// one.
+func localManifest() (map[string]string, error) {
+	dir, err := settings.Dir()
+	if err != nil {
+		return nil, nil
+	}
+	f, err := os.Open(filepath.Join(dir, manifestName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	return parseManifest(f), nil
+}
+
+// remoteManifest fetches the manifest a source serves, or nil.
+func remoteManifest(base string) map[string]string {
+	req, err := http.NewRequest(http.MethodGet, base+"/"+manifestName, nil)
+	if err != nil {
+		return nil
+	}
+	req.Header.Set("User-Agent", version.UserAgent())
+	client := http.Client{Timeout: manifestTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+	return parseManifest(resp.Body)
+}
+
+// expectedSum returns the digest file should have when downloaded from
+// base, or "" when no manifest lists it.
+func expectedSum(local map[string]string, base, file string) string {
+	if sum, ok := local[file]; ok {
+		return sum
+	}
+	return remoteManifest(base)[file]
+}
+
+// checkSum compares the SHA-256 of the file at path with want.
+func checkSum(path, want string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != want {
+		return fmt.Errorf("checksum mismatch: got %s…, want %s…", got[:12], want[:min(len(want), 12)])
+	}
+	return nil
+}
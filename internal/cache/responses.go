@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// responsePath is where the API response for key (its URL) is kept.
+func (c *Cache) responsePath(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(filepath.Dir(c.cacheDir), "responses", hex.EncodeToString(sum[:])+".json")
+}
+
+// CachedResponse returns the stored response for key if it was saved less
+// than ttl ago.
+func (c *Cache) CachedResponse(key string, ttl time.Duration) ([]byte, bool) {
+	path := c.responsePath(key)
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > ttl {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// StoreResponse saves an API response under key. Failures are ignored;
+// the response is simply fetched again next time.
+func (c *Cache) StoreResponse(key string, body []byte) {
+	path := c.responsePath(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	// Write then rename so a concurrent reader never sees half a file.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, body, 0644); err != nil {
+		return
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+	}
+}
+
+// PruneResponses deletes stored responses older than ttl. It does
+// nothing on a nil Cache, as it runs in the background at startup.
+func (c *Cache) PruneResponses(ttl time.Duration) {
+	if c == nil {
+		return
+	}
+	dir := filepath.Join(filepath.Dir(c.cacheDir), "responses")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		if info, err := e.Info(); err == nil && time.Since(info.ModTime()) > ttl {
+			os.Remove(filepath.Join(dir, e.Name()))
+		}
+	}
+}
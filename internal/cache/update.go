@@ -0,0 +1,158 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"sword-tui/internal/api"
+)
+
+// A translation downloaded again isn't swapped in straight away: the new
+// text is unpacked beside the old one, the chapters that differ are
+// listed, and ApplyUpdate or DiscardUpdate settles it.
+
+// ChapterChange is a chapter whose text differs between the downloaded
+// copy of a translation and its update.
+type ChapterChange struct {
+	Book, Chapter int
+	// Before and After are the chapter's verses in each copy; 0 when it
+	// is new or gone.
+	Before, After int
+	// Changed counts the verses in both copies whose text differs.
+	Changed int
+}
+
+// updatePath is where an update to translation waits.
+func (c *Cache) updatePath(translation string) string {
+	return filepath.Join(c.cacheDir, translation+".json.new")
+}
+
+// UpdateTranslation downloads translation again, as DownloadTranslation
+// does, and returns the chapters that differ from the copy on disk,
+// which is kept until ApplyUpdate. None differing means the copy is up
+// to date.
+func (c *Cache) UpdateTranslation(translation string) ([]ChapterChange, error) {
+	if !c.IsCached(translation) {
+		return nil, fmt.Errorf("translation %s not cached", translation)
+	}
+	if err := c.download(translation, true); err != nil {
+		return nil, err
+	}
+	before, err := c.LoadAll(translation)
+	if err != nil {
+		c.DiscardUpdate(translation)
+		return nil, err
+	}
+	after, err := readVerses(c.updatePath(translation))
+	if err != nil {
+		c.DiscardUpdate(translation)
+		return nil, err
+	}
+	return diffTranslations(before, after), nil
+}
+
+// ApplyUpdate replaces translation with the update UpdateTranslation
+// downloaded.
+func (c *Cache) ApplyUpdate(translation string) error {
+	c.mu.Lock()
+	source, ok := c.updates[translation]
+	delete(c.updates, translation)
+	c.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("no update to %s downloaded", translation)
+	}
+	if err := os.Rename(c.updatePath(translation), filepath.Join(c.cacheDir, translation+".json")); err != nil {
+		return err
+	}
+	// The chapter sizes are worked out again from the new text.
+	c.countsMu.Lock()
+	os.Remove(c.countsPath(translation))
+	c.countsMu.Unlock()
+	return c.recordDownload(translation, source)
+}
+
+// DiscardUpdate deletes the update to translation, keeping the copy on
+// disk. It does nothing when there is none.
+func (c *Cache) DiscardUpdate(translation string) error {
+	c.mu.Lock()
+	delete(c.updates, translation)
+	c.mu.Unlock()
+	if err := os.Remove(c.updatePath(translation)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func readVerses(path string) ([]api.Verse, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var verses []api.Verse
+	if err := json.NewDecoder(f).Decode(&verses); err != nil {
+		return nil, err
+	}
+	return verses, nil
+}
+
+// diffTranslations lists the chapters whose verses differ between before
+// and after, in canonical order.
+func diffTranslations(before, after []api.Verse) []ChapterChange {
+	type key struct{ book, chapter int }
+	chapters := func(verses []api.Verse) map[key]map[int]string {
+		out := map[key]map[int]string{}
+		for _, v := range verses {
+			k := key{v.Book, v.Chapter}
+			if out[k] == nil {
+				out[k] = map[int]string{}
+			}
+			out[k][v.Verse] = v.Text
+		}
+		return out
+	}
+	old, updated := chapters(before), chapters(after)
+
+	var changes []ChapterChange
+	note := func(k key) {
+		a, b := old[k], updated[k]
+		ch := ChapterChange{Book: k.book, Chapter: k.chapter, Before: len(a), After: len(b)}
+		for n, text := range a {
+			if t, ok := b[n]; ok && t != text {
+				ch.Changed++
+			}
+		}
+		if ch.Changed > 0 || ch.Before != ch.After || !sameVerses(a, b) {
+			changes = append(changes, ch)
+		}
+	}
+	for k := range old {
+		note(k)
+	}
+	for k := range updated {
+		if _, ok := old[k]; !ok {
+			note(k)
+		}
+	}
+	slices.SortFunc(changes, func(a, b ChapterChange) int {
+		if a.Book != b.Book {
+			return a.Book - b.Book
+		}
+		return a.Chapter - b.Chapter
+	})
+	return changes
+}
+
+// sameVerses reports whether two copies of a chapter number the same
+// verses.
+func sameVerses(a, b map[int]string) bool {
+	for n := range a {
+		if _, ok := b[n]; !ok {
+			return false
+		}
+	}
+	return len(a) == len(b)
+}
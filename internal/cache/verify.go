@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"sword-tui/internal/api"
+)
+
+// Verses in each testament in the KJV versification. Other
+// versifications differ from these by a few dozen.
+const (
+	oldTestamentVerses = 23145
+	newTestamentVerses = 7957
+)
+
+// minVerseShare is how much of a testament's verses a download has to
+// hold to pass as complete.
+const minVerseShare = 0.9
+
+// verifyTranslation checks that the extracted translation at path holds
+// whole testaments: every book of the Old Testament, the New or both,
+// and about as many verses as they have. A download cut short, or a
+// ZIP holding something else, fails, so it never stands in for the
+// provider's text.
+func verifyTranslation(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var verses []api.Verse
+	if err := json.NewDecoder(f).Decode(&verses); err != nil {
+		return fmt.Errorf("the download is not a translation: %w", err)
+	}
+
+	books := map[int]bool{}
+	var ot, nt int // verses
+	for _, v := range verses {
+		switch {
+		case v.Book >= 1 && v.Book <= 39:
+			ot++
+		case v.Book >= 40 && v.Book <= 66:
+			nt++
+		default:
+			continue // books outside the canon
+		}
+		books[v.Book] = true
+	}
+	var otBooks, ntBooks int
+	for b := range books {
+		if b <= 39 {
+			otBooks++
+		} else {
+			ntBooks++
+		}
+	}
+
+	switch {
+	case otBooks+ntBooks == 0:
+		return fmt.Errorf("the download holds no books of the Bible")
+	case otBooks > 0 && otBooks < 39, ntBooks > 0 && ntBooks < 27:
+		return fmt.Errorf("the download is incomplete: %d of the %d books, %d verses", otBooks+ntBooks, expectedBooks(otBooks, ntBooks), ot+nt)
+	case otBooks > 0 && float64(ot) < minVerseShare*oldTestamentVerses,
+		ntBooks > 0 && float64(nt) < minVerseShare*newTestamentVerses:
+		return fmt.Errorf("the download is incomplete: %d verses where about %d were expected", ot+nt, expectedVerses(otBooks, ntBooks))
+	}
+	return nil
+}
+
+// expectedBooks is how many books the testaments a download has begun
+// should have.
+func expectedBooks(otBooks, ntBooks int) int {
+	n := 0
+	if otBooks > 0 {
+		n += 39
+	}
+	if ntBooks > 0 {
+		n += 27
+	}
+	return n
+}
+
+// expectedVerses is how many verses those testaments should have.
+func expectedVerses(otBooks, ntBooks int) int {
+	n := 0
+	if otBooks > 0 {
+		n += oldTestamentVerses
+	}
+	if ntBooks > 0 {
+		n += newTestamentVerses
+	}
+	return n
+}
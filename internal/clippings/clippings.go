@@ -0,0 +1,118 @@
+// Package clippings reads highlights and notes exported from other Bible
+// apps — Kindle's "My Clippings.txt" and YouVersion's CSV export — and
+// maps them onto verses, ready for the annotations store.
+package clippings
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/highlights"
+)
+
+// Kind says what an Item becomes.
+type Kind int
+
+const (
+	Highlight Kind = iota
+	Note
+)
+
+func (k Kind) String() string {
+	if k == Note {
+		return "note"
+	}
+	return "highlight"
+}
+
+// Item is one imported highlight or note on a range of verses in a
+// chapter.
+type Item struct {
+	Kind                 Kind
+	Book, Chapter        int
+	VerseStart, VerseEnd int
+	Color                string // highlights: one of highlights.Colors
+	Text                 string // notes
+	Added                time.Time
+}
+
+// Reference formats the item's verses, e.g. "John 3:16-17".
+func (it Item) Reference() string {
+	name := strconv.Itoa(it.Book)
+	if b, ok := api.CanonicalBook(it.Book); ok {
+		name = b.Name
+	}
+	ref := fmt.Sprintf("%s %d:%d", name, it.Chapter, it.VerseStart)
+	if it.VerseEnd > it.VerseStart {
+		ref += fmt.Sprintf("-%d", it.VerseEnd)
+	}
+	return ref
+}
+
+// Result is what an export held: the items that could be placed, and a
+// description of each entry that couldn't.
+type Result struct {
+	Items   []Item
+	Skipped []string
+}
+
+// Color maps a color from another app, by name or as hex RGB, to the
+// nearest highlight color. It returns "" when s is neither.
+func Color(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, c := range highlights.Colors {
+		if s == c {
+			return c
+		}
+	}
+	switch s {
+	case "orange", "gold":
+		return "yellow"
+	case "teal", "lime":
+		return "green"
+	case "purple", "violet", "cyan":
+		return "blue"
+	case "red", "magenta", "rose":
+		return "pink"
+	}
+
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) != 6 {
+		return ""
+	}
+	rgb, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return ""
+	}
+	r := float64(rgb>>16&0xff) / 255
+	g := float64(rgb>>8&0xff) / 255
+	b := float64(rgb&0xff) / 255
+	hi, lo := max(r, g, b), min(r, g, b)
+	if hi-lo < 0.05 {
+		return "yellow" // grey: no hue to go by
+	}
+	var hue float64
+	switch hi {
+	case r:
+		hue = math.Mod((g-b)/(hi-lo), 6)
+	case g:
+		hue = (b-r)/(hi-lo) + 2
+	default:
+		hue = (r-g)/(hi-lo) + 4
+	}
+	hue = math.Mod(hue*60+360, 360)
+	switch {
+	case hue >= 30 && hue < 75:
+		return "yellow"
+	case hue >= 75 && hue < 165:
+		return "green"
+	case hue >= 165 && hue < 265:
+		return "blue"
+	default:
+		return "pink"
+	}
+}
@@ -0,0 +1,207 @@
+package clippings
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/textnorm"
+)
+
+// Kindle's clippings carry no verse references, only the highlighted
+// text and a location in the e-book, so each highlight is placed by
+// finding its text in a translation the user has downloaded:
+//
+//	The Holy Bible: English Standard Version
+//	- Your Highlight on page 1203 | Location 31500-31502 | Added on Monday, March 4, 2024 10:11:12 AM
+//
+//	For God so loved the world, that he gave his only Son,
+//	==========
+//
+// A note is placed on the highlight it was written against, which Kindle
+// gives the same location.
+
+// kindleMeta matches the second line of a clipping.
+var kindleMeta = regexp.MustCompile(`(?i)^-\s*your (highlight|note|bookmark)\b.*?\blocation (\d+)(?:-(\d+))?.*?(?:\|\s*added on (.+))?$`)
+
+// kindleTime is how Kindle writes "Added on".
+const kindleTime = "Monday, January 2, 2006 3:04:05 PM"
+
+// minWords is the shortest highlight placed by its text; shorter ones
+// match too many verses to trust.
+const minWords = 4
+
+// Kindle reads a "My Clippings.txt" and places its highlights and notes
+// in verses, the text of a translation in canonical order. Highlights
+// get color.
+func Kindle(r io.Reader, verses []api.Verse, color string) (Result, error) {
+	entries, err := readKindle(r)
+	if err != nil {
+		return Result{}, err
+	}
+	idx := newVerseIndex(verses)
+
+	var res Result
+	// placed remembers where each highlight went by title and location,
+	// for the notes written on it.
+	placed := map[string][]Item{}
+	for _, e := range entries {
+		key := e.title + "\x00" + strconv.Itoa(e.locEnd)
+		switch e.kind {
+		case "highlight":
+			items, err := idx.place(e.text)
+			if err != nil {
+				res.Skipped = append(res.Skipped, fmt.Sprintf("highlight at location %d: %v", e.locStart, err))
+				continue
+			}
+			for i := range items {
+				items[i].Color = color
+				items[i].Added = e.added
+			}
+			placed[key] = items
+			res.Items = append(res.Items, items...)
+		case "note":
+			on, ok := placed[key]
+			if !ok {
+				res.Skipped = append(res.Skipped, fmt.Sprintf("note at location %d: no highlight to attach it to", e.locStart))
+				continue
+			}
+			res.Items = append(res.Items, Item{
+				Kind:       Note,
+				Book:       on[0].Book,
+				Chapter:    on[0].Chapter,
+				VerseStart: on[0].VerseStart,
+				VerseEnd:   on[0].VerseStart,
+				Text:       e.text,
+				Added:      e.added,
+			})
+		}
+	}
+	return res, nil
+}
+
+// kindleEntry is one clipping.
+type kindleEntry struct {
+	title            string
+	kind             string // highlight, note or bookmark
+	locStart, locEnd int
+	added            time.Time
+	text             string
+}
+
+func readKindle(r io.Reader) ([]kindleEntry, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var entries []kindleEntry
+	var block []string
+	flush := func() {
+		defer func() { block = block[:0] }()
+		if len(block) < 2 {
+			return
+		}
+		m := kindleMeta.FindStringSubmatch(strings.TrimSpace(block[1]))
+		if m == nil {
+			return
+		}
+		e := kindleEntry{
+			title: strings.TrimSpace(strings.TrimPrefix(block[0], "\ufeff")),
+			kind:  strings.ToLower(m[1]),
+			text:  strings.TrimSpace(strings.Join(block[2:], " ")),
+		}
+		e.locStart, _ = strconv.Atoi(m[2])
+		e.locEnd = e.locStart
+		if m[3] != "" {
+			e.locEnd, _ = strconv.Atoi(m[3])
+		}
+		e.added, _ = time.Parse(kindleTime, strings.TrimSpace(m[4]))
+		entries = append(entries, e)
+	}
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if strings.HasPrefix(line, "==========") {
+			flush()
+			continue
+		}
+		if len(block) == 0 && strings.TrimSpace(line) == "" {
+			continue
+		}
+		block = append(block, line)
+	}
+	flush()
+	return entries, sc.Err()
+}
+
+// verseIndex is a translation's text reduced to lowercase words, for
+// finding where a highlight came from.
+type verseIndex struct {
+	text   string
+	starts []int // offset in text of each verse
+	verses []api.Verse
+}
+
+// words reduces s to its letters, folded and lowercased, one space
+// between words. Verse numbers and punctuation, which e-books and the API
+// write differently, drop out.
+func words(s string) string {
+	s = textnorm.Fold(s)
+	return strings.Join(strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsLetter(r) }), " ")
+}
+
+func newVerseIndex(verses []api.Verse) *verseIndex {
+	idx := &verseIndex{verses: verses, starts: make([]int, len(verses))}
+	var sb strings.Builder
+	for i, v := range verses {
+		idx.starts[i] = sb.Len()
+		sb.WriteString(words(v.Text))
+		sb.WriteByte(' ')
+	}
+	idx.text = sb.String()
+	return idx
+}
+
+// verseAt returns the index of the verse covering offset.
+func (idx *verseIndex) verseAt(offset int) int {
+	return sort.Search(len(idx.starts), func(i int) bool { return idx.starts[i] > offset }) - 1
+}
+
+// place finds the verses text was highlighted in, as one item per
+// chapter it spans.
+func (idx *verseIndex) place(text string) ([]Item, error) {
+	w := words(text)
+	if strings.Count(w, " ")+1 < minWords {
+		return nil, fmt.Errorf("too short to place: %q", text)
+	}
+	at := strings.Index(idx.text, w)
+	if at < 0 {
+		return nil, fmt.Errorf("not found in the translation: %q", ellipsis(text, 60))
+	}
+	if strings.Contains(idx.text[at+1:], w) {
+		return nil, fmt.Errorf("found in more than one place: %q", ellipsis(text, 60))
+	}
+
+	var items []Item
+	for i := idx.verseAt(at); i <= idx.verseAt(at+len(w)-1); i++ {
+		v := idx.verses[i]
+		if n := len(items); n > 0 && items[n-1].Book == v.Book && items[n-1].Chapter == v.Chapter {
+			items[n-1].VerseEnd = v.Verse
+			continue
+		}
+		items = append(items, Item{Kind: Highlight, Book: v.Book, Chapter: v.Chapter, VerseStart: v.Verse, VerseEnd: v.Verse})
+	}
+	return items, nil
+}
+
+// ellipsis shortens s to n runes.
+func ellipsis(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n-1]) + "…"
+	}
+	return s
+}
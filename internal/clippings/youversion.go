@@ -0,0 +1,185 @@
+package clippings
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/reference"
+)
+
+// YouVersion's export is a CSV with a header row. Its column names have
+// changed over the years, so any of these is accepted for each field.
+var youVersionColumns = map[string][]string{
+	"reference": {"usfm", "reference", "references", "verses", "verse"},
+	"color":     {"color", "colour", "highlight color", "highlight_color"},
+	"note":      {"note", "notes", "content", "text"},
+	"date":      {"date", "created", "created_dt", "created at", "created_at"},
+}
+
+// youVersionTimes are the date formats seen in the export.
+var youVersionTimes = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02", "01/02/2006"}
+
+// usfmBooks are the USFM book codes (JHN, 1CO, …) in canonical order, as
+// YouVersion writes references: "JHN.3.16+JHN.3.17".
+var usfmBooks = strings.Fields(`GEN EXO LEV NUM DEU JOS JDG RUT 1SA 2SA 1KI 2KI 1CH 2CH EZR NEH EST JOB PSA PRO ECC SNG ISA JER LAM EZK DAN HOS JOL AMO OBA JON MIC NAM HAB ZEP HAG ZEC MAL
+	MAT MRK LUK JHN ACT ROM 1CO 2CO GAL EPH PHP COL 1TH 2TH 1TI 2TI TIT PHM HEB JAS 1PE 2PE 1JN 2JN 3JN JUD REV`)
+
+// YouVersion reads a YouVersion highlights export. A row with a note
+// becomes a note on its first verse; one with a color, or neither,
+// becomes a highlight, in the nearest color or else color.
+func YouVersion(r io.Reader, color string) (Result, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	records, err := cr.ReadAll()
+	if err != nil {
+		return Result{}, err
+	}
+	if len(records) == 0 {
+		return Result{}, nil
+	}
+
+	cols := map[string]int{}
+	for i, name := range records[0] {
+		n := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		for field, names := range youVersionColumns {
+			for _, want := range names {
+				if _, seen := cols[field]; !seen && n == want {
+					cols[field] = i
+				}
+			}
+		}
+	}
+	if _, ok := cols["reference"]; !ok {
+		return Result{}, fmt.Errorf("no reference column in the header (want one of %s)", strings.Join(youVersionColumns["reference"], ", "))
+	}
+	field := func(rec []string, name string) string {
+		if i, ok := cols[name]; ok && i < len(rec) {
+			return strings.TrimSpace(rec[i])
+		}
+		return ""
+	}
+
+	var res Result
+	for i, rec := range records[1:] {
+		line := i + 2
+		ref := field(rec, "reference")
+		if ref == "" {
+			continue
+		}
+		ranges, err := parseYouVersionRef(ref)
+		if err != nil {
+			res.Skipped = append(res.Skipped, fmt.Sprintf("line %d: %v", line, err))
+			continue
+		}
+		added := parseYouVersionTime(field(rec, "date"))
+
+		if note := field(rec, "note"); note != "" {
+			it := ranges[0]
+			it.Kind, it.VerseEnd, it.Text, it.Added = Note, it.VerseStart, note, added
+			res.Items = append(res.Items, it)
+			if field(rec, "color") == "" {
+				continue
+			}
+		}
+		c := Color(field(rec, "color"))
+		if c == "" {
+			c = color
+		}
+		for _, it := range ranges {
+			it.Kind, it.Color, it.Added = Highlight, c, added
+			res.Items = append(res.Items, it)
+		}
+	}
+	return res, nil
+}
+
+// parseYouVersionRef reads a reference column: USFM ("JHN.3.16",
+// "JHN.3.16-18", "JHN.3.16+JHN.3.17") or plain ("John 3:16-17"). The
+// verses come back as one item per run of consecutive verses.
+func parseYouVersionRef(ref string) ([]Item, error) {
+	var items []Item
+	for _, part := range strings.FieldsFunc(ref, func(r rune) bool { return r == '+' || r == ',' || r == ';' }) {
+		part = strings.TrimSpace(part)
+		it, err := parseUSFM(part)
+		if n := len(items); err != nil && n > 0 && strings.Trim(part, "0123456789- ") == "" {
+			// A bare verse or range after a comma continues the chapter
+			// before it: "John 3:16, 18".
+			last := items[n-1]
+			_, _, start, end, _ := reference.Parse(fmt.Sprintf("%d %d:%s", last.Book, last.Chapter, part), nil)
+			it, err = Item{Book: last.Book, Chapter: last.Chapter, VerseStart: start, VerseEnd: max(end, start)}, nil
+		}
+		if err != nil {
+			book, chapter, start, end, perr := reference.Parse(part, api.CanonicalBooks())
+			if perr != nil {
+				return nil, fmt.Errorf("unrecognized reference %q", ref)
+			}
+			if start == 0 {
+				return nil, fmt.Errorf("%q names no verse", part)
+			}
+			it = Item{Book: book, Chapter: chapter, VerseStart: start, VerseEnd: max(end, start)}
+		}
+		if n := len(items); n > 0 {
+			last := &items[n-1]
+			if last.Book == it.Book && last.Chapter == it.Chapter && it.VerseStart == last.VerseEnd+1 {
+				last.VerseEnd = it.VerseEnd
+				continue
+			}
+		}
+		items = append(items, it)
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("unrecognized reference %q", ref)
+	}
+	for _, it := range items {
+		b, ok := api.CanonicalBook(it.Book)
+		if !ok || it.Chapter < 1 || it.Chapter > b.Chapters {
+			return nil, fmt.Errorf("%s is not in the Bible", it.Reference())
+		}
+	}
+	return items, nil
+}
+
+// parseUSFM reads one USFM reference, "JHN.3.16" or "JHN.3.16-18".
+func parseUSFM(s string) (Item, error) {
+	s, endPart, _ := strings.Cut(s, "-")
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return Item{}, fmt.Errorf("not USFM: %q", s)
+	}
+	book := 0
+	for i, code := range usfmBooks {
+		if strings.EqualFold(parts[0], code) {
+			book = i + 1
+		}
+	}
+	chapter, cerr := strconv.Atoi(parts[1])
+	verse, verr := strconv.Atoi(parts[2])
+	if book == 0 || cerr != nil || verr != nil {
+		return Item{}, fmt.Errorf("not USFM: %q", s)
+	}
+	it := Item{Book: book, Chapter: chapter, VerseStart: verse, VerseEnd: verse}
+	if endPart != "" {
+		// The end is a verse ("18") or a whole reference ("JHN.3.18").
+		end := endPart[strings.LastIndex(endPart, ".")+1:]
+		if n, err := strconv.Atoi(end); err == nil && n >= verse {
+			it.VerseEnd = n
+		}
+	}
+	return it, nil
+}
+
+// parseYouVersionTime reads a date column, or returns the zero time.
+func parseYouVersionTime(s string) time.Time {
+	for _, layout := range youVersionTimes {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,111 @@
+// Package commentary reads commentary kept as Markdown under
+// <config dir>/sword-tui/commentary, one file per chapter named like the
+// notes (book-chapter, 43-003.md). Sections are headed by the verse or
+// range they comment on; text before the first section introduces the
+// chapter:
+//
+//	# John 3
+//	Nicodemus comes by night; the chapter turns on new birth.
+//
+//	## 3:16-21
+//	The evangelist's reflection on the conversation …
+package commentary
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"sword-tui/internal/settings"
+)
+
+// Section comments on verses Start to End.
+type Section struct {
+	Start, End int
+	Text       string
+}
+
+// Chapter is the commentary on one chapter.
+type Chapter struct {
+	Intro    string
+	Sections []Section
+}
+
+// For returns the section covering verse, or nil.
+func (c Chapter) For(verse int) *Section {
+	for i, s := range c.Sections {
+		if verse >= s.Start && verse <= s.End {
+			return &c.Sections[i]
+		}
+	}
+	return nil
+}
+
+// Empty reports whether the chapter has no commentary.
+func (c Chapter) Empty() bool {
+	return c.Intro == "" && len(c.Sections) == 0
+}
+
+// Dir returns the commentary directory.
+func Dir() (string, error) {
+	dir, err := settings.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "commentary"), nil
+}
+
+// heading matches a section heading: "## 3:16", "## 3:16-21" or "## 16".
+var heading = regexp.MustCompile(`^##\s+(?:\d+:)?(\d+)(?:\s*[-–]\s*(?:\d+:)?(\d+))?\s*$`)
+
+// Load reads the commentary on a chapter. A chapter without a file has
+// none.
+func Load(book, chapter int) (Chapter, error) {
+	dir, err := Dir()
+	if err != nil {
+		return Chapter{}, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%02d-%03d.md", book, chapter)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Chapter{}, nil
+		}
+		return Chapter{}, err
+	}
+
+	var c Chapter
+	var text []string
+	cur := -1 // section being read; -1 is the intro
+	flush := func() {
+		t := strings.TrimSpace(strings.Join(text, "\n"))
+		if cur < 0 {
+			c.Intro = t
+		} else {
+			c.Sections[cur].Text = t
+		}
+		text = text[:0]
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if m := heading.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			flush()
+			s := Section{}
+			s.Start, _ = strconv.Atoi(m[1])
+			s.End = s.Start
+			if m[2] != "" {
+				s.End, _ = strconv.Atoi(m[2])
+			}
+			c.Sections = append(c.Sections, s)
+			cur = len(c.Sections) - 1
+			continue
+		}
+		if cur < 0 && strings.HasPrefix(line, "# ") {
+			continue // the file's title
+		}
+		text = append(text, line)
+	}
+	flush()
+	return c, nil
+}
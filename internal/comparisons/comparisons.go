@@ -0,0 +1,102 @@
+// Package comparisons remembers which verses were compared across which
+// translations, in the config directory, so a past comparison can be
+// opened again.
+package comparisons
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"sword-tui/internal/jsonfile"
+	"sword-tui/internal/settings"
+)
+
+// maxEntries is how many comparisons are kept; the oldest go first.
+const maxEntries = 100
+
+// Comparison is one set of verses compared in a set of translations.
+// VerseStart is 0 when the whole chapter was.
+type Comparison struct {
+	Book         int       `json:"book"`
+	BookName     string    `json:"book_name"`
+	Chapter      int       `json:"chapter"`
+	VerseStart   int       `json:"verse_start,omitempty"`
+	VerseEnd     int       `json:"verse_end,omitempty"`
+	Translations []string  `json:"translations"`
+	When         time.Time `json:"when"`
+}
+
+// Reference renders the compared verses as "John 3:16-18".
+func (c Comparison) Reference() string {
+	switch {
+	case c.VerseStart == 0:
+		return fmt.Sprintf("%s %d", c.BookName, c.Chapter)
+	case c.VerseEnd > c.VerseStart:
+		return fmt.Sprintf("%s %d:%d-%d", c.BookName, c.Chapter, c.VerseStart, c.VerseEnd)
+	}
+	return fmt.Sprintf("%s %d:%d", c.BookName, c.Chapter, c.VerseStart)
+}
+
+// same reports whether c and o compare the same verses in the same
+// translations.
+func (c Comparison) same(o Comparison) bool {
+	return c.Book == o.Book && c.Chapter == o.Chapter &&
+		c.VerseStart == o.VerseStart && c.VerseEnd == o.VerseEnd &&
+		slices.Equal(c.Translations, o.Translations)
+}
+
+// Store holds past comparisons, oldest first, without duplicates.
+type Store struct {
+	Entries []Comparison `json:"entries"`
+
+	file jsonfile.File
+}
+
+func path() (string, error) {
+	dir, err := settings.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "comparisons.json"), nil
+}
+
+// Load reads the saved comparisons. A missing file is an empty store;
+// one that can't be read is an empty store that won't be saved.
+func Load() (*Store, error) {
+	s := &Store{}
+	p, err := path()
+	if err != nil {
+		return s, err
+	}
+	if s.file, err = jsonfile.Load(p, s); err != nil {
+		return &Store{file: s.file}, err
+	}
+	return s, nil
+}
+
+func (s *Store) Save() error {
+	p, err := path()
+	if err != nil {
+		return err
+	}
+	return s.file.Save(p, s)
+}
+
+// Add records a comparison as the most recent, moving it up if it was
+// already there.
+func (s *Store) Add(c Comparison) {
+	s.Entries = slices.DeleteFunc(s.Entries, c.same)
+	s.Entries = append(s.Entries, c)
+	if len(s.Entries) > maxEntries {
+		s.Entries = s.Entries[len(s.Entries)-maxEntries:]
+	}
+}
+
+// Recent lists the comparisons newest first.
+func (s *Store) Recent() []Comparison {
+	out := slices.Clone(s.Entries)
+	slices.Reverse(out)
+	return out
+}
@@ -0,0 +1,153 @@
+// Package crossref looks up cross references in
+// <config dir>/sword-tui/cross_references.txt, the tab-separated list
+// published by OpenBible.info:
+//
+//	From Verse	To Verse	Votes
+//	Gen.1.1	Heb.11.3	51
+//	Gen.1.1	Prov.8.22-Prov.8.30	59
+//
+// Verses are in OSIS notation. The file isn't bundled; download it from
+// https://www.openbible.info/labs/cross-references/ and unzip it there.
+package crossref
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"sword-tui/internal/settings"
+)
+
+// FileName is the name of the cross-reference list in the config
+// directory.
+const FileName = "cross_references.txt"
+
+// osisBooks are the OSIS book abbreviations in canonical order.
+var osisBooks = strings.Fields(`Gen Exod Lev Num Deut Josh Judg Ruth 1Sam 2Sam 1Kgs 2Kgs 1Chr 2Chr Ezra Neh Esth Job Ps Prov Eccl Song Isa Jer Lam Ezek Dan Hos Joel Amos Obad Jonah Mic Nah Hab Zeph Hag Zech Mal
+	Matt Mark Luke John Acts Rom 1Cor 2Cor Gal Eph Phil Col 1Thess 2Thess 1Tim 2Tim Titus Phlm Heb Jas 1Pet 2Pet 1John 2John 3John Jude Rev`)
+
+// Ref is a passage a verse refers to. A range that runs into another
+// chapter is shortened to its first verse, so a Ref lies in one chapter.
+type Ref struct {
+	Book, Chapter        int
+	VerseStart, VerseEnd int
+	Votes                int // how many readers found it useful
+}
+
+// verse identifies a verse across the Bible.
+type verse struct{ book, chapter, verse int }
+
+// Index holds the cross references of every verse, most voted first.
+type Index struct {
+	refs map[verse][]Ref
+}
+
+// For returns the cross references of a verse.
+func (idx *Index) For(book, chapter, v int) []Ref {
+	if idx == nil {
+		return nil
+	}
+	return idx.refs[verse{book, chapter, v}]
+}
+
+// Len returns how many verses have cross references.
+func (idx *Index) Len() int {
+	if idx == nil {
+		return 0
+	}
+	return len(idx.refs)
+}
+
+// Path returns where the cross-reference list is looked for.
+func Path() (string, error) {
+	dir, err := settings.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, FileName), nil
+}
+
+// Load reads the cross-reference list. A missing file returns an error
+// satisfying os.IsNotExist.
+func Load() (*Index, error) {
+	p, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	idx := &Index{refs: map[verse][]Ref{}}
+	sc := bufio.NewScanner(f)
+	line := 0
+	for sc.Scan() {
+		line++
+		fields := strings.Split(sc.Text(), "\t")
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "From") || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		from, _, err := parseOSIS(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", FileName, line, err)
+		}
+		start, end, err := parseOSIS(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", FileName, line, err)
+		}
+		r := Ref{Book: start.book, Chapter: start.chapter, VerseStart: start.verse, VerseEnd: start.verse}
+		if end.book == start.book && end.chapter == start.chapter && end.verse > start.verse {
+			r.VerseEnd = end.verse
+		}
+		if len(fields) > 2 {
+			r.Votes, _ = strconv.Atoi(strings.TrimSpace(fields[2]))
+		}
+		idx.refs[from] = append(idx.refs[from], r)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	for _, refs := range idx.refs {
+		sort.SliceStable(refs, func(i, j int) bool { return refs[i].Votes > refs[j].Votes })
+	}
+	return idx, nil
+}
+
+// parseOSIS reads "John.3.16" or a range "John.3.16-John.3.18".
+func parseOSIS(s string) (start, end verse, err error) {
+	a, b, isRange := strings.Cut(strings.TrimSpace(s), "-")
+	if start, err = parseOSISVerse(a); err != nil {
+		return
+	}
+	end = start
+	if isRange {
+		end, err = parseOSISVerse(b)
+	}
+	return
+}
+
+func parseOSISVerse(s string) (verse, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return verse{}, fmt.Errorf("bad verse %q", s)
+	}
+	book := 0
+	for i, name := range osisBooks {
+		if name == parts[0] {
+			book = i + 1
+			break
+		}
+	}
+	chapter, cerr := strconv.Atoi(parts[1])
+	v, verr := strconv.Atoi(parts[2])
+	if book == 0 || cerr != nil || verr != nil {
+		return verse{}, fmt.Errorf("bad verse %q", s)
+	}
+	return verse{book, chapter, v}, nil
+}
@@ -0,0 +1,271 @@
+// Package export renders a passage, or the same passage in several
+// translations side by side, as a Markdown, HTML or plain text document
+// for writing to a file.
+package export
+
+import (
+	"fmt"
+	"html"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"sword-tui/internal/versenum"
+)
+
+// Format is the kind of document written.
+type Format string
+
+const (
+	Markdown Format = "markdown"
+	HTML     Format = "html"
+	Text     Format = "text"
+)
+
+// Formats lists every format in the order the export prompt cycles
+// through them.
+var Formats = []Format{Markdown, HTML, Text}
+
+// Ext is the file name extension the format is recognized by.
+func (f Format) Ext() string {
+	switch f {
+	case Markdown:
+		return ".md"
+	case HTML:
+		return ".html"
+	}
+	return ".txt"
+}
+
+// Name is the format as it is spoken of.
+func (f Format) Name() string {
+	switch f {
+	case Markdown:
+		return "Markdown"
+	case HTML:
+		return "HTML"
+	}
+	return "plain text"
+}
+
+// FormatOf picks the format from a file name's extension: .md and
+// .markdown are Markdown, .html and .htm HTML, anything else plain text.
+func FormatOf(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown":
+		return Markdown
+	case ".html", ".htm":
+		return HTML
+	}
+	return Text
+}
+
+// Layout is how verses are set out.
+type Layout string
+
+const (
+	Verses    Layout = "verses"    // a line (or paragraph) per verse
+	Paragraph Layout = "paragraph" // the verses run together
+)
+
+// ParseLayout returns the named layout, or Verses for "" and unknown
+// names.
+func ParseLayout(name string) Layout {
+	if Layout(name) == Paragraph {
+		return Paragraph
+	}
+	return Verses
+}
+
+// CheckLayout reports a layout name export doesn't know.
+func CheckLayout(name string) error {
+	switch Layout(name) {
+	case "", Verses, Paragraph:
+		return nil
+	}
+	return fmt.Errorf("export_layout: %q is not %q or %q", name, Verses, Paragraph)
+}
+
+// Verse is one verse of a column, its text without markup.
+type Verse struct {
+	Number int
+	Text   string
+}
+
+// Column is the passage in one translation.
+type Column struct {
+	Translation string // as shown to the reader
+	Verses      []Verse
+}
+
+// Passage is what is exported: one column for a passage, several for a
+// comparison.
+type Passage struct {
+	Title   string
+	Columns []Column
+}
+
+// Render writes p out as a document in format f.
+func Render(p Passage, f Format, layout Layout, numbers versenum.Style) string {
+	r := renderer{format: f, layout: layout, numbers: numbers}
+	var sb strings.Builder
+	r.begin(&sb, p.Title)
+	if len(p.Columns) == 1 {
+		r.column(&sb, p.Columns[0])
+	} else if layout == Paragraph {
+		for _, c := range p.Columns {
+			r.heading(&sb, c.Translation)
+			r.column(&sb, c)
+		}
+	} else {
+		r.table(&sb, p.Columns)
+	}
+	r.end(&sb)
+	return sb.String()
+}
+
+type renderer struct {
+	format  Format
+	layout  Layout
+	numbers versenum.Style
+}
+
+func (r renderer) begin(sb *strings.Builder, title string) {
+	switch r.format {
+	case Markdown:
+		fmt.Fprintf(sb, "# %s\n\n", title)
+	case HTML:
+		fmt.Fprintf(sb, `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%[1]s</title>
+<style>
+body { max-width: 42em; margin: 2em auto; padding: 0 1em; font-family: Georgia, serif; line-height: 1.6; }
+sup, th.verse { color: #888; }
+table { border-collapse: collapse; }
+th, td { padding: 0.3em 0.8em; text-align: left; vertical-align: top; }
+</style>
+</head>
+<body>
+<h1>%[1]s</h1>
+`, html.EscapeString(title))
+	default:
+		fmt.Fprintf(sb, "%s\n\n", title)
+	}
+}
+
+func (r renderer) end(sb *strings.Builder) {
+	if r.format == HTML {
+		sb.WriteString("</body>\n</html>\n")
+	}
+}
+
+func (r renderer) heading(sb *strings.Builder, text string) {
+	switch r.format {
+	case Markdown:
+		fmt.Fprintf(sb, "## %s\n\n", text)
+	case HTML:
+		fmt.Fprintf(sb, "<h2>%s</h2>\n", html.EscapeString(text))
+	default:
+		fmt.Fprintf(sb, "%s\n\n", text)
+	}
+}
+
+// verse is a verse with its number in the chosen style.
+func (r renderer) verse(v Verse) string {
+	switch r.format {
+	case Markdown:
+		return r.numbers.Prefix(v.Number, fmt.Sprintf("**%d** ", v.Number)) + v.Text
+	case HTML:
+		// The other styles' numbers need no escaping.
+		return r.numbers.Prefix(v.Number, fmt.Sprintf("<sup>%d</sup> ", v.Number)) + html.EscapeString(v.Text)
+	}
+	return r.numbers.Prefix(v.Number, fmt.Sprintf("%d. ", v.Number)) + v.Text
+}
+
+// column writes one translation's verses as paragraphs: one per verse,
+// or one in all.
+func (r renderer) column(sb *strings.Builder, c Column) {
+	var verses []string
+	for _, v := range c.Verses {
+		verses = append(verses, r.verse(v))
+	}
+	if r.layout == Paragraph {
+		verses = []string{strings.Join(verses, " ")}
+	}
+	for _, s := range verses {
+		if r.format == HTML {
+			fmt.Fprintf(sb, "<p>%s</p>\n", s)
+		} else {
+			fmt.Fprintf(sb, "%s\n\n", s)
+		}
+	}
+}
+
+// table writes a comparison a verse at a time, the translations side by
+// side in Markdown and HTML and one under another in plain text.
+// Translations may differ in which verses they include.
+func (r renderer) table(sb *strings.Builder, cols []Column) {
+	byVerse := map[int][]string{}
+	for i, c := range cols {
+		for _, v := range c.Verses {
+			if byVerse[v.Number] == nil {
+				byVerse[v.Number] = make([]string, len(cols))
+			}
+			byVerse[v.Number][i] = v.Text
+		}
+	}
+	numbers := make([]int, 0, len(byVerse))
+	for n := range byVerse {
+		numbers = append(numbers, n)
+	}
+	slices.Sort(numbers)
+
+	names := make([]string, len(cols))
+	for i, c := range cols {
+		names[i] = c.Translation
+	}
+	switch r.format {
+	case Markdown:
+		row := func(cells []string) string {
+			escaped := make([]string, len(cells))
+			for i, c := range cells {
+				escaped[i] = strings.ReplaceAll(c, "|", `\|`)
+			}
+			return strings.Join(escaped, " | ")
+		}
+		fmt.Fprintf(sb, "| | %s |\n|---|%s\n", row(names), strings.Repeat("---|", len(cols)))
+		for _, n := range numbers {
+			fmt.Fprintf(sb, "| %d | %s |\n", n, row(byVerse[n]))
+		}
+	case HTML:
+		sb.WriteString("<table>\n<tr><th></th>")
+		for _, name := range names {
+			fmt.Fprintf(sb, "<th>%s</th>", html.EscapeString(name))
+		}
+		sb.WriteString("</tr>\n")
+		for _, n := range numbers {
+			fmt.Fprintf(sb, `<tr><th class="verse">%d</th>`, n)
+			for _, text := range byVerse[n] {
+				fmt.Fprintf(sb, "<td>%s</td>", html.EscapeString(text))
+			}
+			sb.WriteString("</tr>\n")
+		}
+		sb.WriteString("</table>\n")
+	default:
+		label := 0
+		for _, name := range names {
+			label = max(label, len(name))
+		}
+		for _, n := range numbers {
+			fmt.Fprintf(sb, "%d\n", n)
+			for i, text := range byVerse[n] {
+				if text != "" {
+					fmt.Fprintf(sb, "  %-*s  %s\n", label, names[i], text)
+				}
+			}
+			sb.WriteString("\n")
+		}
+	}
+}
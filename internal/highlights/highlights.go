@@ -0,0 +1,129 @@
+// Package highlights stores colored verse highlights in the annotations
+// directory.
+package highlights
+
+import (
+	"path/filepath"
+	"sort"
+	"time"
+
+	"sword-tui/internal/jsonfile"
+	"sword-tui/internal/settings"
+)
+
+// Colors are the highlight colors in the order H cycles through them.
+var Colors = []string{"yellow", "green", "blue", "pink"}
+
+// Highlight colors a single verse.
+type Highlight struct {
+	Book    int       `json:"book"`
+	Chapter int       `json:"chapter"`
+	Verse   int       `json:"verse"`
+	Color   string    `json:"color"`
+	Added   time.Time `json:"added"`
+}
+
+// Store holds every highlight in canonical order.
+type Store struct {
+	Highlights []Highlight `json:"highlights"`
+
+	file jsonfile.File
+}
+
+func path() (string, error) {
+	dir, err := settings.AnnotationsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "highlights.json"), nil
+}
+
+// Load reads the saved highlights. A missing file is an empty store;
+// one that can't be read is an empty store that won't be saved.
+func Load() (*Store, error) {
+	s := &Store{}
+	p, err := path()
+	if err != nil {
+		return s, err
+	}
+	if s.file, err = jsonfile.Load(p, s); err != nil {
+		return &Store{file: s.file}, err
+	}
+	return s, nil
+}
+
+func (s *Store) Save() error {
+	p, err := path()
+	if err != nil {
+		return err
+	}
+	return s.file.Save(p, s)
+}
+
+func (s *Store) find(book, chapter, verse int) int {
+	for i, h := range s.Highlights {
+		if h.Book == book && h.Chapter == chapter && h.Verse == verse {
+			return i
+		}
+	}
+	return -1
+}
+
+// Color returns the verse's highlight color, or "" if it has none.
+func (s *Store) Color(book, chapter, verse int) string {
+	if i := s.find(book, chapter, verse); i >= 0 {
+		return s.Highlights[i].Color
+	}
+	return ""
+}
+
+// Set colors a verse; an empty color removes its highlight.
+func (s *Store) Set(book, chapter, verse int, color string) {
+	i := s.find(book, chapter, verse)
+	switch {
+	case color == "" && i >= 0:
+		s.Highlights = append(s.Highlights[:i], s.Highlights[i+1:]...)
+	case color == "":
+	case i >= 0:
+		s.Highlights[i].Color = color
+	default:
+		s.Highlights = append(s.Highlights, Highlight{Book: book, Chapter: chapter, Verse: verse, Color: color, Added: time.Now()})
+		sort.SliceStable(s.Highlights, func(i, j int) bool {
+			a, b := s.Highlights[i], s.Highlights[j]
+			if a.Book != b.Book {
+				return a.Book < b.Book
+			}
+			if a.Chapter != b.Chapter {
+				return a.Chapter < b.Chapter
+			}
+			return a.Verse < b.Verse
+		})
+	}
+}
+
+// Next is the color after c in Colors; after the last comes "" (none).
+func Next(c string) string {
+	for i, o := range Colors {
+		if o == c {
+			if i+1 < len(Colors) {
+				return Colors[i+1]
+			}
+			return ""
+		}
+	}
+	return Colors[0]
+}
+
+// Restore colors a verse as Set does but dates the highlight added, for
+// highlights brought over from elsewhere. It reports whether the verse
+// changed.
+func (s *Store) Restore(book, chapter, verse int, color string, added time.Time) bool {
+	if color == "" || s.Color(book, chapter, verse) == color {
+		return false
+	}
+	s.Set(book, chapter, verse, color)
+	if i := s.find(book, chapter, verse); i >= 0 && !added.IsZero() {
+		s.Highlights[i].Added = added
+	}
+	return true
+}
@@ -0,0 +1,71 @@
+// Package history remembers what was typed into the reference and text
+// search inputs, in the config directory, so earlier queries can be
+// recalled.
+package history
+
+import (
+	"path/filepath"
+	"strings"
+
+	"sword-tui/internal/jsonfile"
+	"sword-tui/internal/settings"
+)
+
+// maxEntries is how many queries are kept; the oldest go first.
+const maxEntries = 200
+
+// Store holds past queries, oldest first, without duplicates.
+type Store struct {
+	Entries []string `json:"entries"`
+
+	file jsonfile.File
+}
+
+func path() (string, error) {
+	dir, err := settings.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "history.json"), nil
+}
+
+// Load reads the saved history. A missing file is an empty store;
+// one that can't be read is an empty store that won't be saved.
+func Load() (*Store, error) {
+	s := &Store{}
+	p, err := path()
+	if err != nil {
+		return s, err
+	}
+	if s.file, err = jsonfile.Load(p, s); err != nil {
+		return &Store{file: s.file}, err
+	}
+	return s, nil
+}
+
+func (s *Store) Save() error {
+	p, err := path()
+	if err != nil {
+		return err
+	}
+	return s.file.Save(p, s)
+}
+
+// Add records a query as the most recent, moving it up if it was already
+// there.
+func (s *Store) Add(q string) {
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return
+	}
+	for i, e := range s.Entries {
+		if e == q {
+			s.Entries = append(s.Entries[:i], s.Entries[i+1:]...)
+			break
+		}
+	}
+	s.Entries = append(s.Entries, q)
+	if len(s.Entries) > maxEntries {
+		s.Entries = s.Entries[len(s.Entries)-maxEntries:]
+	}
+}
@@ -0,0 +1,46 @@
+// Package jsonfile reads and writes the JSON files the bookmarks,
+// highlights, workspace and the other stores keep under the config
+// directory.
+package jsonfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// File remembers whether a store's file could be read. One that couldn't
+// is never written over: the store starts empty, and saving it would
+// replace what the user had with nothing.
+type File struct {
+	loadErr error
+}
+
+// Load reads the JSON at path into v. A missing file leaves v as it is.
+func Load(path string, v any) (File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return File{}, nil
+		}
+		return File{loadErr: err}, err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		err = fmt.Errorf("%s: %w", path, err)
+		return File{loadErr: err}, err
+	}
+	return File{}, nil
+}
+
+// Save writes v to path as indented JSON, unless path couldn't be read
+// when it was loaded.
+func (f File) Save(path string, v any) error {
+	if f.loadErr != nil {
+		return fmt.Errorf("not saving over a file that couldn't be read: %w", f.loadErr)
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
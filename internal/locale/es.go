@@ -0,0 +1,260 @@
+package locale
+
+// spanish is the "es" catalog.
+var spanish = map[string]string{
+	// Key help groups and bindings.
+	"Navigation":                                "Navegación",
+	"Translations":                              "Traducciones",
+	"Study":                                     "Estudio",
+	"Display":                                   "Presentación",
+	"General":                                   "General",
+	"switch focused pane":                       "cambiar de panel",
+	"open book / submit":                        "abrir libro / aceptar",
+	"next / prev chapter":                       "capítulo siguiente / anterior",
+	"chapter grid":                              "cuadrícula de capítulos",
+	"set / jump to mark":                        "poner / ir a marca",
+	"go to verse":                               "ir a versículo",
+	"go to reference on clipboard":              "ir a la referencia del portapapeles",
+	"Miller columns":                            "columnas de Miller",
+	"search Bible":                              "buscar en la Biblia",
+	"compare translations":                      "comparar traducciones",
+	"filter comparison / differences":           "filtrar comparación / diferencias",
+	"compare original, literal, dynamic":        "comparar original, literal, dinámica",
+	"recently compared":                         "comparaciones recientes",
+	"select translation":                        "elegir traducción",
+	"download translations":                     "descargar traducciones",
+	"check connection":                          "comprobar conexión",
+	"yank verse / send to tmux":                 "copiar versículo / enviar a tmux",
+	"select words in verse":                     "seleccionar palabras del versículo",
+	"quick note on verse":                       "nota rápida en el versículo",
+	"longer note on verse":                      "nota larga en el versículo",
+	"read notes, follow references":             "leer notas, seguir referencias",
+	"notes that mention the verse":              "notas que mencionan el versículo",
+	"cycle highlight color":                     "cambiar color de resaltado",
+	"prev / next annotated verse":               "versículo anotado anterior / siguiente",
+	"search annotations":                        "buscar anotaciones",
+	"reading activity":                          "actividad de lectura",
+	"reading plans":                             "planes de lectura",
+	"start screen":                              "pantalla de inicio",
+	"pin passage / workspace":                   "fijar pasaje / espacio de trabajo",
+	"bookmark / bookmarks":                      "marcador / marcadores",
+	"sync annotations":                          "sincronizar anotaciones",
+	"select theme":                              "elegir tema",
+	"compact / comfortable density":             "densidad compacta / cómoda",
+	"typewriter scrolling":                      "desplazamiento de máquina de escribir",
+	"verse number style":                        "estilo de números de versículo",
+	"expand / collapse outline":                 "desplegar / plegar esquema",
+	"study view: cross refs, notes, commentary": "vista de estudio: referencias, notas, comentario",
+	"cross references of verse":                 "referencias cruzadas del versículo",
+	"open chapter in $PAGER":                    "abrir capítulo en $PAGER",
+	"about":                                     "acerca de",
+	"quit":                                      "salir",
+	"mouse on / off":                            "ratón sí / no",
+	"append yanks to clipboard":                 "añadir copias al portapapeles",
+	"export to Markdown, HTML or text file":     "exportar a Markdown, HTML o texto",
+	"star book":                                 "marcar libro favorito",
+	"move down / up":                            "bajar / subir",
+	"move between panes":                        "moverse entre paneles",
+	"scroll a page":                             "desplazar una página",
+	"focus books / text":                        "ir a libros / texto",
+	"fold books section":                        "plegar sección de libros",
+	"close / cancel":                            "cerrar / cancelar",
+	"back to reader":                            "volver al lector",
+
+	// Status bar hints.
+	"all":            "todas",
+	"back":           "volver",
+	"cancel":         "cancelar",
+	"chapter":        "capítulo",
+	"chapters/books": "capítulos/libros",
+	"clear":          "limpiar",
+	"close":          "cerrar",
+	"delete":         "borrar",
+	"differences":    "diferencias",
+	"done":           "listo",
+	"download":       "descargar",
+	"export":         "exportar",
+	"filter":         "filtrar",
+	"focus":          "panel",
+	"go":             "ir",
+	"heat list":      "lista de calor",
+	"hide":           "ocultar",
+	"history":        "historial",
+	"jump to mark":   "ir a marca",
+	"languages":      "idiomas",
+	"mark":           "marcar",
+	"move":           "mover",
+	"navigate":       "navegar",
+	"note":           "nota",
+	"open":           "abrir",
+	"page":           "página",
+	"other end":      "otro extremo",
+	"pager":          "paginador",
+	"plain text":     "texto plano",
+	"reader":         "lector",
+	"remove":         "quitar",
+	"reorder":        "reordenar",
+	"save note":      "guardar nota",
+	"scroll":         "desplazar",
+	"search":         "buscar",
+	"section":        "sección",
+	"select":         "elegir",
+	"set mark":       "poner marca",
+	"theme":          "tema",
+	"translation":    "traducción",
+	"update":         "actualizar",
+	"use":            "usar",
+	"verse":          "versículo",
+	"week":           "semana",
+	"what's new":     "novedades",
+	"word":           "palabra",
+	"yank phrase":    "copiar frase",
+	"● loading":      "● cargando",
+
+	// Panels.
+	"A terminal-based Bible application": "Una Biblia para la terminal",
+	"Version:":                           "Versión:",
+	"Build:":                             "Build:",
+	"Repo:":                              "Repo:",
+	"API:":                               "API:",
+	"License:":                           "Licencia:",
+	"Changes:":                           "Cambios:",
+	"press n":                            "pulsa n",
+	"Books":                              "Libros",
+	"BOOKS":                              "LIBROS",
+	"CHAPTERS":                           "CAPÍTULOS",
+	"VERSES":                             "VERSÍCULOS",
+	"OLD TESTAMENT":                      "ANTIGUO TESTAMENTO",
+	"NEW TESTAMENT":                      "NUEVO TESTAMENTO",
+	"FAVORITES":                          "FAVORITOS",
+	"Loading…":                           "Cargando…",
+	"Go to verse":                        "Ir a versículo",
+	`e.g. "John 3:16" or "1 1:1"`:        `p. ej. "John 3:16" o "1 1:1"`,
+	"Select Translation":                 "Elegir traducción",
+	"Download Translations":              "Descargar traducciones",
+	"Languages":                          "Idiomas",
+	"On this computer":                   "En este equipo",
+	"Select Theme":                       "Elegir tema",
+	"Search Bible":                       "Buscar en la Biblia",
+	"Type a word or phrase, then ⏎":      "Escribe una palabra o frase y pulsa ⏎",
+	"Searching…":                         "Buscando…",
+	"Activity":                           "Actividad",
+	"Annotations":                        "Anotaciones",
+	"Bookmarks":                          "Marcadores",
+	"History":                            "Historial",
+	"Reading plans":                      "Planes de lectura",
+	"Workspace":                          "Espacio de trabajo",
+	"Recently compared":                  "Comparado hace poco",
+	"Note on %s %d:%d":                   "Nota sobre %s %d:%d",
+	"ctrl+s save  ·  esc discard":        "ctrl+s guardar  ·  esc descartar",
+	"What's new in sword-tui":            "Novedades de sword-tui",
+	"Update to":                          "Actualización de",
+	"⏎ replace  ·  esc keep old":         "⏎ reemplazar  ·  esc conservar",
+	"Book %d":                            "Libro %d",
+	"%d (new)":                           "%d (nuevo)",
+	"%d (gone)":                          "%d (eliminado)",
+	"%d (%d→%d verses)":                  "%d (%d→%d versículos)",
+	"Nothing matches.":                   "Nada coincide.",
+	"Nothing yet — chapters you read, bookmark, note or highlight show up here.": "Nada todavía: aquí aparecen los capítulos que lees, marcas, anotas o resaltas.",
+	"No annotations yet — b bookmarks, a notes, H highlights.":                   "Aún no hay anotaciones: b marca, a anota, H resalta.",
+	"No bookmarks yet — press b in the reader, or run sword-tui import.":         "Aún no hay marcadores: pulsa b en el lector o ejecuta sword-tui import.",
+	"Nothing pinned yet — press w in the reader to pin a passage.":               "Nada fijado todavía: pulsa w en el lector para fijar un pasaje.",
+	"Terminal too small — resize to at least 60×18.":                             "Terminal demasiado pequeña: amplíala al menos a 60×18.",
+	"Enter verse reference (e.g., 1 1:1 or Gen 1:1)":                             "Escribe una referencia (p. ej., 1 1:1 o Gen 1:1)",
+	"Type to filter...":   "Escribe para filtrar...",
+	"Search the Bible...": "Buscar en la Biblia...",
+	"Search notes · book:john #tag color:yellow kind:note since:2026-01-01": "Buscar notas · book:john #etiqueta color:yellow kind:note since:2026-01-01",
+	"Quick note for this verse...":                                          "Nota rápida para este versículo...",
+	"Show verses that mention...":                                           "Mostrar versículos que mencionen...",
+	"read alike":                                                            "se leen igual",
+	"Note for this verse, in Markdown...":                                   "Nota para este versículo, en Markdown...",
+	"Note for this passage...":                                              "Nota para este pasaje...",
+
+	// Messages.
+	"mouse off — select text with the terminal": "ratón desactivado: selecciona texto con la terminal",
+	"mouse on":                                                    "ratón activado",
+	"appending yanks — + again to stop":                           "añadiendo copias: + otra vez para parar",
+	"yanks replace the clipboard again":                           "las copias vuelven a reemplazar el portapapeles",
+	"appended · 1 passage on the clipboard":                       "añadido · 1 pasaje en el portapapeles",
+	"books are still loading":                                     "los libros aún se están cargando",
+	"can't hide the translation being read":                       "no se puede ocultar la traducción que estás leyendo",
+	"checking connection…":                                        "comprobando la conexión…",
+	"no annotations in this chapter":                              "no hay anotaciones en este capítulo",
+	"no reading plans — build one with sword-tui plan":            "no hay planes de lectura: crea uno con sword-tui plan",
+	"no reference on the clipboard":                               "no hay ninguna referencia en el portapapeles",
+	"nothing to catch up on":                                      "no hay nada que recuperar",
+	"select a verse to highlight":                                 "elige un versículo para resaltarlo",
+	"select a verse to note":                                      "elige un versículo para anotarlo",
+	"select a verse to read its notes":                            "elige un versículo para leer sus notas",
+	"select a verse to see what links to it":                      "elige un versículo para ver qué remite a él",
+	"select a verse to see its cross references":                  "elige un versículo para ver sus referencias cruzadas",
+	"study view off":                                              "vista de estudio desactivada",
+	"sync disabled — set sync_mode in config.json":                "sincronización desactivada: configura sync_mode en config.json",
+	"typewriter scrolling off":                                    "desplazamiento de máquina de escribir desactivado",
+	"typewriter scrolling on":                                     "desplazamiento de máquina de escribir activado",
+	"workspace is empty":                                          "el espacio de trabajo está vacío",
+	"%s unreachable":                                              "%s no responde",
+	"%s unreachable (timed out)":                                  "%s no responde (tiempo agotado)",
+	"%s: reading offline":                                         "%s: lectura sin conexión",
+	"API slow: %.1fs":                                             "API lenta: %.1fs",
+	"%s is up to date":                                            "%s está al día",
+	"updated %s":                                                  "%s actualizada",
+	"%d chapters in %d books differ, %d verses reworded":          "%d capítulos de %d libros difieren, %d versículos reescritos",
+	"can't read the clipboard (needs xclip, xsel or wl-paste)":    "no se puede leer el portapapeles (hace falta xclip, xsel o wl-paste)",
+	"can't read the clipboard":                                    "no se puede leer el portapapeles",
+	"no other readers — add one with sword-tui plan -reader NAME": "no hay otros lectores: añade uno con sword-tui plan -reader NOMBRE",
+	"showing every verse":                                         "mostrando todos los versículos",
+	"differences only · %s":                                       "solo diferencias · %s",
+	"%d of %d verses":                                             "%d de %d versículos",
+	"%s unstarred":                                                "%s ya no es favorito",
+	"★ %s added to favorites":                                     "★ %s añadido a favoritos",
+	"the provider doesn't say what language its texts are in":     "el proveedor no dice en qué idioma están sus textos",
+	"at least one language has to be listed":                      "hay que listar al menos un idioma",
+	"↑↓ select  ·  space toggle  ·  esc close":                    "↑↓ elegir  ·  espacio marcar  ·  esc cerrar",
+	"nothing compared yet — c compares translations":              "nada comparado todavía: c compara traducciones",
+	"↑↓ select  ·  ⏎ compare again  ·  esc close":                 "↑↓ elegir  ·  ⏎ comparar de nuevo  ·  esc cerrar",
+	"✓ %s · settings updated, restart to apply":                   "✓ %s · ajustes actualizados, reinicia para aplicarlos",
+	"exported %s":                                                 "exportado a %s",
+	"📌 pinned %s (%d in workspace)":                               "📌 %s fijado (%d en el espacio de trabajo)",
+	"no notes mention %s %d:%d":                                   "ninguna nota menciona %s %d:%d",
+	"no notes on %s %d:%d — a adds one":                           "no hay notas en %s %d:%d: a añade una",
+	"%s is not in %s":                                             "%s no está en %s",
+	"reading as %s":                                               "leyendo como %s",
+	"%s rescheduled, ends %s":                                     "%s reprogramado, termina el %s",
+	"study layout: %s":                                            "vista de estudio: %s",
+	"⚑ bookmarked %s":                                             "⚑ marcador en %s",
+	"removed bookmark %s":                                         "marcador quitado: %s",
+	"✎ noted %s":                                                  "✎ nota en %s",
+	"✎ noted %s %d:%d":                                            "✎ nota en %s %d:%d",
+	"cancelled %s":                                                "%s cancelada",
+	"%s answered with status %d":                                  "%s respondió con el estado %d",
+	"%s is slow (%.1f s)":                                         "%s va lento (%.1f s)",
+	"%s reachable (%d ms)":                                        "%s accesible (%d ms)",
+	"removed highlight %s":                                        "resaltado quitado: %s",
+	"mark %s · %s":                                                "marca %s · %s",
+	"mark %s not set":                                             "la marca %s no está puesta",
+	"verse numbers: %s":                                           "números de versículo: %s",
+	"density: %s":                                                 "densidad: %s",
+	"copied as %d posts":                                          "copiado en %d publicaciones",
+	"sent to tmux pane %s":                                        "enviado al panel de tmux %s",
+	"%s has %d chapters":                                          "%s tiene %d capítulos",
+	"%s %d has %d verses":                                         "%s %d tiene %d versículos",
+	"%s shown again":                                              "%s vuelve a mostrarse",
+	"hid %s · a shows all":                                        "%s oculta · a muestra todas",
+	"back at verse %d":                                            "de vuelta en el versículo %d",
+	"⏰ time to read %s: %s (R)":                                   "⏰ hora de leer %s: %s (R)",
+	"copied phrase from %s":                                       "frase copiada de %s",
+	"appended · %d passages on the clipboard":                     "añadido · %d pasajes en el portapapeles",
+	"%s translations hidden":                                      "traducciones en %s ocultas",
+	"%s translations listed":                                      "traducciones en %s listadas",
+
+	// Connection errors.
+	"not found on %s — try another translation (t)":                          "no está en %s: prueba otra traducción (t)",
+	"%s is rate limiting — wait a moment and retry":                          "%s está limitando las peticiones: espera un momento y reintenta",
+	"%s is having trouble (%d) — try later, or download the translation (d)": "%s tiene problemas (%d): prueba más tarde o descarga la traducción (d)",
+	"%s refused the request (%d)":                                            "%s rechazó la petición (%d)",
+	"can't look up %s — check your connection (C to recheck)":                "no se encuentra %s: revisa la conexión (C para comprobar)",
+	"%s timed out — check your connection (C to recheck)":                    "%s no respondió a tiempo: revisa la conexión (C para comprobar)",
+	"can't reach %s — downloaded translations (d) still work offline":        "no se llega a %s: las traducciones descargadas (d) funcionan sin conexión",
+	"unexpected reply from %s — try again later":                             "respuesta inesperada de %s: inténtalo más tarde",
+}
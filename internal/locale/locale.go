@@ -0,0 +1,70 @@
+// Package locale translates the reader's own text — the key help, panel
+// titles, status bar hints and messages — into the language chosen by
+// the "language" setting. The Bible text is whatever language the
+// translation is in and never goes through here.
+//
+// Strings are looked up by their English text, so T("Bookmarks") is
+// "Marcadores" in Spanish, and a string a catalog lacks stays English.
+package locale
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// English is the language the strings are written in.
+const English = "en"
+
+// catalogs holds each shipped language's translations, by English text.
+var catalogs = map[string]map[string]string{
+	"es": spanish,
+}
+
+// current is the catalog in use; nil is English.
+var current map[string]string
+
+// Languages lists the language codes that can be set, English first.
+func Languages() []string {
+	langs := []string{English}
+	for code := range catalogs {
+		langs = append(langs, code)
+	}
+	sort.Strings(langs[1:])
+	return langs
+}
+
+// Check reports a language that isn't shipped.
+func Check(lang string) error {
+	if lang == "" || lang == English {
+		return nil
+	}
+	if _, ok := catalogs[strings.ToLower(lang)]; !ok {
+		return fmt.Errorf("language: %q is not one of %s", lang, strings.Join(Languages(), ", "))
+	}
+	return nil
+}
+
+// Set switches to lang, "" meaning English. An unknown language leaves
+// English in use.
+func Set(lang string) error {
+	current = nil
+	if err := Check(lang); err != nil {
+		return err
+	}
+	current = catalogs[strings.ToLower(lang)]
+	return nil
+}
+
+// T returns s in the current language.
+func T(s string) string {
+	if t, ok := current[s]; ok {
+		return t
+	}
+	return s
+}
+
+// Tf formats with format in the current language.
+func Tf(format string, args ...any) string {
+	return fmt.Sprintf(T(format), args...)
+}
@@ -0,0 +1,79 @@
+// Package marks saves vim-style jump marks, letters naming a verse, in the
+// config directory so they can outlive a session.
+package marks
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"sword-tui/internal/jsonfile"
+	"sword-tui/internal/settings"
+)
+
+// Mark is the verse a letter points at.
+type Mark struct {
+	Book     int    `json:"book"`
+	BookName string `json:"book_name"`
+	Chapter  int    `json:"chapter"`
+	Verse    int    `json:"verse,omitempty"`
+}
+
+// Reference renders the mark as "John 3:16".
+func (m Mark) Reference() string {
+	if m.Verse == 0 {
+		return fmt.Sprintf("%s %d", m.BookName, m.Chapter)
+	}
+	return fmt.Sprintf("%s %d:%d", m.BookName, m.Chapter, m.Verse)
+}
+
+// Store maps mark letters to verses.
+type Store struct {
+	marks map[string]Mark
+	file  jsonfile.File
+}
+
+// New returns an empty store, for marks that last only the session.
+func New() *Store {
+	return &Store{marks: map[string]Mark{}}
+}
+
+// Get returns the verse key points at.
+func (s *Store) Get(key string) (Mark, bool) {
+	mk, ok := s.marks[key]
+	return mk, ok
+}
+
+// Set points key at mk.
+func (s *Store) Set(key string, mk Mark) {
+	s.marks[key] = mk
+}
+
+func path() (string, error) {
+	dir, err := settings.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "marks.json"), nil
+}
+
+// Load reads the saved marks. A missing file is an empty store; one that
+// can't be read is an empty store that won't be saved.
+func Load() (*Store, error) {
+	s := New()
+	p, err := path()
+	if err != nil {
+		return s, err
+	}
+	if s.file, err = jsonfile.Load(p, &s.marks); err != nil {
+		return &Store{marks: map[string]Mark{}, file: s.file}, err
+	}
+	return s, nil
+}
+
+func (s *Store) Save() error {
+	p, err := path()
+	if err != nil {
+		return err
+	}
+	return s.file.Save(p, s.marks)
+}
@@ -0,0 +1,204 @@
+// Package notes keeps per-verse study notes as Markdown, one file per
+// chapter under annotations/notes, so they stay readable and editable
+// outside sword-tui and merge line by line when synced:
+//
+//	# John 3
+//
+//	## 3:16
+//	- 2026-10-17 14:03 — God's love is the motive, not our merit
+//	- 2026-10-17 14:20 — A longer note keeps its line breaks,
+//	  each line after the first indented under the entry.
+package notes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"sword-tui/internal/settings"
+)
+
+// timeLayout is how entry timestamps are written.
+const timeLayout = "2006-01-02 15:04"
+
+// Note is one timestamped entry on a verse. Text may run over several
+// lines.
+type Note struct {
+	Book, Chapter, Verse int
+	Time                 time.Time
+	Text                 string
+}
+
+// Tags returns the #tags written in the note, lowercased and without the #.
+func (n Note) Tags() []string {
+	var tags []string
+	for _, w := range strings.Fields(n.Text) {
+		if t, ok := strings.CutPrefix(w, "#"); ok {
+			if t = strings.ToLower(strings.TrimRight(t, ".,;:!?")); t != "" {
+				tags = append(tags, t)
+			}
+		}
+	}
+	return tags
+}
+
+func dir() (string, error) {
+	base, err := settings.AnnotationsDir()
+	if err != nil {
+		return "", err
+	}
+	d := filepath.Join(base, "notes")
+	if err := os.MkdirAll(d, 0o755); err != nil {
+		return "", err
+	}
+	return d, nil
+}
+
+func path(book, chapter int) (string, error) {
+	d, err := dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(d, fmt.Sprintf("%02d-%03d.md", book, chapter)), nil
+}
+
+func heading(chapter, verse int) string {
+	return fmt.Sprintf("## %d:%d", chapter, verse)
+}
+
+// Append adds a note on a verse, creating the chapter file (titled with
+// bookName) or the verse's section as needed. Blank lines in text are
+// dropped; the others are kept.
+func Append(bookName string, book, chapter, verse int, text string, at time.Time) error {
+	var kept []string
+	for _, l := range strings.Split(text, "\n") {
+		if l = strings.Join(strings.Fields(l), " "); l != "" {
+			kept = append(kept, l)
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	p, err := path(book, chapter)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(p)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	var lines []string
+	if len(data) == 0 {
+		lines = []string{fmt.Sprintf("# %s %d", bookName, chapter)}
+	} else {
+		lines = strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	}
+	entry := fmt.Sprintf("- %s — %s", at.Format(timeLayout), strings.Join(kept, "\n  "))
+
+	// Insert after the last line of the verse's section, or start a new
+	// section at the end of the file.
+	want := heading(chapter, verse)
+	section := -1
+	for i, l := range lines {
+		if strings.TrimSpace(l) == want {
+			section = i
+			break
+		}
+	}
+	if section < 0 {
+		lines = append(lines, "", want, entry)
+	} else {
+		end := section + 1
+		for end < len(lines) && !strings.HasPrefix(lines[end], "## ") {
+			end++
+		}
+		for end > section+1 && strings.TrimSpace(lines[end-1]) == "" {
+			end--
+		}
+		lines = append(lines[:end], append([]string{entry}, lines[end:]...)...)
+	}
+	return os.WriteFile(p, []byte(strings.Join(lines, "\n")+"\n"), 0o644)
+}
+
+// ForChapter returns the notes of a chapter keyed by verse. A chapter
+// without notes returns an empty map.
+func ForChapter(book, chapter int) (map[int][]Note, error) {
+	out := map[int][]Note{}
+	p, err := path(book, chapter)
+	if err != nil {
+		return out, err
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return out, nil
+		}
+		return out, err
+	}
+
+	verse := 0
+	for _, raw := range strings.Split(string(data), "\n") {
+		l := strings.TrimSpace(raw)
+		// An indented line carries on the entry above it.
+		if notes := out[verse]; l != "" && len(notes) > 0 && strings.HasPrefix(raw, "  ") {
+			notes[len(notes)-1].Text += "\n" + l
+			continue
+		}
+		if ref, ok := strings.CutPrefix(l, "## "); ok {
+			verse = 0
+			if _, v, ok := strings.Cut(ref, ":"); ok {
+				verse, _ = strconv.Atoi(v)
+			}
+			continue
+		}
+		entry, ok := strings.CutPrefix(l, "- ")
+		if verse == 0 || !ok {
+			continue
+		}
+		n := Note{Book: book, Chapter: chapter, Verse: verse, Text: entry}
+		if stamp, text, ok := strings.Cut(entry, " — "); ok {
+			if t, err := time.ParseInLocation(timeLayout, stamp, time.Local); err == nil {
+				n.Time, n.Text = t, text
+			}
+		}
+		out[verse] = append(out[verse], n)
+	}
+	return out, nil
+}
+
+// All returns every note, in canonical order.
+func All() ([]Note, error) {
+	d, err := dir()
+	if err != nil {
+		return nil, err
+	}
+	files, err := filepath.Glob(filepath.Join(d, "*.md"))
+	if err != nil {
+		return nil, err
+	}
+	var all []Note
+	for _, f := range files { // names sort canonically: BB-CCC.md
+		var book, chapter int
+		if _, err := fmt.Sscanf(filepath.Base(f), "%d-%d.md", &book, &chapter); err != nil {
+			continue
+		}
+		byVerse, err := ForChapter(book, chapter)
+		if err != nil {
+			return all, err
+		}
+		verses := make([]int, 0, len(byVerse))
+		for v := range byVerse {
+			verses = append(verses, v)
+		}
+		sort.Ints(verses)
+		for _, v := range verses {
+			all = append(all, byVerse[v]...)
+		}
+	}
+	return all, nil
+}
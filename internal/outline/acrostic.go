@@ -0,0 +1,49 @@
+package outline
+
+// Letter is a letter of the Hebrew alphabet heading a stanza of an
+// acrostic poem.
+type Letter struct {
+	Glyph string // e.g. "א"
+	Name  string // e.g. "Aleph"
+}
+
+// alphabet is the Hebrew alphabet in order, named as English Bibles
+// head the stanzas of Psalm 119.
+var alphabet = []Letter{
+	{"א", "Aleph"}, {"ב", "Beth"}, {"ג", "Gimel"}, {"ד", "Daleth"},
+	{"ה", "He"}, {"ו", "Waw"}, {"ז", "Zayin"}, {"ח", "Heth"},
+	{"ט", "Teth"}, {"י", "Yodh"}, {"כ", "Kaph"}, {"ל", "Lamedh"},
+	{"מ", "Mem"}, {"נ", "Nun"}, {"ס", "Samekh"}, {"ע", "Ayin"},
+	{"פ", "Pe"}, {"צ", "Tsadhe"}, {"ק", "Qoph"}, {"ר", "Resh"},
+	{"ש", "Sin and Shin"}, {"ת", "Taw"},
+}
+
+// acrostic is where an acrostic chapter's stanzas start and how many
+// verses each has. Only chapters whose stanzas line up with English
+// verse numbers are listed.
+type acrostic struct {
+	first, stanza int
+}
+
+var acrostics = map[[2]int]acrostic{
+	{19, 119}: {1, 8},  // Psalm 119
+	{20, 31}:  {10, 1}, // Proverbs 31:10-31
+	{25, 1}:   {1, 1},  // Lamentations 1
+	{25, 2}:   {1, 1},
+	{25, 3}:   {1, 3},
+	{25, 4}:   {1, 1},
+}
+
+// Acrostic returns the letter heading each stanza of an acrostic
+// chapter, by the verse it starts at, or nil if the chapter isn't one.
+func Acrostic(book, chapter int) map[int]Letter {
+	a, ok := acrostics[[2]int{book, chapter}]
+	if !ok {
+		return nil
+	}
+	out := make(map[int]Letter, len(alphabet))
+	for i, l := range alphabet {
+		out[a.first+i*a.stanza] = l
+	}
+	return out
+}
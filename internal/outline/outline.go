@@ -0,0 +1,76 @@
+// Package outline provides short chapter outlines: a list of titled
+// sections with their verse ranges. A small set is bundled with the
+// binary; <config dir>/sword-tui/outlines.json can add more, or replace
+// bundled chapters, in the same format.
+package outline
+
+import (
+	_ "embed"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"sword-tui/internal/settings"
+)
+
+// Section is one titled span of a chapter.
+type Section struct {
+	Title string `json:"title"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+// outlines maps book ID → chapter → sections. JSON object keys are the
+// numbers as strings.
+type outlines map[string]map[string][]Section
+
+//go:embed outlines.json
+var bundled []byte
+
+var (
+	loadOnce sync.Once
+	all      outlines
+	loadErr  error
+)
+
+func load() {
+	all = outlines{}
+	if err := json.Unmarshal(bundled, &all); err != nil {
+		loadErr = err
+		return
+	}
+
+	dir, err := settings.Dir()
+	if err != nil {
+		return
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "outlines.json"))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			loadErr = err
+		}
+		return
+	}
+	var user outlines
+	if err := json.Unmarshal(data, &user); err != nil {
+		loadErr = err
+		return
+	}
+	for book, chapters := range user {
+		if all[book] == nil {
+			all[book] = map[string][]Section{}
+		}
+		for ch, sections := range chapters {
+			all[book][ch] = sections
+		}
+	}
+}
+
+// For returns the outline of a chapter, or nil if there is none. The error
+// reports a malformed outlines file; bundled outlines are still returned.
+func For(book, chapter int) ([]Section, error) {
+	loadOnce.Do(load)
+	return all[strconv.Itoa(book)][strconv.Itoa(chapter)], loadErr
+}
@@ -0,0 +1,330 @@
+// Package personal stores translations the user imported themselves (for
+// example a translator's working draft). They are never sent to or fetched
+// from bolls.life; the api client serves them straight from disk and they
+// show up in the translation pickers and comparison view like any other.
+package personal
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/settings"
+)
+
+// Translation is the on-disk form of an imported translation.
+type Translation struct {
+	ShortName string      `json:"short_name"`
+	FullName  string      `json:"full_name"`
+	Verses    []api.Verse `json:"verses"`
+}
+
+// Store gives read access to every imported translation. Files are loaded
+// lazily and kept in memory; drafts are small compared to full Bibles.
+type Store struct {
+	dir string
+
+	mu     sync.Mutex
+	loaded map[string]*Translation
+}
+
+func dir() (string, error) {
+	base, err := settings.Dir()
+	if err != nil {
+		return "", err
+	}
+	d := filepath.Join(base, "translations")
+	if err := os.MkdirAll(d, 0o755); err != nil {
+		return "", err
+	}
+	return d, nil
+}
+
+func NewStore() (*Store, error) {
+	d, err := dir()
+	if err != nil {
+		return nil, err
+	}
+	return &Store{dir: d, loaded: map[string]*Translation{}}, nil
+}
+
+func (s *Store) path(name string) string {
+	return filepath.Join(s.dir, name+".json")
+}
+
+// IsCached reports whether name is an imported translation. The name
+// mirrors the cache's method so the api client can treat both alike.
+func (s *Store) IsCached(name string) bool {
+	_, err := os.Stat(s.path(name))
+	return err == nil
+}
+
+func (s *Store) load(name string) (*Translation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t, ok := s.loaded[name]; ok {
+		return t, nil
+	}
+	data, err := os.ReadFile(s.path(name))
+	if err != nil {
+		return nil, err
+	}
+	var t Translation
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, err
+	}
+	s.loaded[name] = &t
+	return &t, nil
+}
+
+func (s *Store) GetChapter(name string, book, chapter int) ([]api.Verse, error) {
+	t, err := s.load(name)
+	if err != nil {
+		return nil, err
+	}
+	var verses []api.Verse
+	for _, v := range t.Verses {
+		if v.Book == book && v.Chapter == chapter {
+			verses = append(verses, v)
+		}
+	}
+	return verses, nil
+}
+
+func (s *Store) GetVerse(name string, book, chapter, verse int) (*api.Verse, error) {
+	verses, err := s.GetChapter(name, book, chapter)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range verses {
+		if v.Verse == verse {
+			return &v, nil
+		}
+	}
+	return nil, fmt.Errorf("verse not found")
+}
+
+// Translations lists the imported translations.
+func (s *Store) Translations() []api.Translation {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil
+	}
+	var out []api.Translation
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		t, err := s.load(strings.TrimSuffix(e.Name(), ".json"))
+		if err != nil {
+			continue
+		}
+		out = append(out, api.Translation{ShortName: t.ShortName, FullName: t.FullName, Local: true})
+	}
+	return out
+}
+
+// Books returns the books present in an imported translation, named after
+// the canonical list.
+func (s *Store) Books(name string) ([]api.Book, error) {
+	t, err := s.load(name)
+	if err != nil {
+		return nil, err
+	}
+	chapters := map[int]int{}
+	verses := map[int][]int{}
+	for _, v := range t.Verses {
+		if v.Chapter > chapters[v.Book] {
+			chapters[v.Book] = v.Chapter
+		}
+		chs := verses[v.Book]
+		for len(chs) < v.Chapter {
+			chs = append(chs, 0)
+		}
+		chs[v.Chapter-1] = max(chs[v.Chapter-1], v.Verse)
+		verses[v.Book] = chs
+	}
+	var books []api.Book
+	for id, n := range chapters {
+		b, ok := api.CanonicalBook(id)
+		if !ok {
+			b = api.Book{BookID: id, ChronOrder: id, Name: fmt.Sprintf("Book %d", id)}
+		}
+		b.Chapters = n
+		b.Verses = verses[id]
+		books = append(books, b)
+	}
+	sort.Slice(books, func(i, j int) bool { return books[i].BookID < books[j].BookID })
+	return books, nil
+}
+
+// Remove deletes an imported translation.
+func (s *Store) Remove(name string) error {
+	s.mu.Lock()
+	delete(s.loaded, name)
+	s.mu.Unlock()
+	return os.Remove(s.path(name))
+}
+
+// Import parses a CSV or JSON file of book/chapter/verse/text rows and
+// saves it as the translation shortName. Books may be given by number or
+// by name. It returns the number of verses imported and a description of
+// each row that was skipped.
+func Import(r io.Reader, format, shortName, fullName string) (int, []string, error) {
+	if shortName == "" {
+		return 0, nil, fmt.Errorf("a short name is required")
+	}
+	if strings.ContainsAny(shortName, `/\. `) {
+		return 0, nil, fmt.Errorf("short name %q may not contain spaces, dots or slashes", shortName)
+	}
+
+	var rows []row
+	var err error
+	switch strings.ToLower(format) {
+	case "csv":
+		rows, err = readCSV(r)
+	case "json":
+		rows, err = readJSON(r)
+	default:
+		return 0, nil, fmt.Errorf("unsupported format %q (want csv or json)", format)
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+
+	t := Translation{ShortName: shortName, FullName: fullName}
+	if t.FullName == "" {
+		t.FullName = shortName + " (personal)"
+	}
+	var skipped []string
+	for _, rw := range rows {
+		if rw.bad != "" {
+			skipped = append(skipped, fmt.Sprintf("%s: %s", rw.pos, rw.bad))
+			continue
+		}
+		book, ok := resolveBook(rw.book)
+		if !ok {
+			skipped = append(skipped, fmt.Sprintf("%s: unknown book %q", rw.pos, rw.book))
+			continue
+		}
+		if rw.chapter < 1 || rw.verse < 1 || strings.TrimSpace(rw.text) == "" {
+			skipped = append(skipped, fmt.Sprintf("%s: missing chapter, verse or text", rw.pos))
+			continue
+		}
+		t.Verses = append(t.Verses, api.Verse{
+			PK:          len(t.Verses) + 1,
+			Translation: shortName,
+			Book:        book,
+			Chapter:     rw.chapter,
+			Verse:       rw.verse,
+			Text:        strings.TrimSpace(rw.text),
+		})
+	}
+	if len(t.Verses) == 0 {
+		return 0, skipped, fmt.Errorf("no verses found")
+	}
+	sort.SliceStable(t.Verses, func(i, j int) bool {
+		a, b := t.Verses[i], t.Verses[j]
+		if a.Book != b.Book {
+			return a.Book < b.Book
+		}
+		if a.Chapter != b.Chapter {
+			return a.Chapter < b.Chapter
+		}
+		return a.Verse < b.Verse
+	})
+
+	d, err := dir()
+	if err != nil {
+		return 0, skipped, err
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		return 0, skipped, err
+	}
+	if err := os.WriteFile(filepath.Join(d, shortName+".json"), data, 0o644); err != nil {
+		return 0, skipped, err
+	}
+	return len(t.Verses), skipped, nil
+}
+
+type row struct {
+	pos            string // "line 4" of a CSV file, "item 3" of a JSON one
+	book           string
+	chapter, verse int
+	text           string
+	bad            string // why a row too short to read is skipped
+}
+
+func readCSV(r io.Reader) ([]row, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	var rows []row
+	for first := true; ; first = false {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		// A quoted field can span lines, so the record's line is asked
+		// for rather than counted.
+		line, _ := cr.FieldPos(0)
+		pos := fmt.Sprintf("line %d", line)
+		// Skip a header row such as "book,chapter,verse,text".
+		if first && strings.EqualFold(strings.TrimSpace(rec[0]), "book") {
+			continue
+		}
+		if len(rec) < 4 {
+			rows = append(rows, row{pos: pos, bad: "expected book, chapter, verse and text"})
+			continue
+		}
+		ch, _ := strconv.Atoi(strings.TrimSpace(rec[1]))
+		vs, _ := strconv.Atoi(strings.TrimSpace(rec[2]))
+		// Allow unquoted commas in the text column.
+		rows = append(rows, row{pos: pos, book: strings.TrimSpace(rec[0]), chapter: ch, verse: vs, text: strings.Join(rec[3:], ",")})
+	}
+	return rows, nil
+}
+
+func readJSON(r io.Reader) ([]row, error) {
+	var items []struct {
+		Book    json.RawMessage `json:"book"`
+		Chapter int             `json:"chapter"`
+		Verse   int             `json:"verse"`
+		Text    string          `json:"text"`
+	}
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return nil, err
+	}
+	rows := make([]row, len(items))
+	for i, it := range items {
+		book := strings.Trim(string(it.Book), `"`)
+		rows[i] = row{pos: fmt.Sprintf("item %d", i+1), book: book, chapter: it.Chapter, verse: it.Verse, text: it.Text}
+	}
+	return rows, nil
+}
+
+// resolveBook accepts a canonical book number or an exact (case-insensitive)
+// English book name.
+func resolveBook(s string) (int, bool) {
+	if id, err := strconv.Atoi(s); err == nil {
+		_, ok := api.CanonicalBook(id)
+		return id, ok
+	}
+	for _, b := range api.CanonicalBooks() {
+		if strings.EqualFold(b.Name, s) {
+			return b.BookID, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,161 @@
+package plans
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/reference"
+)
+
+// Select lists the chapters a selection names, in order. Items are
+// separated by commas and may be a book ("Romans"), a run of books
+// ("Matt-John"), chapters of a book ("Ps 1-41", "Isa 53") or a
+// testament ("OT", "NT"):
+//
+//	Gen-Deut, Ps 1-41, NT
+func Select(spec string) ([]Reading, error) {
+	books := api.CanonicalBooks()
+	var out []Reading
+	addBooks := func(from, to int) {
+		for id := from; id <= to; id++ {
+			for c := 1; c <= books[id-1].Chapters; c++ {
+				out = append(out, Reading{Book: id, Chapter: c})
+			}
+		}
+	}
+	for _, item := range strings.FieldsFunc(spec, func(r rune) bool { return r == ',' || r == ';' }) {
+		item = strings.TrimSpace(strings.NewReplacer("–", "-", "—", "-").Replace(item))
+		switch strings.ToLower(item) {
+		case "":
+			continue
+		case "ot", "old testament":
+			addBooks(1, 39)
+			continue
+		case "nt", "new testament":
+			addBooks(40, 66)
+			continue
+		case "all", "bible":
+			addBooks(1, 66)
+			continue
+		}
+		if id, _, ok := reference.MatchBook(item, books); ok {
+			addBooks(id, id)
+			continue
+		}
+		if a, b, ok := strings.Cut(item, "-"); ok {
+			from, _, okA := reference.MatchBook(a, books)
+			to, _, okB := reference.MatchBook(b, books)
+			if okA && okB {
+				if to < from {
+					return nil, fmt.Errorf("%q runs backwards", item)
+				}
+				addBooks(from, to)
+				continue
+			}
+		}
+		if m := chaptersRe.FindStringSubmatch(item); m != nil {
+			id, _, ok := reference.MatchBook(m[1], books)
+			if !ok {
+				return nil, fmt.Errorf("unknown book %q", m[1])
+			}
+			from, _ := strconv.Atoi(m[2])
+			to := from
+			if m[3] != "" {
+				to, _ = strconv.Atoi(m[3])
+			}
+			if n := books[id-1].Chapters; from < 1 || to < from || to > n {
+				return nil, fmt.Errorf("%q: %s has chapters 1-%d", item, books[id-1].Name, n)
+			}
+			for c := from; c <= to; c++ {
+				out = append(out, Reading{Book: id, Chapter: c})
+			}
+			continue
+		}
+		return nil, fmt.Errorf("unknown book %q", item)
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no books selected")
+	}
+	return out, nil
+}
+
+// chaptersRe matches a book followed by a chapter or chapter range.
+var chaptersRe = regexp.MustCompile(`(?i)^((?:[123]\s*)?[a-z][a-z. ]*?)\s*(\d+)(?:\s*-\s*(\d+))?$`)
+
+// ParseDays reads the weekdays to read on: "daily", "weekdays",
+// "weekends", or days and runs of days such as "mon-fri" or
+// "mon,wed,fri".
+func ParseDays(spec string) ([7]bool, error) {
+	var on [7]bool
+	s := strings.ToLower(strings.TrimSpace(spec))
+	switch s {
+	case "", "daily", "every day":
+		s = "sun-sat"
+	case "weekdays":
+		s = "mon-fri"
+	case "weekends":
+		s = "sat,sun"
+	}
+	for _, item := range strings.Split(s, ",") {
+		a, b, isRun := strings.Cut(strings.TrimSpace(item), "-")
+		from, err := weekday(a)
+		if err != nil {
+			return on, err
+		}
+		to := from
+		if isRun {
+			if to, err = weekday(b); err != nil {
+				return on, err
+			}
+		}
+		// A run may wrap past Saturday: "fri-mon".
+		for d := from; ; d = (d + 1) % 7 {
+			on[d] = true
+			if d == to {
+				break
+			}
+		}
+	}
+	return on, nil
+}
+
+// weekday reads a day name, or its first three letters.
+func weekday(s string) (time.Weekday, error) {
+	s = strings.TrimSpace(s)
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if len(s) >= 3 && strings.HasPrefix(name, s) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown weekday %q", s)
+}
+
+// Schedule spreads readings evenly over the reading days among the days
+// dates from start. With more days than readings, the days left without
+// a reading are rest days and dropped.
+func Schedule(readings []Reading, start time.Time, days int, on [7]bool) ([]Day, error) {
+	var dates []string
+	for i := 0; i < days; i++ {
+		if d := start.AddDate(0, 0, i); on[d.Weekday()] {
+			dates = append(dates, d.Format(DateLayout))
+		}
+	}
+	if len(dates) == 0 {
+		return nil, fmt.Errorf("no reading days in %d days from %s", days, start.Format(DateLayout))
+	}
+	var out []Day
+	n := len(readings)
+	for i, date := range dates {
+		lo, hi := i*n/len(dates), (i+1)*n/len(dates)
+		if lo == hi {
+			continue
+		}
+		out = append(out, Day{Date: date, Readings: append([]Reading(nil), readings[lo:hi]...)})
+	}
+	return out, nil
+}
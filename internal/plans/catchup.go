@@ -0,0 +1,210 @@
+package plans
+
+import (
+	"sort"
+	"time"
+)
+
+// The ways to catch up on readings missed, which rewrite the schedule
+// from today on. Readings already read stay on the days they were on.
+const (
+	// Spread deals every unread reading out again over the reading days
+	// left, keeping the end date.
+	Spread = "spread"
+	// Double adds each missed day's readings to one of the days ahead,
+	// so the next few days are heavier.
+	Double = "double"
+	// Shift moves every unread day along to the next reading days, so
+	// the plan ends later.
+	Shift = "shift"
+)
+
+// CatchUps are the ways to catch up, in the order offered.
+var CatchUps = []string{Spread, Double, Shift}
+
+// Clone returns a copy of the plan that can be changed freely.
+func (p *Plan) Clone() *Plan {
+	c := *p
+	c.Days = make([]Day, len(p.Days))
+	for i, d := range p.Days {
+		c.Days[i] = Day{Date: d.Date, Readings: append([]Reading(nil), d.Readings...)}
+	}
+	return &c
+}
+
+// MostInADay returns the most readings any day from now's date on has.
+func (p *Plan) MostInADay(now time.Time) int {
+	most := 0
+	for _, d := range p.Days[p.Today(now):] {
+		most = max(most, len(d.Readings))
+	}
+	return most
+}
+
+// CatchUp rewrites the schedule from now's date on the way named, one of
+// CatchUps, and reports whether it knew how.
+func (p *Plan) CatchUp(how string, now time.Time) bool {
+	switch how {
+	case Spread:
+		p.spread(now)
+	case Double:
+		p.double(now)
+	case Shift:
+		p.shift(now)
+	default:
+		return false
+	}
+	return true
+}
+
+// weekdays are the days the plan is read on; all of them if ReadOn no
+// longer parses.
+func (p *Plan) weekdays() [7]bool {
+	on, err := ParseDays(p.ReadOn)
+	if err != nil {
+		on, _ = ParseDays("daily")
+	}
+	return on
+}
+
+// readingDates returns the first n reading days from start on.
+func (p *Plan) readingDates(start time.Time, n int) []string {
+	on := p.weekdays()
+	var out []string
+	for d := start; len(out) < n; d = d.AddDate(0, 0, 1) {
+		if on[d.Weekday()] {
+			out = append(out, d.Format(DateLayout))
+		}
+	}
+	return out
+}
+
+// takeUnread removes the unread readings from the plan and returns them
+// grouped by the day they were on, leaving only days with something
+// read.
+func (p *Plan) takeUnread() [][]Reading {
+	var groups [][]Reading
+	var kept []Day
+	for _, d := range p.Days {
+		var read, unread []Reading
+		for _, r := range d.Readings {
+			if r.Read {
+				read = append(read, r)
+			} else {
+				unread = append(unread, r)
+			}
+		}
+		if len(unread) > 0 {
+			groups = append(groups, unread)
+		}
+		if len(read) > 0 {
+			kept = append(kept, Day{Date: d.Date, Readings: read})
+		}
+	}
+	p.Days = kept
+	return groups
+}
+
+// put adds readings to the plan on date, ahead of what the day has if
+// first is set.
+func (p *Plan) put(date string, readings []Reading, first bool) {
+	i := sort.Search(len(p.Days), func(i int) bool { return p.Days[i].Date >= date })
+	if i < len(p.Days) && p.Days[i].Date == date {
+		if first {
+			p.Days[i].Readings = append(readings, p.Days[i].Readings...)
+		} else {
+			p.Days[i].Readings = append(p.Days[i].Readings, readings...)
+		}
+		return
+	}
+	p.Days = append(p.Days, Day{})
+	copy(p.Days[i+1:], p.Days[i:])
+	p.Days[i] = Day{Date: date, Readings: readings}
+}
+
+func (p *Plan) spread(now time.Time) {
+	end, err := time.ParseInLocation(DateLayout, p.End(), time.Local)
+	var unread []Reading
+	for _, g := range p.takeUnread() {
+		unread = append(unread, g...)
+	}
+	if len(unread) == 0 {
+		return
+	}
+	start := dateOf(now)
+	days := 1
+	if err == nil && end.After(start) {
+		days = int(end.Sub(start).Hours()/24+0.5) + 1
+	}
+	schedule, err := Schedule(unread, start, days, p.weekdays())
+	if err != nil {
+		// No reading day left before the end: read it all on the next.
+		schedule = []Day{{Date: p.readingDates(start, 1)[0], Readings: unread}}
+	}
+	for _, d := range schedule {
+		p.put(d.Date, d.Readings, false)
+	}
+}
+
+func (p *Plan) double(now time.Time) {
+	today := now.Format(DateLayout)
+	var missed [][]Reading
+	for i := range p.Days {
+		d := &p.Days[i]
+		if d.Date >= today {
+			break
+		}
+		var read, unread []Reading
+		for _, r := range d.Readings {
+			if r.Read {
+				read = append(read, r)
+			} else {
+				unread = append(unread, r)
+			}
+		}
+		if len(unread) > 0 {
+			missed = append(missed, unread)
+			d.Readings = read
+		}
+	}
+	var kept []Day
+	for _, d := range p.Days {
+		if len(d.Readings) > 0 {
+			kept = append(kept, d)
+		}
+	}
+	p.Days = kept
+
+	// The days ahead that still have reading to do take one missed day
+	// each; any missed days beyond them go on new reading days after.
+	var ahead []string
+	for _, d := range p.Days {
+		if d.Date >= today && !d.Done() {
+			ahead = append(ahead, d.Date)
+		}
+	}
+	if len(missed) > len(ahead) {
+		from := dateOf(now)
+		if len(ahead) > 0 {
+			last, _ := time.ParseInLocation(DateLayout, ahead[len(ahead)-1], time.Local)
+			from = last.AddDate(0, 0, 1)
+		}
+		ahead = append(ahead, p.readingDates(from, len(missed)-len(ahead))...)
+	}
+	for i, g := range missed {
+		p.put(ahead[i], g, true)
+	}
+}
+
+func (p *Plan) shift(now time.Time) {
+	groups := p.takeUnread()
+	for i, date := range p.readingDates(dateOf(now), len(groups)) {
+		p.put(date, groups[i], false)
+	}
+}
+
+// dateOf returns local midnight on t's date.
+func dateOf(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+}
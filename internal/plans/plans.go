@@ -0,0 +1,256 @@
+// Package plans keeps reading plans: a schedule of chapters over dates,
+// built from a choice of books (see Select and Schedule), with each
+// chapter ticked off as it is read. Each reader (see settings.ReaderDir)
+// has their own plans, saved one file each in a plans directory under
+// the annotations, so sync carries progress between machines.
+package plans
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/settings"
+)
+
+// DateLayout is how Day.Date is written.
+const DateLayout = "2006-01-02"
+
+// Reading is one chapter of a plan.
+type Reading struct {
+	Book    int  `json:"book"`
+	Chapter int  `json:"chapter"`
+	Read    bool `json:"read,omitempty"`
+}
+
+// Reference renders the reading as "John 3".
+func (r Reading) Reference() string {
+	name := fmt.Sprint(r.Book)
+	if b, ok := api.CanonicalBook(r.Book); ok {
+		name = b.Name
+	}
+	return fmt.Sprintf("%s %d", name, r.Chapter)
+}
+
+// Day is what a plan has for one date.
+type Day struct {
+	Date     string    `json:"date"` // local date, YYYY-MM-DD
+	Readings []Reading `json:"readings"`
+}
+
+// Done reports whether every reading of the day has been read.
+func (d Day) Done() bool {
+	for _, r := range d.Readings {
+		if !r.Read {
+			return false
+		}
+	}
+	return true
+}
+
+// Summary renders the day's readings, joining runs of chapters of one
+// book: "Genesis 1–3, Matthew 1".
+func (d Day) Summary() string {
+	var parts []string
+	for i := 0; i < len(d.Readings); {
+		j := i + 1
+		for j < len(d.Readings) && d.Readings[j].Book == d.Readings[i].Book &&
+			d.Readings[j].Chapter == d.Readings[j-1].Chapter+1 {
+			j++
+		}
+		part := d.Readings[i].Reference()
+		if j-i > 1 {
+			part += fmt.Sprintf("–%d", d.Readings[j-1].Chapter)
+		}
+		parts = append(parts, part)
+		i = j
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Plan is a named schedule of readings, oldest day first.
+type Plan struct {
+	Name      string    `json:"name"`
+	Selection string    `json:"selection"`        // the books it was built from, e.g. "Matt-John, Ps"
+	ReadOn    string    `json:"read_on"`          // reading days, e.g. "mon-fri"
+	Remind    string    `json:"remind,omitempty"` // time of day to be reminded, HH:MM
+	Created   time.Time `json:"created"`
+	Days      []Day     `json:"days"`
+
+	Reader string `json:"-"` // whose plan it is; "" is the default reader
+}
+
+// Progress counts the readings read and in all.
+func (p *Plan) Progress() (read, total int) {
+	for _, d := range p.Days {
+		for _, r := range d.Readings {
+			total++
+			if r.Read {
+				read++
+			}
+		}
+	}
+	return read, total
+}
+
+// Behind counts the readings scheduled before now's date still unread.
+func (p *Plan) Behind(now time.Time) int {
+	today := now.Format(DateLayout)
+	n := 0
+	for _, d := range p.Days {
+		if d.Date >= today {
+			break
+		}
+		for _, r := range d.Readings {
+			if !r.Read {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// Today returns the index of the day for now's date, or of the next
+// reading day when now has none; len(p.Days) once the plan is over.
+func (p *Plan) Today(now time.Time) int {
+	today := now.Format(DateLayout)
+	return sort.Search(len(p.Days), func(i int) bool { return p.Days[i].Date >= today })
+}
+
+// End returns the date of the plan's last day.
+func (p *Plan) End() string {
+	if len(p.Days) == 0 {
+		return ""
+	}
+	return p.Days[len(p.Days)-1].Date
+}
+
+// MarkRead ticks off the earliest unread reading of a chapter, reporting
+// whether the plan has it.
+func (p *Plan) MarkRead(book, chapter int) bool {
+	for i := range p.Days {
+		for j := range p.Days[i].Readings {
+			if r := &p.Days[i].Readings[j]; r.Book == book && r.Chapter == chapter && !r.Read {
+				r.Read = true
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SetDay marks every reading of day i read or unread.
+func (p *Plan) SetDay(i int, read bool) {
+	for j := range p.Days[i].Readings {
+		p.Days[i].Readings[j].Read = read
+	}
+}
+
+// validName keeps plan names usable as file names.
+var validName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// CheckName returns an error if name can't name a plan.
+func CheckName(name string) error {
+	if !validName.MatchString(name) {
+		return fmt.Errorf("plan name %q should be letters, digits, - and _", name)
+	}
+	return nil
+}
+
+// Dir returns the directory a reader's plans are saved in.
+func Dir(reader string) (string, error) {
+	base, err := settings.ReaderDir(reader)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "plans"), nil
+}
+
+func path(reader, name string) (string, error) {
+	dir, err := Dir(reader)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+".json"), nil
+}
+
+// Load reads a reader's plan called name.
+func Load(reader, name string) (*Plan, error) {
+	p, err := path(reader, name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+	plan := &Plan{}
+	if err := json.Unmarshal(data, plan); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(p), err)
+	}
+	plan.Name = name
+	plan.Reader = reader
+	return plan, nil
+}
+
+// List reads every plan of a reader, by name. No plans directory is no
+// plans.
+func List(reader string) ([]*Plan, error) {
+	dir, err := Dir(reader)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var out []*Plan
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok || e.IsDir() {
+			continue
+		}
+		p, err := Load(reader, name)
+		if err != nil {
+			return out, err
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+func (p *Plan) Save() error {
+	if err := CheckName(p.Name); err != nil {
+		return err
+	}
+	dir, err := Dir(p.Reader)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, p.Name+".json"), data, 0o644)
+}
+
+// Remove deletes a reader's plan called name.
+func Remove(reader, name string) error {
+	p, err := path(reader, name)
+	if err != nil {
+		return err
+	}
+	return os.Remove(p)
+}
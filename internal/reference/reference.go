@@ -0,0 +1,248 @@
+// Package reference parses human-written verse references such as
+// "Jn 3:16-18" against a list of books.
+package reference
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"sword-tui/internal/api"
+)
+
+// MatchBook attempts to match a book name or abbreviation to a book ID
+func MatchBook(query string, books []api.Book) (int, string, bool) {
+	query = strings.ToLower(strings.TrimSpace(query))
+
+	// Book name abbreviations mapping
+	bookAbbrevs := map[string][]string{
+		"genesis":         {"gen", "ge", "gn"},
+		"exodus":          {"exo", "ex", "exod"},
+		"leviticus":       {"lev", "le", "lv"},
+		"numbers":         {"num", "nu", "nm", "nb"},
+		"deuteronomy":     {"deut", "de", "dt"},
+		"joshua":          {"josh", "jos", "jsh"},
+		"judges":          {"judg", "jdg", "jg", "jdgs"},
+		"ruth":            {"rut", "ru", "rth"},
+		"1 samuel":        {"1sam", "1sa", "1samuel", "1 sam", "1 sa", "1s"},
+		"2 samuel":        {"2sam", "2sa", "2samuel", "2 sam", "2 sa", "2s"},
+		"1 kings":         {"1king", "1kgs", "1ki", "1k", "1 kings", "1 kgs"},
+		"2 kings":         {"2king", "2kgs", "2ki", "2k", "2 kings", "2 kgs"},
+		"1 chronicles":    {"1chron", "1chr", "1ch", "1 chronicles", "1 chr"},
+		"2 chronicles":    {"2chron", "2chr", "2ch", "2 chronicles", "2 chr"},
+		"ezra":            {"ezr", "ez"},
+		"nehemiah":        {"neh", "ne"},
+		"esther":          {"est", "es"},
+		"job":             {"jb"},
+		"psalms":          {"psalm", "psa", "ps", "pss"},
+		"proverbs":        {"prov", "pro", "pr", "prv"},
+		"ecclesiastes":    {"eccl", "ecc", "ec", "qoh"},
+		"song of solomon": {"song", "sos", "so", "canticle", "canticles", "song of songs"},
+		"isaiah":          {"isa", "is"},
+		"jeremiah":        {"jer", "je", "jr"},
+		"lamentations":    {"lam", "la"},
+		"ezekiel":         {"ezek", "eze", "ezk"},
+		"daniel":          {"dan", "da", "dn"},
+		"hosea":           {"hos", "ho"},
+		"joel":            {"joe", "jl"},
+		"amos":            {"amo", "am"},
+		"obadiah":         {"obad", "ob"},
+		"jonah":           {"jon", "jnh"},
+		"micah":           {"mic", "mi"},
+		"nahum":           {"nah", "na"},
+		"habakkuk":        {"hab", "hb"},
+		"zephaniah":       {"zeph", "zep", "zp"},
+		"haggai":          {"hag", "hg"},
+		"zechariah":       {"zech", "zec", "zc"},
+		"malachi":         {"mal", "ml"},
+		"matthew":         {"matt", "mat", "mt"},
+		"mark":            {"mar", "mrk", "mk", "mr"},
+		"luke":            {"luk", "lk"},
+		"john":            {"joh", "jhn", "jn"},
+		"acts":            {"act", "ac"},
+		"romans":          {"rom", "ro", "rm"},
+		"1 corinthians":   {"1cor", "1co", "1 corinthians", "1 cor"},
+		"2 corinthians":   {"2cor", "2co", "2 corinthians", "2 cor"},
+		"galatians":       {"gal", "ga"},
+		"ephesians":       {"eph", "ephes"},
+		"philippians":     {"phil", "php", "pp"},
+		"colossians":      {"col", "co"},
+		"1 thessalonians": {"1thess", "1th", "1 thessalonians", "1 thess"},
+		"2 thessalonians": {"2thess", "2th", "2 thessalonians", "2 thess"},
+		"1 timothy":       {"1tim", "1ti", "1 timothy", "1 tim"},
+		"2 timothy":       {"2tim", "2ti", "2 timothy", "2 tim"},
+		"titus":           {"tit", "ti"},
+		"philemon":        {"philem", "phm", "pm"},
+		"hebrews":         {"heb", "he"},
+		"james":           {"jam", "jas", "jm"},
+		"1 peter":         {"1pet", "1pe", "1pt", "1p", "1 peter", "1 pet"},
+		"2 peter":         {"2pet", "2pe", "2pt", "2p", "2 peter", "2 pet"},
+		"1 john":          {"1john", "1jn", "1jo", "1j", "1 john"},
+		"2 john":          {"2john", "2jn", "2jo", "2j", "2 john"},
+		"3 john":          {"3john", "3jn", "3jo", "3j", "3 john"},
+		"jude":            {"jud", "jd"},
+		"revelation":      {"rev", "re", "rv"},
+	}
+
+	// Try exact match first
+	for _, book := range books {
+		if strings.ToLower(book.Name) == query {
+			return book.BookID, book.Name, true
+		}
+	}
+
+	// Try abbreviation match
+	for _, book := range books {
+		bookNameLower := strings.ToLower(book.Name)
+		if abbrevs, ok := bookAbbrevs[bookNameLower]; ok {
+			for _, abbrev := range abbrevs {
+				if query == abbrev {
+					return book.BookID, book.Name, true
+				}
+			}
+		}
+	}
+
+	// Try prefix match
+	for _, book := range books {
+		if strings.HasPrefix(strings.ToLower(book.Name), query) {
+			return book.BookID, book.Name, true
+		}
+	}
+
+	return 0, "", false
+}
+
+// Parse accepts a wide variety of reference formats:
+//   - "John 3:16"       canonical
+//   - "john3:16"        no spaces
+//   - "john 3 16"       spaces instead of colon
+//   - "john 3:16-17"    range
+//   - "john 3 16-17"    range with spaces
+//   - "1 John 3:16"     book name starting with a digit
+//   - "1john3:16"       no spaces, book starts with digit
+//   - "1 1:1"           book by numeric id + chapter:verse
+//   - "gen 1"           book + chapter only
+//   - "gen"             book only (defaults to chapter 1)
+//
+// The split into book and the rest happens at the boundary between the
+// (optional digit-prefixed) word that names the book and the numeric
+// chapter/verse data that follows. Then any digit-runs in the rest are
+// pulled out in order as chapter / verse-start / verse-end, with a
+// hyphen interpreted as the range separator.
+func Parse(ref string, books []api.Book) (book, chapter, verseStart, verseEnd int, err error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return 0, 0, 0, 0, fmt.Errorf("empty reference")
+	}
+
+	// Book identifier alternatives, in order of specificity. Each
+	// letter run may be followed by ` letter-run` repeats so multi-word
+	// book names like "Song of Solomon" or "1 Samuel" stay intact.
+	//   1. digit + optional whitespace + letters (+ more words)  →  "1 John", "1john", "1 Samuel"
+	//   2. letters (+ more words)                                 →  "John", "rom", "Song of Solomon"
+	//   3. digit                                                  →  "1" (book id)
+	bookRe := regexp.MustCompile(`(?i)^(\d+\s*[a-z]+(?:\s+[a-z]+)*|[a-z]+(?:\s+[a-z]+)*|\d+)\s*(.*)$`)
+	m := bookRe.FindStringSubmatch(ref)
+	if m == nil {
+		return 0, 0, 0, 0, fmt.Errorf("invalid reference: %s", ref)
+	}
+	bookPart := strings.TrimSpace(m[1])
+	rest := m[2]
+
+	// Resolve the book identifier.
+	if id, perr := strconv.Atoi(bookPart); perr == nil {
+		book = id
+	} else if len(books) > 0 {
+		id, _, found := MatchBook(bookPart, books)
+		if !found {
+			return 0, 0, 0, 0, fmt.Errorf("book not found: %s", bookPart)
+		}
+		book = id
+	} else {
+		return 0, 0, 0, 0, fmt.Errorf("no books loaded")
+	}
+
+	// Default chapter when none was supplied.
+	chapter = 1
+
+	if rest == "" {
+		return book, chapter, 0, 0, nil
+	}
+
+	// Split into before/after a hyphen so verse-range parsing is robust
+	// even with arbitrary separators around it ("3:16-17", "3 16 - 17",
+	// "3 16-17").
+	beforeDash, afterDash := rest, ""
+	if i := strings.IndexByte(rest, '-'); i >= 0 {
+		beforeDash = rest[:i]
+		afterDash = rest[i+1:]
+	}
+
+	numRe := regexp.MustCompile(`\d+`)
+	beforeNums := numRe.FindAllString(beforeDash, -1)
+	afterNums := numRe.FindAllString(afterDash, -1)
+
+	if len(beforeNums) >= 1 {
+		chapter, _ = strconv.Atoi(beforeNums[0])
+	}
+	if len(beforeNums) >= 2 {
+		verseStart, _ = strconv.Atoi(beforeNums[1])
+		verseEnd = verseStart
+	}
+	if len(afterNums) >= 1 {
+		verseEnd, _ = strconv.Atoi(afterNums[0])
+		if verseStart == 0 && len(beforeNums) >= 2 {
+			verseStart, _ = strconv.Atoi(beforeNums[1])
+		}
+	}
+
+	return book, chapter, verseStart, verseEnd, nil
+}
+
+// inTextRe picks out reference-like runs inside free text: a book word
+// (optionally digit-prefixed, abbreviated with a dot, or "Song of …")
+// followed by chapter:verse and an optional verse range.
+var inTextRe = regexp.MustCompile(`(?i)\b((?:[123]\s?)?[a-z]{2,}\.?(?:\s+of\s+[a-z]+)?)\s*(\d{1,3}:\d{1,3}(?:\s*[-–—]\s*\d{1,3})?)`)
+
+// Link is a reference found in free text by FindAll.
+type Link struct {
+	Start, End int    // byte offsets of the reference in the text
+	Ref        string // normalized so Parse accepts it, e.g. "Rom 8:28-30"
+}
+
+// FindAll returns every chapter:verse reference in text naming a book
+// in books, in order.
+func FindAll(text string, books []api.Book) []Link {
+	var links []Link
+	for _, m := range inTextRe.FindAllStringSubmatchIndex(text, -1) {
+		name := strings.TrimSuffix(text[m[2]:m[3]], ".")
+		if _, _, ok := MatchBook(name, books); !ok {
+			continue
+		}
+		verses := strings.NewReplacer(" ", "", "\t", "", "–", "-", "—", "-").Replace(text[m[4]:m[5]])
+		links = append(links, Link{Start: m[0], End: m[1], Ref: name + " " + verses})
+	}
+	return links
+}
+
+// Find returns the first chapter:verse reference in text, normalized so
+// Parse accepts it. Failing that, a short text that is nothing but a
+// reference may omit the verse ("rom 8"); elsewhere stray words and
+// numbers aren't mistaken for books.
+func Find(text string, books []api.Book) (string, bool) {
+	text = strings.TrimSpace(strings.NewReplacer("–", "-", "—", "-").Replace(text))
+	if text == "" {
+		return "", false
+	}
+	if links := FindAll(text, books); len(links) > 0 {
+		return links[0].Ref, true
+	}
+	if !strings.ContainsAny(text, "\n") && len(text) <= 40 && strings.ContainsAny(text, "0123456789") {
+		if _, _, _, _, err := Parse(text, books); err == nil {
+			return text, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,188 @@
+// Package remote implements the editor plugin protocol: a line-based
+// request/response protocol spoken over a Unix socket by a running
+// sword-tui (see docs/editor-protocol.md).
+package remote
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Backend answers protocol requests.
+type Backend interface {
+	// Goto makes the reader jump to ref.
+	Goto(ref string) error
+	// Get returns one "reference<TAB>text" line per verse of ref.
+	Get(ref string) ([]string, error)
+	// Search returns one "reference<TAB>text" line per matching verse.
+	Search(query string) ([]string, error)
+	// Play tells the reader that audio of the chapter ref has reached
+	// pos, so it can highlight the verse being spoken.
+	Play(ref string, pos time.Duration) error
+}
+
+// DefaultSocket is where sword-tui listens unless told otherwise:
+// $XDG_RUNTIME_DIR/sword-tui.sock, or a per-user name in the temp dir.
+// Windows has no user ids, but its temp dir is the user's own.
+func DefaultSocket() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "sword-tui.sock")
+	}
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.TempDir(), "sword-tui.sock")
+	}
+	return filepath.Join(os.TempDir(), fmt.Sprintf("sword-tui-%d.sock", os.Getuid()))
+}
+
+// Listen opens the socket at path. A stale socket left behind by a crashed
+// instance is replaced; one that still answers is an error.
+func Listen(path string) (net.Listener, error) {
+	if conn, err := net.Dial("unix", path); err == nil {
+		conn.Close()
+		return nil, fmt.Errorf("%s is in use by another sword-tui", path)
+	}
+	os.Remove(path)
+	return net.Listen("unix", path)
+}
+
+// Serve answers connections on l until it is closed. Each connection may
+// send any number of requests.
+func Serve(l net.Listener, b Backend) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go serveConn(conn, b)
+	}
+}
+
+func serveConn(conn net.Conn, b Backend) {
+	defer conn.Close()
+	sc := bufio.NewScanner(conn)
+	w := bufio.NewWriter(conn)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		arg = strings.TrimSpace(arg)
+
+		var lines []string
+		var err error
+		switch {
+		case arg == "":
+			err = fmt.Errorf("%s needs an argument", strings.ToUpper(verb))
+		case strings.EqualFold(verb, "GOTO"):
+			err = b.Goto(arg)
+		case strings.EqualFold(verb, "GET"):
+			lines, err = b.Get(arg)
+		case strings.EqualFold(verb, "SEARCH"):
+			lines, err = b.Search(arg)
+		case strings.EqualFold(verb, "PLAY"):
+			err = play(b, arg)
+		default:
+			err = fmt.Errorf("unknown command %q", verb)
+		}
+		writeReply(w, lines, err)
+		if w.Flush() != nil {
+			return
+		}
+	}
+}
+
+// play answers PLAY <reference> <seconds>.
+func play(b Backend, arg string) error {
+	bad := errors.New("PLAY needs a chapter and a position in seconds")
+	i := strings.LastIndex(arg, " ")
+	if i < 0 {
+		return bad
+	}
+	at, err := strconv.ParseFloat(arg[i+1:], 64)
+	if err != nil || at < 0 {
+		return bad
+	}
+	return b.Play(strings.TrimSpace(arg[:i]), time.Duration(at*float64(time.Second)))
+}
+
+func writeReply(w *bufio.Writer, lines []string, err error) {
+	if err != nil {
+		fmt.Fprintf(w, "ERR %s\n", oneLine(err.Error()))
+		return
+	}
+	fmt.Fprintf(w, "OK %d\n", len(lines))
+	for _, l := range lines {
+		w.WriteString(oneLine(l) + "\n")
+	}
+}
+
+// oneLine keeps a reply line from breaking the framing.
+func oneLine(s string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+}
+
+// Client is a connection to a running sword-tui.
+type Client struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// Dial connects to the socket at path.
+func Dial(path string) (*Client, error) {
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn, r: bufio.NewReader(conn)}, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Do sends one request and returns the reply lines. An ERR reply is
+// returned as an error.
+func (c *Client) Do(verb, arg string) ([]string, error) {
+	if _, err := fmt.Fprintf(c.conn, "%s %s\n", verb, oneLine(arg)); err != nil {
+		return nil, err
+	}
+	status, err := c.readLine()
+	if err != nil {
+		return nil, err
+	}
+	if msg, ok := strings.CutPrefix(status, "ERR "); ok {
+		return nil, errors.New(msg)
+	}
+	count, ok := strings.CutPrefix(status, "OK ")
+	n, err := strconv.Atoi(count)
+	if !ok || err != nil {
+		return nil, fmt.Errorf("malformed reply %q", status)
+	}
+	lines := make([]string, n)
+	for i := range lines {
+		if lines[i], err = c.readLine(); err != nil {
+			return nil, err
+		}
+	}
+	return lines, nil
+}
+
+func (c *Client) readLine() (string, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(line, "\n"), nil
+}
@@ -0,0 +1,153 @@
+// Package report summarizes a week of reading from local data: chapters
+// read and time spent (from the visit log), and the notes, bookmarks and
+// highlights made.
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/bookmarks"
+	"sword-tui/internal/highlights"
+	"sword-tui/internal/notes"
+	"sword-tui/internal/visits"
+)
+
+// Week is the summary of one Monday-to-Sunday week.
+type Week struct {
+	Start time.Time // Monday 00:00, local time
+	Days  []visits.Day
+	// Chapters read during the week, as "Book C", in first-read order.
+	Chapters   []string
+	Reading    time.Duration
+	Notes      []notes.Note
+	Bookmarks  int
+	Highlights int
+}
+
+// End is the Monday after the week.
+func (w Week) End() time.Time {
+	return w.Start.AddDate(0, 0, 7)
+}
+
+// StartOfWeek returns the Monday at or before t, at midnight.
+func StartOfWeek(t time.Time) time.Time {
+	y, mo, d := t.Date()
+	day := time.Date(y, mo, d, 0, 0, 0, 0, t.Location())
+	back := (int(day.Weekday()) + 6) % 7 // days since Monday
+	return day.AddDate(0, 0, -back)
+}
+
+func chapterName(book, chapter int) string {
+	if b, ok := api.CanonicalBook(book); ok {
+		return fmt.Sprintf("%s %d", b.Name, chapter)
+	}
+	return fmt.Sprintf("Book %d %d", book, chapter)
+}
+
+// ForWeek gathers the week containing t. Chapters and reading time are
+// the reader's; notes, bookmarks and highlights are everyone's.
+func ForWeek(reader string, t time.Time) (Week, error) {
+	w := Week{Start: StartOfWeek(t)}
+	end := w.End()
+	in := func(at time.Time) bool { return !at.Before(w.Start) && at.Before(end) }
+
+	log, err := visits.Load(reader)
+	if err != nil {
+		return w, err
+	}
+	w.Days = log.Between(w.Start, end)
+	seen := map[[2]int]bool{}
+	for _, d := range w.Days {
+		w.Reading += time.Duration(d.Seconds) * time.Second
+		for _, c := range d.Chapters {
+			if !seen[c] {
+				seen[c] = true
+				w.Chapters = append(w.Chapters, chapterName(c[0], c[1]))
+			}
+		}
+	}
+
+	all, err := notes.All()
+	if err != nil {
+		return w, err
+	}
+	for _, n := range all {
+		if in(n.Time) {
+			w.Notes = append(w.Notes, n)
+		}
+	}
+	marks, err := bookmarks.Load()
+	if err != nil {
+		return w, err
+	}
+	for _, b := range marks.Bookmarks {
+		if in(b.Added) {
+			w.Bookmarks++
+		}
+	}
+	hls, err := highlights.Load()
+	if err != nil {
+		return w, err
+	}
+	for _, h := range hls.Highlights {
+		if in(h.Added) {
+			w.Highlights++
+		}
+	}
+	return w, nil
+}
+
+// Duration renders a reading time as "1h 25m" or "12m".
+func Duration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if h := int(d.Hours()); h > 0 {
+		return fmt.Sprintf("%dh %dm", h, int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
+
+// Markdown renders the week as a Markdown document.
+func (w Week) Markdown() string {
+	var b strings.Builder
+	last := w.End().AddDate(0, 0, -1)
+	fmt.Fprintf(&b, "# Reading week of %s – %s\n\n", w.Start.Format("2 Jan"), last.Format("2 Jan 2006"))
+
+	active := 0
+	for _, d := range w.Days {
+		if len(d.Chapters) > 0 || d.Seconds > 0 {
+			active++
+		}
+	}
+	fmt.Fprintf(&b, "- Chapters read: %d\n", len(w.Chapters))
+	fmt.Fprintf(&b, "- Days with reading: %d of 7\n", active)
+	fmt.Fprintf(&b, "- Time spent: %s\n", Duration(w.Reading))
+	fmt.Fprintf(&b, "- Notes: %d · Bookmarks: %d · Highlights: %d\n", len(w.Notes), w.Bookmarks, w.Highlights)
+
+	if len(w.Days) > 0 {
+		b.WriteString("\n## By day\n\n")
+		for _, d := range w.Days {
+			date, _ := time.ParseInLocation("2006-01-02", d.Date, time.Local)
+			var chs []string
+			for _, c := range d.Chapters {
+				chs = append(chs, chapterName(c[0], c[1]))
+			}
+			line := fmt.Sprintf("- **%s** (%s)", date.Format("Mon 2 Jan"), Duration(time.Duration(d.Seconds)*time.Second))
+			if len(chs) > 0 {
+				line += ": " + strings.Join(chs, ", ")
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+
+	if len(w.Notes) > 0 {
+		b.WriteString("\n## Notes\n\n")
+		for _, n := range w.Notes {
+			ref := fmt.Sprintf("%s:%d", chapterName(n.Book, n.Chapter), n.Verse)
+			fmt.Fprintf(&b, "- **%s** — %s\n", ref, strings.ReplaceAll(n.Text, "\n", "\n  "))
+		}
+	}
+	return b.String()
+}
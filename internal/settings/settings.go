@@ -1,9 +1,13 @@
 package settings
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 )
 
 type Settings struct {
@@ -11,9 +15,176 @@ type Settings struct {
 	CurrentBook         int    `json:"current_book"`
 	CurrentChapter      int    `json:"current_chapter"`
 	CurrentTheme        string `json:"current_theme"` // theme display name
+	// Language is the language of the reader's own text: "en" (default)
+	// or "es".
+	Language string `json:"language,omitempty"`
+	// Density is "comfortable" (default) or "compact", which drops the
+	// blank line between verses and tightens picker padding.
+	Density string `json:"density,omitempty"`
+	// PlainText turns off bold, italic and underline everywhere so the
+	// UI relies on color alone, for terminals that render them poorly.
+	PlainText bool `json:"plain_text,omitempty"`
+	// ExitSummary prints where reading stopped, today's plan readings
+	// and the reading streak to the terminal after quitting.
+	ExitSummary bool `json:"exit_summary,omitempty"`
+	// NoMouse leaves the mouse to the terminal, so text can be selected
+	// with it as usual; M turns the reader's mouse support back on.
+	NoMouse bool `json:"no_mouse,omitempty"`
+	// Typewriter keeps the highlighted verse vertically centered in the
+	// reader instead of scrolling it to the top.
+	Typewriter bool `json:"typewriter_scroll,omitempty"`
+	// VerseNumbers is the verse-number style: "plain" (default),
+	// "superscript", "brackets", "dimmed" or "hidden". Copying and
+	// exporting follow it too.
+	VerseNumbers string `json:"verse_numbers,omitempty"`
+	// HideOutlines turns off the chapter outline shown above the first
+	// verse and the letters heading the stanzas of acrostic psalms;
+	// OutlineExpanded lists the outline's sections instead of a summary
+	// line.
+	HideOutlines    bool `json:"hide_outlines,omitempty"`
+	OutlineExpanded bool `json:"outline_expanded,omitempty"`
+	// HighlightStyle is how highlighted verses (H) show in the reader:
+	// "background" (default) tints the verse in its color as well as
+	// marking the gutter, "bar" only marks the gutter.
+	HighlightStyle string `json:"highlight_style,omitempty"`
+	// HighlightLabels name what each highlight color stands for, by
+	// color: {"yellow": "promises", "green": "commands"}.
+	HighlightLabels map[string]string `json:"highlight_labels,omitempty"`
+	// NightLight warms theme colors: "always", or a daily window such as
+	// "21:00-06:00". Empty disables it. NightLightStrength runs from 0 to
+	// 1 and defaults to 0.5.
+	NightLight         string  `json:"night_light,omitempty"`
+	NightLightStrength float64 `json:"night_light_strength,omitempty"`
+	// Colors is how many colors the terminal shows: "truecolor", "256"
+	// or "16". Empty detects it. Themes use palettes of their own below
+	// truecolor.
+	Colors string `json:"colors,omitempty"`
+	// PersistMarks keeps jump marks (m and ') across sessions.
+	PersistMarks bool `json:"persist_marks,omitempty"`
+	// QuietHours silences status-bar confirmations (copied, pinned, …)
+	// during a daily window such as "22:00-07:00", or "always". Errors
+	// still show.
+	QuietHours string `json:"quiet_hours,omitempty"`
+	// Reminders is what the reader does at a reading plan's reminder
+	// time: "bell" (default) says so in the status bar and rings the
+	// terminal bell, "flash" only says so, "off" does neither.
+	Reminders string `json:"reminders,omitempty"`
+	// TranslationNames show translations under names of your own, by
+	// short name: {"NASB": "NASB 2020"}. TranslationAliases are
+	// abbreviations for them, alias to short name: {"n": "NASB"}, for
+	// the picker filter and references typed with /.
+	TranslationNames   map[string]string `json:"translation_names,omitempty"`
+	TranslationAliases map[string]string `json:"translation_aliases,omitempty"`
+	// HiddenTranslations are left out of the translation and download
+	// pickers (h there hides one, a shows them all).
+	HiddenTranslations []string `json:"hidden_translations,omitempty"`
+	// Languages are the languages, as the provider names them, whose
+	// translations the pickers list, in that order; empty means English.
+	// l in the translation picker chooses them.
+	Languages []string `json:"languages,omitempty"`
+	// BookOrder arranges the books in the sidebar and Miller columns and
+	// decides where n and p go past a book's last or first chapter:
+	// "canonical" (the default), "nt-first", "tanakh", "chronological",
+	// or books by name, "Mark, John, Genesis", the rest following in
+	// canonical order.
+	BookOrder string `json:"book_order,omitempty"`
+	// CollapsedTestaments are the sidebar's folded section headers,
+	// "old", "new" and "favorites" (Enter or space on a header folds it).
+	CollapsedTestaments []string `json:"collapsed_testaments,omitempty"`
+	// FavoriteBooks are the books starred with *, by id, listed first in
+	// the sidebar and Miller columns.
+	FavoriteBooks []int `json:"favorite_books,omitempty"`
+	// ShareFormat is how y and Y copy verses: "" for the reference
+	// followed by numbered verses, "paragraph" for the verses without
+	// numbers joined into one paragraph, or "thread" for posts of at most
+	// ShareLimit characters (default 280), marked (1/3), (2/3), … when it
+	// takes more than one.
+	ShareFormat string `json:"share_format,omitempty"`
+	ShareLimit  int    `json:"share_limit,omitempty"`
+	// ExportLayout is how X sets out the verses it writes to a file:
+	// "verses" (default), each on its own line, or "paragraph", run
+	// together into one paragraph per translation.
+	ExportLayout string `json:"export_layout,omitempty"`
+	// WallpaperCommand sets the desktop background for `sword-tui
+	// wallpaper -set`, e.g. "feh --bg-fill {}"; {} is replaced by the
+	// image, which is added at the end if there is no {}.
+	WallpaperCommand string `json:"wallpaper_command,omitempty"`
+	// TmuxTarget is the pane Y sends verses to, in tmux target syntax
+	// ("notes:1.0", "%3"). Empty means the previously active pane.
+	TmuxTarget string `json:"tmux_target,omitempty"`
+	// DownloadMirrors are base URLs to download translations from before
+	// bolls.life, each serving TRANSLATION.zip like
+	// https://bolls.life/static/translations, and optionally a SHA256SUMS
+	// manifest to check them against.
+	DownloadMirrors []string `json:"download_mirrors,omitempty"`
+	// ResponseCacheTTL is how long chapter, comparison and search
+	// responses fetched from the API are reused from disk, as a Go
+	// duration ("24h"). Empty means a week; "0" turns the response cache
+	// off.
+	ResponseCacheTTL string `json:"response_cache_ttl,omitempty"`
+	// MaxRequests is how many requests may be waiting on the provider at
+	// once, e.g. while a comparison loads and neighbouring chapters are
+	// prefetched. 0 means 4.
+	MaxRequests int `json:"max_requests,omitempty"`
+	// Provider selects the service Bible text comes from: "bolls" (the
+	// default, bolls.life), "getbible" (getbible.net), "esv" (the ESV
+	// API), "api.bible", or "sword" to read only downloaded, personal and
+	// SWORD translations and never go online. ProviderKey is the API key
+	// esv and api.bible require.
+	Provider    string `json:"provider,omitempty"`
+	ProviderKey string `json:"provider_key,omitempty"`
+
+	// SyncMode selects how the annotations directory is synced across
+	// devices. "" disables sync; "git" commits and pushes/pulls SyncRemote;
+	// "webdav" mirrors the annotations directory, and the settings
+	// without their secrets (SharedName), to the SyncRemote collection.
+	SyncMode   string `json:"sync_mode,omitempty"`
+	SyncRemote string `json:"sync_remote,omitempty"`
+	// WebDAV credentials. The password may instead be supplied through
+	// the SWORD_TUI_SYNC_PASSWORD environment variable.
+	SyncUser     string `json:"sync_user,omitempty"`
+	SyncPassword string `json:"sync_password,omitempty"`
+	// SyncStrategy resolves files changed on both sides: "lww"
+	// (last writer wins, default) or "merge".
+	SyncStrategy string `json:"sync_strategy,omitempty"`
+
+	// StudyLayouts are named arrangements of the study view (|), in
+	// addition to the built-in "study". StudyLayout is the one on
+	// screen; empty means the study view is off.
+	StudyLayouts map[string]StudyLayout `json:"study_layouts,omitempty"`
+	StudyLayout  string                 `json:"study_layout,omitempty"`
+	// StudyTrio is the translations O compares the highlighted verses
+	// in. "WLC/TR" names one for the Old Testament and one for the New.
+	// Empty means WLC/TR, YLT and NLT.
+	StudyTrio []string `json:"study_trio,omitempty"`
+
+	// Reader is who is reading, when several people share the machine:
+	// each reader's plan progress and reading log are kept apart (see
+	// ReaderDir). Empty is the default reader.
+	Reader string `json:"reader,omitempty"`
+
+	// StartScreen opens the reader on a dashboard of where reading left
+	// off, today's plan readings, the verse of the day and recent
+	// bookmarks.
+	StartScreen bool `json:"start_screen,omitempty"`
+
+	// LastVersion is the version that last ran, so an upgrade can show
+	// what's new once.
+	LastVersion string `json:"last_version,omitempty"`
 }
 
-func configPath() (string, error) {
+// StudyLayout arranges the side panes of the study view.
+type StudyLayout struct {
+	// Panes are any of "crossrefs", "notes" and "commentary", stacked
+	// top to bottom.
+	Panes []string `json:"panes"`
+	// Width is the side column's share of the reading area, in percent
+	// from 20 to 70; 0 means 40.
+	Width int `json:"width,omitempty"`
+}
+
+// Dir returns the sword-tui config directory, creating it if needed.
+func Dir() (string, error) {
 	configDir, err := os.UserConfigDir()
 	if err != nil {
 		return "", err
@@ -24,6 +195,84 @@ func configPath() (string, error) {
 		return "", err
 	}
 
+	return dir, nil
+}
+
+// AnnotationsDir returns the directory that holds user-authored data
+// (bookmarks, notes, highlights). It is kept separate from config.json so
+// it can be synced on its own.
+func AnnotationsDir() (string, error) {
+	base, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(base, "annotations")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
+
+// validReader keeps reader names usable as directory names.
+var validReader = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// CheckReader returns an error if name can't name a reader.
+func CheckReader(name string) error {
+	if name != "" && !validReader.MatchString(name) {
+		return fmt.Errorf("reader name %q should be letters, digits, - and _", name)
+	}
+	return nil
+}
+
+// ReaderDir returns the directory holding a reader's reading log and
+// plans: the annotations directory itself for the default reader, and
+// annotations/readers/NAME for the others, created if needed.
+func ReaderDir(reader string) (string, error) {
+	base, err := AnnotationsDir()
+	if err != nil || reader == "" {
+		return base, err
+	}
+	if err := CheckReader(reader); err != nil {
+		return "", err
+	}
+	dir := filepath.Join(base, "readers", reader)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// Readers returns the readers other than the default one, by name.
+func Readers() ([]string, error) {
+	base, err := AnnotationsDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(filepath.Join(base, "readers"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var out []string
+	for _, e := range entries {
+		if e.IsDir() && validReader.MatchString(e.Name()) {
+			out = append(out, e.Name())
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+func configPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
 	return filepath.Join(dir, "config.json"), nil
 }
 
@@ -64,3 +313,50 @@ func Save(s Settings) error {
 
 	return os.WriteFile(path, data, 0o644)
 }
+
+// SharedName is the file, beside config.json, that a WebDAV sync carries
+// the settings to other machines in. It holds everything but the sync
+// password and the provider key, which stay on the machine they were
+// typed into.
+const SharedName = "settings.json"
+
+// WriteShared writes s, without its secrets, to SharedName and returns
+// the bytes written. An unchanged file isn't touched, so its modification
+// time still says when the settings last changed.
+func WriteShared(s Settings) ([]byte, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	s.SyncPassword, s.ProviderKey = "", ""
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, SharedName)
+	if old, err := os.ReadFile(path); err == nil && bytes.Equal(old, data) {
+		return data, nil
+	}
+	return data, os.WriteFile(path, data, 0o644)
+}
+
+// ReadShared reads the settings another machine shared, keeping local's
+// secrets and how local syncs.
+func ReadShared(local Settings) (Settings, error) {
+	var s Settings
+	dir, err := Dir()
+	if err != nil {
+		return s, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, SharedName))
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return Settings{}, fmt.Errorf("%s: %w", SharedName, err)
+	}
+	s.ProviderKey = local.ProviderKey
+	s.SyncMode, s.SyncRemote, s.SyncUser, s.SyncPassword, s.SyncStrategy =
+		local.SyncMode, local.SyncRemote, local.SyncUser, local.SyncPassword, local.SyncStrategy
+	return s, nil
+}
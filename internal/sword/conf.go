@@ -0,0 +1,107 @@
+package sword
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// Module is an installed SWORD module as described by its conf file in
+// mods.d.
+type Module struct {
+	Name        string // the conf's section name, e.g. "KJV"
+	Description string
+	Lang        string
+	// Driver is ModDrv: "zText", "zText4", "zCom", "RawText", …
+	Driver string
+	// DataPath is where the module's files are, relative to the
+	// library it is installed in.
+	DataPath      string
+	Compress      string // CompressType: "ZIP" (default), "BZIP2", "LZSS", "XZ"
+	SourceType    string // markup: "OSIS", "ThML", "GBF", "TEI" or "Plain"
+	Encoding      string // "UTF-8"; anything else is Latin-1
+	Versification string // "KJV" when unset
+	Locked        bool   // the module is enciphered (CipherKey)
+
+	root string // the library the module is installed in
+}
+
+// Bible reports whether the module is a Bible text rather than a
+// commentary.
+func (m Module) Bible() bool {
+	return strings.HasSuffix(strings.ToLower(m.Driver), "text") ||
+		strings.HasSuffix(strings.ToLower(m.Driver), "text4")
+}
+
+// parseConf reads a module conf file: a [Name] section header, then
+// Key=Value lines, where a line ending in a backslash goes on on the
+// next one. Keys given more than once keep their first value.
+func parseConf(r io.Reader) (Module, error) {
+	var m Module
+	values := map[string]string{}
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	var key, value string
+	flush := func() {
+		if key != "" {
+			if _, ok := values[key]; !ok {
+				values[key] = strings.TrimSpace(value)
+			}
+		}
+		key, value = "", ""
+	}
+	continued := false
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if continued {
+			continued = strings.HasSuffix(line, "\\")
+			value += " " + strings.TrimSuffix(line, "\\")
+			if !continued {
+				flush()
+			}
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "" || strings.HasPrefix(trimmed, "#"):
+			continue
+		case strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]"):
+			if m.Name == "" {
+				m.Name = strings.TrimSpace(trimmed[1 : len(trimmed)-1])
+			}
+			continue
+		}
+		k, v, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(k), v
+		continued = strings.HasSuffix(v, "\\")
+		if continued {
+			value = strings.TrimSuffix(v, "\\")
+			continue
+		}
+		flush()
+	}
+	flush()
+	if err := sc.Err(); err != nil {
+		return m, err
+	}
+
+	m.Description = values["Description"]
+	m.Lang = values["Lang"]
+	m.Driver = values["ModDrv"]
+	m.DataPath = values["DataPath"]
+	m.Compress = strings.ToUpper(values["CompressType"])
+	if m.Compress == "" {
+		m.Compress = "ZIP"
+	}
+	m.SourceType = values["SourceType"]
+	m.Encoding = values["Encoding"]
+	m.Versification = values["Versification"]
+	if m.Versification == "" {
+		m.Versification = "KJV"
+	}
+	_, m.Locked = values["CipherKey"]
+	return m, nil
+}
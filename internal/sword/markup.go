@@ -0,0 +1,38 @@
+package sword
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// notes are footnotes and cross references kept inside the verse
+	// text (OSIS and ThML <note>, GBF <RF>…<Rf>), and titles the section
+	// headings some modules put before a verse. Their text isn't part of
+	// the verse.
+	notePattern = regexp.MustCompile(`(?is)<note\b[^>]*>.*?</note>|<RF>.*?<Rf>|<title\b[^>]*>.*?</title>`)
+	tagPattern  = regexp.MustCompile(`<[^>]*>`)
+	spaces      = regexp.MustCompile(`\s+`)
+)
+
+// plainText turns a module entry into the verse's plain text: decoded
+// from Latin-1 unless the module says UTF-8, without its markup, notes
+// or headings.
+func plainText(raw []byte, encoding string) string {
+	var s string
+	if strings.EqualFold(encoding, "UTF-8") || utf8.Valid(raw) && !strings.EqualFold(encoding, "Latin-1") {
+		s = string(raw)
+	} else {
+		runes := make([]rune, len(raw))
+		for i, b := range raw {
+			runes[i] = rune(b)
+		}
+		s = string(runes)
+	}
+	s = notePattern.ReplaceAllString(s, "")
+	s = tagPattern.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	return strings.TrimSpace(spaces.ReplaceAllString(s, " "))
+}
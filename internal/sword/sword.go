@@ -0,0 +1,224 @@
+// Package sword reads Bibles from a local SWORD library, the module
+// collection Xiphos, BibleTime and other SWORD front ends install, so
+// they can be read without downloading anything. It understands the
+// compressed (zText, zCom) and raw (RawText, RawCom) drivers in the KJV
+// versification, and implements api.LocalSource so the client serves
+// the Bibles from disk like any local translation.
+package sword
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"sword-tui/internal/api"
+)
+
+// DefaultPaths lists where SWORD libraries are looked for: the
+// directories in $SWORD_PATH, then ~/.sword and the system-wide ones.
+// On Windows those are %AppData%\Sword and the all-users one, and on
+// macOS ~/Library/Application Support/Sword comes before ~/.sword.
+func DefaultPaths() []string {
+	var paths []string
+	if env := os.Getenv("SWORD_PATH"); env != "" {
+		paths = append(paths, filepath.SplitList(env)...)
+	}
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		if dir, err := os.UserConfigDir(); err == nil {
+			paths = append(paths, filepath.Join(dir, "Sword"))
+		}
+	}
+	if runtime.GOOS == "windows" {
+		if all := os.Getenv("ALLUSERSPROFILE"); all != "" {
+			paths = append(paths, filepath.Join(all, "Application Data", "Sword"))
+		}
+		return paths
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".sword"))
+	}
+	return append(paths, "/usr/share/sword", "/usr/local/share/sword")
+}
+
+// Library is the modules installed in one or more SWORD libraries.
+type Library struct {
+	modules map[string]*Module
+
+	mu      sync.Mutex
+	readers map[string]*reader // by module name and testament
+}
+
+// Open reads the module confs in each path's mods.d. A module installed
+// in more than one takes the first. Paths without a mods.d are skipped,
+// so Open(DefaultPaths()...) finds whatever is there.
+func Open(paths ...string) (*Library, error) {
+	l := &Library{modules: map[string]*Module{}, readers: map[string]*reader{}}
+	for _, root := range paths {
+		confs, err := filepath.Glob(filepath.Join(root, "mods.d", "*.conf"))
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range confs {
+			f, err := os.Open(path)
+			if err != nil {
+				continue
+			}
+			m, err := parseConf(f)
+			f.Close()
+			if err != nil || m.Name == "" {
+				continue
+			}
+			if _, ok := l.modules[m.Name]; ok {
+				continue
+			}
+			m.root = root
+			l.modules[m.Name] = &m
+		}
+	}
+	return l, nil
+}
+
+// Modules lists every installed module, by name.
+func (l *Library) Modules() []Module {
+	out := make([]Module, 0, len(l.modules))
+	for _, m := range l.modules {
+		out = append(out, *m)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// readable returns the module called name if it is a Bible this package
+// can read.
+func (l *Library) readable(name string) (*Module, bool) {
+	m, ok := l.modules[name]
+	if !ok || !m.Bible() || m.Locked || m.Versification != "KJV" {
+		return nil, false
+	}
+	return m, true
+}
+
+// reader returns the reader for m's New Testament, or its Old.
+func (l *Library) reader(m *Module, nt bool) *reader {
+	testament := "ot"
+	if nt {
+		testament = "nt"
+	}
+	key := m.Name + "/" + testament
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if r, ok := l.readers[key]; ok {
+		return r
+	}
+	r := &reader{mod: *m, testament: testament}
+	l.readers[key] = r
+	return r
+}
+
+// Entry returns the text of book chapter:verse in the module called name,
+// markup and all, or "" if the module leaves the verse out. It reads
+// commentaries as well as Bibles.
+func (l *Library) Entry(name string, book, chapter, verse int) (string, error) {
+	m, ok := l.modules[name]
+	if !ok {
+		return "", fmt.Errorf("no SWORD module %q", name)
+	}
+	if m.Locked {
+		return "", fmt.Errorf("%s is locked", name)
+	}
+	if m.Versification != "KJV" {
+		return "", fmt.Errorf("%s: the %s versification is not supported", name, m.Versification)
+	}
+	idx, nt, ok := kjvIndex(book, chapter, verse)
+	if !ok {
+		return "", fmt.Errorf("%s: no verse %d:%d:%d", name, book, chapter, verse)
+	}
+	raw, err := l.reader(m, nt).entry(idx)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+// IsCached reports whether name is a Bible in the library. The name
+// mirrors the cache's method so the api client can treat both alike.
+func (l *Library) IsCached(name string) bool {
+	_, ok := l.readable(name)
+	return ok
+}
+
+// GetChapter returns the verses of a chapter as plain text, leaving out
+// those the module has no text for.
+func (l *Library) GetChapter(name string, book, chapter int) ([]api.Verse, error) {
+	m, ok := l.readable(name)
+	if !ok {
+		return nil, fmt.Errorf("no SWORD Bible %q", name)
+	}
+	if book < 1 || book > len(kjvVerses) || chapter < 1 || chapter > len(kjvVerses[book-1]) {
+		return nil, fmt.Errorf("%s: no chapter %d:%d", name, book, chapter)
+	}
+	var verses []api.Verse
+	for v := 1; v <= kjvVerses[book-1][chapter-1]; v++ {
+		idx, nt, _ := kjvIndex(book, chapter, v)
+		raw, err := l.reader(m, nt).entry(idx)
+		if err != nil {
+			return nil, err
+		}
+		text := plainText(raw, m.Encoding)
+		if text == "" {
+			continue
+		}
+		verses = append(verses, api.Verse{PK: idx, Verse: v, Text: text, Translation: name, Book: book, Chapter: chapter})
+	}
+	return verses, nil
+}
+
+func (l *Library) GetVerse(name string, book, chapter, verse int) (*api.Verse, error) {
+	verses, err := l.GetChapter(name, book, chapter)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range verses {
+		if v.Verse == verse {
+			return &v, nil
+		}
+	}
+	return nil, fmt.Errorf("verse not found")
+}
+
+// Translations lists the Bibles in the library.
+func (l *Library) Translations() []api.Translation {
+	var out []api.Translation
+	for _, m := range l.Modules() {
+		if _, ok := l.readable(m.Name); ok {
+			out = append(out, api.Translation{ShortName: m.Name, FullName: m.Description, Local: true})
+		}
+	}
+	return out
+}
+
+// Books returns the books of the testaments the module has, with the
+// KJV versification's chapters and verses.
+func (l *Library) Books(name string) ([]api.Book, error) {
+	m, ok := l.readable(name)
+	if !ok {
+		return nil, fmt.Errorf("no SWORD Bible %q", name)
+	}
+	var books []api.Book
+	for id := 1; id <= len(kjvVerses); id++ {
+		if !l.reader(m, id > 39).exists() {
+			continue
+		}
+		b, _ := api.CanonicalBook(id)
+		b.Verses = append([]int(nil), kjvVerses[id-1]...)
+		books = append(books, b)
+	}
+	if len(books) == 0 {
+		return nil, fmt.Errorf("%s: no module files under %s", name, strings.TrimPrefix(m.DataPath, "./"))
+	}
+	return books, nil
+}
@@ -0,0 +1,177 @@
+package sword
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// reader reads entries from one testament of a module. The compressed
+// drivers (zText, zCom) keep verses in blocks: ot.bzv points each verse
+// at a block and a span within it, ot.bzs locates the blocks in ot.bzz.
+// The raw drivers (RawText, RawCom) point each verse straight at a span
+// of the ot file, through ot.vss. The nt files hold the New Testament.
+type reader struct {
+	mod       Module
+	testament string // "ot" or "nt"
+
+	mu sync.Mutex
+	// block and blockNum are the last block decompressed, since verses
+	// read one after another share one.
+	block    []byte
+	blockNum int
+}
+
+func (r *reader) dir() string {
+	return filepath.Join(r.mod.root, filepath.FromSlash(strings.TrimPrefix(r.mod.DataPath, "./")))
+}
+
+func (r *reader) compressed() bool {
+	return strings.HasPrefix(strings.ToLower(r.mod.Driver), "z")
+}
+
+// wide reports whether index entries give sizes in 4 bytes rather than
+// 2, as the drivers ending in 4 do.
+func (r *reader) wide() bool {
+	return strings.HasSuffix(r.mod.Driver, "4")
+}
+
+// exists reports whether the module has this testament at all.
+func (r *reader) exists() bool {
+	name := r.testament + ".vss"
+	if r.compressed() {
+		name = r.testament + ".bzv"
+	}
+	_, err := os.Stat(filepath.Join(r.dir(), name))
+	return err == nil
+}
+
+// entry returns the raw text of verse index idx, or nil if the module
+// has nothing there.
+func (r *reader) entry(idx int) ([]byte, error) {
+	if r.compressed() {
+		return r.zEntry(idx)
+	}
+	return r.rawEntry(idx)
+}
+
+func (r *reader) rawEntry(idx int) ([]byte, error) {
+	size := 6
+	if r.wide() {
+		size = 8
+	}
+	rec, err := readAt(filepath.Join(r.dir(), r.testament+".vss"), int64(idx*size), size)
+	if err != nil || rec == nil {
+		return nil, err
+	}
+	start := int64(binary.LittleEndian.Uint32(rec))
+	var n int
+	if r.wide() {
+		n = int(binary.LittleEndian.Uint32(rec[4:]))
+	} else {
+		n = int(binary.LittleEndian.Uint16(rec[4:]))
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	return readAt(filepath.Join(r.dir(), r.testament), start, n)
+}
+
+func (r *reader) zEntry(idx int) ([]byte, error) {
+	size := 10
+	if r.wide() {
+		size = 12
+	}
+	rec, err := readAt(filepath.Join(r.dir(), r.testament+".bzv"), int64(idx*size), size)
+	if err != nil || rec == nil {
+		return nil, err
+	}
+	blockNum := int(binary.LittleEndian.Uint32(rec))
+	start := int(binary.LittleEndian.Uint32(rec[4:]))
+	var n int
+	if r.wide() {
+		n = int(binary.LittleEndian.Uint32(rec[8:]))
+	} else {
+		n = int(binary.LittleEndian.Uint16(rec[8:]))
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	block, err := r.readBlock(blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if start+n > len(block) {
+		return nil, fmt.Errorf("%s: verse runs past the end of block %d", r.mod.Name, blockNum)
+	}
+	return block[start : start+n], nil
+}
+
+// readBlock returns block num decompressed.
+func (r *reader) readBlock(num int) ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.block != nil && r.blockNum == num {
+		return r.block, nil
+	}
+	rec, err := readAt(filepath.Join(r.dir(), r.testament+".bzs"), int64(num*12), 12)
+	if err != nil {
+		return nil, err
+	}
+	if rec == nil {
+		return nil, fmt.Errorf("%s: no block %d", r.mod.Name, num)
+	}
+	start := int64(binary.LittleEndian.Uint32(rec))
+	n := int(binary.LittleEndian.Uint32(rec[4:]))
+	data, err := readAt(filepath.Join(r.dir(), r.testament+".bzz"), start, n)
+	if err != nil {
+		return nil, err
+	}
+	var zr io.Reader
+	switch r.mod.Compress {
+	case "ZIP":
+		z, err := zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("%s: block %d: %w", r.mod.Name, num, err)
+		}
+		defer z.Close()
+		zr = z
+	case "BZIP2":
+		zr = bzip2.NewReader(bytes.NewReader(data))
+	default:
+		return nil, fmt.Errorf("%s: %s compression is not supported", r.mod.Name, r.mod.Compress)
+	}
+	block, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: block %d: %w", r.mod.Name, num, err)
+	}
+	r.block, r.blockNum = block, num
+	return block, nil
+}
+
+// readAt reads n bytes of the file at path from off, or returns nil if
+// the file ends before off.
+func readAt(path string, off int64, n int) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	buf := make([]byte, n)
+	got, err := f.ReadAt(buf, off)
+	if errors.Is(err, io.EOF) {
+		if got < n {
+			return nil, nil
+		}
+		err = nil
+	}
+	return buf, err
+}
@@ -0,0 +1,139 @@
+package syncer
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Git syncs a directory by committing local changes and exchanging them
+// with a remote repository. It shells out to the user's git binary so
+// credentials, SSH agents and hooks behave exactly as on the command line.
+type Git struct {
+	dir    string
+	remote string
+}
+
+func NewGit(dir, remote string) *Git {
+	return &Git{dir: dir, remote: remote}
+}
+
+// Sync commits any pending changes, pulls (rebasing local commits on top)
+// and pushes back to the remote. It returns a short human-readable summary
+// suitable for the status bar.
+func (g *Git) Sync() (string, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return "", fmt.Errorf("git not found in PATH")
+	}
+
+	if err := g.ensureRepo(); err != nil {
+		return "", err
+	}
+
+	committed, err := g.commit()
+	if err != nil {
+		return "", err
+	}
+
+	if g.remote == "" {
+		if committed {
+			return "committed locally (no remote)", nil
+		}
+		return "nothing to sync (no remote)", nil
+	}
+
+	branch, err := g.run("rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", err
+	}
+
+	// A brand new remote has no branch yet; only pull when there is
+	// something to pull.
+	if _, err := g.run("ls-remote", "--exit-code", "--heads", "origin", branch); err == nil {
+		if _, err := g.run("pull", "--rebase", "origin", branch); err != nil {
+			return "", fmt.Errorf("pull failed: %w", err)
+		}
+	}
+
+	if _, err := g.run("push", "-u", "origin", branch); err != nil {
+		return "", fmt.Errorf("push failed: %w", err)
+	}
+
+	if committed {
+		return "synced (pushed local changes)", nil
+	}
+	return "synced", nil
+}
+
+// ensureRepo initialises the directory as a git repository on first use
+// and points origin at the configured remote.
+func (g *Git) ensureRepo() error {
+	if _, err := os.Stat(filepath.Join(g.dir, ".git")); os.IsNotExist(err) {
+		if _, err := g.run("init"); err != nil {
+			return err
+		}
+	}
+
+	if g.remote == "" {
+		return nil
+	}
+
+	current, err := g.run("remote", "get-url", "origin")
+	if err != nil {
+		_, err = g.run("remote", "add", "origin", g.remote)
+		return err
+	}
+	if current != g.remote {
+		_, err = g.run("remote", "set-url", "origin", g.remote)
+		return err
+	}
+	return nil
+}
+
+// commit stages everything and commits it, reporting whether a commit
+// was actually made.
+func (g *Git) commit() (bool, error) {
+	if _, err := g.run("add", "-A"); err != nil {
+		return false, err
+	}
+
+	status, err := g.run("status", "--porcelain")
+	if err != nil {
+		return false, err
+	}
+	if status == "" {
+		return false, nil
+	}
+
+	host, _ := os.Hostname()
+	msg := fmt.Sprintf("sword-tui sync from %s at %s", host, time.Now().Format(time.RFC3339))
+	args := []string{"commit", "-m", msg}
+	// Fall back to a placeholder identity so a fresh machine without a
+	// global git config can still commit.
+	if _, err := g.run("config", "user.email"); err != nil {
+		args = append([]string{"-c", "user.name=sword-tui", "-c", "user.email=sword-tui@localhost"}, args...)
+	}
+	if _, err := g.run(args...); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (g *Git) run(args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", g.dir}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("git: %s", msg)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,28 @@
+// Package syncer keeps the user's annotations (and optionally settings) in
+// step across devices. Each backend implements Adapter; the UI only ever
+// calls Sync and shows the returned summary.
+package syncer
+
+// Adapter is a sync backend.
+type Adapter interface {
+	// Sync reconciles local and remote state and returns a short
+	// human-readable summary of what happened.
+	Sync() (string, error)
+}
+
+// Conflict resolution strategies used when a file changed both locally and
+// remotely since the last sync.
+const (
+	// StrategyLastWriterWins keeps whichever side was modified most
+	// recently.
+	StrategyLastWriterWins = "lww"
+	// StrategyMerge unions the lines of text files (notes are
+	// append-mostly, so this rarely loses anything) and falls back to
+	// last-writer-wins for everything else.
+	StrategyMerge = "merge"
+)
+
+var (
+	_ Adapter = (*Git)(nil)
+	_ Adapter = (*WebDAV)(nil)
+)
@@ -0,0 +1,543 @@
+package syncer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const webdavStateFile = ".webdav-state.json"
+
+// WebDAVOptions configures a WebDAV (Nextcloud, ownCloud, Apache mod_dav…)
+// sync target.
+type WebDAVOptions struct {
+	// URL is the remote collection files are mirrored into, e.g.
+	// https://cloud.example.com/remote.php/dav/files/me/sword-tui/
+	URL      string
+	Username string
+	Password string
+	// Root is the local directory Paths are relative to.
+	Root string
+	// Paths lists the files and directories under Root to sync.
+	Paths []string
+	// Strategy is StrategyLastWriterWins (default) or StrategyMerge.
+	Strategy string
+}
+
+// WebDAV mirrors a set of local files to a WebDAV collection. A small state
+// file records the content hash and remote ETag of every file as of the
+// last sync, which is what lets it tell "changed here", "changed there" and
+// "changed on both sides" apart.
+type WebDAV struct {
+	opts       WebDAVOptions
+	base       *url.URL
+	httpClient *http.Client
+}
+
+func NewWebDAV(opts WebDAVOptions) (*WebDAV, error) {
+	if opts.URL == "" {
+		return nil, fmt.Errorf("webdav: no sync_remote URL configured")
+	}
+	base, err := url.Parse(opts.URL)
+	if err != nil {
+		return nil, fmt.Errorf("webdav: bad URL: %w", err)
+	}
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path += "/"
+	}
+	if opts.Strategy == "" {
+		opts.Strategy = StrategyLastWriterWins
+	}
+	return &WebDAV{
+		opts:       opts,
+		base:       base,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+type webdavState struct {
+	Files map[string]syncedFile `json:"files"`
+}
+
+type syncedFile struct {
+	Hash string `json:"hash"`
+	ETag string `json:"etag"`
+}
+
+type remoteFile struct {
+	etag    string
+	modTime time.Time
+}
+
+type localFile struct {
+	hash    string
+	modTime time.Time
+}
+
+func (w *WebDAV) Sync() (string, error) {
+	state, err := w.loadState()
+	if err != nil {
+		return "", err
+	}
+
+	remote, err := w.listRemote()
+	if err != nil {
+		return "", err
+	}
+	local, err := w.listLocal()
+	if err != nil {
+		return "", err
+	}
+
+	paths := map[string]bool{}
+	for p := range remote {
+		paths[p] = true
+	}
+	for p := range local {
+		paths[p] = true
+	}
+	for p := range state.Files {
+		paths[p] = true
+	}
+	sorted := make([]string, 0, len(paths))
+	for p := range paths {
+		sorted = append(sorted, p)
+	}
+	sort.Strings(sorted)
+
+	var up, down, deleted, conflicts int
+	for _, p := range sorted {
+		l, hasLocal := local[p]
+		r, hasRemote := remote[p]
+		prev, known := state.Files[p]
+
+		localChanged := hasLocal && (!known || l.hash != prev.Hash)
+		remoteChanged := hasRemote && (!known || r.etag != prev.ETag)
+
+		switch {
+		case hasLocal && !hasRemote:
+			if known && !localChanged {
+				// Deleted on the remote since last sync.
+				if err := os.Remove(w.localPath(p)); err != nil {
+					return "", err
+				}
+				delete(state.Files, p)
+				deleted++
+				continue
+			}
+			if err := w.upload(p); err != nil {
+				return "", err
+			}
+			up++
+
+		case !hasLocal && hasRemote:
+			if known && !remoteChanged {
+				// Deleted locally since last sync.
+				if err := w.remove(p); err != nil {
+					return "", err
+				}
+				delete(state.Files, p)
+				deleted++
+				continue
+			}
+			if err := w.download(p); err != nil {
+				return "", err
+			}
+			down++
+
+		case hasLocal && hasRemote:
+			switch {
+			case localChanged && remoteChanged:
+				conflicts++
+				pushed, err := w.resolve(p, l, r)
+				if err != nil {
+					return "", err
+				}
+				if pushed {
+					up++
+				} else {
+					down++
+				}
+			case localChanged:
+				if err := w.upload(p); err != nil {
+					return "", err
+				}
+				up++
+			case remoteChanged:
+				if err := w.download(p); err != nil {
+					return "", err
+				}
+				down++
+			}
+
+		default:
+			// Gone on both sides.
+			delete(state.Files, p)
+		}
+	}
+
+	// Re-read both sides so the recorded hashes and ETags describe the
+	// state we just converged on.
+	if remote, err = w.listRemote(); err != nil {
+		return "", err
+	}
+	if local, err = w.listLocal(); err != nil {
+		return "", err
+	}
+	state.Files = map[string]syncedFile{}
+	for p, l := range local {
+		if r, ok := remote[p]; ok {
+			state.Files[p] = syncedFile{Hash: l.hash, ETag: r.etag}
+		}
+	}
+	if err := w.saveState(state); err != nil {
+		return "", err
+	}
+
+	summary := fmt.Sprintf("webdav: ↑%d ↓%d", up, down)
+	if deleted > 0 {
+		summary += fmt.Sprintf(" ✕%d", deleted)
+	}
+	if conflicts > 0 {
+		summary += fmt.Sprintf(", %d conflict(s) resolved by %s", conflicts, w.opts.Strategy)
+	}
+	return summary, nil
+}
+
+// resolve settles a file that changed on both sides. It reports whether
+// the result was pushed to the remote (true) or pulled from it (false).
+func (w *WebDAV) resolve(p string, l localFile, r remoteFile) (bool, error) {
+	if w.opts.Strategy == StrategyMerge && isText(p) {
+		remoteData, err := w.get(p)
+		if err != nil {
+			return false, err
+		}
+		localData, err := os.ReadFile(w.localPath(p))
+		if err != nil {
+			return false, err
+		}
+		if err := os.WriteFile(w.localPath(p), mergeLines(localData, remoteData), 0o644); err != nil {
+			return false, err
+		}
+		return true, w.upload(p)
+	}
+
+	if r.modTime.After(l.modTime) {
+		return false, w.download(p)
+	}
+	return true, w.upload(p)
+}
+
+// mergeLines keeps every local line in order and appends remote lines the
+// local copy doesn't already have.
+func mergeLines(local, remote []byte) []byte {
+	seen := map[string]bool{}
+	localLines := strings.Split(strings.TrimRight(string(local), "\n"), "\n")
+	for _, ln := range localLines {
+		seen[ln] = true
+	}
+	out := localLines
+	for _, ln := range strings.Split(strings.TrimRight(string(remote), "\n"), "\n") {
+		if !seen[ln] {
+			out = append(out, ln)
+			seen[ln] = true
+		}
+	}
+	return []byte(strings.Join(out, "\n") + "\n")
+}
+
+func isText(p string) bool {
+	switch strings.ToLower(path.Ext(p)) {
+	case ".md", ".txt":
+		return true
+	}
+	return false
+}
+
+func (w *WebDAV) localPath(p string) string {
+	return filepath.Join(w.opts.Root, filepath.FromSlash(p))
+}
+
+func (w *WebDAV) remoteURL(p string) string {
+	u := *w.base
+	u.Path = path.Join(w.base.Path, p)
+	return u.String()
+}
+
+func (w *WebDAV) listLocal() (map[string]localFile, error) {
+	files := map[string]localFile{}
+	for _, rel := range w.opts.Paths {
+		root := w.localPath(rel)
+		err := filepath.WalkDir(root, func(fp string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() {
+				if d.Name() == ".git" {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			data, err := os.ReadFile(fp)
+			if err != nil {
+				return err
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			relPath, err := filepath.Rel(w.opts.Root, fp)
+			if err != nil {
+				return err
+			}
+			sum := sha256.Sum256(data)
+			files[filepath.ToSlash(relPath)] = localFile{hash: hex.EncodeToString(sum[:]), modTime: info.ModTime()}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+type multistatus struct {
+	Responses []struct {
+		Href     string `xml:"href"`
+		Propstat []struct {
+			Prop struct {
+				ETag         string `xml:"getetag"`
+				LastModified string `xml:"getlastmodified"`
+				ResourceType struct {
+					Collection *struct{} `xml:"collection"`
+				} `xml:"resourcetype"`
+			} `xml:"prop"`
+		} `xml:"propstat"`
+	} `xml:"response"`
+}
+
+const propfindBody = `<?xml version="1.0" encoding="utf-8"?>
+<d:propfind xmlns:d="DAV:"><d:prop><d:getetag/><d:getlastmodified/><d:resourcetype/></d:prop></d:propfind>`
+
+// listRemote walks the remote tree one level at a time (many servers,
+// Nextcloud included, refuse Depth: infinity).
+func (w *WebDAV) listRemote() (map[string]remoteFile, error) {
+	files := map[string]remoteFile{}
+	queue := []string{""}
+	for len(queue) > 0 {
+		dir := queue[0]
+		queue = queue[1:]
+
+		req, err := w.request("PROPFIND", w.remoteURL(dir)+"/", strings.NewReader(propfindBody))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Depth", "1")
+		req.Header.Set("Content-Type", "application/xml")
+		resp, err := w.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			if dir == "" {
+				// First sync against an empty server.
+				if err := w.mkcol(""); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+		if resp.StatusCode != http.StatusMultiStatus {
+			return nil, fmt.Errorf("webdav: PROPFIND returned status %d", resp.StatusCode)
+		}
+
+		var ms multistatus
+		if err := xml.Unmarshal(body, &ms); err != nil {
+			return nil, fmt.Errorf("webdav: bad PROPFIND response: %w", err)
+		}
+		for _, r := range ms.Responses {
+			rel, ok := w.relative(r.Href)
+			if !ok || rel == dir || len(r.Propstat) == 0 {
+				continue
+			}
+			prop := r.Propstat[0].Prop
+			if prop.ResourceType.Collection != nil {
+				queue = append(queue, rel)
+				continue
+			}
+			if !w.included(rel) {
+				continue
+			}
+			mod, _ := http.ParseTime(prop.LastModified)
+			files[rel] = remoteFile{etag: prop.ETag, modTime: mod}
+		}
+	}
+	return files, nil
+}
+
+// relative turns an href from a PROPFIND response into a path relative to
+// the sync base, reporting false for anything outside it.
+func (w *WebDAV) relative(href string) (string, bool) {
+	u, err := url.Parse(href)
+	if err != nil {
+		return "", false
+	}
+	p := u.Path
+	if !strings.HasPrefix(p, w.base.Path) {
+		return "", false
+	}
+	return strings.Trim(strings.TrimPrefix(p, w.base.Path), "/"), true
+}
+
+func (w *WebDAV) included(rel string) bool {
+	for _, p := range w.opts.Paths {
+		if rel == p || strings.HasPrefix(rel, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *WebDAV) upload(p string) error {
+	data, err := os.ReadFile(w.localPath(p))
+	if err != nil {
+		return err
+	}
+	if dir := path.Dir(p); dir != "." {
+		if err := w.mkcolAll(dir); err != nil {
+			return err
+		}
+	}
+	req, err := w.request("PUT", w.remoteURL(p), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	return w.expect(req, http.StatusOK, http.StatusCreated, http.StatusNoContent)
+}
+
+func (w *WebDAV) get(p string) ([]byte, error) {
+	req, err := w.request("GET", w.remoteURL(p), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("webdav: GET %s returned status %d", p, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (w *WebDAV) download(p string) error {
+	data, err := w.get(p)
+	if err != nil {
+		return err
+	}
+	lp := w.localPath(p)
+	if err := os.MkdirAll(filepath.Dir(lp), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(lp, data, 0o644)
+}
+
+func (w *WebDAV) remove(p string) error {
+	req, err := w.request("DELETE", w.remoteURL(p), nil)
+	if err != nil {
+		return err
+	}
+	return w.expect(req, http.StatusOK, http.StatusNoContent, http.StatusNotFound)
+}
+
+func (w *WebDAV) mkcolAll(dir string) error {
+	parts := strings.Split(dir, "/")
+	for i := range parts {
+		if err := w.mkcol(strings.Join(parts[:i+1], "/")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *WebDAV) mkcol(dir string) error {
+	req, err := w.request("MKCOL", w.remoteURL(dir)+"/", nil)
+	if err != nil {
+		return err
+	}
+	// 405 means the collection already exists.
+	return w.expect(req, http.StatusCreated, http.StatusMethodNotAllowed)
+}
+
+func (w *WebDAV) request(method, u string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	if w.opts.Username != "" {
+		req.SetBasicAuth(w.opts.Username, w.opts.Password)
+	}
+	return req, nil
+}
+
+func (w *WebDAV) expect(req *http.Request, codes ...int) error {
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	for _, c := range codes {
+		if resp.StatusCode == c {
+			return nil
+		}
+	}
+	return fmt.Errorf("webdav: %s %s returned status %d", req.Method, req.URL.Path, resp.StatusCode)
+}
+
+func (w *WebDAV) loadState() (webdavState, error) {
+	st := webdavState{Files: map[string]syncedFile{}}
+	data, err := os.ReadFile(filepath.Join(w.opts.Root, webdavStateFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return st, nil
+		}
+		return st, err
+	}
+	if err := json.Unmarshal(data, &st); err != nil {
+		return st, err
+	}
+	if st.Files == nil {
+		st.Files = map[string]syncedFile{}
+	}
+	return st, nil
+}
+
+func (w *WebDAV) saveState(st webdavState) error {
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(w.opts.Root, webdavStateFile), data, 0o644)
+}
@@ -0,0 +1,50 @@
+// Package textnorm folds text for the searches sword-tui runs itself, so
+// that "Jesus’ words", "jesus' words" and a decomposed "Jesús" typed on
+// another keyboard all find the same verse. Queries and the text searched
+// must both go through Fold.
+package textnorm
+
+import (
+	"strings"
+	"unicode"
+)
+
+// latinBase holds the base letter of each character from U+00C0 to
+// U+017F, or the character itself when it has none.
+var latinBase = []rune("AAAAAAÆCEEEEIIIIÐNOOOOO×ØUUUUYÞß" +
+	"aaaaaaæceeeeiiiiðnooooo÷øuuuuyþy" +
+	"AaAaAaCcCcCcCcDdĐđEeEeEeEeEeGgGgGgGgHhĦħIiIiIiIiIıĲĳJjKkĸLlLlLlĿŀŁłNnNnNnŉŊŋ" +
+	"OoOoOoŒœRrRrRrSsSsSsSsTtTtŦŧUuUuUuUuUuUuWwYyYZzZzZzſ")
+
+// spelled replaces letters without a base form and typographic
+// punctuation with what people type on a plain keyboard.
+var spelled = map[rune]string{
+	'æ': "ae", 'œ': "oe", 'ß': "ss", 'ĳ': "ij", 'ø': "o", 'đ': "d", 'ð': "d",
+	'ħ': "h", 'ı': "i", 'ł': "l", 'ŀ': "l", 'ŧ': "t", 'ſ': "s", 'þ': "th",
+	'‘': "'", '’': "'", '‚': "'", '‛': "'", '′': "'", 'ʼ': "'",
+	'“': `"`, '”': `"`, '„': `"`, '‟': `"`, '″': `"`, '«': `"`, '»': `"`,
+	'‐': "-", '‑': "-", '‒': "-", '–': "-", '—': "-", '―': "-", '−': "-",
+	'…': "...", '\u00a0': " ", '\u202f': " ",
+}
+
+// Fold lowercases s, strips accents whether they are precomposed (NFC) or
+// separate combining marks (NFD), and straightens quotes and dashes.
+func Fold(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if r >= 0xC0 && r < 0x180 {
+			r = latinBase[r-0xC0]
+		}
+		r = unicode.ToLower(r)
+		if rep, ok := spelled[r]; ok {
+			b.WriteString(rep)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,104 @@
+package theme
+
+import (
+	"image/color"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/colorprofile"
+)
+
+// Terminals without truecolor round each hex color to the nearest one
+// they have, which can merge a theme's background, highlight and borders
+// into one and leave text unreadable. Every theme therefore also comes
+// in the 256-color cube and in the 16 ANSI colors, chosen by hand so the
+// roles stay apart.
+
+// palette lists a theme's colors in the order of the Theme fields:
+// primary, secondary, accent, muted, error, success, warning, border,
+// active border, background, highlight and shadow.
+type palette [12]string
+
+func (p palette) theme(name string) Theme {
+	c := func(i int) color.Color { return lipgloss.Color(p[i]) }
+	return Theme{
+		Name:         name,
+		Primary:      c(0),
+		Secondary:    c(1),
+		Accent:       c(2),
+		Muted:        c(3),
+		Error:        c(4),
+		Success:      c(5),
+		Warning:      c(6),
+		Border:       c(7),
+		BorderActive: c(8),
+		Background:   c(9),
+		Highlight:    c(10),
+		Shadow:       c(11),
+	}
+}
+
+// palettes256 are the themes in the xterm 256-color cube and grays,
+// leaving out the first 16, which each terminal sets its own way.
+var palettes256 = map[string]palette{
+	CatppuccinMocha.Name: {"189", "146", "218", "243", "211", "151", "223", "239", "111", "237", "239", "233"},
+	CatppuccinLatte.Name: {"240", "60", "176", "248", "161", "70", "172", "254", "27", "255", "252", "251"},
+	Dracula.Name:         {"255", "61", "212", "61", "203", "84", "228", "239", "141", "236", "239", "233"},
+	RosePineMoon.Name:    {"189", "103", "181", "60", "168", "152", "216", "238", "182", "236", "238", "234"},
+	RosePineDawn.Name:    {"60", "244", "174", "247", "132", "67", "179", "254", "103", "255", "253", "252"},
+	SolarizedDark.Name:   {"246", "242", "168", "242", "166", "100", "136", "237", "32", "235", "237", "233"},
+	SolarizedLight.Name:  {"66", "247", "168", "247", "166", "100", "136", "254", "32", "230", "254", "187"},
+	BruEspresso.Name:     {"224", "187", "209", "101", "203", "107", "178", "237", "69", "234", "236", "232"},
+	BruLatte.Name:        {"236", "240", "130", "101", "160", "64", "136", "187", "26", "230", "187", "180"},
+	JoziNights.Name:      {"146", "153", "199", "103", "199", "78", "178", "239", "135", "235", "237", "233"},
+	JoziMorning.Name:     {"238", "235", "162", "61", "162", "29", "172", "249", "62", "188", "251", "248"},
+	JoziMidnight.Name:    {"146", "153", "199", "103", "199", "78", "178", "236", "135", "233", "235", "232"},
+	OkabeItoDark.Name:    {"254", "250", "175", "102", "166", "35", "221", "238", "74", "234", "236", "233"},
+	OkabeItoLight.Name:   {"235", "238", "175", "243", "166", "35", "178", "252", "25", "255", "253", "251"},
+}
+
+// ansiDark and ansiLight are the 16-color themes for dark and light
+// backgrounds; each theme puts its own accent and active border in. On
+// dark ones muted text is plain white, as bright black is the highlight.
+func ansiDark(accent, active string) palette {
+	return palette{"15", "7", accent, "7", "9", "10", "11", "8", active, "0", "8", "0"}
+}
+
+func ansiLight(accent, active string) palette {
+	return palette{"0", "8", accent, "8", "1", "2", "3", "7", active, "15", "7", "7"}
+}
+
+// palettes16 are the themes in the 16 ANSI colors.
+var palettes16 = map[string]palette{
+	CatppuccinMocha.Name: ansiDark("13", "12"),
+	CatppuccinLatte.Name: ansiLight("5", "4"),
+	Dracula.Name:         ansiDark("13", "5"),
+	RosePineMoon.Name:    ansiDark("13", "5"),
+	RosePineDawn.Name:    ansiLight("1", "5"),
+	SolarizedDark.Name:   ansiDark("13", "12"),
+	SolarizedLight.Name:  ansiLight("5", "4"),
+	BruEspresso.Name:     ansiDark("3", "12"),
+	BruLatte.Name:        ansiLight("3", "4"),
+	JoziNights.Name:      ansiDark("13", "5"),
+	JoziMorning.Name:     ansiLight("5", "4"),
+	JoziMidnight.Name:    ansiDark("13", "5"),
+	OkabeItoDark.Name:    ansiDark("13", "12"),
+	OkabeItoLight.Name:   ansiLight("5", "4"),
+}
+
+// ForProfile returns t as it is best shown with the colors of profile:
+// unchanged in truecolor, and its hand-picked palette in 256 or 16
+// colors. Profiles without color get t too; the renderer strips it.
+func (t Theme) ForProfile(profile colorprofile.Profile) Theme {
+	var p palette
+	var ok bool
+	switch profile {
+	case colorprofile.ANSI256:
+		p, ok = palettes256[t.Name]
+	case colorprofile.ANSI:
+		p, ok = palettes16[t.Name]
+	}
+	if !ok {
+		return t
+	}
+	return p.theme(t.Name)
+}
@@ -223,6 +223,43 @@ var (
 		Highlight:    lipgloss.Color("#1a1b26"),
 		Shadow:       lipgloss.Color("#040406"),
 	}
+
+	// The Okabe-Ito themes take their states and highlight colors from
+	// Okabe and Ito's palette, which stays distinct under the common
+	// forms of color blindness: error is vermillion, success bluish
+	// green, warning yellow (orange on light), with sky blue and reddish
+	// purple besides.
+	OkabeItoDark = Theme{
+		Name:         "Okabe-Ito Dark",
+		Primary:      lipgloss.Color("#e6e6e6"),
+		Secondary:    lipgloss.Color("#b8bcc6"),
+		Accent:       lipgloss.Color("#cc79a7"),
+		Muted:        lipgloss.Color("#7d8590"),
+		Error:        lipgloss.Color("#d55e00"),
+		Success:      lipgloss.Color("#009e73"),
+		Warning:      lipgloss.Color("#f0e442"),
+		Border:       lipgloss.Color("#3a3f4b"),
+		BorderActive: lipgloss.Color("#56b4e9"),
+		Background:   lipgloss.Color("#1c1f26"),
+		Highlight:    lipgloss.Color("#2a2f3a"),
+		Shadow:       lipgloss.Color("#0d0f13"),
+	}
+
+	OkabeItoLight = Theme{
+		Name:         "Okabe-Ito Light",
+		Primary:      lipgloss.Color("#1f2328"),
+		Secondary:    lipgloss.Color("#3d434c"),
+		Accent:       lipgloss.Color("#cc79a7"),
+		Muted:        lipgloss.Color("#6e7681"),
+		Error:        lipgloss.Color("#d55e00"),
+		Success:      lipgloss.Color("#009e73"),
+		Warning:      lipgloss.Color("#e69f00"),
+		Border:       lipgloss.Color("#d0d7de"),
+		BorderActive: lipgloss.Color("#0072b2"),
+		Background:   lipgloss.Color("#f7f7f5"),
+		Highlight:    lipgloss.Color("#e6e8eb"),
+		Shadow:       lipgloss.Color("#c8ccd2"),
+	}
 )
 
 // AllThemes returns a list of all available themes
@@ -240,6 +277,8 @@ func AllThemes() []Theme {
 		JoziNights,
 		JoziMorning,
 		JoziMidnight,
+		OkabeItoDark,
+		OkabeItoLight,
 	}
 }
 
@@ -258,6 +297,8 @@ func GetTheme(name string) Theme {
 		"jozi-nights":      JoziNights,
 		"jozi-morning":     JoziMorning,
 		"jozi-midnight":    JoziMidnight,
+		"okabe-ito-dark":   OkabeItoDark,
+		"okabe-ito-light":  OkabeItoLight,
 	}
 
 	if theme, ok := themes[name]; ok {
@@ -265,3 +306,49 @@ func GetTheme(name string) Theme {
 	}
 	return CatppuccinMocha
 }
+
+// Tint mixes amount (0 to 1) of c into base, for a background that takes
+// on a color without drowning the text on it.
+func Tint(base, c color.Color, amount float64) color.Color {
+	if base == nil || c == nil {
+		return base
+	}
+	amount = min(max(amount, 0), 1)
+	br, bg, bb, _ := base.RGBA()
+	cr, cg, cb, _ := c.RGBA()
+	mix := func(b, c uint32) uint8 {
+		return uint8(float64(b>>8)*(1-amount) + float64(c>>8)*amount)
+	}
+	return color.RGBA{R: mix(br, cr), G: mix(bg, cg), B: mix(bb, cb), A: 255}
+}
+
+// Warm returns t with every color shifted toward warmer tones, cutting blue
+// light for night reading. strength runs from 0 (unchanged) to 1 (strongest).
+func (t Theme) Warm(strength float64) Theme {
+	strength = min(max(strength, 0), 1)
+	warm := func(c color.Color) color.Color {
+		if c == nil {
+			return nil
+		}
+		r, g, b, a := c.RGBA()
+		rf, gf, bf := float64(r>>8), float64(g>>8), float64(b>>8)
+		rf += (255 - rf) * 0.08 * strength
+		gf *= 1 - 0.12*strength
+		bf *= 1 - 0.45*strength
+		return color.RGBA{R: uint8(rf), G: uint8(gf), B: uint8(bf), A: uint8(a >> 8)}
+	}
+
+	t.Primary = warm(t.Primary)
+	t.Secondary = warm(t.Secondary)
+	t.Accent = warm(t.Accent)
+	t.Muted = warm(t.Muted)
+	t.Error = warm(t.Error)
+	t.Success = warm(t.Success)
+	t.Warning = warm(t.Warning)
+	t.Border = warm(t.Border)
+	t.BorderActive = warm(t.BorderActive)
+	t.Background = warm(t.Background)
+	t.Highlight = warm(t.Highlight)
+	t.Shadow = warm(t.Shadow)
+	return t
+}
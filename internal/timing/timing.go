@@ -0,0 +1,70 @@
+// Package timing reads verse timings for chapter audio: the offset at
+// which each verse starts, so the reader can highlight the verse being
+// spoken and follow along.
+//
+// Timings live in <config dir>/sword-tui/timings/<TRANSLATION>/BB-CCC.json
+// (book and chapter numbers, zero-padded) as an array of
+// {"verse": 1, "start": 0.0} objects, start in seconds.
+package timing
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"sword-tui/internal/settings"
+)
+
+// Start is when a verse begins in the chapter's audio.
+type Start struct {
+	Verse int
+	At    time.Duration
+}
+
+// Chapter holds the verse starts of one chapter, in playback order.
+type Chapter struct {
+	Starts []Start
+}
+
+// Load reads the timings of a chapter. It returns nil and no error when
+// the chapter has none.
+func Load(translation string, book, chapter int) (*Chapter, error) {
+	dir, err := settings.Dir()
+	if err != nil {
+		return nil, err
+	}
+	p := filepath.Join(dir, "timings", translation, fmt.Sprintf("%02d-%03d.json", book, chapter))
+	data, err := os.ReadFile(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var raw []struct {
+		Verse int     `json:"verse"`
+		Start float64 `json:"start"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%s: %w", p, err)
+	}
+	c := &Chapter{Starts: make([]Start, len(raw))}
+	for i, r := range raw {
+		c.Starts[i] = Start{Verse: r.Verse, At: time.Duration(r.Start * float64(time.Second))}
+	}
+	sort.SliceStable(c.Starts, func(i, j int) bool { return c.Starts[i].At < c.Starts[j].At })
+	return c, nil
+}
+
+// VerseAt returns the verse being spoken at pos, or 0 before the first
+// verse starts.
+func (c *Chapter) VerseAt(pos time.Duration) int {
+	i := sort.Search(len(c.Starts), func(i int) bool { return c.Starts[i].At > pos })
+	if i == 0 {
+		return 0
+	}
+	return c.Starts[i-1].Verse
+}
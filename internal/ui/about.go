@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"sword-tui/internal/locale"
+	"sword-tui/internal/version"
+
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// aboutWidth is the About panel's outer width.
+func (m Model) aboutWidth() int {
+	width := 64
+	if m.width-leftPaneOuterWidth-6 < width {
+		width = max(m.width-leftPaneOuterWidth-6, 40)
+	}
+	return width
+}
+
+// openAbout shows the About page from the top.
+func (m *Model) openAbout() {
+	m.mode = modeAbout
+	m.layoutAbout()
+	m.about.GotoTop()
+}
+
+// layoutAbout fits the About page's viewport to the terminal, keeping
+// the scroll position where it can.
+func (m *Model) layoutAbout() {
+	padY, padX := m.panelPadding()
+	w := m.aboutWidth() - 2 - 2*padX
+	// Leave a couple of lines around the panel, then take off its
+	// border, padding and the scroll line.
+	h := m.height - headerOuterHeight - statusOuterHeight - 4 - 2 - 2*padY - 1
+	h = max(h, 5)
+
+	content, sections := m.aboutContent(w)
+	offset := m.about.YOffset()
+	m.about = viewport.New(viewport.WithWidth(w), viewport.WithHeight(min(h, lipgloss.Height(content))))
+	m.about.Style = lipgloss.NewStyle().Background(m.currentTheme.Background)
+	m.about.SetContent(content)
+	m.about.SetYOffset(offset)
+	m.aboutSections = sections
+}
+
+// aboutContent renders the About page width cells wide and returns the
+// lines its sections start on.
+func (m Model) aboutContent(width int) (string, []int) {
+	bg := m.currentTheme.Background
+	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(m.styled())
+	sectionStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Primary).Background(bg)
+	labelStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Secondary).Background(bg).Bold(m.styled())
+	valueStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Primary).Background(bg)
+	linkStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Underline(m.styled())
+	pad := lipgloss.NewStyle().Background(bg).Width(width).MaxWidth(width)
+
+	var lines []string
+	add := func(s string) { lines = append(lines, pad.Render(s)) }
+	labelW := 0
+	for _, l := range []string{"Version:", "Build:", "Repo:", "API:", "License:", "Changes:"} {
+		labelW = max(labelW, lipgloss.Width(locale.T(l))+1)
+	}
+	label := func(s string) string { return labelStyle.Render(fmt.Sprintf("%-*s", labelW, locale.T(s))) }
+
+	add(titleStyle.Render("sword-tui"))
+	add(sectionStyle.Render(locale.T("A terminal-based Bible application")))
+	add("")
+	add(label("Version:") + valueStyle.Render(version.Version))
+	add(label("Build:") + valueStyle.Render(version.BuildNumber))
+	add("")
+	add(label("Repo:") + linkStyle.Render("github.com/kmf/sword-tui"))
+	add(label("API:") + valueStyle.Render(m.client.Provider().Name()))
+	add(label("License:") + valueStyle.Render("GPL-2.0-or-later"))
+	add(label("Changes:") + valueStyle.Render(locale.T("press n")))
+
+	sections := []int{0}
+	keyW := KeyWidth() + 2
+	for _, g := range keymap {
+		add("")
+		sections = append(sections, len(lines))
+		add(titleStyle.Render(locale.T(g.Title)))
+		for _, k := range g.Keys {
+			add(labelStyle.Render(fmt.Sprintf("%-*s", keyW, k.Keys)) + sectionStyle.Render(locale.T(k.Desc)))
+		}
+	}
+	return strings.Join(lines, "\n"), sections
+}
+
+// updateAbout handles keys on the About page: j/k and the page keys
+// scroll, [ and ] jump between sections.
+func (m Model) updateAbout(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "?", "r":
+		m.mode = modeReader
+	case "n":
+		m.showWhatsNew(version.Since(""))
+	case "down", "j":
+		m.about.ScrollDown(1)
+	case "up", "k":
+		m.about.ScrollUp(1)
+	case "pgdown", "space", "f":
+		m.about.PageDown()
+	case "pgup", "b":
+		m.about.PageUp()
+	case "ctrl+d":
+		m.about.HalfPageDown()
+	case "ctrl+u":
+		m.about.HalfPageUp()
+	case "home", "g":
+		m.about.GotoTop()
+	case "end", "G":
+		m.about.GotoBottom()
+	case "]", "tab":
+		for _, line := range m.aboutSections {
+			if line > m.about.YOffset() {
+				m.about.SetYOffset(line)
+				break
+			}
+		}
+	case "[", "shift+tab":
+		for i := len(m.aboutSections) - 1; i >= 0; i-- {
+			if line := m.aboutSections[i]; line < m.about.YOffset() {
+				m.about.SetYOffset(line)
+				break
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m Model) renderAbout() string {
+	bg := m.currentTheme.Background
+
+	containerStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.currentTheme.BorderActive).
+		BorderBackground(bg).
+		Background(bg).
+		Width(m.aboutWidth()).
+		Padding(m.panelPadding())
+
+	body := m.about.View()
+	if m.about.TotalLineCount() > m.about.Height() {
+		// Where the page is scrolled to, since it doesn't all fit.
+		mutedStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(m.styled())
+		var more string
+		switch {
+		case m.about.AtTop():
+			more = "↓ more"
+		case m.about.AtBottom():
+			more = "↑ more"
+		default:
+			more = fmt.Sprintf("↕ %d%%", int(m.about.ScrollPercent()*100))
+		}
+		body += "\n" + mutedStyle.Render(more)
+	}
+	return containerStyle.Render(body)
+}
@@ -0,0 +1,372 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/locale"
+	"sword-tui/internal/notes"
+	"sword-tui/internal/report"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// activityWindow is how many rows the activity overlay lists at once.
+const activityWindow = 14
+
+// heatWidth is the width of an activity row's heat bar.
+const heatWidth = 12
+
+// activityRow is how much attention a chapter (or, grouped, a book) got.
+// For a book, chapter is its busiest chapter.
+type activityRow struct {
+	book, chapter                        int
+	visits, bookmarks, notes, highlights int
+}
+
+// score weighs deliberate marks above simply opening a chapter.
+func (r activityRow) score() int {
+	return r.visits + 3*(r.bookmarks+r.notes) + 2*r.highlights
+}
+
+func (r activityRow) label(byBook bool) string {
+	name := fmt.Sprint(r.book)
+	if b, ok := api.CanonicalBook(r.book); ok {
+		name = b.Name
+	}
+	if byBook {
+		return name
+	}
+	return fmt.Sprintf("%s %d", name, r.chapter)
+}
+
+// collectActivity tallies visits, bookmarks, notes and highlights per
+// chapter, or per book when byBook is set, busiest first.
+func (m Model) collectActivity(byBook bool) ([]activityRow, error) {
+	type key struct{ book, chapter int }
+	rows := map[key]*activityRow{}
+	row := func(book, chapter int) *activityRow {
+		k := key{book, chapter}
+		if rows[k] == nil {
+			rows[k] = &activityRow{book: book, chapter: chapter}
+		}
+		return rows[k]
+	}
+
+	if m.visitStore != nil {
+		for _, v := range m.visitStore.Chapters {
+			row(v.Book, v.Chapter).visits += v.Count
+		}
+	}
+	if m.bookmarkStore != nil {
+		for _, b := range m.bookmarkStore.Bookmarks {
+			row(b.Book, b.Chapter).bookmarks++
+		}
+	}
+	if m.highlightStore != nil {
+		for _, h := range m.highlightStore.Highlights {
+			row(h.Book, h.Chapter).highlights++
+		}
+	}
+	all, err := notes.All()
+	for _, n := range all {
+		row(n.Book, n.Chapter).notes++
+	}
+
+	var out []activityRow
+	if byBook {
+		books := map[int]*activityRow{}
+		for _, r := range rows {
+			b := books[r.book]
+			if b == nil {
+				b = &activityRow{book: r.book, chapter: r.chapter}
+				books[r.book] = b
+			} else if busiest := rows[key{r.book, b.chapter}]; r.score() > busiest.score() ||
+				(r.score() == busiest.score() && r.chapter < b.chapter) {
+				b.chapter = r.chapter
+			}
+			b.visits += r.visits
+			b.bookmarks += r.bookmarks
+			b.notes += r.notes
+			b.highlights += r.highlights
+		}
+		for _, b := range books {
+			out = append(out, *b)
+		}
+	} else {
+		for _, r := range rows {
+			out = append(out, *r)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.score() != b.score() {
+			return a.score() > b.score()
+		}
+		if a.book != b.book {
+			return a.book < b.book
+		}
+		return a.chapter < b.chapter
+	})
+	return out, err
+}
+
+// openActivity snapshots the tallies and opens the overlay.
+func (m *Model) openActivity() {
+	rows, err := m.collectActivity(m.activityByBook)
+	if err != nil {
+		m.err = err
+	}
+	m.activityRows = rows
+	m.activitySelected = 0
+	m.weekReport = nil
+	m.mode = modeActivity
+}
+
+// maxReadingStretch caps the reading time credited to one chapter, so a
+// reader left open overnight doesn't count as hours of study.
+const maxReadingStretch = 10 * time.Minute
+
+// recordVisit counts the chapter on screen as visited, once per arrival,
+// and credits the time spent on the previous one.
+func (m *Model) recordVisit() {
+	here := [2]int{m.currentBook, m.currentChapter}
+	if m.visitStore == nil || here == m.lastVisit {
+		return
+	}
+	m.creditReading()
+	m.lastVisit = here
+	m.visitStore.Record(m.currentBook, m.currentChapter, time.Now())
+	if err := m.visitStore.Save(); err != nil {
+		m.err = err
+	}
+	m.tickPlans()
+}
+
+// creditReading logs the time since the current chapter was opened as
+// reading time. The caller saves the store.
+func (m *Model) creditReading() {
+	now := time.Now()
+	if m.visitStore != nil && !m.readingSince.IsZero() {
+		m.visitStore.AddReading(min(now.Sub(m.readingSince), maxReadingStretch), now)
+	}
+	m.readingSince = now
+}
+
+// loadWeekReport snapshots the summary of the week activityWeeksAgo back.
+func (m *Model) loadWeekReport() {
+	w, err := report.ForWeek(m.cfg.Reader, time.Now().AddDate(0, 0, -7*m.activityWeeksAgo))
+	if err != nil {
+		m.err = err
+	}
+	m.weekReport = &w
+}
+
+// updateWeekReport handles keys while the activity overlay shows the
+// weekly summary.
+func (m Model) updateWeekReport(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "I", "q":
+		m.mode = modeReader
+	case "w":
+		m.weekReport = nil
+	case "left", "h":
+		m.activityWeeksAgo++
+		m.loadWeekReport()
+	case "right", "l":
+		if m.activityWeeksAgo > 0 {
+			m.activityWeeksAgo--
+			m.loadWeekReport()
+		}
+	case "m":
+		dir, err := os.Getwd()
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		out := filepath.Join(dir, "reading-week-"+m.weekReport.Start.Format("2006-01-02")+".md")
+		if err := os.WriteFile(out, []byte(m.weekReport.Markdown()), 0o644); err != nil {
+			m.err = err
+			return m, nil
+		}
+		return m, m.flash(locale.Tf("exported %s", out))
+	}
+	return m, nil
+}
+
+// updateActivity handles keys while the activity overlay is open.
+func (m Model) updateActivity(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.weekReport != nil {
+		return m.updateWeekReport(msg)
+	}
+	n := len(m.activityRows)
+	switch msg.String() {
+	case "w":
+		m.activityWeeksAgo = 0
+		m.loadWeekReport()
+	case "esc", "I", "q":
+		m.mode = modeReader
+	case "up", "k":
+		if m.activitySelected > 0 {
+			m.activitySelected--
+		}
+	case "down", "j":
+		if m.activitySelected < n-1 {
+			m.activitySelected++
+		}
+	case "tab", "g":
+		m.activityByBook = !m.activityByBook
+		m.openActivity()
+	case "enter":
+		if m.activitySelected < n {
+			r := m.activityRows[m.activitySelected]
+			m.mode = modeReader
+			m.currentBook = r.book
+			m.currentChapter = r.chapter
+			if b, ok := api.CanonicalBook(r.book); ok {
+				m.currentBookName = b.Name
+			}
+			for _, b := range m.books {
+				if b.BookID == r.book {
+					m.currentBookName = b.Name
+					break
+				}
+			}
+			m.highlightedVerseStart = 0
+			m.highlightedVerseEnd = 0
+			m.loading = true
+			return m, loadChapter(m.client, m.gen.nextContent(), m.selectedTranslation, m.currentBook, m.currentChapter)
+		}
+	}
+	return m, nil
+}
+
+// heatStyle colors a heat bar by how close it is to the busiest row.
+func (m Model) heatStyle(ratio float64) lipgloss.Style {
+	color := m.currentTheme.Accent
+	switch {
+	case ratio > 2.0/3:
+		color = m.currentTheme.Error
+	case ratio > 1.0/3:
+		color = m.currentTheme.Warning
+	}
+	return lipgloss.NewStyle().Foreground(color).Background(m.currentTheme.Background)
+}
+
+func (m Model) renderActivity() string {
+	bg := m.currentTheme.Background
+
+	maxAvail := m.width - leftPaneOuterWidth - 8
+	width := maxAvail
+	if width > 90 {
+		width = 90
+	}
+	if width < 40 {
+		width = 40
+	}
+	innerW := width - 6
+
+	containerStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.currentTheme.BorderActive).
+		BorderBackground(bg).
+		Background(bg).
+		Width(width).
+		Padding(m.panelPadding())
+
+	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(m.styled())
+	selectedStyle := lipgloss.NewStyle().Foreground(bg).Background(m.currentTheme.Accent).Bold(m.styled())
+	normalStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Primary).Background(bg)
+	countStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Secondary).Background(bg)
+	mutedStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(m.styled())
+
+	if m.weekReport != nil {
+		return containerStyle.Render(m.renderWeekReport(innerW))
+	}
+
+	grouping := "by chapter · tab for books"
+	if m.activityByBook {
+		grouping = "by book · tab for chapters"
+	}
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(locale.T("Activity")) + mutedStyle.Render("  "+grouping) + m.panelTitleGap())
+
+	rows := m.activityRows
+	if len(rows) == 0 {
+		content.WriteString(mutedStyle.Render(locale.T("Nothing yet — chapters you read, bookmark, note or highlight show up here.")))
+		return containerStyle.Render(content.String())
+	}
+
+	top := max(rows[0].score(), 1)
+	start := m.overlayWindowStart(m.activitySelected, len(rows), activityWindow)
+	end := min(start+activityWindow, len(rows))
+	if start > 0 {
+		content.WriteString(mutedStyle.Render(fmt.Sprintf("  ↑ %d more", start)) + "\n")
+	}
+	for i := start; i < end; i++ {
+		r := rows[i]
+		ratio := float64(r.score()) / float64(top)
+		filled := max(int(ratio*heatWidth+0.5), 1)
+		bar := strings.Repeat("█", filled) + strings.Repeat("░", heatWidth-filled)
+		counts := fmt.Sprintf("%4d↻ %3d⚑ %3d✎ %3d▌", r.visits, r.bookmarks, r.notes, r.highlights)
+		label := clipText(r.label(m.activityByBook), innerW-2-heatWidth-2-lipgloss.Width(counts)-2)
+		pad := max(innerW-2-lipgloss.Width(label)-2-heatWidth-2-lipgloss.Width(counts), 1)
+
+		if i == m.activitySelected {
+			line := "▸ " + label + strings.Repeat(" ", pad) + bar + "  " + counts
+			if w := lipgloss.Width(line); w < innerW {
+				line += strings.Repeat(" ", innerW-w)
+			}
+			content.WriteString(selectedStyle.Render(line) + "\n")
+			continue
+		}
+		content.WriteString(normalStyle.Render("  "+label+strings.Repeat(" ", pad)) +
+			m.heatStyle(ratio).Render(bar) + normalStyle.Render("  ") + countStyle.Render(counts) + "\n")
+	}
+	if end < len(rows) {
+		content.WriteString(mutedStyle.Render(fmt.Sprintf("  ↓ %d more", len(rows)-end)) + "\n")
+	}
+	content.WriteString("\n" + mutedStyle.Render("↻ visits  ⚑ bookmarks  ✎ notes  ▌ highlights"))
+
+	return containerStyle.Render(content.String())
+}
+
+// renderWeekReport shows the weekly summary inside the activity overlay.
+func (m Model) renderWeekReport(innerW int) string {
+	bg := m.currentTheme.Background
+	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(m.styled())
+	headStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Warning).Background(bg).Bold(m.styled())
+	normalStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Primary).Background(bg)
+	mutedStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(m.styled())
+
+	w := m.weekReport
+	var content strings.Builder
+	last := w.End().AddDate(0, 0, -1)
+	content.WriteString(titleStyle.Render("Week of "+w.Start.Format("2 Jan")+" – "+last.Format("2 Jan 2006")) +
+		mutedStyle.Render("  ←/→ week · m export · w back") + m.panelTitleGap())
+
+	// Skip the document title; the panel has its own.
+	lines := strings.Split(strings.TrimRight(w.Markdown(), "\n"), "\n")[2:]
+	shown := 0
+	for _, l := range lines {
+		if shown == activityWindow+4 {
+			content.WriteString(mutedStyle.Render("… export with m for the rest") + "\n")
+			break
+		}
+		l = strings.NewReplacer("**", "").Replace(l)
+		switch {
+		case strings.HasPrefix(l, "## "):
+			content.WriteString(headStyle.Render(strings.TrimPrefix(l, "## ")) + "\n")
+		default:
+			content.WriteString(normalStyle.Render(clipText(l, innerW)) + "\n")
+		}
+		shown++
+	}
+	return strings.TrimSuffix(content.String(), "\n")
+}
@@ -0,0 +1,345 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/locale"
+	"sword-tui/internal/notes"
+	"sword-tui/internal/reference"
+	"sword-tui/internal/textnorm"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// annotationWindow is how many annotations the browser lists at once.
+const annotationWindow = 12
+
+// annotationListRow is the panel row (after the title) of the first list
+// entry: the query input and a blank line come first.
+const annotationListRow = 2
+
+// annotation is a note, bookmark or highlight as listed by the browser.
+type annotation struct {
+	kind                 string // "note", "bookmark" or "highlight"
+	book, chapter        int
+	verseStart, verseEnd int
+	text                 string // note text, bookmark collection or highlight color
+	color                string
+	tags                 []string
+	when                 time.Time
+}
+
+func (a annotation) reference() string {
+	name := fmt.Sprint(a.book)
+	if b, ok := api.CanonicalBook(a.book); ok {
+		name = b.Name
+	}
+	switch {
+	case a.verseStart == 0:
+		return fmt.Sprintf("%s %d", name, a.chapter)
+	case a.verseEnd > a.verseStart:
+		return fmt.Sprintf("%s %d:%d-%d", name, a.chapter, a.verseStart, a.verseEnd)
+	}
+	return fmt.Sprintf("%s %d:%d", name, a.chapter, a.verseStart)
+}
+
+// collectAnnotations gathers every note, bookmark and highlight in
+// canonical order, notes first within a verse.
+func (m Model) collectAnnotations() ([]annotation, error) {
+	var all []annotation
+	ns, err := notes.All()
+	for _, n := range ns {
+		all = append(all, annotation{kind: "note", book: n.Book, chapter: n.Chapter, verseStart: n.Verse, verseEnd: n.Verse, text: strings.ReplaceAll(n.Text, "\n", " "), tags: n.Tags(), when: n.Time})
+	}
+	if m.bookmarkStore != nil {
+		for _, b := range m.bookmarkStore.Bookmarks {
+			all = append(all, annotation{kind: "bookmark", book: b.Book, chapter: b.Chapter, verseStart: b.VerseStart, verseEnd: b.VerseEnd, text: b.Collection, when: b.Added})
+		}
+	}
+	if m.highlightStore != nil {
+		for _, h := range m.highlightStore.Highlights {
+			all = append(all, annotation{kind: "highlight", book: h.Book, chapter: h.Chapter, verseStart: h.Verse, verseEnd: h.Verse, text: m.highlightLabel(h.Color), color: h.Color, when: h.Added})
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		a, b := all[i], all[j]
+		if a.book != b.book {
+			return a.book < b.book
+		}
+		if a.chapter != b.chapter {
+			return a.chapter < b.chapter
+		}
+		return a.verseStart < b.verseStart
+	})
+	return all, err
+}
+
+// annotationFilter is a parsed browser query. Plain words must all appear
+// in the reference or text; the rest narrow by field:
+//
+//	book:john  #tag  color:yellow  kind:note  since:2026-01-01  until:2026-06-30
+type annotationFilter struct {
+	words        []string
+	book         int
+	tags         []string
+	color, kind  string
+	since, until time.Time
+}
+
+func parseAnnotationFilter(q string) annotationFilter {
+	var f annotationFilter
+	for _, tok := range strings.Fields(textnorm.Fold(q)) {
+		key, val, hasKey := strings.Cut(tok, ":")
+		switch {
+		case strings.HasPrefix(tok, "#") && len(tok) > 1:
+			f.tags = append(f.tags, tok[1:])
+		case hasKey && key == "book" && val != "":
+			if id, _, ok := reference.MatchBook(val, api.CanonicalBooks()); ok {
+				f.book = id
+			} else {
+				f.book = -1 // matches nothing
+			}
+		case hasKey && key == "color":
+			f.color = val
+		case hasKey && (key == "kind" || key == "is"):
+			f.kind = strings.TrimSuffix(val, "s")
+		case hasKey && (key == "since" || key == "after"):
+			f.since, _ = time.ParseInLocation("2006-01-02", val, time.Local)
+		case hasKey && (key == "until" || key == "before"):
+			if t, err := time.ParseInLocation("2006-01-02", val, time.Local); err == nil {
+				f.until = t.AddDate(0, 0, 1) // inclusive of that day
+			}
+		default:
+			f.words = append(f.words, tok)
+		}
+	}
+	return f
+}
+
+func (f annotationFilter) match(a annotation) bool {
+	if f.book != 0 && a.book != f.book {
+		return false
+	}
+	if f.kind != "" && a.kind != f.kind {
+		return false
+	}
+	if f.color != "" && a.color != f.color && !(a.kind == "highlight" && strings.EqualFold(a.text, f.color)) {
+		return false
+	}
+	if !f.since.IsZero() && a.when.Before(f.since) {
+		return false
+	}
+	if !f.until.IsZero() && !a.when.Before(f.until) {
+		return false
+	}
+	for _, t := range f.tags {
+		found := false
+		for _, at := range a.tags {
+			if textnorm.Fold(at) == t {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	hay := textnorm.Fold(a.reference() + " " + a.text)
+	for _, w := range f.words {
+		if !strings.Contains(hay, w) {
+			return false
+		}
+	}
+	return true
+}
+
+// filteredAnnotations applies the query to the snapshot taken when the
+// browser opened.
+func (m Model) filteredAnnotations() []annotation {
+	f := parseAnnotationFilter(m.annotationQuery.Value())
+	var out []annotation
+	for _, a := range m.annotations {
+		if f.match(a) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// openAnnotations snapshots every annotation and opens the browser.
+func (m *Model) openAnnotations() tea.Cmd {
+	all, err := m.collectAnnotations()
+	if err != nil {
+		m.err = err
+	}
+	m.annotations = all
+	m.annotationSelected = 0
+	m.annotationQuery.SetValue("")
+	m.mode = modeAnnotations
+	return m.annotationQuery.Focus()
+}
+
+// updateAnnotations handles keys while the browser is open. Typing edits
+// the query; arrows move the selection.
+func (m Model) updateAnnotations(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	list := m.filteredAnnotations()
+	switch msg.String() {
+	case "esc":
+		m.mode = modeReader
+		m.annotationQuery.Blur()
+		return m, nil
+	case "up", "ctrl+p":
+		if m.annotationSelected > 0 {
+			m.annotationSelected--
+		}
+		return m, nil
+	case "down", "ctrl+n":
+		if m.annotationSelected < len(list)-1 {
+			m.annotationSelected++
+		}
+		return m, nil
+	case "enter":
+		if m.annotationSelected >= len(list) {
+			return m, nil
+		}
+		a := list[m.annotationSelected]
+		m.mode = modeReader
+		m.annotationQuery.Blur()
+		m.currentBook = a.book
+		m.currentChapter = a.chapter
+		m.highlightedVerseStart = a.verseStart
+		m.highlightedVerseEnd = a.verseEnd
+		if b, ok := api.CanonicalBook(a.book); ok {
+			m.currentBookName = b.Name
+		}
+		for _, b := range m.books {
+			if b.BookID == a.book {
+				m.currentBookName = b.Name
+				break
+			}
+		}
+		m.loading = true
+		return m, loadChapter(m.client, m.gen.nextContent(), m.selectedTranslation, m.currentBook, m.currentChapter)
+	}
+
+	var cmd tea.Cmd
+	m.annotationQuery, cmd = m.annotationQuery.Update(msg)
+	m.annotationSelected = 0
+	return m, cmd
+}
+
+func (m Model) renderAnnotations() string {
+	bg := m.currentTheme.Background
+
+	maxAvail := m.width - leftPaneOuterWidth - 8
+	width := maxAvail
+	if width > 90 {
+		width = 90
+	}
+	if width < 40 {
+		width = 40
+	}
+	innerW := width - 6
+
+	containerStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.currentTheme.BorderActive).
+		BorderBackground(bg).
+		Background(bg).
+		Width(width).
+		Padding(m.panelPadding())
+
+	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(m.styled())
+	selectedStyle := lipgloss.NewStyle().Foreground(bg).Background(m.currentTheme.Accent).Bold(m.styled())
+	normalStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Primary).Background(bg)
+	textStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Secondary).Background(bg)
+	mutedStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(m.styled())
+
+	list := m.filteredAnnotations()
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(locale.T("Annotations")) +
+		mutedStyle.Render(fmt.Sprintf("  %d of %d", len(list), len(m.annotations))) + m.panelTitleGap())
+
+	ti := m.annotationQuery
+	ti.SetStyles(m.themedInputStyles())
+	ti.SetWidth(innerW - 2)
+	content.WriteString(ti.View() + "\n\n")
+
+	if len(list) == 0 {
+		if len(m.annotations) == 0 {
+			content.WriteString(mutedStyle.Render(locale.T("No annotations yet — b bookmarks, a notes, H highlights.")))
+		} else {
+			content.WriteString(mutedStyle.Render(locale.T("Nothing matches.")))
+		}
+		return containerStyle.Render(content.String())
+	}
+
+	start := m.overlayWindowStart(m.annotationSelected, len(list), annotationWindow)
+	end := min(start+annotationWindow, len(list))
+	if start > 0 {
+		content.WriteString(mutedStyle.Render(fmt.Sprintf("  ↑ %d more", start)) + "\n")
+	}
+	for i := start; i < end; i++ {
+		a := list[i]
+		var icon string
+		switch a.kind {
+		case "note":
+			icon = "✎"
+		case "bookmark":
+			icon = "⚑"
+		default:
+			icon = highlightMark(a.color)
+		}
+		ref := a.reference()
+		date := ""
+		if !a.when.IsZero() {
+			date = a.when.Format("2006-01-02")
+		}
+		textW := innerW - 2 - 2 - lipgloss.Width(ref) - 2 - len(date) - 1
+		text := a.text
+		if lipgloss.Width(text) > textW {
+			text = clipText(text, textW)
+		}
+		pad := max(innerW-2-2-lipgloss.Width(ref)-2-lipgloss.Width(text)-len(date), 1)
+
+		if i == m.annotationSelected {
+			line := "▸ " + icon + " " + ref + "  " + text + strings.Repeat(" ", pad) + date
+			if w := lipgloss.Width(line); w < innerW {
+				line += strings.Repeat(" ", innerW-w)
+			}
+			content.WriteString(selectedStyle.Render(line) + "\n")
+			continue
+		}
+		iconStyle := textStyle
+		if a.kind == "highlight" {
+			iconStyle = lipgloss.NewStyle().Foreground(m.highlightColor(a.color)).Background(bg)
+		} else if a.kind == "bookmark" {
+			iconStyle = lipgloss.NewStyle().Foreground(m.currentTheme.Warning).Background(bg)
+		}
+		content.WriteString(normalStyle.Render("  ") + iconStyle.Render(icon) + normalStyle.Render(" "+ref+"  ") +
+			textStyle.Render(text) + normalStyle.Render(strings.Repeat(" ", pad)) + mutedStyle.Render(date) + "\n")
+	}
+	if end < len(list) {
+		content.WriteString(mutedStyle.Render(fmt.Sprintf("  ↓ %d more", len(list)-end)) + "\n")
+	}
+
+	return containerStyle.Render(strings.TrimSuffix(content.String(), "\n"))
+}
+
+// clipText shortens s to at most n runes, ending in an ellipsis.
+func clipText(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	if n < 1 {
+		return ""
+	}
+	return string(r[:n-1]) + "…"
+}
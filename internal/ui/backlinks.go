@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/locale"
+	"sword-tui/internal/notes"
+	"sword-tui/internal/reference"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// Backlinks are the notes on other verses whose text mentions a verse,
+// found with the same reference detection as the note popup's links. The
+// reader's title counts them for the highlighted verse, and L lists them.
+
+// backlinkWindow is how many backlinks the popup lists at once.
+const backlinkWindow = 10
+
+// verseKey identifies a verse across the Bible.
+type verseKey struct{ book, chapter, verse int }
+
+// backlinkIndex maps each verse to the notes elsewhere that mention it.
+type backlinkIndex map[verseKey][]notes.Note
+
+// indexBacklinks reads every note and indexes the references in it. A
+// note that mentions its own verse doesn't link back to it, and one that
+// mentions a verse twice is listed once.
+func indexBacklinks() backlinkIndex {
+	all, _ := notes.All()
+	idx := backlinkIndex{}
+	for _, n := range all {
+		seen := map[verseKey]bool{}
+		for _, l := range reference.FindAll(n.Text, api.CanonicalBooks()) {
+			book, chapter, start, end, err := reference.Parse(l.Ref, api.CanonicalBooks())
+			if err != nil || start == 0 {
+				continue
+			}
+			for v := start; v <= max(end, start); v++ {
+				k := verseKey{book, chapter, v}
+				if seen[k] || k == (verseKey{n.Book, n.Chapter, n.Verse}) {
+					continue
+				}
+				seen[k] = true
+				idx[k] = append(idx[k], n)
+			}
+		}
+	}
+	return idx
+}
+
+// backlinksHere returns the notes that mention the highlighted verse.
+func (m Model) backlinksHere() []notes.Note {
+	if m.highlightedVerseStart == 0 {
+		return nil
+	}
+	return m.backlinks[verseKey{m.currentBook, m.currentChapter, m.highlightedVerseStart}]
+}
+
+// renderBacklinkCount is the title's "↩ 2 notes" for the highlighted
+// verse, or "" when nothing mentions it.
+func (m Model) renderBacklinkCount() string {
+	n := len(m.backlinksHere())
+	if n == 0 || m.mode != modeReader {
+		return ""
+	}
+	label := "notes"
+	if n == 1 {
+		label = "note"
+	}
+	style := lipgloss.NewStyle().Foreground(m.currentTheme.Success).Background(m.currentTheme.Background)
+	return style.Render(fmt.Sprintf("  ↩ %d %s", n, label))
+}
+
+// noteRef formats where a note is, e.g. "John 3:16".
+func noteRef(n notes.Note) string {
+	name := fmt.Sprint(n.Book)
+	if b, ok := api.CanonicalBook(n.Book); ok {
+		name = b.Name
+	}
+	return fmt.Sprintf("%s %d:%d", name, n.Chapter, n.Verse)
+}
+
+// openBacklinks opens the popup listing the notes that mention the
+// highlighted verse.
+func (m *Model) openBacklinks() tea.Cmd {
+	if m.highlightedVerseStart == 0 {
+		return m.flash("select a verse to see what links to it")
+	}
+	if len(m.backlinksHere()) == 0 {
+		return m.flash(locale.Tf("no notes mention %s %d:%d", m.currentBookName, m.currentChapter, m.highlightedVerseStart))
+	}
+	m.backlinkVerse = m.highlightedVerseStart
+	m.backlinkSelected = 0
+	m.pushOverlay(overlay{
+		name:  overlayBacklinks,
+		place: placeCenter,
+		dim:   true,
+		view:  Model.renderBacklinks,
+		key:   Model.updateBacklinks,
+	})
+	return nil
+}
+
+// shownBacklinks are the notes the popup lists.
+func (m Model) shownBacklinks() []notes.Note {
+	return m.backlinks[verseKey{m.currentBook, m.currentChapter, m.backlinkVerse}]
+}
+
+func (m Model) updateBacklinks(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	list := m.shownBacklinks()
+	switch msg.String() {
+	case "down", "j", "tab":
+		m.backlinkSelected = min(m.backlinkSelected+1, len(list)-1)
+	case "up", "k", "shift+tab":
+		m.backlinkSelected = max(m.backlinkSelected-1, 0)
+	case "enter":
+		if m.backlinkSelected < len(list) {
+			ref := noteRef(list[m.backlinkSelected])
+			if name, ok := m.missingBook(ref); ok {
+				return m, m.flash(locale.Tf("%s is not in %s", name, m.selectedTranslation)), true
+			}
+			m.closeOverlay(overlayBacklinks)
+			next, cmd := m.gotoReference(ref)
+			return next, cmd, true
+		}
+		m.closeOverlay(overlayBacklinks)
+	case "q":
+		m.closeOverlay(overlayBacklinks)
+	case "esc":
+		return m, nil, false
+	}
+	// Everything else is swallowed while the popup has the focus.
+	return m, nil, true
+}
+
+func (m Model) renderBacklinks() string {
+	bg := m.currentTheme.Background
+	w, _ := m.popupSize()
+	_, padX := m.panelPadding()
+
+	containerStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.currentTheme.BorderActive).
+		BorderBackground(bg).
+		Background(bg).
+		Width(w + 2 + 2*padX).
+		Padding(m.panelPadding())
+	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(m.styled())
+	selectedStyle := lipgloss.NewStyle().Foreground(bg).Background(m.currentTheme.Accent).Bold(m.styled())
+	refStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Primary).Background(bg)
+	textStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Secondary).Background(bg)
+	mutedStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(m.styled())
+
+	list := m.shownBacklinks()
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(fmt.Sprintf("Notes mentioning %s %d:%d", m.currentBookName, m.currentChapter, m.backlinkVerse)) +
+		mutedStyle.Render(fmt.Sprintf("  %d", len(list))) + m.panelTitleGap())
+
+	start := m.overlayWindowStart(m.backlinkSelected, len(list), backlinkWindow)
+	end := min(start+backlinkWindow, len(list))
+	if start > 0 {
+		content.WriteString(mutedStyle.Render(fmt.Sprintf("  ↑ %d more", start)) + "\n")
+	}
+	for i := start; i < end; i++ {
+		n := list[i]
+		ref := noteRef(n)
+		text := clipText(strings.ReplaceAll(n.Text, "\n", " "), max(w-2-lipgloss.Width(ref)-2, 1))
+		if i == m.backlinkSelected {
+			line := "▸ " + ref + "  " + text
+			line += strings.Repeat(" ", max(w-lipgloss.Width(line), 0))
+			content.WriteString(selectedStyle.Render(line) + "\n")
+			continue
+		}
+		content.WriteString(refStyle.Render("  "+ref+"  ") + textStyle.Render(text) + "\n")
+	}
+	if end < len(list) {
+		content.WriteString(mutedStyle.Render(fmt.Sprintf("  ↓ %d more", len(list)-end)) + "\n")
+	}
+	content.WriteString("\n" + mutedStyle.Render("↑↓ select  ·  ⏎ go to note  ·  esc close"))
+	return containerStyle.Render(content.String())
+}
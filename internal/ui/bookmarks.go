@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"sword-tui/internal/bookmarks"
+	"sword-tui/internal/locale"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// bookmarkWindow is how many bookmarks the bookmarks panel lists at once.
+const bookmarkWindow = 12
+
+// toggleBookmark bookmarks the highlighted verse range (or the whole
+// chapter when nothing is highlighted), or removes that bookmark if it
+// already exists.
+func (m *Model) toggleBookmark() tea.Cmd {
+	if m.currentVerses == nil || m.bookmarkStore == nil {
+		return nil
+	}
+	b := bookmarks.Bookmark{
+		Book:       m.currentBook,
+		BookName:   m.currentBookName,
+		Chapter:    m.currentChapter,
+		VerseStart: m.highlightedVerseStart,
+		VerseEnd:   m.highlightedVerseEnd,
+	}
+	added := m.bookmarkStore.Toggle(b)
+	if err := m.bookmarkStore.Save(); err != nil {
+		m.err = err
+		return nil
+	}
+	m.refreshContent()
+	if added {
+		return m.flash(locale.Tf("⚑ bookmarked %s", b.Reference()))
+	}
+	return m.flash(locale.Tf("removed bookmark %s", b.Reference()))
+}
+
+// updateBookmarks handles keys while the bookmarks panel is open.
+func (m Model) updateBookmarks(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	store := m.bookmarkStore
+	n := len(store.Bookmarks)
+
+	switch msg.String() {
+	case "esc", "B", "q":
+		m.mode = modeReader
+	case "up", "k":
+		if m.bookmarkSelected > 0 {
+			m.bookmarkSelected--
+		}
+	case "down", "j":
+		if m.bookmarkSelected < n-1 {
+			m.bookmarkSelected++
+		}
+	case "x":
+		if n > 0 {
+			store.Remove(m.bookmarkSelected)
+			if m.bookmarkSelected >= len(store.Bookmarks) && m.bookmarkSelected > 0 {
+				m.bookmarkSelected--
+			}
+			if err := store.Save(); err != nil {
+				m.err = err
+			}
+			m.refreshContent()
+		}
+	case "enter":
+		if m.bookmarkSelected < n {
+			b := store.Bookmarks[m.bookmarkSelected]
+			m.mode = modeReader
+			m.currentBook = b.Book
+			m.currentBookName = b.BookName
+			m.currentChapter = b.Chapter
+			m.highlightedVerseStart = b.VerseStart
+			m.highlightedVerseEnd = b.VerseEnd
+			m.loading = true
+			return m, loadChapter(m.client, m.gen.nextContent(), m.selectedTranslation, m.currentBook, m.currentChapter)
+		}
+	}
+	return m, nil
+}
+
+func (m Model) renderBookmarks() string {
+	bg := m.currentTheme.Background
+
+	maxAvail := m.width - leftPaneOuterWidth - 8
+	width := maxAvail
+	if width > 70 {
+		width = 70
+	}
+	if width < 40 {
+		width = 40
+	}
+	innerW := width - 6
+
+	containerStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.currentTheme.BorderActive).
+		BorderBackground(bg).
+		Background(bg).
+		Width(width).
+		Padding(m.panelPadding())
+
+	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(m.styled())
+	selectedStyle := lipgloss.NewStyle().Foreground(bg).Background(m.currentTheme.Accent).Bold(m.styled())
+	normalStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Primary).Background(bg)
+	collectionStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Secondary).Background(bg)
+	mutedStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(m.styled())
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(locale.T("Bookmarks")) + m.panelTitleGap())
+
+	list := m.bookmarkStore.Bookmarks
+	if len(list) == 0 {
+		content.WriteString(mutedStyle.Render(locale.T("No bookmarks yet — press b in the reader, or run sword-tui import.")))
+		return containerStyle.Render(content.String())
+	}
+
+	start := m.overlayWindowStart(m.bookmarkSelected, len(list), bookmarkWindow)
+	end := start + bookmarkWindow
+	if end > len(list) {
+		end = len(list)
+	}
+	if start > 0 {
+		content.WriteString(mutedStyle.Render(fmt.Sprintf("  ↑ %d more", start)) + "\n")
+	}
+	for i := start; i < end; i++ {
+		b := list[i]
+		ref := "⚑ " + b.Reference()
+		coll := b.Collection
+		if pad := innerW - 2 - lipgloss.Width(ref) - lipgloss.Width(coll); pad > 0 {
+			coll = strings.Repeat(" ", pad) + coll
+		}
+		if i == m.bookmarkSelected {
+			line := "▸ " + ref + coll
+			if w := lipgloss.Width(line); w < innerW {
+				line += strings.Repeat(" ", innerW-w)
+			}
+			content.WriteString(selectedStyle.Render(line) + "\n")
+		} else {
+			content.WriteString(normalStyle.Render("  "+ref) + collectionStyle.Render(coll) + "\n")
+		}
+	}
+	if end < len(list) {
+		content.WriteString(mutedStyle.Render(fmt.Sprintf("  ↓ %d more", len(list)-end)) + "\n")
+	}
+
+	return containerStyle.Render(strings.TrimSuffix(content.String(), "\n"))
+}
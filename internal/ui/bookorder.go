@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/locale"
+	"sword-tui/internal/reference"
+)
+
+// book_order arranges the books in the sidebar and Miller columns, and
+// the book n and p move on to at the end of one: the canonical order
+// (the default), "nt-first", "tanakh" (the Hebrew Bible's Torah,
+// Prophets and Writings, then the New Testament), "chronological" (as
+// bolls.life dates them) or a comma-separated list of books, which the
+// books it leaves out follow in canonical order.
+
+// The book_order presets.
+const (
+	orderCanonical     = "canonical"
+	orderNTFirst       = "nt-first"
+	orderTanakh        = "tanakh"
+	orderChronological = "chronological"
+)
+
+// tanakhOrder lists the Old Testament books by id in the Hebrew Bible's
+// order.
+var tanakhOrder = []int{
+	1, 2, 3, 4, 5, // Torah
+	6, 7, 9, 10, 11, 12, 23, 24, 26, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, // Prophets
+	19, 20, 18, 22, 8, 25, 21, 17, 27, 15, 16, 13, 14, // Writings
+}
+
+// checkBookOrder reports a book_order the reader doesn't understand.
+func checkBookOrder(order string) error {
+	_, err := bookRanks(order, api.CanonicalBooks())
+	return err
+}
+
+// bookRanks gives each book id its place in order, lowest first; books
+// it doesn't place are left out. Names are matched against books.
+func bookRanks(order string, books []api.Book) (map[int]int, error) {
+	rank := map[int]int{}
+	switch strings.TrimSpace(order) {
+	case "", orderCanonical:
+	case orderNTFirst:
+		for _, b := range books {
+			if b.BookID >= 40 {
+				rank[b.BookID] = b.BookID - 40
+			} else {
+				rank[b.BookID] = b.BookID + 40
+			}
+		}
+	case orderTanakh:
+		for i, id := range tanakhOrder {
+			rank[id] = i
+		}
+	case orderChronological:
+		for _, b := range books {
+			rank[b.BookID] = b.ChronOrder
+		}
+	default:
+		for i, name := range strings.Split(order, ",") {
+			id, _, ok := reference.MatchBook(name, books)
+			if !ok {
+				return nil, fmt.Errorf("book_order: no book called %q (or use %q, %q, %q or %q)", strings.TrimSpace(name), orderCanonical, orderNTFirst, orderTanakh, orderChronological)
+			}
+			rank[id] = i
+		}
+	}
+	return rank, nil
+}
+
+// orderBooks returns books in the book_order setting's order.
+func (m Model) orderBooks(books []api.Book) []api.Book {
+	rank, err := bookRanks(m.cfg.BookOrder, books)
+	if err != nil || len(rank) == 0 {
+		return books
+	}
+	out := slices.Clone(books)
+	slices.SortStableFunc(out, func(a, b api.Book) int {
+		ra, oka := rank[a.BookID]
+		rb, okb := rank[b.BookID]
+		switch {
+		case oka && okb:
+			return ra - rb
+		case oka:
+			return -1
+		case okb:
+			return 1
+		}
+		return a.BookID - b.BookID
+	})
+	return out
+}
+
+// bookSection is a run of books from one testament, as the sidebar and
+// Miller columns list them under a heading.
+type bookSection struct {
+	testament string // testamentOld, testamentNew or testamentFavorites
+	title     string
+	books     []int // indexes into m.books
+}
+
+// bookSections splits m.books into runs by testament, in order, after
+// the favorites if any are starred. The canonical order makes two runs;
+// others may make more.
+func (m Model) bookSections() []bookSection {
+	var sections []bookSection
+	fav := bookSection{testament: testamentFavorites, title: locale.T("FAVORITES")}
+	for _, id := range m.cfg.FavoriteBooks {
+		if i := slices.IndexFunc(m.books, func(b api.Book) bool { return b.BookID == id }); i >= 0 {
+			fav.books = append(fav.books, i)
+		}
+	}
+	if len(fav.books) > 0 {
+		sections = append(sections, fav)
+	}
+	for i, b := range m.books {
+		testament, title := testamentOld, locale.T("OLD TESTAMENT")
+		if b.BookID >= 40 {
+			testament, title = testamentNew, locale.T("NEW TESTAMENT")
+		}
+		if len(sections) == 0 || sections[len(sections)-1].testament != testament {
+			sections = append(sections, bookSection{testament: testament, title: title})
+		}
+		s := &sections[len(sections)-1]
+		s.books = append(s.books, i)
+	}
+	return sections
+}
+
+// adjacentBook returns the book before (delta -1) or after (delta 1) the
+// current one in m.books.
+func (m Model) adjacentBook(delta int) (api.Book, bool) {
+	i := slices.IndexFunc(m.books, func(b api.Book) bool { return b.BookID == m.currentBook })
+	if i < 0 || i+delta < 0 || i+delta >= len(m.books) {
+		return api.Book{}, false
+	}
+	return m.books[i+delta], true
+}
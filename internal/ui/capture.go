@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"sword-tui/internal/locale"
+	"sword-tui/internal/notes"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// captureRef is the verse a captured note is attached to.
+func (m Model) captureRef() string {
+	return fmt.Sprintf("%s %d:%d", m.currentBookName, m.currentChapter, m.highlightedVerseStart)
+}
+
+// startCapture opens the one-line note input in the status bar for the
+// highlighted verse.
+func (m *Model) startCapture() tea.Cmd {
+	if m.highlightedVerseStart == 0 {
+		return m.flash("select a verse to note")
+	}
+	m.capturing = true
+	m.captureInput.SetValue("")
+	return m.captureInput.Focus()
+}
+
+// updateCapture handles keys while the note input is open. Enter appends
+// the note with the current time; esc discards it.
+func (m Model) updateCapture(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.capturing = false
+		m.captureInput.Blur()
+		text := m.captureInput.Value()
+		if text == "" {
+			return m, nil
+		}
+		if err := notes.Append(m.currentBookName, m.currentBook, m.currentChapter, m.highlightedVerseStart, text, time.Now()); err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.chapterNotes, _ = notes.ForChapter(m.currentBook, m.currentChapter)
+		m.backlinks = indexBacklinks()
+		m.refreshContent()
+		return m, m.flash(locale.Tf("✎ noted %s", m.captureRef()))
+	case "esc":
+		m.capturing = false
+		m.captureInput.Blur()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.captureInput, cmd = m.captureInput.Update(msg)
+	return m, cmd
+}
+
+// renderCapture draws the note input in place of the status bar hints.
+func (m Model) renderCapture(label lipgloss.Style, width int) string {
+	prompt := label.Render("✎ " + m.captureRef() + " ")
+	ti := m.captureInput
+	ti.SetStyles(m.themedInputStyles())
+	ti.SetWidth(max(width-lipgloss.Width(prompt)-2, 10))
+	return prompt + ti.View()
+}
@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+const (
+	// gridColumns is how many chapter cells make up a grid row.
+	gridColumns = 10
+	// gridCellWidth is the width of one cell, its number right-aligned.
+	gridCellWidth = 5
+)
+
+// gridChapters is the number of chapters in the current book, or 0 while
+// the books are loading.
+func (m Model) gridChapters() int {
+	for _, b := range m.books {
+		if b.BookID == m.currentBook {
+			return b.Chapters
+		}
+	}
+	return 0
+}
+
+// openChapterGrid shows the current book's chapters as a grid.
+func (m *Model) openChapterGrid() tea.Cmd {
+	if m.gridChapters() == 0 {
+		return m.flash("books are still loading")
+	}
+	m.gridSelected = max(m.currentChapter, 1)
+	m.gridTyped = ""
+	m.mode = modeChapterGrid
+	return nil
+}
+
+// openGridChapter loads the selected chapter and closes the grid.
+func (m Model) openGridChapter() (tea.Model, tea.Cmd) {
+	m.mode = modeReader
+	m.currentChapter = m.gridSelected
+	m.highlightedVerseStart = 0
+	m.highlightedVerseEnd = 0
+	m.loading = true
+	return m, loadChapter(m.client, m.gen.nextContent(), m.selectedTranslation, m.currentBook, m.currentChapter)
+}
+
+// updateChapterGrid handles keys while the grid is open. Arrows move;
+// typing a number selects that chapter and opens it as soon as no longer
+// number could match, so "37" in Genesis goes straight there.
+func (m Model) updateChapterGrid(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	n := m.gridChapters()
+	key := msg.String()
+	if len(key) == 1 && key[0] >= '0' && key[0] <= '9' {
+		typed := m.gridTyped + key
+		ch, _ := strconv.Atoi(typed)
+		if ch < 1 || ch > n {
+			return m, nil
+		}
+		m.gridTyped = typed
+		m.gridSelected = ch
+		if ch*10 > n {
+			return m.openGridChapter()
+		}
+		return m, nil
+	}
+
+	m.gridTyped = ""
+	switch key {
+	case "esc", "g", "q":
+		m.mode = modeReader
+	case "left", "h":
+		m.gridSelected = max(m.gridSelected-1, 1)
+	case "right", "l":
+		m.gridSelected = min(m.gridSelected+1, n)
+	case "up", "k":
+		if m.gridSelected > gridColumns {
+			m.gridSelected -= gridColumns
+		}
+	case "down", "j":
+		if m.gridSelected+gridColumns <= n {
+			m.gridSelected += gridColumns
+		}
+	case "home":
+		m.gridSelected = 1
+	case "end", "G":
+		m.gridSelected = n
+	case "enter":
+		return m.openGridChapter()
+	}
+	return m, nil
+}
+
+// gridClick selects and opens the chapter in the clicked cell. row counts
+// from the first grid row, x from the panel's inner left edge.
+func (m *Model) gridClick(row, x int) tea.Cmd {
+	col := x / gridCellWidth
+	ch := row*gridColumns + col + 1
+	if col < 0 || col >= gridColumns || ch < 1 || ch > m.gridChapters() {
+		return nil
+	}
+	m.gridSelected = ch
+	next, cmd := m.openGridChapter()
+	*m = next.(Model)
+	return cmd
+}
+
+func (m Model) renderChapterGrid() string {
+	bg := m.currentTheme.Background
+
+	width := gridColumns*gridCellWidth + 6
+	containerStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.currentTheme.BorderActive).
+		BorderBackground(bg).
+		Background(bg).
+		Width(width).
+		Padding(m.panelPadding())
+
+	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(m.styled())
+	selectedStyle := lipgloss.NewStyle().Foreground(bg).Background(m.currentTheme.Accent).Bold(m.styled())
+	currentStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Warning).Background(bg).Bold(m.styled())
+	normalStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Primary).Background(bg)
+	mutedStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(m.styled())
+
+	n := m.gridChapters()
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(m.currentBookName) +
+		mutedStyle.Render(fmt.Sprintf("  %d chapters", n)) + m.panelTitleGap())
+
+	for ch := 1; ch <= n; ch++ {
+		cell := fmt.Sprintf("%*d ", gridCellWidth-1, ch)
+		switch {
+		case ch == m.gridSelected:
+			content.WriteString(selectedStyle.Render(cell))
+		case ch == m.currentChapter:
+			content.WriteString(currentStyle.Render(cell))
+		default:
+			content.WriteString(normalStyle.Render(cell))
+		}
+		if ch%gridColumns == 0 && ch < n {
+			content.WriteString("\n")
+		}
+	}
+
+	prompt := "type a number to jump"
+	if m.gridTyped != "" {
+		prompt = "go to " + m.gridTyped + "…"
+	}
+	content.WriteString("\n\n" + mutedStyle.Render(prompt))
+	return containerStyle.Render(content.String())
+}
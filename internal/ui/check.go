@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/export"
+	"sword-tui/internal/locale"
+	"sword-tui/internal/outline"
+	"sword-tui/internal/settings"
+	"sword-tui/internal/syncer"
+	"sword-tui/internal/theme"
+	"sword-tui/internal/versenum"
+)
+
+// CheckSettings reports the problems in cfg that the reader would either
+// refuse or silently replace with a default, one error per setting. It
+// parses the schedules the same way NewModel does.
+func CheckSettings(cfg settings.Settings) []error {
+	var errs []error
+	add := func(err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if cfg.CurrentTheme != "" && !slices.ContainsFunc(theme.AllThemes(), func(th theme.Theme) bool { return th.Name == cfg.CurrentTheme }) {
+		add(fmt.Errorf("current_theme: no theme called %q (pick one with T)", cfg.CurrentTheme))
+	}
+	add(locale.Check(cfg.Language))
+	if cfg.Density != "" && cfg.Density != densityComfortable && cfg.Density != densityCompact {
+		add(fmt.Errorf("density: %q is not %q or %q", cfg.Density, densityComfortable, densityCompact))
+	}
+	if cfg.VerseNumbers != "" && !slices.Contains(versenum.Styles, versenum.Style(cfg.VerseNumbers)) {
+		add(fmt.Errorf("verse_numbers: unknown style %q, using plain", cfg.VerseNumbers))
+	}
+	_, err := parseNightLight(cfg.NightLight, cfg.NightLightStrength)
+	add(err)
+	_, err = parseColors(cfg.Colors)
+	add(err)
+	_, err = parseDailyWindow("quiet_hours", cfg.QuietHours)
+	add(err)
+	add(checkShareFormat(cfg.ShareFormat))
+	add(export.CheckLayout(cfg.ExportLayout))
+	add(checkBookOrder(cfg.BookOrder))
+	add(checkHighlights(cfg.HighlightStyle, cfg.HighlightLabels))
+	for alias, t := range cfg.TranslationAliases {
+		if len(strings.Fields(alias)) != 1 || strings.TrimSpace(t) == "" {
+			add(fmt.Errorf("translation_aliases: %q → %q should be one word for a translation", alias, t))
+		}
+	}
+	for _, mirror := range cfg.DownloadMirrors {
+		if u, err := url.Parse(mirror); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			add(fmt.Errorf("download_mirrors: %q is not an http(s) URL", mirror))
+		}
+	}
+	add(checkReminders(cfg.Reminders))
+	add(checkStudyTrio(cfg.StudyTrio))
+	if _, err := api.NewProvider(cfg.Provider, cfg.ProviderKey); err != nil {
+		add(fmt.Errorf("provider: %w", err))
+	}
+	if cfg.ResponseCacheTTL != "" {
+		if _, err := time.ParseDuration(cfg.ResponseCacheTTL); err != nil {
+			add(fmt.Errorf("response_cache_ttl: %w", err))
+		}
+	}
+	add(checkMaxRequests(cfg.MaxRequests))
+	if _, err := outline.For(0, 0); err != nil {
+		add(fmt.Errorf("outlines.json: %w", err))
+	}
+
+	for _, name := range studyLayoutNames(cfg)[1:] {
+		l := cfg.StudyLayouts[name]
+		if len(l.Panes) == 0 {
+			add(fmt.Errorf("study_layouts.%s: no panes (use any of %s)", name, strings.Join(studyPaneKinds, ", ")))
+		}
+		for _, p := range l.Panes {
+			if !slices.Contains(studyPaneKinds, p) {
+				add(fmt.Errorf("study_layouts.%s: unknown pane %q (use %s)", name, p, strings.Join(studyPaneKinds, ", ")))
+			}
+		}
+		if l.Width != 0 && (l.Width < studyMinWidth || l.Width > studyMaxWidth) {
+			add(fmt.Errorf("study_layouts.%s: width %d is not between %d and %d, using %d", name, l.Width, studyMinWidth, studyMaxWidth, studyDefaultWidth))
+		}
+	}
+	if _, ok := studyLayouts(cfg)[cfg.StudyLayout]; cfg.StudyLayout != "" && !ok {
+		add(fmt.Errorf("study_layout: no layout called %q", cfg.StudyLayout))
+	}
+
+	switch cfg.SyncMode {
+	case "":
+	case "git", "webdav":
+		if cfg.SyncRemote == "" {
+			add(fmt.Errorf("sync_remote: needed for sync_mode %q", cfg.SyncMode))
+		}
+	default:
+		add(fmt.Errorf("sync_mode: unknown mode %q (use \"git\" or \"webdav\")", cfg.SyncMode))
+	}
+	if s := cfg.SyncStrategy; s != "" && s != syncer.StrategyLastWriterWins && s != syncer.StrategyMerge {
+		add(fmt.Errorf("sync_strategy: %q is not %q or %q", s, syncer.StrategyLastWriterWins, syncer.StrategyMerge))
+	}
+	return errs
+}
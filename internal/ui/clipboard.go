@@ -0,0 +1,26 @@
+package ui
+
+import (
+	"runtime"
+	"strings"
+
+	"github.com/atotto/clipboard"
+)
+
+// writeClipboard puts text on the system clipboard. Windows programs
+// expect CRLF line ends there, so on Windows text gets them.
+func writeClipboard(text string) error {
+	if runtime.GOOS == "windows" {
+		text = strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\n", "\r\n")
+	}
+	return clipboard.WriteAll(text)
+}
+
+// clipboardUnreadable is the alert when the clipboard can't be read.
+// Only on Linux and the BSDs does that take a helper program.
+func clipboardUnreadable() string {
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		return "can't read the clipboard"
+	}
+	return "can't read the clipboard (needs xclip, xsel or wl-paste)"
+}
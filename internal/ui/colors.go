@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/colorprofile"
+)
+
+// colorProfiles are the values of the colors setting.
+var colorProfiles = map[string]colorprofile.Profile{
+	"truecolor": colorprofile.TrueColor,
+	"256":       colorprofile.ANSI256,
+	"16":        colorprofile.ANSI,
+}
+
+// parseColors reads the colors setting. Empty leaves the profile to be
+// detected, and gives colorprofile.Unknown.
+func parseColors(s string) (colorprofile.Profile, error) {
+	if s == "" {
+		return colorprofile.Unknown, nil
+	}
+	p, ok := colorProfiles[s]
+	if !ok {
+		return colorprofile.Unknown, fmt.Errorf("colors: %q is not \"truecolor\", \"256\" or \"16\", detecting", s)
+	}
+	return p, nil
+}
+
+// ColorProfile is the color profile the colors setting forces on the
+// terminal, if it does.
+func (m Model) ColorProfile() (colorprofile.Profile, bool) {
+	p, err := parseColors(m.cfg.Colors)
+	return p, err == nil && p != colorprofile.Unknown
+}
+
+// tintable reports whether the terminal shows enough colors for a
+// background tinted toward another color. With 16 a tint is rounded back
+// to the background, so highlights keep to the gutter.
+func (m Model) tintable() bool {
+	return m.colorProfile != colorprofile.ANSI
+}
@@ -0,0 +1,172 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"sword-tui/internal/locale"
+	"sword-tui/internal/textnorm"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// In the comparison, / narrows the verses to those where some
+// translation mentions a term, and = to those the translations render
+// differently. Runs of the verses left out fold into one line, so what
+// remains stands out.
+
+// comparisonAlike is how much of their wording two renderings of a verse
+// share, as a fraction of the words in either, for = to count them as
+// saying the same.
+const comparisonAlike = 0.8
+
+// comparisonFilter is what the comparison hides.
+type comparisonFilter struct {
+	term   string // folded; empty keeps every verse
+	differ bool   // hide verses every translation renders alike
+}
+
+func (m Model) comparisonFilter() comparisonFilter {
+	return comparisonFilter{
+		term:   textnorm.Fold(strings.TrimSpace(m.comparisonFilterInput.Value())),
+		differ: m.comparisonDiffOnly,
+	}
+}
+
+// hides reports whether a verse with these renderings, one per
+// translation, is left out.
+func (f comparisonFilter) hides(texts []string) bool {
+	if f.term != "" {
+		found := false
+		for _, t := range texts {
+			if strings.Contains(textnorm.Fold(t), f.term) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return true
+		}
+	}
+	return f.differ && renderedAlike(texts)
+}
+
+// foldLabel describes the verses from to to that a fold stands for.
+func (f comparisonFilter) foldLabel(from, to int) string {
+	verses := fmt.Sprint(from)
+	if to > from {
+		verses = fmt.Sprintf("%d–%d", from, to)
+	}
+	if f.term == "" {
+		return "≈ " + verses + " " + locale.T("read alike")
+	}
+	return "⋯ " + verses
+}
+
+// renderedAlike reports whether every translation has the verse and each
+// pair share at least comparisonAlike of their words.
+func renderedAlike(texts []string) bool {
+	words := make([]map[string]bool, len(texts))
+	for i, t := range texts {
+		if t == "" {
+			return false
+		}
+		words[i] = map[string]bool{}
+		for _, w := range strings.FieldsFunc(textnorm.Fold(t), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			words[i][w] = true
+		}
+	}
+	for i := range words {
+		for j := i + 1; j < len(words); j++ {
+			shared := 0
+			for w := range words[i] {
+				if words[j][w] {
+					shared++
+				}
+			}
+			if all := len(words[i]) + len(words[j]) - shared; all > 0 && float64(shared)/float64(all) < comparisonAlike {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// relayoutComparison lays the comparison out again under the current
+// filter.
+func (m *Model) relayoutComparison() {
+	if m.currentParallelVerses == nil {
+		return
+	}
+	m.parallel = layoutParallel(m.currentParallelVerses, m.comparisonTranslations, m.viewport.Width(), m.comparisonFilter())
+	m.content = m.parallel.placeholder()
+	m.viewport.SetContent(m.content)
+	m.viewport.GotoTop()
+}
+
+// comparisonShown is the flash after the filter changes.
+func (m Model) comparisonShown() string {
+	if m.parallel == nil {
+		return ""
+	}
+	return locale.Tf("%d of %d verses", m.parallel.shown, m.parallel.total)
+}
+
+// toggleComparisonDiff shows only the verses the translations render
+// differently, or every verse again.
+func (m *Model) toggleComparisonDiff() tea.Cmd {
+	m.comparisonDiffOnly = !m.comparisonDiffOnly
+	m.relayoutComparison()
+	if !m.comparisonDiffOnly {
+		return m.flash("showing every verse")
+	}
+	return m.flash(locale.Tf("differences only · %s", m.comparisonShown()))
+}
+
+// startComparisonFilter opens the filter input in the status bar.
+func (m *Model) startComparisonFilter() tea.Cmd {
+	m.comparisonFiltering = true
+	return m.comparisonFilterInput.Focus()
+}
+
+// updateComparisonFilter handles keys while the filter input is open. The
+// comparison narrows as the term is typed; Enter keeps it and esc clears
+// it.
+func (m Model) updateComparisonFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.comparisonFiltering = false
+		m.comparisonFilterInput.Blur()
+		if m.comparisonFilterInput.Value() == "" {
+			return m, nil
+		}
+		return m, m.flash(m.comparisonShown())
+	case "esc":
+		m.comparisonFiltering = false
+		m.comparisonFilterInput.Blur()
+		m.comparisonFilterInput.SetValue("")
+		m.relayoutComparison()
+		return m, nil
+	}
+	before := m.comparisonFilterInput.Value()
+	var cmd tea.Cmd
+	m.comparisonFilterInput, cmd = m.comparisonFilterInput.Update(msg)
+	if m.comparisonFilterInput.Value() != before {
+		m.relayoutComparison()
+	}
+	return m, cmd
+}
+
+// renderComparisonFilter draws the filter input in place of the status
+// bar hints.
+func (m Model) renderComparisonFilter(label lipgloss.Style, width int) string {
+	prompt := label.Render(locale.T("filter") + " ")
+	ti := m.comparisonFilterInput
+	ti.SetStyles(m.themedInputStyles())
+	ti.SetWidth(max(width-lipgloss.Width(prompt)-2, 10))
+	return prompt + ti.View()
+}
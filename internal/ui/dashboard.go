@@ -0,0 +1,228 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/bookmarks"
+	"sword-tui/internal/locale"
+	"sword-tui/internal/plans"
+	"sword-tui/internal/votd"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// With start_screen set, the reader opens on a dashboard rather than
+// straight into the last chapter: where reading left off, what each plan
+// has for today, the verse of the day and the latest bookmarks. Enter
+// goes to the one selected; esc stays on the chapter underneath. 0 brings
+// it back.
+
+// dashBookmarks is how many of the latest bookmarks the dashboard lists.
+const dashBookmarks = 5
+
+// dashItem is one line of the dashboard that can be selected.
+type dashItem struct {
+	section string // heading it goes under
+	label   string
+	note    string // shown muted after the label
+	ref     string // where Enter goes; "" stays on the chapter on screen
+}
+
+// verseOfDayMsg carries the text of the verse of the day.
+type verseOfDayMsg struct {
+	ref  string
+	text string
+}
+
+// loadVerseOfDay fetches the text of v in translation. Failing quietly
+// leaves the dashboard with just the reference.
+func loadVerseOfDay(client *api.Client, translation string, v votd.Verse) tea.Cmd {
+	return func() tea.Msg {
+		verses, err := client.GetChapter(translation, v.Book, v.Chapter)
+		if err != nil {
+			return verseOfDayMsg{ref: v.Ref}
+		}
+		var parts []string
+		for _, vs := range verses {
+			if vs.Verse >= v.VerseStart && vs.Verse <= v.VerseEnd {
+				parts = append(parts, strings.TrimSpace(stripHTMLTags(vs.Text)))
+			}
+		}
+		return verseOfDayMsg{ref: v.Ref, text: strings.Join(parts, " ")}
+	}
+}
+
+// openDashboard opens the start screen, fetching the verse of the day
+// unless it is already in hand.
+func (m *Model) openDashboard() tea.Cmd {
+	m.dashSelected = 0
+	m.pushOverlay(overlay{
+		name:  overlayDashboard,
+		place: placeCenter,
+		dim:   true,
+		view:  Model.renderDashboard,
+		key:   Model.updateDashboard,
+	})
+	v := votd.For(time.Now())
+	if m.verseOfDay.ref == v.Ref && m.verseOfDay.text != "" {
+		return nil
+	}
+	m.verseOfDay = verseOfDayMsg{ref: v.Ref}
+	return loadVerseOfDay(m.client, m.selectedTranslation, v)
+}
+
+// dashboardItems lists what the dashboard offers, section by section.
+func (m Model) dashboardItems() []dashItem {
+	now := time.Now()
+	name := fmt.Sprint(m.currentBook)
+	if b, ok := api.CanonicalBook(m.currentBook); ok {
+		name = b.Name
+	}
+	here := fmt.Sprintf("%s %d", name, m.currentChapter)
+	if m.visitStore != nil {
+		if verse, _ := m.visitStore.Position(m.currentBook, m.currentChapter); verse > 0 {
+			here += fmt.Sprintf(":%d", verse)
+		}
+	}
+	items := []dashItem{{section: "Continue reading", label: here}}
+
+	for _, p := range m.readingPlans {
+		items = append(items, planToday(p, now))
+	}
+
+	v := votd.For(now)
+	items = append(items, dashItem{section: "Verse of the day", label: v.Ref, ref: v.Ref})
+
+	if m.bookmarkStore != nil {
+		latest := slices.Clone(m.bookmarkStore.Bookmarks)
+		slices.SortStableFunc(latest, func(a, b bookmarks.Bookmark) int { return b.Added.Compare(a.Added) })
+		for _, b := range latest[:min(len(latest), dashBookmarks)] {
+			items = append(items, dashItem{section: "Recent bookmarks", label: b.Reference(), note: b.Collection, ref: b.Reference()})
+		}
+	}
+	return items
+}
+
+// planToday is a plan's line on the dashboard: today's reading, or the
+// next day's when today has none. Enter opens the earliest chapter still
+// to read up to then, so missed days come first.
+func planToday(p *plans.Plan, now time.Time) dashItem {
+	item := dashItem{section: "Today's plan"}
+	i := p.Today(now)
+	if i >= len(p.Days) {
+		item.label = p.Name + "  finished"
+		return item
+	}
+	day := p.Days[i]
+	when := ""
+	if day.Date != now.Format(plans.DateLayout) {
+		date, _ := time.ParseInLocation(plans.DateLayout, day.Date, time.Local)
+		when = date.Format("Mon 02 Jan") + ": "
+	}
+	item.label = p.Name + "  " + when + day.Summary()
+	switch behind := p.Behind(now); {
+	case behind > 0:
+		item.note = fmt.Sprintf("%d behind", behind)
+	case day.Done():
+		item.note = "✓ done"
+	}
+	for _, d := range p.Days[:i+1] {
+		for _, r := range d.Readings {
+			if !r.Read && item.ref == "" {
+				item.ref = r.Reference()
+			}
+		}
+	}
+	return item
+}
+
+func (m Model) updateDashboard(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	items := m.dashboardItems()
+	switch msg.String() {
+	case "down", "j", "tab":
+		m.dashSelected = min(m.dashSelected+1, len(items)-1)
+	case "up", "k", "shift+tab":
+		m.dashSelected = max(m.dashSelected-1, 0)
+	case "enter":
+		ref := items[min(m.dashSelected, len(items)-1)].ref
+		if ref == "" {
+			m.closeOverlay(overlayDashboard)
+			break
+		}
+		if name, ok := m.missingBook(ref); ok {
+			return m, m.flash(locale.Tf("%s is not in %s", name, m.selectedTranslation)), true
+		}
+		m.closeOverlay(overlayDashboard)
+		next, cmd := m.gotoReference(ref)
+		return next, cmd, true
+	case "q", "0":
+		m.closeOverlay(overlayDashboard)
+	case "esc":
+		return m, nil, false
+	}
+	// Everything else is swallowed while the popup has the focus.
+	return m, nil, true
+}
+
+func (m Model) renderDashboard() string {
+	bg := m.currentTheme.Background
+	w, _ := m.popupSize()
+	_, padX := m.panelPadding()
+
+	containerStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.currentTheme.BorderActive).
+		BorderBackground(bg).
+		Background(bg).
+		Width(w + 2 + 2*padX).
+		Padding(m.panelPadding())
+	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(m.styled())
+	headingStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Success).Background(bg).Bold(m.styled())
+	selectedStyle := lipgloss.NewStyle().Foreground(bg).Background(m.currentTheme.Accent).Bold(m.styled())
+	textStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Primary).Background(bg)
+	mutedStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(m.styled())
+	pad := lipgloss.NewStyle().Background(bg).Width(w)
+
+	var content strings.Builder
+	sub := "  · " + m.selectedTranslation
+	if m.cfg.Reader != "" {
+		sub += " · " + m.cfg.Reader
+	}
+	content.WriteString(titleStyle.Render(time.Now().Format("Monday 2 January")) + mutedStyle.Render(sub))
+	content.WriteString(m.panelTitleGap())
+
+	section := ""
+	for i, it := range m.dashboardItems() {
+		if it.section != section {
+			if section != "" {
+				content.WriteString("\n")
+			}
+			section = it.section
+			content.WriteString(headingStyle.Render(section) + "\n")
+		}
+		note := ""
+		if it.note != "" {
+			note = "  " + it.note
+		}
+		label := clipText(it.label, max(w-2-lipgloss.Width(note), 1))
+		if i == m.dashSelected {
+			line := "▸ " + label + note
+			line += strings.Repeat(" ", max(w-lipgloss.Width(line), 0))
+			content.WriteString(selectedStyle.Render(line) + "\n")
+		} else {
+			content.WriteString(pad.Render(textStyle.Render("  "+label)+mutedStyle.Render(note)) + "\n")
+		}
+		if it.section == "Verse of the day" && m.verseOfDay.ref == it.label && m.verseOfDay.text != "" {
+			for _, l := range strings.Split(wrapText(m.verseOfDay.text, w-4), "\n") {
+				content.WriteString(pad.Render(mutedStyle.Render("    "+l)) + "\n")
+			}
+		}
+	}
+	content.WriteString("\n" + mutedStyle.Render(clipText("j/k move  ·  ⏎ go  ·  esc read on", w)))
+	return containerStyle.Render(content.String())
+}
@@ -0,0 +1,58 @@
+package ui
+
+import "sword-tui/internal/versenum"
+
+// Density values accepted for the "density" setting.
+const (
+	densityComfortable = "comfortable"
+	densityCompact     = "compact"
+)
+
+// compact reports whether the compact density is active: no blank line
+// between verses and tighter padding around the pickers, so more text
+// fits on small screens.
+func (m Model) compact() bool {
+	return m.density == densityCompact
+}
+
+// verseNumberWidth is the width of the verse-number column in the reader;
+// bracketed numbers need an extra cell for three-digit verses.
+func (m Model) verseNumberWidth() int {
+	if m.verseNumbers == versenum.Brackets {
+		return 5
+	}
+	return 4
+}
+
+// verseGap is the number of blank lines between verses in the reader.
+func (m Model) verseGap() int {
+	if m.compact() {
+		return 0
+	}
+	return 1
+}
+
+// panelPadding is the vertical and horizontal padding inside overlay
+// panels.
+func (m Model) panelPadding() (int, int) {
+	if m.compact() {
+		return 0, 1
+	}
+	return 1, 2
+}
+
+// panelWidth narrows an overlay panel that would be want cells wide to
+// what fits beside the books pane, so panels and the progress bars in
+// them follow the terminal when it is resized.
+func (m Model) panelWidth(want int) int {
+	return max(min(want, m.width-leftPaneOuterWidth-4), 30)
+}
+
+// panelTitleGap ends an overlay panel's title line: in the comfortable
+// density it is followed by a blank line.
+func (m Model) panelTitleGap() string {
+	if m.compact() {
+		return "\n"
+	}
+	return "\n\n"
+}
@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"sword-tui/internal/locale"
+
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// The cache manager downloads one translation at a time and queues the
+// rest. Space marks translations and Enter queues the marked ones (or
+// the one under the cursor), u a downloaded one to update; a download
+// that fails is tried again a couple of times before it is marked
+// failed, and x cancels a queued or running download.
+
+// maxDownloadTries is how many times a translation is tried before it
+// is marked failed.
+const maxDownloadTries = 3
+
+// downloadRetryDelay is the pause before a failed download is tried
+// again.
+const downloadRetryDelay = 2 * time.Second
+
+// downloadQueue is the cache manager's state beyond the running
+// download (downloadingTranslation).
+type downloadQueue struct {
+	marked  map[string]bool
+	waiting []string         // in the order they will be downloaded
+	tries   map[string]int   // failed attempts so far
+	failed  map[string]error // given up on, until queued again
+	updates map[string]bool  // downloaded already, being downloaded again
+}
+
+// downloadRetryMsg starts the next download once the pause after a
+// failure is over.
+type downloadRetryMsg struct{}
+
+// downloadable reports whether translation i can be queued: neither
+// local nor downloaded already.
+func (m Model) downloadable(i int) bool {
+	t := m.translations[i]
+	return m.cache != nil && !t.Local && !m.cache.IsCached(t.ShortName)
+}
+
+// queued reports whether translation is waiting or downloading.
+func (m Model) queued(translation string) bool {
+	return m.downloadingTranslation == translation || slices.Contains(m.downloads.waiting, translation)
+}
+
+// toggleMarked marks translation i for downloading, or unmarks it.
+func (m *Model) toggleMarked(i int) {
+	t := m.translations[i].ShortName
+	if !m.downloadable(i) || m.queued(t) {
+		return
+	}
+	if m.downloads.marked == nil {
+		m.downloads.marked = map[string]bool{}
+	}
+	if m.downloads.marked[t] {
+		delete(m.downloads.marked, t)
+	} else {
+		m.downloads.marked[t] = true
+	}
+}
+
+// queueDownloads queues the marked translations in list order, or
+// translation i when none are marked, and starts downloading if nothing
+// is.
+func (m *Model) queueDownloads(i int) tea.Cmd {
+	var add []string
+	if len(m.downloads.marked) == 0 {
+		if m.downloadable(i) {
+			add = append(add, m.translations[i].ShortName)
+		}
+	} else {
+		for j, t := range m.translations {
+			if m.downloads.marked[t.ShortName] && m.downloadable(j) {
+				add = append(add, t.ShortName)
+			}
+		}
+		clear(m.downloads.marked)
+	}
+	for _, t := range add {
+		if !m.queued(t) {
+			m.downloads.waiting = append(m.downloads.waiting, t)
+			delete(m.downloads.failed, t)
+			delete(m.downloads.tries, t)
+		}
+	}
+	if m.downloadingTranslation != "" {
+		return nil
+	}
+	return m.nextDownload()
+}
+
+// nextDownload starts the first waiting download, if any.
+func (m *Model) nextDownload() tea.Cmd {
+	if len(m.downloads.waiting) == 0 || m.cache == nil {
+		return nil
+	}
+	trans := m.downloads.waiting[0]
+	m.downloads.waiting = m.downloads.waiting[1:]
+	m.downloadingTranslation = trans
+	m.downloadProgress = 0
+	m.downloadRead, m.downloadTotal = 0, 0
+	if m.downloads.updates[trans] {
+		return tea.Batch(updateTranslation(m.cache, trans), downloadTick())
+	}
+	return tea.Batch(downloadTranslation(m.cache, trans), downloadTick())
+}
+
+// downloadFailed handles a download that ended in err: cancelled, to be
+// tried again, or given up on. The queue goes on either way.
+func (m *Model) downloadFailed(translation string, err error) tea.Cmd {
+	if errors.Is(err, context.Canceled) {
+		delete(m.downloads.tries, translation)
+		delete(m.downloads.updates, translation)
+		return tea.Batch(m.flash(locale.Tf("cancelled %s", translation)), m.nextDownload())
+	}
+	if m.downloads.tries == nil {
+		m.downloads.tries = map[string]int{}
+	}
+	m.downloads.tries[translation]++
+	if m.downloads.tries[translation] < maxDownloadTries {
+		m.downloads.waiting = append([]string{translation}, m.downloads.waiting...)
+		return tea.Tick(downloadRetryDelay, func(time.Time) tea.Msg { return downloadRetryMsg{} })
+	}
+	delete(m.downloads.tries, translation)
+	m.err = fmt.Errorf("%s: %w", translation, err)
+	if m.downloads.updates[translation] {
+		// The copy on disk is still there to read.
+		delete(m.downloads.updates, translation)
+		return m.nextDownload()
+	}
+	if m.downloads.failed == nil {
+		m.downloads.failed = map[string]error{}
+	}
+	m.downloads.failed[translation] = err
+	return m.nextDownload()
+}
+
+// cancelDownload cancels translation i's download, running or queued,
+// or forgets that it failed. It reports false when there was nothing to
+// cancel.
+func (m *Model) cancelDownload(i int) bool {
+	t := m.translations[i].ShortName
+	switch {
+	case m.downloadingTranslation == t:
+		m.cache.CancelDownload()
+	case slices.Contains(m.downloads.waiting, t):
+		m.downloads.waiting = slices.DeleteFunc(m.downloads.waiting, func(w string) bool { return w == t })
+		delete(m.downloads.tries, t)
+		delete(m.downloads.updates, t)
+	case m.downloads.failed[t] != nil:
+		delete(m.downloads.failed, t)
+	default:
+		return false
+	}
+	return true
+}
+
+// queueBadge marks a translation that is queued, marked or failed, or
+// returns "".
+func (m Model) queueBadge(translation string) (string, pickerTone) {
+	switch {
+	case slices.Contains(m.downloads.waiting, translation):
+		if n := m.downloads.tries[translation]; n > 0 {
+			return fmt.Sprintf("⟳ retrying (%d of %d)", n+1, maxDownloadTries), toneBusy
+		}
+		return "… queued", toneBusy
+	case m.downloads.marked[translation]:
+		return "● marked", toneMarked
+	case m.downloads.failed[translation] != nil:
+		return "✗ failed", toneFailed
+	}
+	return "", toneNormal
+}
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ExitSummary is what sword-tui prints after quitting when exit_summary
+// is set, so it stays in the terminal once the reader's screen is gone:
+// where reading stopped, each plan's reading for today and the streak
+// of days read. It is "" when the setting is off.
+func (m Model) ExitSummary(now time.Time) string {
+	if !m.cfg.ExitSummary || m.currentBookName == "" {
+		return ""
+	}
+	var sb strings.Builder
+	ref := fmt.Sprintf("%s %d", m.currentBookName, m.currentChapter)
+	if m.highlightedVerseStart > 0 {
+		ref += fmt.Sprintf(":%d", m.highlightedVerseStart)
+	}
+	fmt.Fprintf(&sb, "Last read: %s (%s)\n", ref, m.translationName(m.selectedTranslation))
+	for _, p := range m.readingPlans {
+		item := planToday(p, now)
+		line := "Plan " + p.Name + ": " + strings.TrimPrefix(item.label, p.Name+"  ")
+		if item.note != "" {
+			line += " (" + item.note + ")"
+		}
+		sb.WriteString(line + "\n")
+	}
+	if m.visitStore != nil {
+		switch n := m.visitStore.Streak(now); n {
+		case 0:
+		case 1:
+			sb.WriteString("Streak: 1 day\n")
+		default:
+			fmt.Fprintf(&sb, "Streak: %d days\n", n)
+		}
+	}
+	return sb.String()
+}
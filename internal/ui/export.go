@@ -0,0 +1,159 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/export"
+	"sword-tui/internal/locale"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// X writes the passage on screen to a file: the highlighted verses or
+// the chapter in the reader, the chapter in every column in the
+// comparison. The status bar asks for the file name, proposing one in
+// the working directory; its extension picks Markdown, HTML or plain
+// text, and tab cycles through them. export_layout sets the verses out
+// a line each or as one paragraph.
+
+// exportRef is the reference of what X exports.
+func (m Model) exportRef() string {
+	ref := fmt.Sprintf("%s %d", m.currentBookName, m.currentChapter)
+	switch {
+	case m.mode == modeComparison || m.highlightedVerseStart == 0:
+	case m.highlightedVerseStart == m.highlightedVerseEnd:
+		ref += fmt.Sprintf(":%d", m.highlightedVerseStart)
+	default:
+		ref += fmt.Sprintf(":%d-%d", m.highlightedVerseStart, m.highlightedVerseEnd)
+	}
+	return ref
+}
+
+// exportName proposes a file name for ref, "1-john-3-16-18.md".
+func exportName(ref string, comparison bool) string {
+	name := strings.ToLower(strings.Join(strings.FieldsFunc(ref, func(r rune) bool {
+		return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9')
+	}), "-"))
+	if comparison {
+		name += "-compare"
+	}
+	return name + export.Markdown.Ext()
+}
+
+// startExport opens the file name input in the status bar.
+func (m *Model) startExport() tea.Cmd {
+	comparison := m.mode == modeComparison
+	if comparison && m.currentParallelVerses == nil || !comparison && m.currentVerses == nil {
+		return nil
+	}
+	m.exporting = true
+	m.exportInput.SetValue(exportName(m.exportRef(), comparison))
+	m.exportInput.CursorEnd()
+	return m.exportInput.Focus()
+}
+
+// exportPassage collects what X exports.
+func (m Model) exportPassage() export.Passage {
+	columns := func(translation string, verses []api.Verse) export.Column {
+		c := export.Column{Translation: m.translationName(translation)}
+		for _, v := range verses {
+			if m.mode == modeComparison || m.highlightedVerseStart == 0 ||
+				v.Verse >= m.highlightedVerseStart && v.Verse <= m.highlightedVerseEnd {
+				c.Verses = append(c.Verses, export.Verse{Number: v.Verse, Text: stripHTMLTags(v.Text)})
+			}
+		}
+		return c
+	}
+	ref := m.exportRef()
+	if m.mode != modeComparison {
+		return export.Passage{
+			Title:   ref + " (" + m.translationName(m.selectedTranslation) + ")",
+			Columns: []export.Column{columns(m.selectedTranslation, m.currentVerses)},
+		}
+	}
+	p := export.Passage{Title: ref}
+	for _, t := range m.comparisonTranslations {
+		if verses, ok := m.currentParallelVerses[t]; ok {
+			p.Columns = append(p.Columns, columns(t, verses))
+		}
+	}
+	return p
+}
+
+// writeExport writes the passage to path, relative to the working
+// directory, and returns where it went.
+func (m Model) writeExport(path string) (string, error) {
+	if rest, ok := strings.CutPrefix(path, "~"+string(filepath.Separator)); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, rest)
+	}
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	text := export.Render(m.exportPassage(), export.FormatOf(path), export.ParseLayout(m.cfg.ExportLayout), m.verseNumbers)
+	if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// cycleExportFormat swaps the file name's extension for the next
+// format's.
+func (m *Model) cycleExportFormat() {
+	path := m.exportInput.Value()
+	f := export.FormatOf(path)
+	next := export.Formats[(slices.Index(export.Formats, f)+1)%len(export.Formats)]
+	m.exportInput.SetValue(strings.TrimSuffix(path, filepath.Ext(path)) + next.Ext())
+	m.exportInput.CursorEnd()
+}
+
+// updateExport handles keys while the file name input is open. Enter
+// writes the file; esc cancels.
+func (m Model) updateExport(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		path := strings.TrimSpace(m.exportInput.Value())
+		if path == "" {
+			return m, nil
+		}
+		m.exporting = false
+		m.exportInput.Blur()
+		out, err := m.writeExport(path)
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		return m, m.flash(locale.Tf("exported %s", out))
+	case "tab":
+		m.cycleExportFormat()
+		return m, nil
+	case "esc":
+		m.exporting = false
+		m.exportInput.Blur()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.exportInput, cmd = m.exportInput.Update(msg)
+	return m, cmd
+}
+
+// renderExport draws the file name input in place of the status bar
+// hints.
+func (m Model) renderExport(label lipgloss.Style, width int) string {
+	format := locale.T(export.FormatOf(m.exportInput.Value()).Name())
+	prompt := label.Render(fmt.Sprintf("⤓ %s (%s) ", m.exportRef(), format))
+	ti := m.exportInput
+	ti.SetStyles(m.themedInputStyles())
+	ti.SetWidth(max(width-lipgloss.Width(prompt)-2, 10))
+	return prompt + ti.View()
+}
@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/locale"
+)
+
+// * stars the book selected in the sidebar or the Miller books column,
+// or unstars it. Starred books are listed again under FAVORITES at the
+// top of both, in the order they were starred, and kept in
+// favorite_books.
+
+// testamentFavorites names the favorites section in
+// collapsed_testaments.
+const testamentFavorites = "favorites"
+
+// favoriteBooks returns the starred books there are in m.books.
+func (m Model) favoriteBooks() []api.Book {
+	var favs []api.Book
+	for _, id := range m.cfg.FavoriteBooks {
+		if i := slices.IndexFunc(m.books, func(b api.Book) bool { return b.BookID == id }); i >= 0 {
+			favs = append(favs, m.books[i])
+		}
+	}
+	return favs
+}
+
+// millerBooks is the Miller books column: the favorites, then every
+// book.
+func (m Model) millerBooks() []api.Book {
+	if m.books == nil {
+		return nil
+	}
+	return append(m.favoriteBooks(), m.books...)
+}
+
+// toggleFavorite stars book, or unstars it.
+func (m *Model) toggleFavorite(book api.Book) tea.Cmd {
+	if i := slices.Index(m.cfg.FavoriteBooks, book.BookID); i >= 0 {
+		m.cfg.FavoriteBooks = slices.Delete(slices.Clone(m.cfg.FavoriteBooks), i, i+1)
+		return m.flash(locale.Tf("%s unstarred", book.Name))
+	}
+	m.cfg.FavoriteBooks = append(slices.Clone(m.cfg.FavoriteBooks), book.BookID)
+	return m.flash(locale.Tf("★ %s added to favorites", book.Name))
+}
+
+// starSelected stars or unstars the book selected in the Miller books
+// column or the sidebar, keeping the selection on it.
+func (m *Model) starSelected() tea.Cmd {
+	if m.hasOverlay(overlayMiller) {
+		books := m.millerBooks()
+		if m.millerFilter != "" && m.millerFilteredBooks != nil {
+			books = m.millerFilteredBooks
+		}
+		if m.millerColumn != 0 || m.millerBookIdx >= len(books) {
+			return nil
+		}
+		book := books[m.millerBookIdx]
+		cmd := m.toggleFavorite(book)
+		if m.millerFilter == "" {
+			i := slices.IndexFunc(m.books, func(b api.Book) bool { return b.BookID == book.BookID })
+			m.millerBookIdx = len(m.favoriteBooks()) + i
+		}
+		return cmd
+	}
+	if m.focus != paneBooks || m.sidebarOnHeader || m.sidebarSelected >= len(m.books) {
+		return nil
+	}
+	// The favorites section coming or going moves the others.
+	had := len(m.favoriteBooks()) > 0
+	cmd := m.toggleFavorite(m.books[m.sidebarSelected])
+	if has := len(m.favoriteBooks()) > 0; has && !had {
+		m.sidebarSection++
+	} else if had && !has {
+		m.sidebarSection--
+	}
+	return cmd
+}
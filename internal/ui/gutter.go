@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"image/color"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+// gutterWidth is the annotation column to the left of the verse numbers:
+// one cell each for the highlight bar, bookmark and note marks, plus a
+// space.
+const gutterWidth = 4
+
+// annotationMarks reports what is attached to a verse of the current
+// chapter.
+func (m Model) annotationMarks(verse int) (highlight string, bookmarked, noted bool) {
+	if m.highlightStore != nil {
+		highlight = m.highlightStore.Color(m.currentBook, m.currentChapter, verse)
+	}
+	if m.bookmarkStore != nil {
+		bookmarked = m.bookmarkStore.Has(m.currentBook, m.currentChapter, verse)
+	}
+	noted = len(m.chapterNotes[verse]) > 0
+	return highlight, bookmarked, noted
+}
+
+// highlightColorOf returns the highlight color of a verse of the current
+// chapter, or "".
+func (m Model) highlightColorOf(verse int) string {
+	h, _, _ := m.annotationMarks(verse)
+	return h
+}
+
+func (m Model) annotated(verse int) bool {
+	h, b, n := m.annotationMarks(verse)
+	return h != "" || b || n
+}
+
+// showGutter reports whether the chapter has any annotations; the gutter
+// takes no room otherwise.
+func (m Model) showGutter(verses []int) bool {
+	for _, v := range verses {
+		if m.annotated(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// highlightColor maps a highlight color name onto the current theme.
+func (m Model) highlightColor(name string) color.Color {
+	switch name {
+	case "green":
+		return m.currentTheme.Success
+	case "blue":
+		return m.currentTheme.BorderActive
+	case "pink":
+		return m.currentTheme.Accent
+	}
+	return m.currentTheme.Warning
+}
+
+// highlightMark is the gutter mark of a highlight color. Each color has
+// its own shape, so highlights tell apart without seeing color.
+func highlightMark(name string) string {
+	switch name {
+	case "green":
+		return "▲"
+	case "blue":
+		return "■"
+	case "pink":
+		return "◆"
+	}
+	return "▌"
+}
+
+// addGutter prefixes every line of formatted chapter content with the
+// gutter column. markAt maps a line to the verse whose marks it carries.
+func (m Model) addGutter(content string, markAt map[int]int) string {
+	bg := lipgloss.NewStyle().Background(m.currentTheme.Background)
+	blank := bg.Render(strings.Repeat(" ", gutterWidth))
+	bookmarkStyle := bg.Foreground(m.currentTheme.Warning)
+	noteStyle := bg.Foreground(m.currentTheme.Secondary)
+
+	lines := strings.Split(content, "\n")
+	for i, ln := range lines {
+		if i == len(lines)-1 && ln == "" {
+			break
+		}
+		verse, ok := markAt[i]
+		if !ok {
+			lines[i] = blank + ln
+			continue
+		}
+		h, b, n := m.annotationMarks(verse)
+		cell := bg.Render(" ")
+		if h != "" {
+			cell = bg.Foreground(m.highlightColor(h)).Render(highlightMark(h))
+		}
+		if b {
+			cell += bookmarkStyle.Render("⚑")
+		} else {
+			cell += bg.Render(" ")
+		}
+		if n {
+			cell += noteStyle.Render("✎")
+		} else {
+			cell += bg.Render(" ")
+		}
+		lines[i] = cell + bg.Render(" ") + ln
+	}
+	return strings.Join(lines, "\n")
+}
+
+// jumpToAnnotation highlights the next (dir > 0) or previous annotated
+// verse of the chapter, wrapping around.
+func (m *Model) jumpToAnnotation(dir int) bool {
+	n := len(m.currentVerses)
+	if n == 0 {
+		return false
+	}
+	cur := -1
+	for i, v := range m.currentVerses {
+		if v.Verse == m.highlightedVerseStart {
+			cur = i
+			break
+		}
+	}
+	if cur < 0 && dir < 0 {
+		cur = n
+	}
+	for step := 1; step <= n; step++ {
+		i := ((cur+dir*step)%n + n) % n
+		if v := m.currentVerses[i].Verse; m.annotated(v) {
+			m.highlightedVerseStart, m.highlightedVerseEnd = v, v
+			m.refreshContent()
+			m.scrollToHighlightedVerse()
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"fmt"
+
+	"sword-tui/internal/version"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// header is the bar across the top: the app name, the translation, book
+// and chapter being read, and the version.
+type header struct {
+	translation string
+	book        string
+	chapter     int
+}
+
+func (m Model) header() header {
+	return header{
+		translation: m.translationName(m.selectedTranslation),
+		book:        m.currentBookName,
+		chapter:     m.currentChapter,
+	}
+}
+
+// View renders the header across width.
+func (h header) View(s styles, width int) string {
+	bookName := h.book
+	if bookName == "" {
+		bookName = "—"
+	}
+	chapter := fmt.Sprintf("%d", h.chapter)
+	if h.chapter == 0 {
+		chapter = "—"
+	}
+
+	breadcrumb := s.accent.Render("† sword-tui") +
+		s.muted.Render("  ·  ") +
+		s.text.Render(h.translation) +
+		s.muted.Render(" › ") +
+		s.text.Render(bookName) +
+		s.muted.Render(" › ") +
+		s.text.Render(chapter)
+
+	versionStr := s.strongSuccess.Render(version.Version)
+
+	innerWidth := width - 4 - 2 // -2 border -2 padding -2 safety
+	rightW := lipgloss.Width(versionStr)
+	leftW := innerWidth - rightW - 1
+	if leftW < 1 {
+		leftW = 1
+	}
+	// Long names give up the breadcrumb's end rather than wrap the bar.
+	leftSlot := s.bg.Width(leftW).MaxWidth(leftW).Render(ansi.Truncate(breadcrumb, leftW, "…"))
+
+	return s.activeBar.Width(width - 2).Render(leftSlot + s.bg.Render(" ") + versionStr)
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"sword-tui/internal/theme"
+	"sword-tui/internal/version"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// barThemes are a dark and a light theme, for the components to be
+// rendered in.
+var barThemes = []theme.Theme{theme.CatppuccinMocha, theme.CatppuccinLatte}
+
+// checkBar fails unless out is a one-row bar width-2 cells wide (the
+// View leaves a cell either side), drawn on th's background.
+func checkBar(t *testing.T, out string, th theme.Theme, width int) {
+	t.Helper()
+	if h := lipgloss.Height(out); h != 3 {
+		t.Errorf("bar is %d lines tall, want 3:\n%s", h, ansi.Strip(out))
+	}
+	for i, l := range strings.Split(out, "\n") {
+		if w := lipgloss.Width(l); w != width-2 {
+			t.Errorf("line %d is %d cells wide, want %d", i, w, width-2)
+		}
+	}
+	r, g, b, _ := th.Background.RGBA()
+	if bg := fmt.Sprintf("48;2;%d;%d;%d", r>>8, g>>8, b>>8); !strings.Contains(out, bg) {
+		t.Errorf("bar isn't drawn on the %s background", th.Name)
+	}
+}
+
+// middleRow is the text of a bar's only row, between its borders.
+func middleRow(out string) string {
+	lines := strings.Split(ansi.Strip(out), "\n")
+	if len(lines) < 2 {
+		return ""
+	}
+	return lines[1]
+}
+
+func TestHeaderView(t *testing.T) {
+	tests := []struct {
+		name   string
+		header header
+		width  int
+		want   []string
+	}{
+		{"reading", header{"KJV", "Genesis", 3}, 80, []string{"† sword-tui", "KJV › Genesis › 3", version.Version}},
+		{"nothing loaded yet", header{translation: "KJV"}, 80, []string{"KJV › — › —"}},
+		{"long names, narrowest terminal", header{"New Living Translation (2015 edition)", "Song of Solomon", 8}, 60, []string{"† sword-tui", version.Version}},
+	}
+	for _, th := range barThemes {
+		for _, tt := range tests {
+			t.Run(th.Name+"/"+tt.name, func(t *testing.T) {
+				out := tt.header.View(newStyles(th, true), tt.width)
+				checkBar(t, out, th, tt.width)
+				row := middleRow(out)
+				for _, w := range tt.want {
+					if !strings.Contains(row, w) {
+						t.Errorf("header %q doesn't show %q", row, w)
+					}
+				}
+			})
+		}
+	}
+}
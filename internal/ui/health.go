@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/locale"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+const (
+	// healthInterval is how often the connection is probed in the
+	// background.
+	healthInterval = 2 * time.Minute
+	// healthTimeout gives up on a probe; the API counts as offline.
+	healthTimeout = 8 * time.Second
+	// slowLatency marks the API degraded when a probe takes longer.
+	slowLatency = 2 * time.Second
+	// slowShown is how long after a request as slow the status bar
+	// says so.
+	slowShown = 30 * time.Second
+)
+
+// connStatus is what the last connectivity probe found.
+type connStatus int
+
+const (
+	connUnknown connStatus = iota // no probe finished yet
+	connOnline
+	connDegraded // slow, or answering with errors
+	connOffline  // unreachable
+)
+
+func (s connStatus) String() string {
+	switch s {
+	case connOnline:
+		return "online"
+	case connDegraded:
+		return "degraded"
+	case connOffline:
+		return "offline"
+	}
+	return "checking"
+}
+
+// healthMsg carries the result of a connectivity probe. manual is set for
+// checks the user asked for, which report back in the status bar.
+type healthMsg struct {
+	status  int // HTTP status, 0 when the server wasn't reached
+	latency time.Duration
+	err     error
+	manual  bool
+}
+
+// checkConnection probes the API.
+func checkConnection(client *api.Client, manual bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), healthTimeout)
+		defer cancel()
+		status, latency, err := client.Ping(ctx)
+		return healthMsg{status: status, latency: latency, err: err, manual: manual}
+	}
+}
+
+// healthTick schedules the next background probe.
+func healthTick() tea.Cmd {
+	return tea.Tick(healthInterval, func(time.Time) tea.Msg { return healthTickMsg{} })
+}
+
+type healthTickMsg struct{}
+
+// applyHealth records a probe result, flashing it when the user asked.
+func (m *Model) applyHealth(msg healthMsg) tea.Cmd {
+	switch {
+	case msg.err != nil:
+		m.conn = connOffline
+	case msg.status >= 400 || msg.latency > slowLatency:
+		m.conn = connDegraded
+	default:
+		m.conn = connOnline
+	}
+	if !msg.manual {
+		return nil
+	}
+	service := m.client.Provider().Name()
+	switch {
+	case errors.Is(msg.err, api.ErrOffline):
+		return m.alert(locale.Tf("%s: reading offline", service))
+	case errors.Is(msg.err, context.DeadlineExceeded):
+		return m.alert(locale.Tf("%s unreachable (timed out)", service))
+	case msg.err != nil:
+		return m.alert(locale.Tf("%s unreachable", service))
+	case msg.status >= 400:
+		return m.flash(locale.Tf("%s answered with status %d", service, msg.status))
+	case msg.latency > slowLatency:
+		return m.flash(locale.Tf("%s is slow (%.1f s)", service, msg.latency.Seconds()))
+	}
+	return m.flash(locale.Tf("%s reachable (%d ms)", service, msg.latency.Milliseconds()))
+}
+
+// RequestStats returns how the reader's requests to the provider went.
+func (m Model) RequestStats() api.RequestStats {
+	return m.client.Stats()
+}
+
+// renderConnStatus is the status-bar dot. Downloaded translations read
+// fine without a connection, which the label says. For a while after a
+// slow request it says how slow instead, so a sluggish reader can be
+// told from a sluggish server.
+func (m Model) renderConnStatus(bg lipgloss.Style) string {
+	if st := m.client.Stats(); st.Last > slowLatency && time.Since(st.LastAt) < slowShown {
+		return bg.Foreground(m.currentTheme.Warning).Render("◐") + bg.Render(" "+locale.Tf("API slow: %.1fs", st.Last.Seconds()))
+	}
+	// The dot's shape says as much as its color.
+	color, dot := m.currentTheme.Muted, "·"
+	switch m.conn {
+	case connOnline:
+		color, dot = m.currentTheme.Success, "●"
+	case connDegraded:
+		color, dot = m.currentTheme.Warning, "◐"
+	case connOffline:
+		color, dot = m.currentTheme.Error, "○"
+	}
+	label := m.conn.String()
+	if m.cache != nil && m.cache.IsCached(m.selectedTranslation) {
+		label += " · downloaded"
+	}
+	return bg.Foreground(color).Render(dot) + bg.Render(" "+label)
+}
+
+// checkMaxRequests reports a max_requests the client can't use.
+func checkMaxRequests(n int) error {
+	if n < 0 {
+		return fmt.Errorf("max_requests: %d is negative", n)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"slices"
+	"strings"
+
+	"sword-tui/internal/highlights"
+	"sword-tui/internal/locale"
+	"sword-tui/internal/theme"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// The highlight_style values.
+const (
+	highlightBackground = "background"
+	highlightBar        = "bar"
+)
+
+// highlightTint is how much of a highlight's color goes into the
+// background of its verse.
+const highlightTint = 0.22
+
+// checkHighlights reports a highlight_style or highlight_labels color the
+// reader doesn't know.
+func checkHighlights(style string, labels map[string]string) error {
+	var errs []error
+	if style != "" && style != highlightBackground && style != highlightBar {
+		errs = append(errs, fmt.Errorf("highlight_style: %q is not %q or %q", style, highlightBackground, highlightBar))
+	}
+	for c := range labels {
+		if !slices.Contains(highlights.Colors, c) {
+			errs = append(errs, fmt.Errorf("highlight_labels: %q is not a highlight color (%s)", c, strings.Join(highlights.Colors, ", ")))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// highlightLabel is what a highlight color stands for, from
+// highlight_labels, or the color's own name.
+func (m Model) highlightLabel(c string) string {
+	if l := m.cfg.HighlightLabels[c]; l != "" {
+		return l
+	}
+	return c
+}
+
+// verseBackground is the background of a verse highlighted c, or nil
+// when highlights only mark the gutter.
+func (m Model) verseBackground(c string) color.Color {
+	if c == "" || m.cfg.HighlightStyle == highlightBar || !m.tintable() {
+		return nil
+	}
+	return theme.Tint(m.currentTheme.Background, m.highlightColor(c), highlightTint)
+}
+
+// cycleHighlight moves the highlighted verses to the next highlight color
+// (after the last one the highlight is removed). A range takes the color
+// that follows its first verse's.
+func (m *Model) cycleHighlight() tea.Cmd {
+	if m.highlightStore == nil || m.highlightedVerseStart == 0 {
+		return m.flash("select a verse to highlight")
+	}
+	next := highlights.Next(m.highlightStore.Color(m.currentBook, m.currentChapter, m.highlightedVerseStart))
+	for v := m.highlightedVerseStart; v <= m.highlightedVerseEnd; v++ {
+		m.highlightStore.Set(m.currentBook, m.currentChapter, v, next)
+	}
+	if err := m.highlightStore.Save(); err != nil {
+		m.err = err
+		return nil
+	}
+	m.refreshContent()
+
+	ref := fmt.Sprintf("%s %d:%d", m.currentBookName, m.currentChapter, m.highlightedVerseStart)
+	if m.highlightedVerseEnd > m.highlightedVerseStart {
+		ref += fmt.Sprintf("-%d", m.highlightedVerseEnd)
+	}
+	if next == "" {
+		return m.flash(locale.Tf("removed highlight %s", ref))
+	}
+	return m.flash(highlightMark(next) + " " + m.highlightLabel(next) + " " + ref)
+}
@@ -0,0 +1,181 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"sword-tui/internal/locale"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// historyWindow is how many entries the history popup lists at once.
+const historyWindow = 12
+
+// typingQuery reports whether the reference or text search input has
+// focus, where history recall applies.
+func (m Model) typingQuery() bool {
+	return m.mode == modeSearch ||
+		m.mode == modeWordSearch && m.wordSearchResults == nil && !m.wordSearchLoading
+}
+
+// queryInput is the search input being typed in.
+func (m *Model) queryInput() *textinput.Model {
+	if m.mode == modeWordSearch {
+		return &m.wordSearchInput
+	}
+	return &m.textInput
+}
+
+// rememberQuery adds a submitted query to the shared history.
+func (m *Model) rememberQuery(q string) {
+	m.historyPos = -1
+	if m.history == nil {
+		return
+	}
+	m.history.Add(q)
+	if err := m.history.Save(); err != nil {
+		m.err = err
+	}
+}
+
+// recallQuery steps through the history into the search input: up goes
+// back, down forward, and past the newest entry restores what was being
+// typed.
+func (m *Model) recallQuery(key string) {
+	if m.history == nil || len(m.history.Entries) == 0 {
+		return
+	}
+	in := m.queryInput()
+	entries := m.history.Entries
+	switch key {
+	case "up":
+		if m.historyPos < 0 {
+			m.historyDraft = in.Value()
+			m.historyPos = len(entries)
+		}
+		if m.historyPos == 0 {
+			return
+		}
+		m.historyPos--
+		in.SetValue(entries[m.historyPos])
+	case "down":
+		if m.historyPos < 0 {
+			return
+		}
+		m.historyPos++
+		if m.historyPos >= len(entries) {
+			m.historyPos = -1
+			in.SetValue(m.historyDraft)
+		} else {
+			in.SetValue(entries[m.historyPos])
+		}
+	}
+	in.CursorEnd()
+}
+
+// openHistory shows every remembered query, newest first, to pick one for
+// the input it was opened from.
+func (m *Model) openHistory() {
+	if m.history == nil || len(m.history.Entries) == 0 {
+		return
+	}
+	m.historyReturn = m.mode
+	m.historySelected = 0
+	m.mode = modeHistory
+}
+
+// historyNewestFirst lists the entries the way the popup shows them.
+func (m Model) historyNewestFirst() []string {
+	entries := m.history.Entries
+	out := make([]string, len(entries))
+	for i, e := range entries {
+		out[len(entries)-1-i] = e
+	}
+	return out
+}
+
+// pickHistory puts the selected entry into the input the popup was opened
+// from.
+func (m *Model) pickHistory() {
+	list := m.historyNewestFirst()
+	m.mode = m.historyReturn
+	if m.historySelected < len(list) {
+		in := m.queryInput()
+		in.SetValue(list[m.historySelected])
+		in.CursorEnd()
+	}
+	m.historyPos = -1
+}
+
+// updateHistory handles keys while the history popup is open.
+func (m Model) updateHistory(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	n := len(m.history.Entries)
+	switch msg.String() {
+	case "esc", "ctrl+r":
+		m.mode = m.historyReturn
+	case "up", "k", "ctrl+p":
+		if m.historySelected > 0 {
+			m.historySelected--
+		}
+	case "down", "j", "ctrl+n":
+		if m.historySelected < n-1 {
+			m.historySelected++
+		}
+	case "enter":
+		m.pickHistory()
+	}
+	return m, nil
+}
+
+func (m Model) renderHistory() string {
+	bg := m.currentTheme.Background
+
+	maxAvail := m.width - leftPaneOuterWidth - 8
+	width := min(maxAvail, 60)
+	if width < 40 {
+		width = 40
+	}
+	innerW := width - 6
+
+	containerStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.currentTheme.BorderActive).
+		BorderBackground(bg).
+		Background(bg).
+		Width(width).
+		Padding(m.panelPadding())
+
+	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(m.styled())
+	selectedStyle := lipgloss.NewStyle().Foreground(bg).Background(m.currentTheme.Accent).Bold(m.styled())
+	normalStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Primary).Background(bg)
+	mutedStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(m.styled())
+
+	list := m.historyNewestFirst()
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(locale.T("History")) + m.panelTitleGap())
+
+	start := m.overlayWindowStart(m.historySelected, len(list), historyWindow)
+	end := min(start+historyWindow, len(list))
+	if start > 0 {
+		content.WriteString(mutedStyle.Render(fmt.Sprintf("  ↑ %d more", start)) + "\n")
+	}
+	for i := start; i < end; i++ {
+		label := clipText(list[i], innerW-2)
+		if i == m.historySelected {
+			line := "▸ " + label
+			if w := lipgloss.Width(line); w < innerW {
+				line += strings.Repeat(" ", innerW-w)
+			}
+			content.WriteString(selectedStyle.Render(line) + "\n")
+		} else {
+			content.WriteString(normalStyle.Render("  "+label) + "\n")
+		}
+	}
+	if end < len(list) {
+		content.WriteString(mutedStyle.Render(fmt.Sprintf("  ↓ %d more", len(list)-end)) + "\n")
+	}
+	return containerStyle.Render(strings.TrimSuffix(content.String(), "\n"))
+}
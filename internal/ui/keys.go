@@ -0,0 +1,100 @@
+package ui
+
+import "unicode/utf8"
+
+// Key is one documented key binding of the reader.
+type Key struct {
+	Keys string // as typed, e.g. "n / p"
+	Desc string
+}
+
+// KeyGroup is a titled set of key bindings.
+type KeyGroup struct {
+	Title string
+	Keys  []Key
+}
+
+// Keymap returns the reader's key bindings, grouped. The About page,
+// --help and the man page are all generated from it, so a binding added
+// to Update belongs here too; keys_test.go checks that every key Update
+// handles is listed.
+func Keymap() []KeyGroup {
+	return keymap
+}
+
+// KeyWidth is how many characters the longest binding in the keymap
+// takes, for lining up the descriptions after them.
+func KeyWidth() int {
+	w := 0
+	for _, g := range keymap {
+		for _, k := range g.Keys {
+			w = max(w, utf8.RuneCountInString(k.Keys))
+		}
+	}
+	return w
+}
+
+var keymap = []KeyGroup{
+	{"Navigation", []Key{
+		{"j / k", "move down / up"},
+		{"h / l", "move between panes"},
+		{"pgup / pgdown", "scroll a page"},
+		{"tab / shift+tab", "switch focused pane"},
+		{"[ / ]", "focus books / text"},
+		{"⏎", "open book / submit"},
+		{"space", "fold books section"},
+		{"n / p", "next / prev chapter"},
+		{"g", "chapter grid"},
+		{"m / '", "set / jump to mark"},
+		{"/", "go to verse"},
+		{"V", "go to reference on clipboard"},
+		{"v", "Miller columns"},
+		{"*", "star book"},
+	}},
+	{"Translations", []Key{
+		{"s", "search Bible"},
+		{"c", "compare translations"},
+		{"/ / =", "filter comparison / differences"},
+		{"O", "compare original, literal, dynamic"},
+		{"ctrl+r", "recently compared"},
+		{"t", "select translation"},
+		{"d", "download translations"},
+		{"C", "check connection"},
+	}},
+	{"Study", []Key{
+		{"y / Y", "yank verse / send to tmux"},
+		{"+", "append yanks to clipboard"},
+		{"X", "export to Markdown, HTML or text file"},
+		{"e", "select words in verse"},
+		{"a", "quick note on verse"},
+		{"E", "longer note on verse"},
+		{"N", "read notes, follow references"},
+		{"L", "notes that mention the verse"},
+		{"H", "cycle highlight color"},
+		{"{ / }", "prev / next annotated verse"},
+		{"A", "search annotations"},
+		{"I", "reading activity"},
+		{"R", "reading plans"},
+		{"0", "start screen"},
+		{"w / W", "pin passage / workspace"},
+		{"b / B", "bookmark / bookmarks"},
+		{"S", "sync annotations"},
+	}},
+	{"Display", []Key{
+		{"T", "select theme"},
+		{"D", "compact / comfortable density"},
+		{"z", "typewriter scrolling"},
+		{"#", "verse number style"},
+		{"o", "expand / collapse outline"},
+		{"|", "study view: cross refs, notes, commentary"},
+		{"x", "cross references of verse"},
+		{"P", "open chapter in $PAGER"},
+		{"M", "mouse on / off"},
+	}},
+	{"General", []Key{
+		{"?", "about"},
+		{"esc", "close / cancel"},
+		{"r", "back to reader"},
+		{"q", "quit"},
+	}},
+}
@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// keyAliases names the keys Update matches by the name the keymap lists
+// them under.
+var keyAliases = map[string]string{
+	"enter":  "⏎",
+	"up":     "k",
+	"down":   "j",
+	"left":   "h",
+	"right":  "l",
+	"ctrl+c": "q",
+}
+
+// updateKeys returns the keys of Update's main key switch: the one that
+// quits on "q".
+func updateKeys(t *testing.T) []string {
+	t.Helper()
+	f, err := parser.ParseFile(token.NewFileSet(), "model.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	ast.Inspect(f, func(n ast.Node) bool {
+		fn, ok := n.(*ast.FuncDecl)
+		if !ok || fn.Name.Name != "Update" || fn.Recv == nil {
+			return true
+		}
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			sw, ok := n.(*ast.SwitchStmt)
+			if !ok || keys != nil {
+				return true
+			}
+			var found []string
+			quits := false
+			for _, stmt := range sw.Body.List {
+				for _, e := range stmt.(*ast.CaseClause).List {
+					lit, ok := e.(*ast.BasicLit)
+					if !ok || lit.Kind != token.STRING {
+						continue
+					}
+					k, _ := strconv.Unquote(lit.Value)
+					found = append(found, k)
+					quits = quits || k == "q"
+				}
+			}
+			if quits {
+				keys = found
+			}
+			return true
+		})
+		return false
+	})
+	if keys == nil {
+		t.Fatal("no key switch quitting on q in Update")
+	}
+	return keys
+}
+
+// TestKeymapListsUpdateKeys checks that the keymap, and so the About
+// page, --help and the man page, lists every key the reader handles.
+func TestKeymapListsUpdateKeys(t *testing.T) {
+	listed := map[string]bool{}
+	for _, g := range Keymap() {
+		for _, k := range g.Keys {
+			for _, name := range strings.Fields(k.Keys) {
+				listed[name] = true
+			}
+		}
+	}
+	for _, k := range updateKeys(t) {
+		name := k
+		if a, ok := keyAliases[k]; ok {
+			name = a
+		}
+		if !listed[name] {
+			t.Errorf("Update handles %q, but the keymap doesn't list it", k)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/locale"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// Translations come in every language the provider has. The pickers list
+// those in the languages of cfg.Languages, English unless set, and hide
+// the rest like hidden translations, so a shows them too. l in the
+// translation picker chooses the languages. When a picker lists more
+// than one, each language's translations are headed by its name.
+
+// defaultLanguage is listed when cfg.Languages is empty.
+const defaultLanguage = "English"
+
+// languageWindow is how many languages the chooser lists at once.
+const languageWindow = 14
+
+// languages returns the languages the pickers list, in the order they
+// are listed.
+func (m Model) languages() []string {
+	if len(m.cfg.Languages) == 0 {
+		return []string{defaultLanguage}
+	}
+	return m.cfg.Languages
+}
+
+// languageShown reports whether the pickers list translations in lang.
+// Personal and SWORD translations, which have none, always are.
+func (m Model) languageShown(lang string) bool {
+	return lang == "" || slices.ContainsFunc(m.languages(), func(l string) bool { return strings.EqualFold(l, lang) })
+}
+
+// languageLabel heads the translations in lang.
+func languageLabel(lang string) string {
+	if lang == "" {
+		return locale.T("On this computer")
+	}
+	return lang
+}
+
+// orderTranslations sorts ts by language for the pickers: the languages
+// listed first, in their order, then personal and SWORD translations,
+// then the other languages alphabetically. Within a language the
+// provider's order is kept.
+func (m Model) orderTranslations(ts []api.Translation) []api.Translation {
+	rank := func(lang string) int {
+		if i := slices.IndexFunc(m.languages(), func(l string) bool { return strings.EqualFold(l, lang) }); i >= 0 {
+			return i
+		}
+		if lang == "" {
+			return len(m.languages())
+		}
+		return len(m.languages()) + 1
+	}
+	out := slices.Clone(ts)
+	slices.SortStableFunc(out, func(a, b api.Translation) int {
+		if c := cmp.Compare(rank(a.Language), rank(b.Language)); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Language, b.Language)
+	})
+	return out
+}
+
+// allLanguages returns the languages of the translations, in picker
+// order, with how many translations each has.
+func (m Model) allLanguages() ([]string, map[string]int) {
+	var langs []string
+	count := map[string]int{}
+	for _, t := range m.translations {
+		if t.Language == "" {
+			continue
+		}
+		if count[t.Language] == 0 {
+			langs = append(langs, t.Language)
+		}
+		count[t.Language]++
+	}
+	return langs, count
+}
+
+// openLanguages opens the language chooser over the translation picker.
+func (m *Model) openLanguages() tea.Cmd {
+	langs, _ := m.allLanguages()
+	if len(langs) == 0 {
+		return m.flash("the provider doesn't say what language its texts are in")
+	}
+	m.languageSelected = 0
+	m.pushOverlay(overlay{
+		name:  overlayLanguages,
+		place: placeCenter,
+		dim:   true,
+		view:  Model.renderLanguages,
+		key:   Model.updateLanguages,
+	})
+	return nil
+}
+
+func (m Model) updateLanguages(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	langs, _ := m.allLanguages()
+	switch msg.String() {
+	case "down", "j", "tab":
+		m.languageSelected = min(m.languageSelected+1, len(langs)-1)
+	case "up", "k", "shift+tab":
+		m.languageSelected = max(m.languageSelected-1, 0)
+	case "space", "enter":
+		if m.languageSelected < len(langs) {
+			return m, m.toggleLanguage(langs[m.languageSelected]), true
+		}
+	case "q", "l":
+		m.closeOverlay(overlayLanguages)
+	case "esc":
+		return m, nil, false
+	}
+	// Everything else is swallowed while the chooser has the focus.
+	return m, nil, true
+}
+
+// toggleLanguage lists lang's translations in the pickers, or stops
+// listing them. The pickers stay on the translations they were on, and
+// the chooser on lang. The languages are saved with the other settings
+// on quit.
+func (m *Model) toggleLanguage(lang string) tea.Cmd {
+	langs := slices.Clone(m.languages())
+	i := slices.IndexFunc(langs, func(l string) bool { return strings.EqualFold(l, lang) })
+	var msg string
+	switch {
+	case i >= 0 && len(langs) == 1:
+		return m.flash("at least one language has to be listed")
+	case i >= 0:
+		langs = slices.Delete(langs, i, i+1)
+		msg = locale.Tf("%s translations hidden", lang)
+	default:
+		langs = append(langs, lang)
+		msg = locale.Tf("%s translations listed", lang)
+	}
+
+	selected := func(p picker) string {
+		if p.selected < len(m.translations) {
+			return m.translations[p.selected].ShortName
+		}
+		return ""
+	}
+	translation, download := selected(m.translationPicker), selected(m.cachePicker)
+	m.cfg.Languages = langs
+	m.translations = m.orderTranslations(m.translations)
+	m.translationPicker.selected = m.translationIndex(translation)
+	m.cachePicker.selected = m.translationIndex(download)
+	m.translationPicker.settle(m.translationItems())
+	m.cachePicker.settle(m.cacheItems())
+	sorted, _ := m.allLanguages()
+	m.languageSelected = max(slices.Index(sorted, lang), 0)
+	return m.flash(msg)
+}
+
+// translationRTL reports whether short is written right to left.
+func (m Model) translationRTL(short string) bool {
+	i := slices.IndexFunc(m.translations, func(t api.Translation) bool { return t.ShortName == short })
+	return i >= 0 && strings.EqualFold(m.translations[i].Dir, "rtl")
+}
+
+// alignRight sets each line of wrapped flush with the right edge of
+// width cells, for translations written right to left.
+func alignRight(wrapped string, width int) string {
+	lines := strings.Split(wrapped, "\n")
+	for i, ln := range lines {
+		ln = strings.TrimSpace(ln)
+		lines[i] = strings.Repeat(" ", max(width-lipgloss.Width(ln), 0)) + ln
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m Model) renderLanguages() string {
+	bg := m.currentTheme.Background
+	w, _ := m.popupSize()
+	w = min(w, 44)
+	_, padX := m.panelPadding()
+
+	containerStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.currentTheme.BorderActive).
+		BorderBackground(bg).
+		Background(bg).
+		Width(w + 2 + 2*padX).
+		Padding(m.panelPadding())
+	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(m.styled())
+	selectedStyle := lipgloss.NewStyle().Foreground(bg).Background(m.currentTheme.Accent).Bold(m.styled())
+	shownStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Success).Background(bg)
+	textStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Primary).Background(bg)
+	mutedStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(m.styled())
+
+	langs, count := m.allLanguages()
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(locale.T("Languages")) +
+		mutedStyle.Render(fmt.Sprintf("  %d", len(langs))) + m.panelTitleGap())
+
+	start := m.overlayWindowStart(m.languageSelected, len(langs), languageWindow)
+	end := min(start+languageWindow, len(langs))
+	if start > 0 {
+		content.WriteString(mutedStyle.Render(fmt.Sprintf("  ↑ %d more", start)) + "\n")
+	}
+	for i := start; i < end; i++ {
+		lang := langs[i]
+		mark, style := "○", textStyle
+		if m.languageShown(lang) {
+			mark, style = "●", shownStyle
+		}
+		n := fmt.Sprint(count[lang])
+		name := clipText(lang, max(w-6-len(n), 1))
+		pad := strings.Repeat(" ", max(w-4-lipgloss.Width(name)-len(n), 1))
+		if i == m.languageSelected {
+			line := "▸ " + mark + " " + name + pad + n
+			line += strings.Repeat(" ", max(w-lipgloss.Width(line), 0))
+			content.WriteString(selectedStyle.Render(line) + "\n")
+			continue
+		}
+		content.WriteString(textStyle.Render("  ") + style.Render(mark+" "+name) + textStyle.Render(pad) + mutedStyle.Render(n) + "\n")
+	}
+	if end < len(langs) {
+		content.WriteString(mutedStyle.Render(fmt.Sprintf("  ↓ %d more", len(langs)-end)) + "\n")
+	}
+	content.WriteString("\n" + mutedStyle.Render(locale.T("↑↓ select  ·  space toggle  ·  esc close")))
+	return containerStyle.Render(content.String())
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"sword-tui/internal/locale"
+	"sword-tui/internal/marks"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// lastJumpMark is the mark set automatically before every jump, so ' '
+// returns to where you were, as in vim.
+const lastJumpMark = "'"
+
+// isMarkName reports whether key can name a mark: a letter.
+func isMarkName(key string) bool {
+	return len(key) == 1 && (key[0] >= 'a' && key[0] <= 'z' || key[0] >= 'A' && key[0] <= 'Z')
+}
+
+// hereMark is a mark at the highlighted verse.
+func (m Model) hereMark() marks.Mark {
+	return marks.Mark{Book: m.currentBook, BookName: m.currentBookName, Chapter: m.currentChapter, Verse: m.highlightedVerseStart}
+}
+
+// updateMarkPending finishes "m<letter>" or "'<letter>"; any other key
+// cancels.
+func (m Model) updateMarkPending(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	op := m.markPending
+	m.markPending = ""
+	key := msg.String()
+	switch {
+	case op == "m" && isMarkName(key):
+		mk := m.hereMark()
+		m.marks.Set(key, mk)
+		cmd := m.saveMarks()
+		return m, tea.Batch(cmd, m.flash(locale.Tf("mark %s · %s", key, mk.Reference())))
+	case op == "'" && (isMarkName(key) || key == lastJumpMark):
+		mk, ok := m.marks.Get(key)
+		if !ok {
+			return m, m.flash(locale.Tf("mark %s not set", key))
+		}
+		return m.jumpToMark(mk)
+	}
+	return m, nil
+}
+
+// jumpToMark moves to a mark, remembering the current verse as '.
+func (m Model) jumpToMark(mk marks.Mark) (tea.Model, tea.Cmd) {
+	m.marks.Set(lastJumpMark, m.hereMark())
+	save := m.saveMarks()
+
+	if mk.Book == m.currentBook && mk.Chapter == m.currentChapter && m.currentVerses != nil {
+		if mk.Verse > 0 {
+			m.highlightedVerseStart, m.highlightedVerseEnd = mk.Verse, mk.Verse
+			m.refreshContent()
+			m.scrollToHighlightedVerse()
+		}
+		return m, save
+	}
+	m.currentBook = mk.Book
+	m.currentBookName = mk.BookName
+	m.currentChapter = mk.Chapter
+	m.highlightedVerseStart, m.highlightedVerseEnd = mk.Verse, mk.Verse
+	m.loading = true
+	return m, tea.Batch(save, loadChapter(m.client, m.gen.nextContent(), m.selectedTranslation, m.currentBook, m.currentChapter))
+}
+
+// saveMarks writes the marks to disk when persist_marks is on; otherwise
+// they last for the session.
+func (m *Model) saveMarks() tea.Cmd {
+	if !m.cfg.PersistMarks {
+		return nil
+	}
+	if err := m.marks.Save(); err != nil {
+		m.err = err
+	}
+	return nil
+}
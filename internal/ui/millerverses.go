@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"sword-tui/internal/api"
+)
+
+// The Miller verses column lists the highlighted chapter's verse numbers
+// straight away from the book's verse counts, and fills in their text
+// once the chapter arrives, without loading it into the reader. The
+// text is fetched when the chapter cursor comes to rest, so the column
+// follows it without pressing right.
+
+// millerPreviewDelay is how long the cursor rests on a chapter before it
+// is fetched, so running down the list doesn't fetch each one passed.
+const millerPreviewDelay = 150 * time.Millisecond
+
+// millerPreviewMsg carries the text of a chapter for the verses column.
+type millerPreviewMsg struct {
+	gen     int
+	book    int
+	chapter int
+	verses  []api.Verse
+	err     error
+}
+
+// millerSettleMsg fires millerPreviewDelay after the cursor moved onto
+// book's chapter.
+type millerSettleMsg struct {
+	book    int
+	chapter int
+}
+
+func loadMillerPreview(client *api.Client, gen int, translation string, book, chapter int) tea.Cmd {
+	return func() tea.Msg {
+		verses, err := client.GetChapter(translation, book, chapter)
+		return millerPreviewMsg{gen, book, chapter, verses, err}
+	}
+}
+
+// millerSelection returns the book and chapter highlighted in the Miller
+// columns.
+func (m Model) millerSelection() (api.Book, int, bool) {
+	books := m.millerBooks()
+	if m.millerFilter != "" && m.millerFilteredBooks != nil {
+		books = m.millerFilteredBooks
+	}
+	if m.millerBookIdx >= len(books) {
+		return api.Book{}, 0, false
+	}
+	return books[m.millerBookIdx], m.millerChapterIdx + 1, true
+}
+
+// millerVerses lists the highlighted chapter's verses: with their text
+// when it is the chapter being read or its preview has arrived, as bare
+// numbers from the verse counts before that, and nil if neither is
+// known.
+func (m Model) millerVerses() []api.Verse {
+	book, chapter, ok := m.millerSelection()
+	if !ok {
+		return nil
+	}
+	if book.BookID == m.currentBook && chapter == m.currentChapter && m.currentVerses != nil {
+		return m.currentVerses
+	}
+	if m.millerPreviewOf == [2]int{book.BookID, chapter} && m.millerPreview != nil {
+		return m.millerPreview
+	}
+	n := book.VerseCount(chapter)
+	if n == 0 {
+		return nil
+	}
+	verses := make([]api.Verse, n)
+	for i := range verses {
+		verses[i] = api.Verse{Verse: i + 1}
+	}
+	return verses
+}
+
+// previewMiller fetches the highlighted chapter's text for the verses
+// column unless it is already at hand.
+func (m *Model) previewMiller() tea.Cmd {
+	book, chapter, ok := m.millerSelection()
+	if !ok || book.BookID == m.currentBook && chapter == m.currentChapter && m.currentVerses != nil ||
+		m.millerPreviewOf == [2]int{book.BookID, chapter} {
+		return nil
+	}
+	m.millerPreviewOf = [2]int{book.BookID, chapter}
+	m.millerPreview = nil
+	return loadMillerPreview(m.client, m.gen.nextMiller(), m.selectedTranslation, book.BookID, chapter)
+}
+
+// settleMiller fetches the highlighted chapter's text if the cursor is
+// still on it after millerPreviewDelay.
+func (m Model) settleMiller() tea.Cmd {
+	book, chapter, ok := m.millerSelection()
+	if !ok {
+		return nil
+	}
+	return tea.Tick(millerPreviewDelay, func(time.Time) tea.Msg {
+		return millerSettleMsg{book.BookID, chapter}
+	})
+}
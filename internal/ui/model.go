@@ -1,23 +1,49 @@
 package ui
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 	"sword-tui/internal/api"
+	"sword-tui/internal/bookmarks"
+	"sword-tui/internal/cache"
+	"sword-tui/internal/commentary"
+	"sword-tui/internal/comparisons"
+	"sword-tui/internal/crossref"
+	"sword-tui/internal/export"
+	"sword-tui/internal/highlights"
+	"sword-tui/internal/history"
+	"sword-tui/internal/locale"
+	"sword-tui/internal/marks"
+	"sword-tui/internal/notes"
+	"sword-tui/internal/outline"
+	"sword-tui/internal/plans"
+	"sword-tui/internal/reference"
+	"sword-tui/internal/report"
 	"sword-tui/internal/settings"
+	"sword-tui/internal/syncer"
+	"sword-tui/internal/textnorm"
 	"sword-tui/internal/theme"
-	"sword-tui/internal/version"
+	"sword-tui/internal/timing"
+	"sword-tui/internal/versenum"
+	"sword-tui/internal/visits"
+	"sword-tui/internal/votd"
+	"sword-tui/internal/workspace"
 	"time"
 
-	"github.com/atotto/clipboard"
 	"charm.land/bubbles/v2/progress"
+	"charm.land/bubbles/v2/textarea"
 	"charm.land/bubbles/v2/textinput"
 	"charm.land/bubbles/v2/viewport"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/colorprofile"
 )
 
 type viewMode int
@@ -32,6 +58,12 @@ const (
 	modeThemeSelect
 	modeAbout
 	modeWordSearch
+	modeWorkspace
+	modeBookmarks
+	modeAnnotations
+	modeActivity
+	modeChapterGrid
+	modeHistory
 )
 
 type focusPane int
@@ -39,6 +71,7 @@ type focusPane int
 const (
 	paneBooks focusPane = iota
 	paneContent
+	paneStudy // a pane of the study view; see study.go
 )
 
 type Model struct {
@@ -59,38 +92,75 @@ type Model struct {
 	err                    error
 	loading                bool
 	comparisonTranslations []string
-	sidebarSelected        int
-	showSidebar            bool
-	currentVerses          []api.Verse
-	currentParallelVerses  map[string][]api.Verse
-	highlightedVerseStart  int // Start of highlighted verse range
-	highlightedVerseEnd    int // End of highlighted verse range
+	// comparisonVerses limits the comparison to a range of verses (O);
+	// zero compares the whole chapter.
+	comparisonVerses [2]int
+	// comparisonLog remembers past comparisons for ctrl+r (see
+	// recentcompare.go).
+	comparisonLog            *comparisons.Store
+	recentComparisonSelected int
+	sidebarSelected          int
+	// sidebarOnHeader is set when the sidebar selection is on a
+	// section's header rather than a book; sidebarSection is the
+	// section of the selected line.
+	sidebarOnHeader       bool
+	sidebarSection        int
+	currentVerses         []api.Verse
+	currentParallelVerses map[string][]api.Verse
+	highlightedVerseStart int // Start of highlighted verse range
+	highlightedVerseEnd   int // End of highlighted verse range
 	// Miller columns state
 	millerColumn         int // 0=books, 1=chapters, 2=verses
 	millerBookIdx        int
 	millerChapterIdx     int
 	millerVerseIdx       int
-	showMillerColumns    bool
 	millerFilterInput    textinput.Model
 	millerFilter         string
 	millerFilteredBooks  []api.Book
 	millerFilteredVerses []api.Verse
-	millerFilterMode     bool // When true, all keys go to filter input
+	// millerPreview is the text of millerPreviewOf, the book and chapter
+	// the verses column last fetched; see millerverses.go.
+	millerPreview   []api.Verse
+	millerPreviewOf [2]int
+	// overlays are the popups over everything else, the top one focused;
+	// see overlay.go.
+	overlays []overlay
+	// about scrolls the About page; aboutSections are the content lines
+	// its sections start on, for [ and ].
+	about         viewport.Model
+	aboutSections []int
+	// whatsNew is the changelog shown in the what's-new popup, scrolled
+	// to line whatsNewTop.
+	whatsNew    string
+	whatsNewTop int
+	// updateTop scrolls the popup reviewing a translation update.
+	updateTop int
+	// noteVerse is the verse of the current chapter whose notes the note
+	// popup shows; noteLink is the reference selected in them.
+	noteVerse        int
+	noteLink         int
+	millerFilterMode bool // When true, all keys go to filter input
 	// Cache management state
 	cache                  CacheInterface
 	cachedTranslations     []string
-	cacheSelected          int
+	cachePicker            picker
 	downloadingTranslation string
 	// Translation selection state
-	translationSelected int
+	translationPicker picker
+	languageSelected  int // the row of the language chooser
 	// Theme state
-	currentTheme  theme.Theme
-	themeSelected int
+	currentTheme theme.Theme
+	styles       styles // built from currentTheme, see applyNightLight
+	themePicker  picker
+	// themeBefore is the theme in use when the picker opened, which the
+	// reader returns to if the preview of another is dismissed.
+	themeBefore theme.Theme
 	// Word search state
 	wordSearchInput    textinput.Model
 	wordSearchQuery    string
 	wordSearchResults  []api.Verse
 	wordSearchTotal    int
+	wordSearchPage     int // from 1, of api.SearchPageSize results
 	wordSearchSelected int
 	wordSearchLoading  bool
 	// Pane focus (book list vs content)
@@ -104,6 +174,42 @@ type Model struct {
 	// the viewport. The right pane title surfaces it as a sticky scroll
 	// indicator so the reader always knows where they are.
 	topVisibleVerse int
+	// verseOffsets is where each verse of the current chapter starts in
+	// m.content, as laid out by formatChapter.
+	verseOffsets []verseOffset
+	// density is densityComfortable or densityCompact.
+	density string
+	// plainText disables bold/italic/underline (see styled).
+	plainText bool
+	// verseNumbers is how verse numbers are drawn and copied.
+	verseNumbers versenum.Style
+	// wordSelect narrows the highlighted verse to the words between
+	// wordAnchor and wordCursor (see wordselect.go).
+	wordSelect             bool
+	wordAnchor, wordCursor int
+	// yankAppend adds each yank to the clipboard after the ones in
+	// yanked rather than replacing it (see yankappend.go).
+	yankAppend bool
+	yanked     []string
+	// hideOutlines turns off the chapter outline above the first verse;
+	// outlineExpanded shows its sections rather than a one-line summary.
+	hideOutlines    bool
+	outlineExpanded bool
+	// typewriter keeps the highlighted verse centered while moving.
+	typewriter bool
+	// noMouse turns mouse capture off so the terminal's own selection
+	// works.
+	noMouse bool
+	// inline draws in the terminal's main screen instead of the
+	// alternate one, so the last screen stays in the scrollback.
+	inline bool
+	// nightLight warms the theme's colors on a schedule.
+	nightLight nightLight
+	// colorProfile is how many colors the terminal shows, as detected
+	// or set by the colors setting; the theme is picked for it.
+	colorProfile colorprofile.Profile
+	// quietHours silences status-bar confirmations on a schedule.
+	quietHours dailyWindow
 	// Last known mouse position. Updated on every MouseClickMsg /
 	// MouseMotionMsg / MouseWheelMsg. The render functions read these
 	// to surface hover state (book row hover in the left pane, verse
@@ -120,10 +226,139 @@ type Model struct {
 	// picker. -1 means the picker is not scoped to a column (i.e. the
 	// normal "set the active translation" flow).
 	comparisonPickerColumn int
-	// Translation download progress in [0, 1]. Polled from the cache
-	// every ~120ms while a download is running.
+	// Translation download progress in [0, 1], and the bytes received
+	// of downloadTotal (-1 if unknown). Polled from the cache every
+	// ~120ms while a download is running.
 	downloadProgress float64
+	downloadRead     int64
+	downloadTotal    int64
 	progressBar      progress.Model
+	// downloads queues translations after the one being downloaded
+	// (see downloads.go).
+	downloads downloadQueue
+	// cfg is the settings snapshot loaded at startup. Quitting writes it
+	// back with the current position/theme so fields the model doesn't
+	// otherwise touch (sync configuration, etc.) survive a save.
+	cfg settings.Settings
+	// statusMsg is a transient message shown on the right of the status
+	// bar. statusSeq guards against an older clear tick wiping a newer
+	// message.
+	statusMsg string
+	statusSeq int
+	syncing   bool
+	// gen numbers in-flight requests so superseded responses are dropped.
+	gen *generations
+	// Study workspace: passages pinned with w, browsed with W.
+	workspace          *workspace.Workspace
+	workspaceSelected  int
+	workspaceNoteInput textinput.Model
+	workspaceEditing   bool
+	// capturing shows captureInput in the status bar to jot a note on
+	// the highlighted verse (see capture.go).
+	capturing    bool
+	captureInput textinput.Model
+	// comparisonFiltering shows comparisonFilterInput in the status bar;
+	// its term and comparisonDiffOnly narrow the comparison (see
+	// comparefilter.go).
+	comparisonFiltering   bool
+	comparisonFilterInput textinput.Model
+	comparisonDiffOnly    bool
+	// exporting shows exportInput in the status bar, the file X writes
+	// the passage on screen to (see export.go).
+	exporting   bool
+	exportInput textinput.Model
+	// noteEditor writes a longer note on noteEditVerse (E; see
+	// noteeditor.go).
+	noteEditor    textarea.Model
+	noteEditVerse int
+	// Bookmarks: toggled with b, browsed with B.
+	bookmarkStore    *bookmarks.Store
+	bookmarkSelected int
+	// Verse highlights, cycled with H, and the notes of the current
+	// chapter; both feed the annotation gutter.
+	highlightStore *highlights.Store
+	chapterNotes   map[int][]notes.Note
+	// backlinks index the notes that mention each verse; L lists those
+	// on backlinkVerse.
+	backlinks        backlinkIndex
+	backlinkVerse    int
+	backlinkSelected int
+	// Reading plans (R), ticked off as chapters are read: planIndex is
+	// the one the popup shows and planSelected its highlighted day;
+	// planCatchUp shows the ways to catch up on missed days.
+	readingPlans []*plans.Plan
+	planIndex    int
+	planSelected int
+	planCatchUp  bool
+	// Start screen (0, or on launch with start_screen set): the line
+	// selected and the verse of the day with its text once loaded.
+	dashSelected int
+	verseOfDay   verseOfDayMsg
+	// reminded maps each plan to the date its reminder last went off.
+	reminded map[string]string
+	// Study view (|): the side pane with the focus when focus is
+	// paneStudy, the cross reference selected and how far each pane is
+	// scrolled, all for the verse studyAt. Cross references load in the
+	// background; commentary loads with the chapter.
+	studyFocus        int
+	studySelected     int
+	studyScroll       [3]int
+	studyAt           verseKey
+	crossrefs         *crossref.Index
+	crossrefErr       error
+	chapterCommentary commentary.Chapter
+	// layoutBeforeCrossrefs is the layout x replaced with the cross
+	// references, put back when x closes them.
+	layoutBeforeCrossrefs string
+	// Annotations browser (A): a snapshot of every annotation, filtered
+	// by annotationQuery.
+	annotations        []annotation
+	annotationQuery    textinput.Model
+	annotationSelected int
+	// timings are the verse start times of the chapter's audio, if any,
+	// read for the chapter timingsFor names when audio of it first plays.
+	timings    *timing.Chapter
+	timingsFor string
+
+	// conn is what the last connectivity probe found.
+	conn connStatus
+	// retries holds requests waiting to be repeated after 429/5xx replies.
+	retries *retryQueue
+	// renders keeps recent layouts of the current chapter.
+	renders *renderCache
+	// parallel is the comparison being shown, drawn a window at a time.
+	parallel *parallelLayout
+
+	// Activity overlay: chapter visit counts and the tallies on show.
+	visitStore       *visits.Store
+	lastVisit        [2]int // book, chapter last counted
+	shownChapter     [2]int // book, chapter currentVerses are from
+	readingSince     time.Time
+	activityRows     []activityRow
+	activitySelected int
+	activityByBook   bool
+	// weekReport is the weekly summary shown in the activity overlay
+	// (nil shows the heat list); activityWeeksAgo picks the week.
+	weekReport       *report.Week
+	activityWeeksAgo int
+
+	// Chapter grid: the selected chapter and digits typed so far.
+	gridSelected int
+	gridTyped    string
+
+	// marks are the vim-style jump marks; markPending is "m" or "'"
+	// while waiting for the mark letter.
+	marks       *marks.Store
+	markPending string
+
+	// Query history shared by the reference and text search inputs.
+	// historyPos is the entry being recalled (-1 when typing afresh) and
+	// historyDraft what was typed before recalling.
+	history         *history.Store
+	historyPos      int
+	historyDraft    string
+	historySelected int
+	historyReturn   viewMode
 }
 
 type CacheInterface interface {
@@ -136,6 +371,18 @@ type CacheInterface interface {
 	// short-name being downloaded ("" if idle). Safe to call from any
 	// goroutine.
 	DownloadProgress() (float64, string)
+	// DownloadBytes reports the bytes of the current download received
+	// so far and its size, -1 if unknown.
+	DownloadBytes() (read, total int64)
+	// CancelDownload stops the running download, which then fails with
+	// context.Canceled.
+	CancelDownload()
+	// UpdateTranslation downloads a translation again and lists the
+	// chapters that differ, keeping the old copy until ApplyUpdate
+	// replaces it or DiscardUpdate drops the new one.
+	UpdateTranslation(translation string) ([]cache.ChapterChange, error)
+	ApplyUpdate(translation string) error
+	DiscardUpdate(translation string) error
 	ListCached() ([]string, error)
 	GetCacheSize() (int64, error)
 	RemoveTranslation(translation string) error
@@ -143,23 +390,77 @@ type CacheInterface interface {
 }
 
 type (
-	errMsg                  struct{ err error }
-	translationsLoadedMsg   struct{ translations []api.Translation }
-	booksLoadedMsg          struct{ books []api.Book }
-	chapterLoadedMsg        struct{ verses []api.Verse }
-	parallelVersesLoadedMsg struct{ verses map[string][]api.Verse }
-	cacheListLoadedMsg      struct{ translations []string }
-	downloadCompleteMsg     struct{ translation string }
-	downloadErrorMsg        struct {
+	errMsg                struct{ err error }
+	translationsLoadedMsg struct {
+		translations []api.Translation
+		stored       bool // from the response cache; see loadStored
+	}
+	cacheListLoadedMsg  struct{ translations []string }
+	downloadCompleteMsg struct{ translation string }
+	downloadErrorMsg    struct {
 		translation string
 		err         error
 	}
 )
 
+// The responses below carry the generation of the request that produced
+// them (see generations) and any error, so a stale failure is dropped as
+// quietly as a stale success.
+type booksLoadedMsg struct {
+	gen    int
+	books  []api.Book
+	err    error
+	stored bool // from the response cache; see loadStored
+}
+
+type chapterLoadedMsg struct {
+	gen    int
+	verses []api.Verse
+	err    error
+}
+
+type parallelVersesLoadedMsg struct {
+	gen    int
+	verses map[string][]api.Verse
+	err    error
+}
+
+// generations numbers async requests so that the response to a request
+// the user has since superseded (navigated on, switched translation,
+// re-ran a search) can be recognised and dropped instead of overwriting
+// newer state. Chapter and comparison loads share a counter because both
+// replace the reader content; Miller column previews have their own. It
+// is held by pointer so every copy of the Model sees the same counters.
+type generations struct {
+	content int
+	books   int
+	search  int
+	miller  int
+}
+
+func (g *generations) nextContent() int { g.content++; return g.content }
+func (g *generations) nextBooks() int   { g.books++; return g.books }
+func (g *generations) nextSearch() int  { g.search++; return g.search }
+func (g *generations) nextMiller() int  { g.miller++; return g.miller }
+
+type syncDoneMsg struct {
+	summary string
+	// settings are the ones another machine shared, when the sync
+	// pulled newer ones.
+	settings *settings.Settings
+	err      error
+}
+
+// statusClearMsg clears m.statusMsg if no newer message replaced it.
+type statusClearMsg struct{ seq int }
+
 type searchResultsLoadedMsg struct {
+	gen     int
+	err     error
 	results []api.Verse
 	total   int
 	query   string
+	page    int
 }
 
 // downloadTickMsg fires roughly every 120ms while a translation download
@@ -174,25 +475,83 @@ func downloadTick() tea.Cmd {
 
 func (e errMsg) Error() string { return e.err.Error() }
 
+// flash shows msg in the status bar for a few seconds.
+// During quiet hours it is dropped; use alert for failures that must be
+// seen.
+func (m *Model) flash(msg string) tea.Cmd {
+	if m.quietHours.active(time.Now()) {
+		return nil
+	}
+	return m.alert(msg)
+}
+
+// alert is flash that ignores quiet hours.
+func (m *Model) alert(msg string) tea.Cmd {
+	m.statusMsg = locale.T(msg)
+	m.statusSeq++
+	seq := m.statusSeq
+	return tea.Tick(4*time.Second, func(time.Time) tea.Msg {
+		return statusClearMsg{seq}
+	})
+}
+
 func NewModel() Model {
+	// --- Load persisted settings (if any) ---
+	cfg, err := settings.Load()
+	langErr := locale.Set(cfg.Language)
+
 	ti := textinput.New()
-	ti.Placeholder = "Enter verse reference (e.g., 1 1:1 or Gen 1:1)"
+	ti.Placeholder = locale.T("Enter verse reference (e.g., 1 1:1 or Gen 1:1)")
 	ti.Focus()
 	ti.CharLimit = 50
 	ti.SetWidth(50)
 
 	millerFilter := textinput.New()
-	millerFilter.Placeholder = "Type to filter..."
+	millerFilter.Placeholder = locale.T("Type to filter...")
 	millerFilter.CharLimit = 50
 	millerFilter.SetWidth(25)
 
 	wordSearch := textinput.New()
-	wordSearch.Placeholder = "Search the Bible..."
+	wordSearch.Placeholder = locale.T("Search the Bible...")
 	wordSearch.CharLimit = 100
 	wordSearch.SetWidth(50)
 
-	// --- Load persisted settings (if any) ---
-	cfg, err := settings.Load()
+	annotationQuery := textinput.New()
+	annotationQuery.Placeholder = locale.T("Search notes · book:john #tag color:yellow kind:note since:2026-01-01")
+	annotationQuery.CharLimit = 200
+
+	capture := textinput.New()
+	capture.Placeholder = locale.T("Quick note for this verse...")
+	capture.CharLimit = 500
+
+	comparisonFilter := textinput.New()
+	comparisonFilter.Placeholder = locale.T("Show verses that mention...")
+	comparisonFilter.CharLimit = 100
+
+	exportPath := textinput.New()
+	exportPath.CharLimit = 500
+
+	noteEditor := textarea.New()
+	noteEditor.Placeholder = locale.T("Note for this verse, in Markdown...")
+	noteEditor.ShowLineNumbers = false
+	noteEditor.Prompt = ""
+	noteEditor.CharLimit = 5000
+
+	workspaceNote := textinput.New()
+	workspaceNote.Placeholder = locale.T("Note for this passage...")
+	workspaceNote.CharLimit = 500
+	workspaceNote.SetWidth(50)
+
+	// A store whose file can't be read starts empty, is reported and
+	// isn't saved over.
+	ws, wsErr := workspace.Load()
+	bookmarkStore, bookmarksErr := bookmarks.Load()
+	hls, highlightsErr := highlights.Load()
+	queries, historyErr := history.Load()
+	compared, comparisonsErr := comparisons.Load()
+
+	seen, visitsErr := visits.Load(cfg.Reader)
+	readingPlans, planErr := plans.List(cfg.Reader)
 
 	selectedTranslation := "NLT"
 	currentBook := 1
@@ -220,26 +579,95 @@ func NewModel() Model {
 		}
 	}
 
-	return Model{
-		client:                 api.NewClient(),
+	night, nightErr := parseNightLight(cfg.NightLight, cfg.NightLightStrength)
+	_, colorsErr := parseColors(cfg.Colors)
+	jumpMarks := marks.New()
+	var marksErr error
+	if cfg.PersistMarks {
+		jumpMarks, marksErr = marks.Load()
+	}
+	quiet, quietErr := parseDailyWindow("quiet_hours", cfg.QuietHours)
+	client := api.NewClient()
+	provider, providerErr := api.NewProvider(cfg.Provider, cfg.ProviderKey)
+	if providerErr != nil {
+		providerErr = fmt.Errorf("provider: %w", providerErr)
+	} else {
+		client.SetProvider(provider)
+	}
+	var ttlErr error
+	if cfg.ResponseCacheTTL != "" {
+		ttl, err := time.ParseDuration(cfg.ResponseCacheTTL)
+		if err != nil {
+			ttlErr = fmt.Errorf("response_cache_ttl: %w", err)
+		} else {
+			client.SetResponseTTL(ttl)
+		}
+	}
+	client.SetMaxRequests(cfg.MaxRequests)
+	_, outlineErr := outline.For(0, 0) // surface a malformed outlines.json early
+
+	m := Model{
+		client:                 client,
 		textInput:              ti,
 		millerFilterInput:      millerFilter,
 		wordSearchInput:        wordSearch,
 		selectedTranslation:    selectedTranslation,
 		currentBook:            currentBook,
 		currentChapter:         currentChapter,
-		currentBookName:        "Genesis", // corrected after books load
+		currentBookName:        canonicalName(currentBook), // corrected after books load
 		mode:                   modeReader,
 		comparisonTranslations: []string{"NLT", "KJV", "WEB"},
 		currentTheme:           currentTheme,
-		themeSelected:          0,
+		translationPicker:      picker{window: translationWindow},
+		cachePicker:            picker{window: cacheWindow},
 		focus:                  paneContent,
 		// If the user had a theme stored in settings, treat it as pinned
 		// so auto-detect from the terminal background doesn't override it.
 		themePinned:            err == nil && cfg.CurrentTheme != "",
 		progressBar:            progress.New(progress.WithDefaultBlend(), progress.WithoutPercentage()),
 		comparisonPickerColumn: -1,
-	}
+		cfg:                    cfg,
+		density:                cfg.Density,
+		plainText:              cfg.PlainText,
+		typewriter:             cfg.Typewriter,
+		noMouse:                cfg.NoMouse,
+		hideOutlines:           cfg.HideOutlines,
+		outlineExpanded:        cfg.OutlineExpanded,
+		verseNumbers:           versenum.Parse(cfg.VerseNumbers),
+		nightLight:             night,
+		quietHours:             quiet,
+		workspace:              ws,
+		workspaceNoteInput:     workspaceNote,
+		captureInput:           capture,
+		comparisonFilterInput:  comparisonFilter,
+		exportInput:            exportPath,
+		noteEditor:             noteEditor,
+		annotationQuery:        annotationQuery,
+		bookmarkStore:          bookmarkStore,
+		highlightStore:         hls,
+		backlinks:              indexBacklinks(),
+		readingPlans:           readingPlans,
+		visitStore:             seen,
+		marks:                  jumpMarks,
+		history:                queries,
+		comparisonLog:          compared,
+		historyPos:             -1,
+		gen:                    &generations{},
+		retries:                &retryQueue{},
+		renders:                &renderCache{},
+		err:                    errors.Join(langErr, nightErr, colorsErr, quietErr, outlineErr, providerErr, wsErr, bookmarksErr, highlightsErr, historyErr, comparisonsErr, visitsErr, marksErr, ttlErr, checkMaxRequests(cfg.MaxRequests), planErr, checkShareFormat(cfg.ShareFormat), export.CheckLayout(cfg.ExportLayout), checkBookOrder(cfg.BookOrder), checkHighlights(cfg.HighlightStyle, cfg.HighlightLabels), checkReminders(cfg.Reminders), checkStudyTrio(cfg.StudyTrio)),
+	}
+	m.applyNightLight()
+	if cfg.StartScreen {
+		m.openDashboard() // Init fetches the verse of the day
+	}
+	m.noticeUpgrade(err == nil && cfg.SelectedTranslation != "")
+	return m
+}
+
+// SetLocalTranslations plugs in the store of user-imported translations.
+func (m *Model) SetLocalTranslations(local api.LocalSource) {
+	m.client.SetLocal(local)
 }
 
 func (m *Model) SetCache(cache CacheInterface) {
@@ -247,51 +675,95 @@ func (m *Model) SetCache(cache CacheInterface) {
 	if cache != nil {
 		// Set cache on API client too
 		m.client.SetCache(cache)
+		if c, ok := cache.(interface{ SetMirrors([]string) }); ok {
+			c.SetMirrors(m.cfg.DownloadMirrors)
+		}
+		// Drop API responses that have outlived their TTL.
+		if p, ok := cache.(interface{ PruneResponses(time.Duration) }); ok && m.client.ResponseTTL() > 0 {
+			go p.PruneResponses(m.client.ResponseTTL())
+		}
 	}
 }
 
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(
+	books := m.gen.nextBooks()
+	cmds := []tea.Cmd{
+		loadStored(m.client, books, m.selectedTranslation),
 		loadTranslations(m.client),
-		loadBooks(m.client, m.selectedTranslation),
-		loadChapter(m.client, m.selectedTranslation, m.currentBook, m.currentChapter),
+		loadBooks(m.client, books, m.selectedTranslation),
+		loadChapter(m.client, m.gen.nextContent(), m.selectedTranslation, m.currentBook, m.currentChapter),
 		// Ask the terminal for its background color so we can auto-pick
 		// a light or dark default theme if the user hasn't pinned one.
 		tea.RequestBackgroundColor,
-	)
+	}
+	if m.nightLight.scheduled() {
+		cmds = append(cmds, nightLightTick())
+	}
+	if _, ok := m.studyLayout(); ok {
+		cmds = append(cmds, loadCrossrefs())
+	}
+	if m.hasOverlay(overlayDashboard) {
+		cmds = append(cmds, loadVerseOfDay(m.client, m.selectedTranslation, votd.For(time.Now())))
+	}
+	cmds = append(cmds, checkConnection(m.client, false), healthTick(), reminderTick())
+	return tea.Batch(cmds...)
 }
 
 func loadTranslations(client *api.Client) tea.Cmd {
 	return func() tea.Msg {
 		translations, err := client.GetTranslations()
-		if err != nil {
+		if err != nil && len(translations) == 0 {
 			return errMsg{err}
 		}
-		return translationsLoadedMsg{translations}
+		return translationsLoadedMsg{translations: translations}
 	}
 }
 
-func loadBooks(client *api.Client, translation string) tea.Cmd {
+// canonicalName is a book's name in the built-in list, to show until the
+// translation's own list loads.
+func canonicalName(book int) string {
+	if b, ok := api.CanonicalBook(book); ok {
+		return b.Name
+	}
+	return ""
+}
+
+// loadStored answers the startup requests for the translation and book
+// lists from the last replies stored on disk, however old, so the
+// sidebar fills in at once; the replies from the network, requested
+// alongside, replace them when they come.
+func loadStored(client *api.Client, gen int, translation string) tea.Cmd {
+	return tea.Batch(
+		func() tea.Msg {
+			if translations, ok := client.StoredTranslations(); ok {
+				return translationsLoadedMsg{translations, true}
+			}
+			return nil
+		},
+		func() tea.Msg {
+			if books, ok := client.StoredBooks(translation); ok {
+				return booksLoadedMsg{gen: gen, books: books, stored: true}
+			}
+			return nil
+		},
+	)
+}
+
+func loadBooks(client *api.Client, gen int, translation string) tea.Cmd {
 	return func() tea.Msg {
 		books, err := client.GetBooks(translation)
-		if err != nil {
-			return errMsg{err}
-		}
-		return booksLoadedMsg{books}
+		return booksLoadedMsg{gen: gen, books: books, err: err}
 	}
 }
 
-func loadChapter(client *api.Client, translation string, book, chapter int) tea.Cmd {
+func loadChapter(client *api.Client, gen int, translation string, book, chapter int) tea.Cmd {
 	return func() tea.Msg {
 		verses, err := client.GetChapter(translation, book, chapter)
-		if err != nil {
-			return errMsg{err}
-		}
-		return chapterLoadedMsg{verses}
+		return chapterLoadedMsg{gen, verses, err}
 	}
 }
 
-func loadParallelVerses(client *api.Client, translations []string, book, chapter int, verses []int) tea.Cmd {
+func loadParallelVerses(client *api.Client, gen int, translations []string, book, chapter int, verses []int) tea.Cmd {
 	return func() tea.Msg {
 		req := api.ParallelVerseRequest{
 			Translations: translations,
@@ -300,10 +772,7 @@ func loadParallelVerses(client *api.Client, translations []string, book, chapter
 			Book:         book,
 		}
 		result, err := client.GetParallelVerses(req)
-		if err != nil {
-			return errMsg{err}
-		}
-		return parallelVersesLoadedMsg{result}
+		return parallelVersesLoadedMsg{gen, result, err}
 	}
 }
 
@@ -327,16 +796,70 @@ func downloadTranslation(cache CacheInterface, translation string) tea.Cmd {
 	}
 }
 
-func loadSearchResults(client *api.Client, translation, query string) tea.Cmd {
+func syncAnnotations(cfg settings.Settings) tea.Cmd {
 	return func() tea.Msg {
-		resp, err := client.SearchVerses(translation, query)
+		dir, err := settings.AnnotationsDir()
 		if err != nil {
-			return errMsg{err}
+			return syncDoneMsg{err: err}
+		}
+		var adapter syncer.Adapter
+		var shared []byte
+		switch cfg.SyncMode {
+		case "git":
+			adapter = syncer.NewGit(dir, cfg.SyncRemote)
+		case "webdav":
+			password := cfg.SyncPassword
+			if env := os.Getenv("SWORD_TUI_SYNC_PASSWORD"); env != "" {
+				password = env
+			}
+			// config.json holds the sync password and provider key, so
+			// the settings travel as a copy without them.
+			if shared, err = settings.WriteShared(cfg); err != nil {
+				return syncDoneMsg{err: err}
+			}
+			root := filepath.Dir(dir)
+			adapter, err = syncer.NewWebDAV(syncer.WebDAVOptions{
+				URL:      cfg.SyncRemote,
+				Username: cfg.SyncUser,
+				Password: password,
+				Root:     root,
+				Paths:    []string{filepath.Base(dir), settings.SharedName},
+				Strategy: cfg.SyncStrategy,
+			})
+			if err != nil {
+				return syncDoneMsg{err: err}
+			}
+		default:
+			return syncDoneMsg{err: fmt.Errorf("unknown sync_mode %q", cfg.SyncMode)}
+		}
+		summary, err := adapter.Sync()
+		if err != nil || shared == nil {
+			return syncDoneMsg{summary: summary, err: err}
+		}
+		// Settings pulled from another machine replace these ones.
+		if data, err := os.ReadFile(filepath.Join(filepath.Dir(dir), settings.SharedName)); err == nil && !bytes.Equal(data, shared) {
+			pulled, err := settings.ReadShared(cfg)
+			if err != nil {
+				return syncDoneMsg{summary: summary, err: err}
+			}
+			return syncDoneMsg{summary: summary, settings: &pulled}
+		}
+		return syncDoneMsg{summary: summary}
+	}
+}
+
+func loadSearchResults(client *api.Client, gen int, translation, query string, page int) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := client.SearchVersesPage(translation, query, page)
+		if err != nil {
+			return searchResultsLoadedMsg{gen: gen, err: err, query: query, page: page}
 		}
 		return searchResultsLoadedMsg{
+			gen:     gen,
 			results: resp.Results,
 			total:   resp.Total,
 			query:   query,
+			page:    page,
 		}
 	}
 }
@@ -345,18 +868,96 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
+	if paste, ok := msg.(tea.PasteMsg); ok {
+		msg = m.cleanPaste(paste)
+	}
+	m.notePosition()
+
 	switch msg := msg.(type) {
+	case tea.PasteMsg:
+		if next, cmd, ok := m.pasteIntoOverlay(msg); ok {
+			return next, cmd
+		}
+
 	case tea.KeyMsg:
+		if len(m.overlays) > 0 && msg.String() != "ctrl+c" {
+			if next, cmd, ok := m.updateOverlay(msg); ok {
+				return next, cmd
+			}
+		}
+		if m.mode == modeWorkspace && msg.String() != "ctrl+c" {
+			return m.updateWorkspace(msg)
+		}
+		if m.mode == modeBookmarks && msg.String() != "ctrl+c" {
+			return m.updateBookmarks(msg)
+		}
+		if m.mode == modeAnnotations && msg.String() != "ctrl+c" {
+			return m.updateAnnotations(msg)
+		}
+		if m.mode == modeActivity && msg.String() != "ctrl+c" {
+			return m.updateActivity(msg)
+		}
+		if m.mode == modeChapterGrid && msg.String() != "ctrl+c" {
+			return m.updateChapterGrid(msg)
+		}
+		if m.mode == modeAbout && msg.String() != "ctrl+c" && msg.String() != "q" {
+			return m.updateAbout(msg)
+		}
+		if m.mode == modeTranslationSelect || m.mode == modeThemeSelect || m.mode == modeCacheManager {
+			if next, cmd, ok := m.updatePicker(msg); ok {
+				return next, cmd
+			}
+		}
+		if m.capturing && msg.String() != "ctrl+c" {
+			return m.updateCapture(msg)
+		}
+		if m.comparisonFiltering && msg.String() != "ctrl+c" {
+			return m.updateComparisonFilter(msg)
+		}
+		if m.exporting && msg.String() != "ctrl+c" {
+			return m.updateExport(msg)
+		}
+		if m.wordSelect && m.mode == modeReader && msg.String() != "ctrl+c" {
+			return m.updateWordSelect(msg)
+		}
+		if m.markPending != "" && msg.String() != "ctrl+c" {
+			return m.updateMarkPending(msg)
+		}
+		if m.mode == modeHistory && msg.String() != "ctrl+c" {
+			return m.updateHistory(msg)
+		}
+		if m.focus == paneStudy && m.mode == modeReader {
+			if next, cmd, ok := m.updateStudy(msg); ok {
+				return next, cmd
+			}
+		}
+		if m.typingQuery() {
+			switch msg.String() {
+			case "up", "down":
+				m.recallQuery(msg.String())
+				return m, nil
+			case "ctrl+r":
+				m.openHistory()
+				return m, nil
+			}
+		}
 		switch msg.String() {
 		case "ctrl+c", "q":
 			// Save settings synchronously before quitting to avoid race condition
-			cfg := settings.Settings{
-				SelectedTranslation: m.selectedTranslation,
-				CurrentBook:         m.currentBook,
-				CurrentChapter:      m.currentChapter,
-				CurrentTheme:        m.currentTheme.Name,
-			}
+			cfg := m.cfg
+			cfg.SelectedTranslation = m.selectedTranslation
+			cfg.CurrentBook = m.currentBook
+			cfg.CurrentChapter = m.currentChapter
+			cfg.CurrentTheme = m.committedTheme().Name
+			cfg.Density = m.density
+			cfg.Typewriter = m.typewriter
+			cfg.VerseNumbers = string(m.verseNumbers)
+			cfg.OutlineExpanded = m.outlineExpanded
 			_ = settings.Save(cfg)
+			if m.visitStore != nil {
+				m.creditReading()
+				_ = m.visitStore.Save()
+			}
 			return m, tea.Quit
 		case "[":
 			if m.mode == modeReader {
@@ -365,6 +966,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					for i, book := range m.books {
 						if book.BookID == m.currentBook {
 							m.sidebarSelected = i
+							m.sidebarOnHeader = false
 							break
 						}
 					}
@@ -378,38 +980,34 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case "tab":
 			if m.mode == modeReader {
-				if m.focus == paneBooks {
-					m.focus = paneContent
-				} else {
-					m.focus = paneBooks
-					if m.books != nil && m.sidebarSelected == 0 {
-						for i, book := range m.books {
-							if book.BookID == m.currentBook {
-								m.sidebarSelected = i
-								break
-							}
-						}
-					}
-				}
+				m.cycleFocus(1)
 				return m, nil
 			}
 		case "shift+tab":
 			if m.mode == modeReader {
-				if m.focus == paneBooks {
-					m.focus = paneContent
-				} else {
-					m.focus = paneBooks
-				}
+				m.cycleFocus(-1)
 				return m, nil
 			}
+		case "|":
+			if m.mode == modeReader || m.mode == modeComparison {
+				cmd := m.cycleStudyLayout()
+				return m, cmd
+			}
+		case "x":
+			if m.mode == modeReader {
+				cmd := m.toggleCrossrefs()
+				return m, cmd
+			}
 		case "v":
 			if m.mode == modeReader {
-				m.showMillerColumns = !m.showMillerColumns
-				if m.showMillerColumns && m.books != nil {
+				if m.hasOverlay(overlayMiller) {
+					m.closeOverlay(overlayMiller)
+				} else if m.books != nil {
+					m.pushOverlay(m.millerOverlay())
 					// Initialize Miller columns with current position
 					for i, book := range m.books {
 						if book.BookID == m.currentBook {
-							m.millerBookIdx = i
+							m.millerBookIdx = len(m.favoriteBooks()) + i
 							break
 						}
 					}
@@ -422,11 +1020,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.millerFilteredBooks = nil
 					m.millerFilteredVerses = nil
 					m.millerFilterMode = false
+					m.millerPreview = nil
+					m.millerPreviewOf = [2]int{}
 				}
 				return m, nil
 			}
 		case "/":
-			if m.showMillerColumns {
+			if m.hasOverlay(overlayMiller) {
 				// Toggle filter mode in Miller columns
 				m.millerFilterMode = !m.millerFilterMode
 				if m.millerFilterMode {
@@ -435,10 +1035,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.millerFilterInput.Blur()
 				}
 				return m, nil
+			} else if m.mode == modeComparison {
+				return m, m.startComparisonFilter()
 			} else if m.mode == modeReader {
 				// Close sidebar if open when entering search mode
 				m.focus = paneContent
 				m.mode = modeSearch
+				m.historyPos = -1
 				m.textInput.Focus()
 				return m, nil
 			}
@@ -446,16 +1049,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.mode == modeWordSearch && m.wordSearchResults != nil && m.wordSearchSelected > 0 {
 				m.wordSearchSelected--
 				return m, nil
-			} else if m.mode == modeTranslationSelect && m.translations != nil && m.translationSelected > 0 {
-				m.translationSelected--
-				return m, nil
-			} else if m.mode == modeThemeSelect && m.themeSelected > 0 {
-				m.themeSelected--
-				return m, nil
-			} else if m.mode == modeCacheManager && m.translations != nil && m.cacheSelected > 0 {
-				m.cacheSelected--
-				return m, nil
-			} else if m.showMillerColumns && !m.millerFilterMode {
+			} else if m.hasOverlay(overlayMiller) && !m.millerFilterMode {
 				switch m.millerColumn {
 				case 0: // Books column
 					if m.millerBookIdx > 0 {
@@ -473,9 +1067,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						m.millerVerseIdx--
 					}
 				}
-				return m, nil
-			} else if m.focus == paneBooks && m.sidebarSelected > 0 {
-				m.sidebarSelected--
+				return m, m.settleMiller()
+			} else if m.focus == paneBooks && m.books != nil {
+				m.moveSidebar(-1)
 				return m, nil
 			} else if m.mode == modeReader && m.currentVerses != nil {
 				// Navigate to previous verse
@@ -489,7 +1083,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if currentIdx > 0 {
 					m.highlightedVerseStart = m.currentVerses[currentIdx-1].Verse
 					m.highlightedVerseEnd = m.highlightedVerseStart
-					m.content = m.formatChapter(m.currentVerses, m.currentBookName, m.currentChapter, m.viewport.Width(), m.highlightedVerseStart, m.highlightedVerseEnd)
+					m.content, m.verseOffsets = m.layoutChapter(m.viewport.Width())
 					m.viewport.SetContent(m.content)
 					m.scrollToHighlightedVerse()
 				}
@@ -499,19 +1093,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.mode == modeWordSearch && m.wordSearchResults != nil && m.wordSearchSelected < len(m.wordSearchResults)-1 {
 				m.wordSearchSelected++
 				return m, nil
-			} else if m.mode == modeTranslationSelect && m.translations != nil && m.translationSelected < len(m.translations)-1 {
-				m.translationSelected++
-				return m, nil
-			} else if m.mode == modeThemeSelect && m.themeSelected < len(theme.AllThemes())-1 {
-				m.themeSelected++
-				return m, nil
-			} else if m.mode == modeCacheManager && m.translations != nil && m.cacheSelected < len(m.translations)-1 {
-				m.cacheSelected++
-				return m, nil
-			} else if m.showMillerColumns && !m.millerFilterMode && m.books != nil {
+			} else if m.hasOverlay(overlayMiller) && !m.millerFilterMode && m.books != nil {
 				switch m.millerColumn {
 				case 0: // Books column
-					booksToUse := m.books
+					booksToUse := m.millerBooks()
 					if m.millerFilter != "" && m.millerFilteredBooks != nil {
 						booksToUse = m.millerFilteredBooks
 					}
@@ -521,7 +1106,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						m.millerVerseIdx = 0
 					}
 				case 1: // Chapters column
-					booksToUse := m.books
+					booksToUse := m.millerBooks()
 					if m.millerFilter != "" && m.millerFilteredBooks != nil {
 						booksToUse = m.millerFilteredBooks
 					}
@@ -533,7 +1118,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						}
 					}
 				case 2: // Verses column
-					versesToUse := m.currentVerses
+					versesToUse := m.millerVerses()
 					if m.millerFilter != "" && m.millerFilteredVerses != nil {
 						versesToUse = m.millerFilteredVerses
 					}
@@ -541,9 +1126,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						m.millerVerseIdx++
 					}
 				}
-				return m, nil
-			} else if m.focus == paneBooks && m.books != nil && m.sidebarSelected < len(m.books)-1 {
-				m.sidebarSelected++
+				return m, m.settleMiller()
+			} else if m.focus == paneBooks && m.books != nil {
+				m.moveSidebar(1)
 				return m, nil
 			} else if m.mode == modeReader && m.currentVerses != nil {
 				// Navigate to next verse
@@ -557,35 +1142,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if currentIdx >= 0 && currentIdx < len(m.currentVerses)-1 {
 					m.highlightedVerseStart = m.currentVerses[currentIdx+1].Verse
 					m.highlightedVerseEnd = m.highlightedVerseStart
-					m.content = m.formatChapter(m.currentVerses, m.currentBookName, m.currentChapter, m.viewport.Width(), m.highlightedVerseStart, m.highlightedVerseEnd)
+					m.content, m.verseOffsets = m.layoutChapter(m.viewport.Width())
 					m.viewport.SetContent(m.content)
 					m.scrollToHighlightedVerse()
 				}
 				return m, nil
 			}
 		case "left", "h":
-			if m.showMillerColumns && !m.millerFilterMode && m.millerColumn > 0 {
+			if m.hasOverlay(overlayMiller) && !m.millerFilterMode && m.millerColumn > 0 {
 				m.millerColumn--
 				return m, nil
 			}
 		case "right", "l":
-			if m.showMillerColumns && !m.millerFilterMode {
+			if m.hasOverlay(overlayMiller) && !m.millerFilterMode {
 				if m.millerColumn < 2 {
 					m.millerColumn++
-					// When moving to verses column, load the chapter if not already loaded
+					// When moving to verses column, fetch the chapter's text
 					if m.millerColumn == 2 {
-						booksToUse := m.books
-						if m.millerFilter != "" && m.millerFilteredBooks != nil {
-							booksToUse = m.millerFilteredBooks
-						}
-						if m.millerBookIdx < len(booksToUse) {
-							selectedBook := booksToUse[m.millerBookIdx]
-							selectedChapter := m.millerChapterIdx + 1
-							// Only load if different from current
-							if selectedBook.BookID != m.currentBook || selectedChapter != m.currentChapter {
-								return m, loadChapter(m.client, m.selectedTranslation, selectedBook.BookID, selectedChapter)
-							}
-						}
+						return m, m.previewMiller()
 					}
 				}
 				return m, nil
@@ -593,11 +1167,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "c":
 			if m.mode == modeReader {
 				m.mode = modeComparison
-				verses := []int{}
-				for i := 1; i <= 31; i++ {
-					verses = append(verses, i)
-				}
-				return m, loadParallelVerses(m.client, m.comparisonTranslations, m.currentBook, m.currentChapter, verses)
+				m.comparisonFilterInput.SetValue("")
+				m.comparisonVerses = [2]int{}
+				return m, loadParallelVerses(m.client, m.gen.nextContent(), m.comparisonTranslations, m.currentBook, m.currentChapter, m.comparisonVerseList())
 			}
 		case "r":
 			// Don't intercept 'r' when typing in search inputs
@@ -606,42 +1178,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else if m.mode == modeWordSearch && m.wordSearchResults == nil && !m.wordSearchLoading {
 				// Let it pass through to word search input
 			} else if m.mode != modeReader {
+				m.revertThemePreview()
 				m.mode = modeReader
 				return m, nil
 			}
 		case "t":
 			if m.mode == modeReader {
 				m.mode = modeTranslationSelect
-				m.translationSelected = 0
-				// Find current translation in list
-				if m.translations != nil {
-					for i, trans := range m.translations {
-						if trans.ShortName == m.selectedTranslation {
-							m.translationSelected = i
-							break
-						}
-					}
-				}
+				m.translationPicker.open(m.translationIndex(m.selectedTranslation))
 				return m, nil
 			}
 		case "T":
 			if m.mode == modeReader {
 				m.mode = modeThemeSelect
-				m.themeSelected = 0
-				// Find current theme in list
-				themes := theme.AllThemes()
-				for i, thm := range themes {
-					if thm.Name == m.currentTheme.Name {
-						m.themeSelected = i
-						break
-					}
-				}
+				m.themeBefore = m.currentTheme
+				m.themePicker.open(themeIndex(m.currentTheme.Name))
 				return m, nil
 			}
 		case "d":
 			if m.mode == modeReader {
 				m.mode = modeCacheManager
-				m.cacheSelected = 0
+				m.cachePicker.open(0)
 				if m.cache != nil {
 					return m, loadCachedList(m.cache)
 				}
@@ -649,20 +1206,198 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case "?":
 			if m.mode == modeReader {
-				m.mode = modeAbout
+				m.openAbout()
+				return m, nil
+			}
+		case "w":
+			if m.mode == modeReader {
+				return m, m.pinToWorkspace()
+			}
+		case "W":
+			if m.mode == modeReader {
+				m.mode = modeWorkspace
+				m.workspaceEditing = false
+				if m.workspaceSelected >= len(m.workspace.Passages) {
+					m.workspaceSelected = 0
+				}
+				return m, nil
+			}
+		case "b":
+			if m.mode == modeReader {
+				cmd := m.toggleBookmark()
+				return m, cmd
+			}
+		case "B":
+			if m.mode == modeReader {
+				m.mode = modeBookmarks
+				if m.bookmarkSelected >= len(m.bookmarkStore.Bookmarks) {
+					m.bookmarkSelected = 0
+				}
+				return m, nil
+			}
+		case "A":
+			if m.mode == modeReader {
+				cmd := m.openAnnotations()
+				return m, cmd
+			}
+		case "I":
+			if m.mode == modeReader {
+				m.openActivity()
+			}
+		case "g":
+			if m.mode == modeReader {
+				cmd := m.openChapterGrid()
+				return m, cmd
+			}
+		case "m", "'":
+			// Set (m) or jump to (') a mark; the letter comes next
+			if m.mode == modeReader && m.currentVerses != nil {
+				m.markPending = msg.String()
+			}
+		case "P":
+			if m.mode == modeReader || m.mode == modeComparison {
+				return m, m.openInPager()
+			}
+		case "H":
+			if m.mode == modeReader && m.currentVerses != nil {
+				cmd := m.cycleHighlight()
+				return m, cmd
+			}
+		case "}", "{":
+			if m.mode == modeReader && m.currentVerses != nil {
+				dir := 1
+				if msg.String() == "{" {
+					dir = -1
+				}
+				if !m.jumpToAnnotation(dir) {
+					return m, m.flash("no annotations in this chapter")
+				}
+				return m, nil
+			}
+		case "a":
+			if m.mode == modeReader && m.currentVerses != nil {
+				cmd := m.startCapture()
+				return m, cmd
+			}
+		case "E":
+			if m.mode == modeReader && m.currentVerses != nil {
+				return m, m.startNoteEditor()
+			}
+		case "N":
+			if m.mode == modeReader && m.currentVerses != nil {
+				cmd := m.openNotes()
+				return m, cmd
+			}
+		case "L":
+			if m.mode == modeReader && m.currentVerses != nil {
+				cmd := m.openBacklinks()
+				return m, cmd
+			}
+		case "R":
+			if m.mode == modeReader {
+				cmd := m.openPlans()
+				return m, cmd
+			}
+		case "0":
+			if m.mode == modeReader {
+				cmd := m.openDashboard()
+				return m, cmd
+			}
+		case "e":
+			if m.mode == modeReader && m.currentVerses != nil {
+				m.startWordSelect()
+				return m, nil
+			}
+		case "o":
+			if m.mode == modeReader && !m.hideOutlines {
+				m.outlineExpanded = !m.outlineExpanded
+				if m.currentVerses != nil {
+					m.content, m.verseOffsets = m.layoutChapter(m.viewport.Width())
+					m.viewport.SetContent(m.content)
+				}
 				return m, nil
 			}
+		case "#":
+			if m.mode == modeReader {
+				m.verseNumbers = m.verseNumbers.Next()
+				if m.currentVerses != nil {
+					m.content, m.verseOffsets = m.layoutChapter(m.viewport.Width())
+					m.viewport.SetContent(m.content)
+					m.scrollToHighlightedVerse()
+				}
+				return m, m.flash(locale.Tf("verse numbers: %s", m.verseNumbers))
+			}
+		case "M":
+			if m.mode == modeReader {
+				m.noMouse = !m.noMouse
+				if m.noMouse {
+					return m, m.flash("mouse off — select text with the terminal")
+				}
+				return m, m.flash("mouse on")
+			}
+		case "ctrl+r":
+			if m.mode == modeReader || m.mode == modeComparison {
+				return m, m.openRecentComparisons()
+			}
+		case "O":
+			if m.mode == modeReader {
+				return m, m.openStudyTrio()
+			}
+		case "=":
+			if m.mode == modeComparison {
+				return m, m.toggleComparisonDiff()
+			}
+		case "z":
+			if m.mode == modeReader {
+				m.typewriter = !m.typewriter
+				if m.currentVerses != nil && m.highlightedVerseStart > 0 {
+					m.scrollToHighlightedVerse()
+				}
+				if m.typewriter {
+					return m, m.flash("typewriter scrolling on")
+				}
+				return m, m.flash("typewriter scrolling off")
+			}
+		case "D":
+			if m.mode == modeReader {
+				if m.compact() {
+					m.density = densityComfortable
+				} else {
+					m.density = densityCompact
+				}
+				if m.currentVerses != nil {
+					m.content, m.verseOffsets = m.layoutChapter(m.viewport.Width())
+					m.viewport.SetContent(m.content)
+					m.scrollToHighlightedVerse()
+				}
+				return m, m.flash(locale.Tf("density: %s", m.density))
+			}
+		case "S":
+			if m.mode == modeReader && !m.syncing {
+				if m.cfg.SyncMode == "" {
+					return m, m.flash("sync disabled — set sync_mode in config.json")
+				}
+				m.syncing = true
+				m.statusMsg = "syncing annotations…"
+				return m, syncAnnotations(m.cfg)
+			}
 		case "s":
 			if m.mode == modeReader {
 				m.mode = modeWordSearch
+				m.historyPos = -1
 				m.wordSearchInput.SetValue("")
 				m.wordSearchInput.Focus()
 				m.wordSearchResults = nil
 				m.wordSearchSelected = 0
 				m.wordSearchLoading = false
+				// Drop the results of any search still in flight.
+				m.gen.nextSearch()
 				return m, nil
 			}
 		case "n":
+			if m.mode == modeWordSearch && m.wordSearchResults != nil {
+				return m, m.searchPage(1)
+			}
 			if m.mode == modeReader && m.books != nil {
 				for _, book := range m.books {
 					if book.BookID == m.currentBook {
@@ -671,49 +1406,77 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							m.loading = true
 							m.highlightedVerseStart = 0
 							m.highlightedVerseEnd = 0
-							return m, loadChapter(m.client, m.selectedTranslation, m.currentBook, m.currentChapter)
+							return m, loadChapter(m.client, m.gen.nextContent(), m.selectedTranslation, m.currentBook, m.currentChapter)
 						}
 					}
 				}
+				// Past the last chapter, on to the next book in book_order.
+				if next, ok := m.adjacentBook(1); ok {
+					m.currentBook = next.BookID
+					m.currentBookName = next.Name
+					m.currentChapter = 1
+					m.loading = true
+					m.highlightedVerseStart = 0
+					m.highlightedVerseEnd = 0
+					return m, loadChapter(m.client, m.gen.nextContent(), m.selectedTranslation, m.currentBook, m.currentChapter)
+				}
 			}
 		case "p":
+			if m.mode == modeWordSearch && m.wordSearchResults != nil {
+				return m, m.searchPage(-1)
+			}
 			if m.mode == modeReader && m.currentChapter > 1 {
 				m.currentChapter--
 				m.loading = true
 				m.highlightedVerseStart = 0
 				m.highlightedVerseEnd = 0
-				return m, loadChapter(m.client, m.selectedTranslation, m.currentBook, m.currentChapter)
+				return m, loadChapter(m.client, m.gen.nextContent(), m.selectedTranslation, m.currentBook, m.currentChapter)
+			}
+			// Before the first chapter, back to the end of the previous book.
+			if prev, ok := m.adjacentBook(-1); m.mode == modeReader && ok && prev.Chapters > 0 {
+				m.currentBook = prev.BookID
+				m.currentBookName = prev.Name
+				m.currentChapter = prev.Chapters
+				m.loading = true
+				m.highlightedVerseStart = 0
+				m.highlightedVerseEnd = 0
+				return m, loadChapter(m.client, m.gen.nextContent(), m.selectedTranslation, m.currentBook, m.currentChapter)
 			}
 		case "y":
 			// Yank (copy) highlighted verse(s) or current chapter to clipboard
 			if m.mode == modeReader && m.currentVerses != nil {
-				var textToCopy strings.Builder
-
-				// If verses are highlighted, only copy those
-				if m.highlightedVerseStart > 0 {
-					if m.highlightedVerseStart == m.highlightedVerseEnd {
-						textToCopy.WriteString(fmt.Sprintf("%s %s %d:%d\n\n", m.selectedTranslation, m.currentBookName, m.currentChapter, m.highlightedVerseStart))
-					} else {
-						textToCopy.WriteString(fmt.Sprintf("%s %s %d:%d-%d\n\n", m.selectedTranslation, m.currentBookName, m.currentChapter, m.highlightedVerseStart, m.highlightedVerseEnd))
-					}
-
-					for _, v := range m.currentVerses {
-						if v.Verse >= m.highlightedVerseStart && v.Verse <= m.highlightedVerseEnd {
-							text := stripHTMLTags(v.Text)
-							textToCopy.WriteString(fmt.Sprintf("%d. %s\n\n", v.Verse, text))
-						}
-					}
-				} else {
-					// Copy entire chapter
-					textToCopy.WriteString(fmt.Sprintf("%s %s %d\n\n", m.selectedTranslation, m.currentBookName, m.currentChapter))
-
-					for _, v := range m.currentVerses {
-						text := stripHTMLTags(v.Text)
-						textToCopy.WriteString(fmt.Sprintf("%d. %s\n\n", v.Verse, text))
-					}
+				text := m.yankText()
+				if n := m.copyYank(text); m.yankAppend {
+					return m, m.yankAppended(n)
 				}
-
-				clipboard.WriteAll(textToCopy.String())
+				if n := strings.Count(text, "\n\n") + 1; m.cfg.ShareFormat == shareThread && n > 1 {
+					return m, m.flash(locale.Tf("copied as %d posts", n))
+				}
+			}
+		case "+":
+			if m.mode == modeReader {
+				return m, m.toggleYankAppend()
+			}
+		case "X":
+			// Write the chapter, verses or comparison to a file
+			if m.mode == modeReader || m.mode == modeComparison {
+				return m, m.startExport()
+			}
+		case "Y":
+			// Send the same text to another tmux pane
+			if m.mode == modeReader && m.currentVerses != nil {
+				return m, sendToTmux(m.cfg.TmuxTarget, m.yankText())
+			}
+		case "V":
+			// Go to the reference on the clipboard
+			if m.mode == modeReader {
+				return m, readClipboard
+			}
+		case "C":
+			// Check the connection to the API now
+			if m.mode == modeReader {
+				m.conn = connUnknown
+				return m, tea.Batch(m.flash("checking connection…"), checkConnection(m.client, true))
 			}
 		case "pgdown":
 			// Page down = next chapter
@@ -725,7 +1488,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							m.loading = true
 							m.highlightedVerseStart = 0
 							m.highlightedVerseEnd = 0
-							return m, loadChapter(m.client, m.selectedTranslation, m.currentBook, m.currentChapter)
+							return m, loadChapter(m.client, m.gen.nextContent(), m.selectedTranslation, m.currentBook, m.currentChapter)
 						}
 						break
 					}
@@ -738,51 +1501,26 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.loading = true
 				m.highlightedVerseStart = 0
 				m.highlightedVerseEnd = 0
-				return m, loadChapter(m.client, m.selectedTranslation, m.currentBook, m.currentChapter)
+				return m, loadChapter(m.client, m.gen.nextContent(), m.selectedTranslation, m.currentBook, m.currentChapter)
 			}
-		case "enter":
-			if m.mode == modeTranslationSelect && m.translations != nil && m.translationSelected < len(m.translations) {
-				newTrans := m.translations[m.translationSelected].ShortName
-				// Picker was opened from a comparison column header:
-				// swap that column instead of changing the main reader.
-				if m.comparisonPickerColumn >= 0 && m.comparisonPickerColumn < len(m.comparisonTranslations) {
-					m.comparisonTranslations[m.comparisonPickerColumn] = newTrans
-					m.comparisonPickerColumn = -1
-					m.mode = modeComparison
-					m.loading = true
-					return m, loadParallelVerses(m.client, m.comparisonTranslations, m.currentBook, m.currentChapter, m.comparisonVerseList())
-				}
-				m.selectedTranslation = newTrans
-				m.mode = modeReader
-				m.loading = true
-				return m, tea.Batch(
-					loadBooks(m.client, m.selectedTranslation),
-					loadChapter(m.client, m.selectedTranslation, m.currentBook, m.currentChapter),
-				)
-			} else if m.mode == modeThemeSelect && m.themeSelected < len(theme.AllThemes()) {
-				// Select theme and update all colors
-				themes := theme.AllThemes()
-				m.currentTheme = themes[m.themeSelected]
-				m.themePinned = true
-				m.mode = modeReader
-				return m, nil
-			} else if m.mode == modeCacheManager && m.translations != nil && m.cacheSelected < len(m.translations) {
-				// Download selected translation
-				translation := m.translations[m.cacheSelected].ShortName
-				if m.cache != nil && !m.cache.IsCached(translation) {
-					m.downloadingTranslation = translation
-					m.downloadProgress = 0
-					return m, tea.Batch(downloadTranslation(m.cache, translation), downloadTick())
-				}
+		case "*":
+			if m.mode == modeReader && m.books != nil {
+				return m, m.starSelected()
+			}
+		case "space":
+			if m.mode == modeReader && m.focus == paneBooks && m.sidebarOnHeader {
+				m.toggleTestament()
 				return m, nil
-			} else if m.showMillerColumns && m.millerFilterMode {
+			}
+		case "enter":
+			if m.hasOverlay(overlayMiller) && m.millerFilterMode {
 				// Exit filter mode on enter
 				m.millerFilterMode = false
 				m.millerFilterInput.Blur()
 				return m, nil
-			} else if m.showMillerColumns && m.books != nil && m.currentVerses != nil {
+			} else if m.hasOverlay(overlayMiller) && m.books != nil {
 				// Navigate to the selected verse
-				booksToUse := m.books
+				booksToUse := m.millerBooks()
 				if m.millerFilter != "" && m.millerFilteredBooks != nil {
 					booksToUse = m.millerFilteredBooks
 				}
@@ -792,14 +1530,26 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.currentBook = selectedBook.BookID
 					m.currentBookName = selectedBook.Name
 					m.currentChapter = selectedChapter
-					m.showMillerColumns = false
+					m.closeOverlay(overlayMiller)
 					m.loading = true
 					m.highlightedVerseStart = 0
 					m.highlightedVerseEnd = 0
 					// Scroll viewport to the selected verse
-					return m, loadChapter(m.client, m.selectedTranslation, m.currentBook, m.currentChapter)
+					versesToUse := m.millerVerses()
+					if m.millerFilter != "" && m.millerFilteredVerses != nil {
+						versesToUse = m.millerFilteredVerses
+					}
+					if m.millerColumn == 2 && m.millerVerseIdx < len(versesToUse) {
+						m.highlightedVerseStart = versesToUse[m.millerVerseIdx].Verse
+						m.highlightedVerseEnd = m.highlightedVerseStart
+					}
+					return m, loadChapter(m.client, m.gen.nextContent(), m.selectedTranslation, m.currentBook, m.currentChapter)
 				}
 			} else if m.focus == paneBooks && m.books != nil {
+				if m.sidebarOnHeader {
+					m.toggleTestament()
+					return m, nil
+				}
 				// Select book from sidebar
 				if m.sidebarSelected < len(m.books) {
 					m.currentBook = m.books[m.sidebarSelected].BookID
@@ -809,41 +1559,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.loading = true
 					m.highlightedVerseStart = 0
 					m.highlightedVerseEnd = 0
-					return m, loadChapter(m.client, m.selectedTranslation, m.currentBook, m.currentChapter)
+					return m, loadChapter(m.client, m.gen.nextContent(), m.selectedTranslation, m.currentBook, m.currentChapter)
 				}
 			} else if m.mode == modeSearch {
 				input := m.textInput.Value()
-				book, chapter, verseStart, verseEnd, err := parseReference(input, m.books)
-				if err == nil {
-					m.currentBook = book
-					m.currentChapter = chapter
-					m.highlightedVerseStart = verseStart
-					m.highlightedVerseEnd = verseEnd
-
-					// Look up the book name from the book ID
-					for _, b := range m.books {
-						if b.BookID == book {
-							m.currentBookName = b.Name
-							break
-						}
-					}
-
-					m.mode = modeReader
-					m.loading = true
+				if _, _, _, _, _, err := m.parseReference(input, m.books); err == nil {
+					m.rememberQuery(input)
 					m.textInput.SetValue("")
-					return m, loadChapter(m.client, m.selectedTranslation, m.currentBook, m.currentChapter)
+					return m.gotoReference(input)
 				}
 			} else if m.mode == modeWordSearch {
 				if m.wordSearchResults == nil && !m.wordSearchLoading {
 					query := m.wordSearchInput.Value()
 					if query != "" {
+						m.rememberQuery(query)
 						// If the query contains digits AND parses as a verse
 						// reference (e.g. "rom8", "rom 8:8", "john 3:16"),
 						// jump there instead of doing a full-text search.
 						// Plain words like "love" or "rom" fall through to
 						// the full-text path.
 						if strings.ContainsAny(query, "0123456789") {
-							if book, chapter, vs, ve, refErr := parseReference(query, m.books); refErr == nil && book > 0 {
+							if book, chapter, vs, ve, refErr := reference.Parse(query, m.books); refErr == nil && book > 0 {
 								m.currentBook = book
 								m.currentChapter = chapter
 								m.highlightedVerseStart = vs
@@ -858,14 +1594,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 								m.loading = true
 								m.wordSearchInput.SetValue("")
 								m.wordSearchInput.Blur()
-								return m, loadChapter(m.client, m.selectedTranslation, m.currentBook, m.currentChapter)
+								return m, loadChapter(m.client, m.gen.nextContent(), m.selectedTranslation, m.currentBook, m.currentChapter)
 							}
 						}
 						m.wordSearchLoading = true
 						m.wordSearchInput.Blur()
-						return m, loadSearchResults(m.client, m.selectedTranslation, query)
+						return m, loadSearchResults(m.client, m.gen.nextSearch(), m.selectedTranslation, query, 1)
 					}
-				} else if m.wordSearchResults != nil && len(m.wordSearchResults) > 0 {
+				} else if m.wordSearchResults != nil && len(m.wordSearchResults) > 0 && !m.wordSearchLoading {
 					// Navigate to selected result
 					result := m.wordSearchResults[m.wordSearchSelected]
 					m.currentBook = result.Book
@@ -883,7 +1619,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 					m.mode = modeReader
 					m.loading = true
-					return m, loadChapter(m.client, m.selectedTranslation, m.currentBook, m.currentChapter)
+					return m, loadChapter(m.client, m.gen.nextContent(), m.selectedTranslation, m.currentBook, m.currentChapter)
 				}
 			} else if m.mode == modeTranslationSelect {
 				// Simple translation selection (cycle through common ones)
@@ -897,35 +1633,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.mode = modeReader
 				m.loading = true
 				return m, tea.Batch(
-					loadBooks(m.client, m.selectedTranslation),
-					loadChapter(m.client, m.selectedTranslation, m.currentBook, m.currentChapter),
+					loadBooks(m.client, m.gen.nextBooks(), m.selectedTranslation),
+					loadChapter(m.client, m.gen.nextContent(), m.selectedTranslation, m.currentBook, m.currentChapter),
 				)
 			}
-		case "x":
-			// Delete cached translation
-			if m.mode == modeCacheManager && m.translations != nil && m.cacheSelected < len(m.translations) {
-				translation := m.translations[m.cacheSelected].ShortName
-				if m.cache != nil && m.cache.IsCached(translation) {
-					if err := m.cache.RemoveTranslation(translation); err == nil {
-						return m, loadCachedList(m.cache)
-					}
-				}
-				return m, nil
-			}
 		case "esc":
 			if m.mode == modeCacheManager {
 				m.mode = modeReader
 				return m, nil
 			}
-			if m.showMillerColumns && m.millerFilterMode {
-				// Exit filter mode on esc
-				m.millerFilterMode = false
-				m.millerFilterInput.Blur()
-				return m, nil
-			} else if m.showMillerColumns {
-				m.showMillerColumns = false
-				return m, nil
-			}
 			if m.mode == modeSearch || m.mode == modeTranslationSelect || m.mode == modeThemeSelect || m.mode == modeAbout || m.mode == modeComparison || m.mode == modeWordSearch || m.mode == modeCacheManager {
 				// Picker was opened from a comparison column: dismiss
 				// it back into comparison view instead of dropping all
@@ -935,6 +1651,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.mode = modeComparison
 					return m, nil
 				}
+				m.revertThemePreview()
 				m.mode = modeReader
 				m.wordSearchResults = nil
 				m.wordSearchInput.SetValue("")
@@ -952,6 +1669,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			break
 		}
 
+		if len(m.overlays) > 0 {
+			m.clickOverlay(msg.X, msg.Y)
+			return m, nil
+		}
+
 		// Overlay is active: clicks inside its panel select items;
 		// clicks outside close the overlay.
 		if m.overlayActive() {
@@ -965,15 +1687,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.mode = modeComparison
 					return m, nil
 				}
+				m.revertThemePreview()
 				m.mode = modeReader
 				m.wordSearchResults = nil
 				m.wordSearchInput.SetValue("")
 				return m, nil
 			}
 			// Inner content area sits one row in from the top border and
-			// one row down for the box's top padding, plus the panel's
-			// title line and its trailing blank line. Item rows then start.
-			rowInPanel := msg.Y - py - 1 - 1 - 1 - 1
+			// down by the box's top padding, plus the panel's title line
+			// and (unless compact) its trailing blank line. Item rows
+			// then start.
+			padTop, _ := m.panelPadding()
+			titleRows := strings.Count(m.panelTitleGap(), "\n")
+			rowInPanel := msg.Y - py - 1 - padTop - titleRows
 			cmd := m.overlayClick(rowInPanel)
 			return m, cmd
 		}
@@ -983,12 +1709,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.focus = paneBooks
 			if i, ok := m.bookAtRow(msg.Y); ok {
 				m.sidebarSelected = i
+				m.sidebarOnHeader = false
 				m.currentBook = m.books[i].BookID
 				m.currentBookName = m.books[i].Name
 				m.currentChapter = 1
 				m.focus = paneContent
 				m.loading = true
-				return m, loadChapter(m.client, m.selectedTranslation, m.currentBook, m.currentChapter)
+				return m, loadChapter(m.client, m.gen.nextContent(), m.selectedTranslation, m.currentBook, m.currentChapter)
+			}
+			return m, nil
+		}
+
+		// Click in the study column — focus that pane.
+		if studyW := m.studyWidth(); studyW > 0 && msg.X >= m.width-studyW {
+			if i, ok := m.studyPaneAt(msg.Y); ok && m.mode == modeReader {
+				m.focus = paneStudy
+				m.studyFocus = i
 			}
 			return m, nil
 		}
@@ -1004,15 +1740,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if col := m.comparisonColumnAtX(msg.X); col >= 0 {
 					m.comparisonPickerColumn = col
 					m.mode = modeTranslationSelect
-					m.translationSelected = 0
-					if m.translations != nil {
-						for i, t := range m.translations {
-							if t.ShortName == m.comparisonTranslations[col] {
-								m.translationSelected = i
-								break
-							}
-						}
-					}
+					m.translationPicker.open(m.translationIndex(m.comparisonTranslations[col]))
 					return m, nil
 				}
 			}
@@ -1050,7 +1778,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.highlightedVerseEnd = v
 					m.dragAnchorVerse = v
 				}
-				m.content = m.formatChapter(m.currentVerses, m.currentBookName, m.currentChapter, m.viewport.Width(), m.highlightedVerseStart, m.highlightedVerseEnd)
+				m.content, m.verseOffsets = m.layoutChapter(m.viewport.Width())
 				m.viewport.SetContent(m.content)
 			}
 		}
@@ -1083,7 +1811,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if start != m.highlightedVerseStart || end != m.highlightedVerseEnd {
 					m.highlightedVerseStart = start
 					m.highlightedVerseEnd = end
-					m.content = m.formatChapter(m.currentVerses, m.currentBookName, m.currentChapter, m.viewport.Width(), m.highlightedVerseStart, m.highlightedVerseEnd)
+					m.content, m.verseOffsets = m.layoutChapter(m.viewport.Width())
 					m.viewport.SetContent(m.content)
 				}
 			}
@@ -1106,23 +1834,39 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.X >= 0 && msg.X < leftPaneOuterWidth && m.books != nil {
 			switch msg.Button {
 			case tea.MouseWheelUp:
-				if m.sidebarSelected > 0 {
-					m.sidebarSelected--
-				}
+				m.moveSidebar(-1)
 			case tea.MouseWheelDown:
-				if m.sidebarSelected < len(m.books)-1 {
-					m.sidebarSelected++
-				}
+				m.moveSidebar(1)
 			}
 			m.focus = paneBooks
 			return m, nil
 		}
+		// The study column scrolls the pane under the wheel.
+		if studyW := m.studyWidth(); studyW > 0 && msg.X >= m.width-studyW {
+			if i, ok := m.studyPaneAt(msg.Y); ok && m.mode == modeReader {
+				m.focus = paneStudy
+				m.studyFocus = i
+				key := tea.KeyPressMsg{Code: tea.KeyDown}
+				if msg.Button == tea.MouseWheelUp {
+					key = tea.KeyPressMsg{Code: tea.KeyUp}
+				}
+				next, _, _ := m.updateStudy(key)
+				return next, nil
+			}
+			return m, nil
+		}
 		// Otherwise forward to the viewport in the content pane.
 		if m.focus == paneContent || msg.X >= leftPaneOuterWidth {
 			m.viewport, cmd = m.viewport.Update(msg)
 			cmds = append(cmds, cmd)
 		}
 
+	case tea.ColorProfileMsg:
+		// Sent once at startup, with the detected profile or the one
+		// the colors setting forces.
+		m.colorProfile = msg.Profile
+		m.applyNightLight()
+
 	case tea.BackgroundColorMsg:
 		// Only act on the first BackgroundColorMsg if the user hasn't
 		// pinned a theme. Pick a sensible default for the terminal's
@@ -1135,57 +1879,82 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				chosen = theme.CatppuccinLatte
 			}
 			m.currentTheme = chosen
-			// Sync themeSelected so the picker opens on the right row
-			// next time the user presses T.
-			for i, th := range theme.AllThemes() {
-				if th.Name == chosen.Name {
-					m.themeSelected = i
-					break
-				}
-			}
+			m.applyNightLight()
+			// Sync the theme picker so it opens on the right row next
+			// time the user presses T.
+			m.themePicker.selected = themeIndex(chosen.Name)
 		}
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-
-		vpW, vpH := m.viewportSize()
+		if m.inline && m.height > 1 {
+			m.height-- // View adds a blank line at the bottom
+		}
 
 		if !m.ready {
+			vpW, vpH := m.viewportSize()
 			m.viewport = viewport.New(viewport.WithWidth(vpW), viewport.WithHeight(vpH))
 			m.viewport.YPosition = 4
 			m.ready = true
-		} else {
-			m.viewport.SetWidth(vpW)
-			m.viewport.SetHeight(vpH)
 		}
-
 		// Reformat content with new width
-		if m.currentVerses != nil {
-			m.content = m.formatChapter(m.currentVerses, m.currentBookName, m.currentChapter, vpW, m.highlightedVerseStart, m.highlightedVerseEnd)
-		} else if m.currentParallelVerses != nil {
-			m.content = m.formatParallelVerses(m.currentParallelVerses, m.comparisonTranslations, m.currentBookName, m.currentChapter, vpW)
-		}
-		m.viewport.SetContent(m.content)
+		m.resizeReader()
 
 	case translationsLoadedMsg:
-		m.translations = msg.translations
+		// A stored list only stands in until the network answers.
+		if msg.stored && m.translations != nil {
+			break
+		}
+		m.translations = m.orderTranslations(msg.translations)
 
 	case booksLoadedMsg:
-		m.books = msg.books
-		for _, book := range m.books {
+		if msg.gen != m.gen.books || msg.stored && m.books != nil {
+			break
+		}
+		if msg.err != nil {
+			if cmd, ok := m.queueRetry(retryBooks, msg.gen, msg.err, loadBooks(m.client, msg.gen, m.selectedTranslation)); ok {
+				return m, cmd
+			}
+			m.err = msg.err
+			break
+		}
+		m.books = m.orderBooks(msg.books)
+		for i, book := range m.books {
 			if book.BookID == m.currentBook {
 				m.currentBookName = book.Name
+				m.sidebarSelected = i
+				m.sidebarOnHeader = false
 				break
 			}
 		}
 
 	case chapterLoadedMsg:
+		if msg.gen != m.gen.content {
+			break
+		}
 		m.loading = false
+		m.wordSelect = false
+		m.chapterNotes, _ = notes.ForChapter(m.currentBook, m.currentChapter)
+		m.chapterCommentary, _ = commentary.Load(m.currentBook, m.currentChapter)
+		if msg.err != nil {
+			retry := loadChapter(m.client, msg.gen, m.selectedTranslation, m.currentBook, m.currentChapter)
+			if cmd, ok := m.queueRetry(retryContent, msg.gen, msg.err, retry); ok {
+				return m, cmd
+			}
+			m.err = msg.err
+			break
+		}
 		m.currentVerses = msg.verses
 		m.currentParallelVerses = nil
+		m.parallel = nil
+		m.shownChapter = [2]int{m.currentBook, m.currentChapter}
+		m.noteVerseCount(msg.verses)
+		m.recordVisit()
+		cmds = append(cmds, m.prefetchAdjacent())
 		// Track if we came from a search (highlighted verse was set)
 		cameFromSearch := m.highlightedVerseStart > 1
+		resume := m.highlightedVerseStart == 0
 		// Initialize highlighted verse to first verse or use the range from search
 		if m.highlightedVerseStart == 0 {
 			if len(msg.verses) > 0 {
@@ -1196,7 +1965,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.highlightedVerseEnd = 1
 			}
 		}
-		m.content = m.formatChapter(msg.verses, m.currentBookName, m.currentChapter, m.viewport.Width(), m.highlightedVerseStart, m.highlightedVerseEnd)
+		m.renders.reset()
+		m.content, m.verseOffsets = m.layoutChapter(m.viewport.Width())
 		m.viewport.SetContent(m.content)
 
 		// If we came from a search, scroll to the highlighted verse
@@ -1206,13 +1976,29 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			m.viewport.GotoTop()
 			m.topVisibleVerse = 0
+			if resume {
+				cmds = append(cmds, m.resumePosition())
+			}
 		}
 
 	case parallelVersesLoadedMsg:
+		if msg.gen != m.gen.content {
+			break
+		}
 		m.loading = false
+		if msg.err != nil {
+			retry := loadParallelVerses(m.client, msg.gen, m.comparisonTranslations, m.currentBook, m.currentChapter, m.comparisonVerseList())
+			if cmd, ok := m.queueRetry(retryContent, msg.gen, msg.err, retry); ok {
+				return m, cmd
+			}
+			m.err = msg.err
+			break
+		}
 		m.currentParallelVerses = msg.verses
 		m.currentVerses = nil
-		m.content = m.formatParallelVerses(msg.verses, m.comparisonTranslations, m.currentBookName, m.currentChapter, m.viewport.Width())
+		m.rememberComparison()
+		m.parallel = layoutParallel(msg.verses, m.comparisonTranslations, m.viewport.Width(), m.comparisonFilter())
+		m.content = m.parallel.placeholder()
 		m.viewport.SetContent(m.content)
 		m.viewport.GotoTop()
 
@@ -1222,14 +2008,30 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case downloadCompleteMsg:
 		m.downloadingTranslation = ""
 		m.downloadProgress = 0
-		if m.cache != nil {
-			return m, loadCachedList(m.cache)
-		}
+		m.downloadRead, m.downloadTotal = 0, 0
+		delete(m.downloads.tries, msg.translation)
+		if m.cache != nil {
+			return m, tea.Batch(loadCachedList(m.cache), m.nextDownload())
+		}
+
+	case updateReadyMsg:
+		m.downloadingTranslation = ""
+		m.downloadProgress = 0
+		m.downloadRead, m.downloadTotal = 0, 0
+		delete(m.downloads.tries, msg.translation)
+		delete(m.downloads.updates, msg.translation)
+		return m, tea.Batch(m.updateReady(msg), loadCachedList(m.cache), m.nextDownload())
 
 	case downloadErrorMsg:
 		m.downloadingTranslation = ""
 		m.downloadProgress = 0
-		m.err = msg.err
+		m.downloadRead, m.downloadTotal = 0, 0
+		return m, m.downloadFailed(msg.translation, msg.err)
+
+	case downloadRetryMsg:
+		if m.downloadingTranslation == "" {
+			return m, m.nextDownload()
+		}
 
 	case downloadTickMsg:
 		// Poll the cache for current byte-level progress and reschedule
@@ -1237,14 +2039,121 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.downloadingTranslation != "" && m.cache != nil {
 			p, _ := m.cache.DownloadProgress()
 			m.downloadProgress = p
+			m.downloadRead, m.downloadTotal = m.cache.DownloadBytes()
 			return m, downloadTick()
 		}
 
+	case crossrefsLoadedMsg:
+		m.crossrefs, m.crossrefErr = msg.index, msg.err
+
+	case verseOfDayMsg:
+		if msg.ref == m.verseOfDay.ref {
+			m.verseOfDay = msg
+		}
+
+	case syncDoneMsg:
+		m.syncing = false
+		if msg.err != nil {
+			m.statusMsg = ""
+			m.err = msg.err
+			return m, nil
+		}
+		m.backlinks = indexBacklinks()
+		if msg.settings != nil {
+			// Saved now, so quitting doesn't write the old ones back;
+			// most of them take effect on the next start.
+			m.cfg = *msg.settings
+			if err := settings.Save(m.cfg); err != nil {
+				m.err = err
+			}
+			return m, m.flash(locale.Tf("✓ %s · settings updated, restart to apply", msg.summary))
+		}
+		return m, m.flash("✓ " + msg.summary)
+
+	case tmuxSentMsg:
+		if msg.err != nil {
+			return m, m.alert("tmux: " + msg.err.Error())
+		}
+		return m, m.flash(locale.Tf("sent to tmux pane %s", msg.target))
+
+	case nightLightMsg:
+		m.applyNightLight()
+		return m, nightLightTick()
+
+	case healthMsg:
+		cmd := m.applyHealth(msg)
+		return m, cmd
+
+	case retryTickMsg:
+		return m, m.fireRetries(time.Now())
+
+	case healthTickMsg:
+		return m, tea.Batch(checkConnection(m.client, false), healthTick())
+
+	case reminderTickMsg:
+		return m, tea.Batch(m.remind(msg.now), reminderTick())
+
+	case gotoMsg:
+		return m.gotoReference(msg.ref)
+
+	case clipboardMsg:
+		return m.gotoClipboard(msg)
+
+	case playbackMsg:
+		m.followPlayback(msg)
+		return m, nil
+
+	case translationQueryMsg:
+		msg.reply <- m.selectedTranslation
+		return m, nil
+
+	case pagerDoneMsg:
+		if msg.err != nil {
+			m.err = msg.err
+		}
+
+	case statusClearMsg:
+		if msg.seq == m.statusSeq {
+			m.statusMsg = ""
+		}
+
+	case millerSettleMsg:
+		if book, chapter, ok := m.millerSelection(); ok && m.hasOverlay(overlayMiller) &&
+			book.BookID == msg.book && chapter == msg.chapter {
+			return m, m.previewMiller()
+		}
+
+	case millerPreviewMsg:
+		if msg.gen != m.gen.miller {
+			break
+		}
+		if msg.err != nil {
+			// Leave the bare verse numbers; the next look tries again.
+			m.millerPreviewOf = [2]int{}
+			break
+		}
+		m.millerPreview = msg.verses
+		if m.millerFilter != "" {
+			m.applyMillerFilter()
+		}
+
 	case searchResultsLoadedMsg:
+		if msg.gen != m.gen.search {
+			break
+		}
 		m.wordSearchLoading = false
+		if msg.err != nil {
+			retry := loadSearchResults(m.client, msg.gen, m.selectedTranslation, msg.query, msg.page)
+			if cmd, ok := m.queueRetry(retrySearch, msg.gen, msg.err, retry); ok {
+				return m, cmd
+			}
+			m.err = msg.err
+			break
+		}
 		m.wordSearchResults = msg.results
 		m.wordSearchTotal = msg.total
 		m.wordSearchQuery = msg.query
+		m.wordSearchPage = msg.page
 		m.wordSearchSelected = 0
 		// Sort results by book order
 		sort.Slice(m.wordSearchResults, func(i, j int) bool {
@@ -1270,7 +2179,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Update word search input when typing query
 		m.wordSearchInput, cmd = m.wordSearchInput.Update(msg)
 		cmds = append(cmds, cmd)
-	} else if m.showMillerColumns && m.millerFilterMode {
+	} else if m.hasOverlay(overlayMiller) && m.millerFilterMode {
 		// Update Miller filter input when in filter mode
 		m.millerFilterInput, cmd = m.millerFilterInput.Update(msg)
 		cmds = append(cmds, cmd)
@@ -1300,7 +2209,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if newTopVerse != m.highlightedVerseStart {
 				m.highlightedVerseStart = newTopVerse
 				m.highlightedVerseEnd = newTopVerse
-				m.content = m.formatChapter(m.currentVerses, m.currentBookName, m.currentChapter, m.viewport.Width(), m.highlightedVerseStart, m.highlightedVerseEnd)
+				m.content, m.verseOffsets = m.layoutChapter(m.viewport.Width())
 				m.viewport.SetContent(m.content)
 			}
 		}
@@ -1310,13 +2219,33 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) View() tea.View {
-	return tea.View{
+	v := tea.View{
 		Content:   m.renderView(),
-		AltScreen: true,
+		AltScreen: !m.inline,
 		// AllMotion gives us motion events even when no button is held,
 		// which is what we need for hover highlights.
 		MouseMode: tea.MouseModeAllMotion,
 	}
+	if m.noMouse {
+		v.MouseMode = tea.MouseModeNone
+	}
+	if m.inline {
+		// The last line is cleared on quit: keep it an empty one.
+		v.Content += "\n"
+	}
+	return v
+}
+
+// SetInline runs the reader without the alternate screen, so what it
+// showed last is left in the terminal after quitting.
+func (m *Model) SetInline() {
+	m.inline = true
+}
+
+// DisableMouse starts the reader with mouse capture off, as no_mouse
+// does.
+func (m *Model) DisableMouse() {
+	m.noMouse = true
 }
 
 // Layout constants for the two-pane shell.
@@ -1332,7 +2261,7 @@ const (
 // viewport fills the pane's inner content area exactly (no unstyled gap
 // at the right edge).
 func (m Model) viewportSize() (int, int) {
-	w := m.width - leftPaneOuterWidth - 2 - 4
+	w := m.width - leftPaneOuterWidth - m.studyWidth() - 2 - 4
 	if w < 20 {
 		w = 20
 	}
@@ -1348,7 +2277,8 @@ func (m Model) viewportSize() (int, int) {
 func (m Model) overlayActive() bool {
 	switch m.mode {
 	case modeSearch, modeTranslationSelect, modeThemeSelect,
-		modeCacheManager, modeAbout, modeWordSearch:
+		modeCacheManager, modeAbout, modeWordSearch, modeWorkspace, modeBookmarks,
+		modeAnnotations, modeActivity, modeChapterGrid, modeHistory:
 		return true
 	}
 	return false
@@ -1362,171 +2292,19 @@ func (m Model) renderView() string {
 	if m.width < 60 || m.height < 18 {
 		fitStyle := lipgloss.NewStyle().
 			Foreground(m.currentTheme.Warning).
-			Bold(true)
-		return "\n  " + fitStyle.Render("Terminal too small — resize to at least 60×18.")
+			Bold(m.styled())
+		return "\n  " + fitStyle.Render(locale.T("Terminal too small — resize to at least 60×18."))
 	}
 
-	header := m.renderHeader()
+	header := m.header().View(m.styles, m.width)
 	body := m.renderBody()
-	status := m.renderStatusBar()
-
-	base := lipgloss.JoinVertical(lipgloss.Left, header, body, status)
-
-	if !m.overlayActive() {
-		return base
-	}
-
-	return m.composeOverlay(base)
-}
-
-func (m Model) renderHeader() string {
-	width := m.width
-
-	bg := m.currentTheme.Background
-	accent := m.currentTheme.Accent
-	successCol := m.currentTheme.Success
-
-	logo := "†"
-	logoStyle := lipgloss.NewStyle().Foreground(accent).Background(bg).Bold(true)
-	breadcrumbStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Primary).Background(bg)
-	separatorStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg)
-	versionStyle := lipgloss.NewStyle().Foreground(successCol).Background(bg).Bold(true)
-
-	bookName := m.currentBookName
-	if bookName == "" {
-		bookName = "—"
-	}
-	chapter := fmt.Sprintf("%d", m.currentChapter)
-	if m.currentChapter == 0 {
-		chapter = "—"
-	}
-
-	breadcrumb := logoStyle.Render(logo+" sword-tui") +
-		separatorStyle.Render("  ·  ") +
-		breadcrumbStyle.Render(m.selectedTranslation) +
-		separatorStyle.Render(" › ") +
-		breadcrumbStyle.Render(bookName) +
-		separatorStyle.Render(" › ") +
-		breadcrumbStyle.Render(chapter)
+	status := m.statusBar().View(m.styles, m.width)
 
-	versionStr := versionStyle.Render(version.Version)
-
-	innerWidth := width - 4 - 2 // -2 border -2 padding -2 safety
-	rightW := lipgloss.Width(versionStr)
-	leftW := innerWidth - rightW - 1
-	if leftW < 1 {
-		leftW = 1
-	}
-	leftSlot := lipgloss.NewStyle().
-		Background(bg).
-		Width(leftW).
-		MaxWidth(leftW).
-		Render(breadcrumb)
-	gap := lipgloss.NewStyle().Background(bg).Render(" ")
-	content := leftSlot + gap + versionStr
-
-	box := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(m.currentTheme.BorderActive).
-		BorderBackground(bg).
-		Background(bg).
-		Width(width - 2).
-		Padding(0, 1)
-
-	return box.Render(content)
-}
-
-func (m Model) renderStatusBar() string {
-	width := m.width
-	bg := m.currentTheme.Background
-
-	hintStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg)
-	keyStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(true)
-	rightStyle := lipgloss.NewStyle().Background(bg)
-
-	hints := m.statusHints(keyStyle, hintStyle)
-
-	// Right side: loading indicator or error condensed
-	var right string
-	if m.loading {
-		right = lipgloss.NewStyle().Foreground(m.currentTheme.Warning).Background(bg).Bold(true).Render("● loading")
-	} else if m.err != nil {
-		errStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Error).Background(bg).Bold(true)
-		msg := m.err.Error()
-		if len(msg) > 40 {
-			msg = msg[:37] + "..."
-		}
-		right = errStyle.Render("⚠ " + msg)
-	} else if m.cache != nil && m.cache.IsCached(m.selectedTranslation) {
-		right = lipgloss.NewStyle().Foreground(m.currentTheme.Success).Background(bg).Render("● offline")
-	} else {
-		right = hintStyle.Render("● online")
-	}
-
-	innerWidth := width - 4 - 2 // -2 border -2 padding -2 safety
-	rightW := lipgloss.Width(right)
-	leftW := innerWidth - rightW - 1
-	if leftW < 1 {
-		leftW = 1
+	view := lipgloss.JoinVertical(lipgloss.Left, header, body, status)
+	if m.overlayActive() {
+		view = m.composeOverlay(view)
 	}
-	hintsSlot := rightStyle.Width(leftW).MaxWidth(leftW).Render(hints)
-	gap := lipgloss.NewStyle().Background(bg).Render(" ")
-
-	box := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(m.currentTheme.Border).
-		BorderBackground(bg).
-		Background(bg).
-		Width(width - 2).
-		Padding(0, 1)
-
-	return box.Render(hintsSlot + gap + right)
-}
-
-func (m Model) statusHints(key, dim lipgloss.Style) string {
-	type hint struct{ k, label string }
-
-	var hs []hint
-	switch m.mode {
-	case modeTranslationSelect, modeThemeSelect:
-		hs = []hint{{"↑↓", "navigate"}, {"⏎", "select"}, {"esc", "close"}}
-	case modeCacheManager:
-		hs = []hint{{"↑↓", "navigate"}, {"⏎", "download"}, {"x", "delete"}, {"esc", "close"}}
-	case modeAbout:
-		hs = []hint{{"esc", "close"}}
-	case modeWordSearch:
-		if m.wordSearchResults != nil {
-			hs = []hint{{"↑↓", "navigate"}, {"⏎", "go to verse"}, {"esc", "close"}}
-		} else {
-			hs = []hint{{"⏎", "search"}, {"esc", "close"}}
-		}
-	case modeComparison:
-		hs = []hint{{"↑↓", "scroll"}, {"r", "reader"}, {"esc", "back"}}
-	case modeSearch:
-		hs = []hint{{"⏎", "go"}, {"esc", "cancel"}}
-	default:
-		hs = []hint{
-			{"tab", "focus"},
-			{"⏎", "open"},
-			{"n/p", "chapter"},
-			{"t", "translation"},
-			{"T", "theme"},
-			{"/", "verse"},
-			{"s", "search"},
-			{"?", "about"},
-			{"q", "quit"},
-		}
-	}
-
-	// Each part bundles the key + leading space + label into a single
-	// dim.Render() so the gap between the styled key and label gets the
-	// pane background (raw spaces between two styled spans would inherit
-	// the terminal default and show through as black blocks).
-	var parts []string
-	for _, h := range hs {
-		parts = append(parts, key.Render(h.k)+dim.Render(" "+h.label))
-	}
-	return strings.Join(parts, dim.Render("  ·  "))
+	return m.composeStack(view)
 }
 
 func (m Model) renderBody() string {
@@ -1536,10 +2314,14 @@ func (m Model) renderBody() string {
 	}
 
 	leftW := leftPaneOuterWidth
-	rightW := m.width - leftW
+	studyW := m.studyWidth()
+	rightW := m.width - leftW - studyW
 
 	left := m.renderLeftPane(leftW, bodyHeight)
 	right := m.renderRightPane(rightW, bodyHeight)
+	if studyW > 0 {
+		return lipgloss.JoinHorizontal(lipgloss.Top, left, right, m.renderStudyColumn(studyW, bodyHeight))
+	}
 
 	return lipgloss.JoinHorizontal(lipgloss.Top, left, right)
 }
@@ -1552,16 +2334,16 @@ func (m Model) renderLeftPane(outerW, outerH int) string {
 	}
 	bg := m.currentTheme.Background
 
-	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(true)
-	sectionStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Success).Background(bg).Bold(true)
+	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(m.styled())
+	sectionStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Success).Background(bg).Bold(m.styled())
 	selectedStyle := lipgloss.NewStyle().
 		Foreground(m.currentTheme.Background).
 		Background(m.currentTheme.Accent).
-		Bold(true)
-	currentStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Warning).Background(bg).Bold(true)
+		Bold(m.styled())
+	currentStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Warning).Background(bg).Bold(m.styled())
 	normalStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Primary).Background(bg)
 	hoverStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Primary).Background(m.currentTheme.Highlight)
-	mutedStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(m.styled())
 
 	// Resolve hover only when the mouse is actually inside this pane.
 	hoveredBookIdx := -1
@@ -1575,7 +2357,7 @@ func (m Model) renderLeftPane(outerW, outerH int) string {
 	innerH := outerH - 4 // 2 border + 2 padding
 
 	var sb strings.Builder
-	sb.WriteString(titleStyle.Render("Books"))
+	sb.WriteString(titleStyle.Render(locale.T("Books")))
 	sb.WriteString("\n")
 
 	// Reserve: 1 for the "Books" title above, plus 2 (one per direction)
@@ -1585,8 +2367,10 @@ func (m Model) renderLeftPane(outerW, outerH int) string {
 		contentLines = 1
 	}
 
-	if m.books == nil {
-		sb.WriteString(mutedStyle.Render("Loading…"))
+	if m.books == nil && m.err != nil && !m.loading {
+		sb.WriteString(mutedStyle.Render(wrapText(friendlyError(m.err, m.client.Provider().Name()), innerW)))
+	} else if m.books == nil {
+		sb.WriteString(mutedStyle.Render(locale.T("Loading…")))
 	} else {
 		type entry struct {
 			isHeader bool
@@ -1596,47 +2380,34 @@ func (m Model) renderLeftPane(outerW, outerH int) string {
 			isSel    bool
 		}
 		var entries []entry
-		entries = append(entries, entry{isHeader: true, label: "OLD TESTAMENT"})
-		for i, b := range m.books {
-			if b.BookID > 39 {
-				continue
-			}
-			label := b.Name
-			if lipgloss.Width(label) > innerW-2 {
-				label = label[:innerW-2]
-			}
-			entries = append(entries, entry{
-				label:   label,
-				bookIdx: i,
-				isCur:   b.BookID == m.currentBook,
-				isSel:   i == m.sidebarSelected,
-			})
-		}
-		entries = append(entries, entry{isHeader: true, label: "NEW TESTAMENT"})
-		for i, b := range m.books {
-			if b.BookID < 40 {
+		secs := m.bookSections()
+		rows := m.sidebarRows()
+		cursor := m.sidebarCursor(rows)
+		for n, r := range rows {
+			if r.header {
+				sec := secs[r.section]
+				label := "▾ " + sec.title
+				if m.testamentCollapsed(sec.testament) {
+					label = "▹ " + sec.title
+				}
+				entries = append(entries, entry{isHeader: true, label: label, isSel: n == cursor})
 				continue
 			}
+			b := m.books[r.bookIdx]
 			label := b.Name
 			if lipgloss.Width(label) > innerW-2 {
 				label = label[:innerW-2]
 			}
 			entries = append(entries, entry{
 				label:   label,
-				bookIdx: i,
+				bookIdx: r.bookIdx,
 				isCur:   b.BookID == m.currentBook,
-				isSel:   i == m.sidebarSelected,
+				isSel:   n == cursor,
 			})
 		}
 
 		// Virtual scrolling: center on selected index
-		selIdx := -1
-		for i, e := range entries {
-			if e.isSel {
-				selIdx = i
-				break
-			}
-		}
+		selIdx := cursor
 		start, end := 0, len(entries)
 		if len(entries) > contentLines {
 			if selIdx < 0 {
@@ -1662,6 +2433,11 @@ func (m Model) renderLeftPane(outerW, outerH int) string {
 		}
 		for i := start; i < end; i++ {
 			e := entries[i]
+			if e.isHeader && e.isSel {
+				line := e.label + strings.Repeat(" ", max(innerW-lipgloss.Width(e.label), 0))
+				sb.WriteString(selectedStyle.Render(line) + "\n")
+				continue
+			}
 			if e.isHeader {
 				sb.WriteString(sectionStyle.Render(e.label) + "\n")
 				continue
@@ -1728,13 +2504,19 @@ func (m Model) renderRightPane(outerW, outerH int) string {
 	}
 	bg := m.currentTheme.Background
 
-	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(true)
-	mutedStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(true)
+	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(m.styled())
+	mutedStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(m.styled())
 
 	var titleText string
 	switch m.mode {
 	case modeComparison:
 		titleText = fmt.Sprintf("Comparison · %s %d", m.currentBookName, m.currentChapter)
+		if from, to := m.comparisonVerses[0], m.comparisonVerses[1]; from > 0 {
+			titleText += fmt.Sprintf(":%d", from)
+			if to > from {
+				titleText += fmt.Sprintf("-%d", to)
+			}
+		}
 	default:
 		if m.currentBookName == "" {
 			titleText = "Reader"
@@ -1752,15 +2534,19 @@ func (m Model) renderRightPane(outerW, outerH int) string {
 	// (j/k inside the visible area), surface the verse range. The two
 	// states never duplicate.
 	scrolled := m.ready && m.viewport.YOffset() > 0 && m.mode == modeReader
+	var ofTotal string
+	if n := m.verseCount(m.currentBook, m.currentChapter); n > 0 {
+		ofTotal = fmt.Sprintf(" of %d", n)
+	}
 	var locator string
 	switch {
 	case scrolled && m.highlightedVerseStart > 0:
-		locator = mutedStyle.Render(fmt.Sprintf("  ↑ v. %d", m.highlightedVerseStart))
+		locator = mutedStyle.Render(fmt.Sprintf("  ↑ v. %d%s", m.highlightedVerseStart, ofTotal))
 	case !scrolled && m.highlightedVerseStart > 0 && m.highlightedVerseStart != m.highlightedVerseEnd:
 		locator = mutedStyle.Render(fmt.Sprintf("  v. %d–%d", m.highlightedVerseStart, m.highlightedVerseEnd))
 	case !scrolled && m.highlightedVerseStart > 1:
 		// Show the verse only when it's not the obvious "verse 1 at top".
-		locator = mutedStyle.Render(fmt.Sprintf("  v. %d", m.highlightedVerseStart))
+		locator = mutedStyle.Render(fmt.Sprintf("  v. %d%s", m.highlightedVerseStart, ofTotal))
 	}
 
 	// Hover indicator: when the mouse cursor is over a verse in the
@@ -1768,13 +2554,16 @@ func (m Model) renderRightPane(outerW, outerH int) string {
 	// Suppressed when the hovered verse happens to be the same one the
 	// locator above is already showing — no point saying it twice.
 	if hoveredVerse := m.verseAtMouseY(m.mouseY); hoveredVerse > 0 && hoveredVerse != m.highlightedVerseStart {
-		hoverStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(true)
+		hoverStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(m.styled())
 		locator += hoverStyle.Render(fmt.Sprintf("  ⊙ v. %d", hoveredVerse))
 	}
 
-	header := title + locator
+	header := title + locator + m.renderBacklinkCount()
 
 	body := m.viewport.View()
+	if m.parallel != nil {
+		body = m.parallelView()
+	}
 
 	innerW := outerW - 2 - 4 // border + padding(1,2)
 	spacer := lipgloss.NewStyle().Background(bg).Width(innerW).Render("")
@@ -1793,13 +2582,69 @@ func (m Model) renderRightPane(outerW, outerH int) string {
 	return box.Render(content)
 }
 
+// verseCount returns how many verses chapter has, or 0 if not yet known.
+func (m Model) verseCount(book, chapter int) int {
+	for _, b := range m.books {
+		if b.BookID == book {
+			return b.VerseCount(chapter)
+		}
+	}
+	return 0
+}
+
+// noteVerseCount records the size of a freshly loaded chapter in m.books
+// so it is known on later visits without a metadata refresh.
+func (m *Model) noteVerseCount(verses []api.Verse) {
+	if len(verses) == 0 {
+		return
+	}
+	last := 0
+	for _, v := range verses {
+		last = max(last, v.Verse)
+	}
+	for i := range m.books {
+		b := &m.books[i]
+		if b.BookID != m.currentBook || b.VerseCount(m.currentChapter) == last {
+			continue
+		}
+		counts := make([]int, max(len(b.Verses), m.currentChapter))
+		copy(counts, b.Verses)
+		counts[m.currentChapter-1] = last
+		b.Verses = counts
+	}
+}
+
+// checkReference describes why book/chapter/verse doesn't exist, or
+// returns "" if it does (or if the verse count is not known yet).
+func (m Model) checkReference(book, chapter, verse int) string {
+	for _, b := range m.books {
+		if b.BookID != book {
+			continue
+		}
+		if chapter > b.Chapters {
+			return locale.Tf("%s has %d chapters", b.Name, b.Chapters)
+		}
+		if n := b.VerseCount(chapter); n > 0 && verse > n {
+			return locale.Tf("%s %d has %d verses", b.Name, chapter, n)
+		}
+	}
+	return ""
+}
+
 // comparisonVerseList returns the verse-number list we want
-// loadParallelVerses to fetch. In comparison mode m.currentVerses is
-// nil (cleared when parallelVersesLoadedMsg lands), so we derive the
-// list from the longest column we already have. Falls back to 1..31
-// when nothing's loaded yet (matches the initial "c" trigger).
+// loadParallelVerses to fetch: the chapter's known verse count, or else
+// the longest column we already have (in comparison mode m.currentVerses
+// is nil, cleared when parallelVersesLoadedMsg lands). Falls back to
+// 1..31 when nothing's known yet. A range set by O is fetched alone.
 func (m Model) comparisonVerseList() []int {
-	maxV := 0
+	if from, to := m.comparisonVerses[0], m.comparisonVerses[1]; from > 0 {
+		out := make([]int, 0, to-from+1)
+		for v := from; v <= to; v++ {
+			out = append(out, v)
+		}
+		return out
+	}
+	maxV := m.verseCount(m.currentBook, m.currentChapter)
 	if m.currentVerses != nil {
 		for _, v := range m.currentVerses {
 			if v.Verse > maxV {
@@ -1876,50 +2721,23 @@ func (m Model) verseAtMouseY(y int) int {
 		return 0
 	}
 	line := y - viewportTopY + m.viewport.YOffset()
-	if line < 0 {
+	if line < 0 || line >= strings.Count(m.content, "\n") {
 		return 0
 	}
+	return m.verseAtLine(line)
+}
 
-	// Same width math as formatChapter (width - 8 from viewport width).
-	textWidth := m.viewport.Width() - 8
-	if textWidth < 12 {
-		textWidth = 12
-	}
-	indent := 6
-	currentLine := 0
-
-	for i, v := range m.currentVerses {
-		text := stripHTMLTags(v.Text)
-		isHighlighted := m.highlightedVerseStart > 0 && v.Verse >= m.highlightedVerseStart && v.Verse <= m.highlightedVerseEnd
-		nextIsHighlighted := false
-		if i+1 < len(m.currentVerses) {
-			nv := m.currentVerses[i+1]
-			nextIsHighlighted = m.highlightedVerseStart > 0 && nv.Verse >= m.highlightedVerseStart && nv.Verse <= m.highlightedVerseEnd
-		}
-
-		var verseLines int
-		if isHighlighted {
-			wrapped := wrapTextWithIndent(text, textWidth-4, indent)
-			ln := strings.Count(wrapped, "\n") + 1
-			if !nextIsHighlighted {
-				// end of range: wrapped content + top + bottom borders + 1 trailing blank
-				verseLines = ln + 2 + 1
-			} else {
-				// in-range verse contributes its lines plus a 1-line spacer
-				verseLines = ln + 1
-			}
-		} else {
-			wrapped := wrapTextWithIndent(text, textWidth, indent)
-			ln := strings.Count(wrapped, "\n") + 1
-			verseLines = ln + 1
-		}
-
-		if currentLine+verseLines > line {
-			return v.Verse
+// verseAtLine returns the verse whose rendering covers content line n of
+// the current chapter, or 0 if there is none.
+func (m Model) verseAtLine(n int) int {
+	verse := 0
+	for _, o := range m.verseOffsets {
+		if o.line > n {
+			break
 		}
-		currentLine += verseLines
+		verse = o.verse
 	}
-	return 0
+	return verse
 }
 
 // overlayPanelBounds returns the (x, y, width, height) of the floating
@@ -1929,19 +2747,7 @@ func (m Model) overlayPanelBounds() (int, int, int, int) {
 	if panel == "" {
 		return 0, 0, 0, 0
 	}
-	panelW := lipgloss.Width(panel)
-	panelH := lipgloss.Height(panel)
-	rightX := leftPaneOuterWidth
-	rightW := m.width - rightX
-	x := rightX + (rightW-panelW)/2
-	y := (m.height-panelH)/2 + 1
-	if x < 1 {
-		x = 1
-	}
-	if y < 1 {
-		y = 1
-	}
-	return x, y, panelW, panelH
+	return m.overlayBounds(placeCenter, panel)
 }
 
 // overlayClick resolves a click at row N (0-indexed from the first
@@ -1950,65 +2756,68 @@ func (m *Model) overlayClick(row int) tea.Cmd {
 	if row < 0 {
 		return nil
 	}
+	if p, items := m.activePicker(); p != nil {
+		if i, ok := p.at(items, row); ok {
+			return m.pick(i)
+		}
+		return nil
+	}
 	switch m.mode {
-	case modeThemeSelect:
-		themes := theme.AllThemes()
-		if row < len(themes) {
-			m.themeSelected = row
-			m.currentTheme = themes[row]
-			m.themePinned = true
-			m.mode = modeReader
+	case modeWorkspace:
+		start := m.overlayWindowStart(m.workspaceSelected, len(m.workspace.Passages), workspaceWindow)
+		offset := 0
+		if start > 0 {
+			offset = 1
 		}
-	case modeTranslationSelect:
-		if m.translations == nil {
-			return nil
+		idx := start + row - offset
+		if idx >= 0 && idx < len(m.workspace.Passages) {
+			m.workspaceSelected = idx
 		}
-		start := m.overlayWindowStart(m.translationSelected, len(m.translations), 16)
+	case modeBookmarks:
+		start := m.overlayWindowStart(m.bookmarkSelected, len(m.bookmarkStore.Bookmarks), bookmarkWindow)
 		offset := 0
 		if start > 0 {
-			offset = 1 // the "↑ N more" indicator line
+			offset = 1
 		}
 		idx := start + row - offset
-		if idx < 0 || idx >= len(m.translations) {
-			return nil
+		if idx >= 0 && idx < len(m.bookmarkStore.Bookmarks) {
+			m.bookmarkSelected = idx
 		}
-		m.translationSelected = idx
-		newTrans := m.translations[idx].ShortName
-		// Picker scoped to a comparison column: swap that column.
-		if m.comparisonPickerColumn >= 0 && m.comparisonPickerColumn < len(m.comparisonTranslations) {
-			m.comparisonTranslations[m.comparisonPickerColumn] = newTrans
-			m.comparisonPickerColumn = -1
-			m.mode = modeComparison
-			m.loading = true
-			return loadParallelVerses(m.client, m.comparisonTranslations, m.currentBook, m.currentChapter, m.comparisonVerseList())
-		}
-		if newTrans == m.selectedTranslation {
-			m.mode = modeReader
-			return nil
+	case modeAnnotations:
+		list := m.filteredAnnotations()
+		start := m.overlayWindowStart(m.annotationSelected, len(list), annotationWindow)
+		offset := annotationListRow
+		if start > 0 {
+			offset++
 		}
-		m.selectedTranslation = newTrans
-		m.mode = modeReader
-		m.loading = true
-		return loadChapter(m.client, m.selectedTranslation, m.currentBook, m.currentChapter)
-	case modeCacheManager:
-		if m.translations == nil {
-			return nil
+		idx := start + row - offset
+		if idx >= 0 && idx < len(list) {
+			m.annotationSelected = idx
 		}
-		start := m.overlayWindowStart(m.cacheSelected, len(m.translations), 14)
+	case modeHistory:
+		n := len(m.history.Entries)
+		start := m.overlayWindowStart(m.historySelected, n, historyWindow)
 		offset := 0
 		if start > 0 {
 			offset = 1
 		}
-		idx := start + row - offset
-		if idx < 0 || idx >= len(m.translations) {
-			return nil
+		if idx := start + row - offset; idx >= 0 && idx < n {
+			m.historySelected = idx
+			m.pickHistory()
+		}
+	case modeChapterGrid:
+		px, _, _, _ := m.overlayPanelBounds()
+		_, padX := m.panelPadding()
+		return m.gridClick(row, m.mouseX-px-1-padX)
+	case modeActivity:
+		start := m.overlayWindowStart(m.activitySelected, len(m.activityRows), activityWindow)
+		offset := 0
+		if start > 0 {
+			offset = 1
 		}
-		m.cacheSelected = idx
-		trans := m.translations[idx].ShortName
-		if m.cache != nil && !m.cache.IsCached(trans) && m.downloadingTranslation == "" {
-			m.downloadingTranslation = trans
-			m.downloadProgress = 0
-			return tea.Batch(downloadTranslation(m.cache, trans), downloadTick())
+		idx := start + row - offset
+		if idx >= 0 && idx < len(m.activityRows) {
+			m.activitySelected = idx
 		}
 	}
 	return nil
@@ -2016,53 +2825,69 @@ func (m *Model) overlayClick(row int) tea.Cmd {
 
 // overlayNudge moves the selection in the active overlay by delta (±1).
 func (m *Model) overlayNudge(delta int) {
+	if p, items := m.activePicker(); p != nil {
+		p.move(items, delta)
+		m.previewTheme()
+		return
+	}
 	switch m.mode {
-	case modeThemeSelect:
-		next := m.themeSelected + delta
-		max := len(theme.AllThemes()) - 1
-		if next < 0 {
-			next = 0
-		}
-		if next > max {
-			next = max
+	case modeAbout:
+		if delta < 0 {
+			m.about.ScrollUp(-delta)
+		} else {
+			m.about.ScrollDown(delta)
 		}
-		m.themeSelected = next
-	case modeTranslationSelect:
-		if m.translations == nil {
+	case modeWordSearch:
+		if m.wordSearchResults == nil {
 			return
 		}
-		next := m.translationSelected + delta
+		next := m.wordSearchSelected + delta
 		if next < 0 {
 			next = 0
 		}
-		if next > len(m.translations)-1 {
-			next = len(m.translations) - 1
-		}
-		m.translationSelected = next
-	case modeCacheManager:
-		if m.translations == nil {
-			return
+		if next > len(m.wordSearchResults)-1 {
+			next = len(m.wordSearchResults) - 1
 		}
-		next := m.cacheSelected + delta
+		m.wordSearchSelected = next
+	case modeWorkspace:
+		next := m.workspaceSelected + delta
 		if next < 0 {
 			next = 0
 		}
-		if next > len(m.translations)-1 {
-			next = len(m.translations) - 1
+		if next > len(m.workspace.Passages)-1 {
+			next = len(m.workspace.Passages) - 1
 		}
-		m.cacheSelected = next
-	case modeWordSearch:
-		if m.wordSearchResults == nil {
-			return
+		if next >= 0 {
+			m.workspaceSelected = next
 		}
-		next := m.wordSearchSelected + delta
+	case modeBookmarks:
+		next := m.bookmarkSelected + delta
 		if next < 0 {
 			next = 0
 		}
-		if next > len(m.wordSearchResults)-1 {
-			next = len(m.wordSearchResults) - 1
+		if next > len(m.bookmarkStore.Bookmarks)-1 {
+			next = len(m.bookmarkStore.Bookmarks) - 1
+		}
+		if next >= 0 {
+			m.bookmarkSelected = next
+		}
+	case modeAnnotations:
+		next := m.annotationSelected + delta
+		if n := len(m.filteredAnnotations()); next > n-1 {
+			next = n - 1
+		}
+		if next >= 0 {
+			m.annotationSelected = next
+		}
+	case modeChapterGrid:
+		m.gridSelected = min(max(m.gridSelected+delta*gridColumns, 1), m.gridChapters())
+	case modeHistory:
+		m.historySelected = min(max(m.historySelected+delta, 0), len(m.history.Entries)-1)
+	case modeActivity:
+		next := min(m.activitySelected+delta, len(m.activityRows)-1)
+		if next >= 0 {
+			m.activitySelected = next
 		}
-		m.wordSearchSelected = next
 	}
 }
 
@@ -2109,28 +2934,12 @@ func (m Model) bookAtRow(y int) (int, bool) {
 		bookIdx  int
 	}
 	var entries []entry
-	entries = append(entries, entry{isHeader: true})
-	for i, b := range m.books {
-		if b.BookID > 39 {
-			continue
-		}
-		entries = append(entries, entry{bookIdx: i})
-	}
-	entries = append(entries, entry{isHeader: true})
-	for i, b := range m.books {
-		if b.BookID < 40 {
-			continue
-		}
-		entries = append(entries, entry{bookIdx: i})
+	rows := m.sidebarRows()
+	for _, r := range rows {
+		entries = append(entries, entry{isHeader: r.header, bookIdx: r.bookIdx})
 	}
 
-	selIdx := -1
-	for i := range entries {
-		if !entries[i].isHeader && entries[i].bookIdx == m.sidebarSelected {
-			selIdx = i
-			break
-		}
-	}
+	selIdx := m.sidebarCursor(rows)
 	start, end := 0, len(entries)
 	if len(entries) > contentLines {
 		if selIdx < 0 {
@@ -2180,20 +2989,7 @@ func (m Model) composeOverlay(base string) string {
 	if panel == "" {
 		return base
 	}
-	panelW := lipgloss.Width(panel)
-	panelH := lipgloss.Height(panel)
-
-	// Center over the right pane
-	rightX := leftPaneOuterWidth
-	rightW := m.width - rightX
-	x := rightX + (rightW-panelW)/2
-	y := (m.height-panelH)/2 + 1
-	if x < 1 {
-		x = 1
-	}
-	if y < 1 {
-		y = 1
-	}
+	x, y, panelW, panelH := m.overlayBounds(placeCenter, panel)
 
 	shadowStyle := lipgloss.NewStyle().
 		Background(m.currentTheme.Shadow).
@@ -2229,14 +3025,26 @@ func (m Model) renderOverlayPanel() string {
 		return m.renderAbout()
 	case modeWordSearch:
 		return m.renderWordSearch()
+	case modeWorkspace:
+		return m.renderWorkspace()
+	case modeBookmarks:
+		return m.renderBookmarks()
+	case modeAnnotations:
+		return m.renderAnnotations()
+	case modeActivity:
+		return m.renderActivity()
+	case modeChapterGrid:
+		return m.renderChapterGrid()
+	case modeHistory:
+		return m.renderHistory()
 	}
 	return ""
 }
 
 func (m Model) renderSearchPanel() string {
 	bg := m.currentTheme.Background
-	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(true)
-	hintStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(true)
+	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(m.styled())
+	hintStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(m.styled())
 
 	// Size from the available right-pane area.
 	maxAvail := m.width - leftPaneOuterWidth - 8
@@ -2255,7 +3063,7 @@ func (m Model) renderSearchPanel() string {
 		BorderBackground(bg).
 		Background(bg).
 		Width(width).
-		Padding(1, 2)
+		Padding(m.panelPadding())
 
 	// Apply the theme's background to the bubbles textinput so it doesn't
 	// punch a terminal-default-colored hole through the panel.
@@ -2263,9 +3071,9 @@ func (m Model) renderSearchPanel() string {
 	ti.SetStyles(m.themedInputStyles())
 	ti.SetWidth(innerW - 2)
 
-	body := titleStyle.Render("Go to verse") + "\n\n" +
+	body := titleStyle.Render(locale.T("Go to verse")) + m.panelTitleGap() +
 		ti.View() + "\n\n" +
-		hintStyle.Render("e.g. \"John 3:16\" or \"1 1:1\"")
+		hintStyle.Render(locale.T("e.g. \"John 3:16\" or \"1 1:1\""))
 
 	return box.Render(body)
 }
@@ -2276,8 +3084,8 @@ func (m Model) renderSearchPanel() string {
 func (m Model) themedInputStyles() textinput.Styles {
 	bg := m.currentTheme.Background
 	primary := lipgloss.NewStyle().Foreground(m.currentTheme.Primary).Background(bg)
-	muted := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(true)
-	prompt := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(true)
+	muted := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(m.styled())
+	prompt := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(m.styled())
 
 	state := textinput.StyleState{
 		Text:        primary,
@@ -2301,68 +3109,13 @@ func (m Model) calculateHighlightedVerse() int {
 		return 1
 	}
 
-	// Calculate which verse is at the top of the viewport
-	yOffset := m.viewport.YOffset()
-
-	// Calculate text width the same way formatChapter does. We use the
-	// viewport's width (not m.width — that's the full terminal) and
-	// match formatChapter's `width - 8` for the textWidth.
-	textWidth := m.viewport.Width() - 8
-	if textWidth < 12 {
-		textWidth = 12
-	}
-
-	// Count lines to find which verse we're at
-	currentLine := 0
-	indent := 6 // verse number width + 2 spaces
-
-	for i, verse := range m.currentVerses {
-		text := stripHTMLTags(verse.Text)
-
-		// Check if this verse is highlighted (which would add a border)
-		isHighlighted := m.highlightedVerseStart > 0 && verse.Verse >= m.highlightedVerseStart && verse.Verse <= m.highlightedVerseEnd
-
-		// Check if next verse is also highlighted
-		nextIsHighlighted := false
-		if i+1 < len(m.currentVerses) {
-			nextVerse := m.currentVerses[i+1]
-			nextIsHighlighted = m.highlightedVerseStart > 0 && nextVerse.Verse >= m.highlightedVerseStart && nextVerse.Verse <= m.highlightedVerseEnd
-		}
-
-		var verseTotalLines int
-		if isHighlighted {
-			// Highlighted verses use narrower width due to border padding
-			wrappedText := wrapTextWithIndent(text, textWidth-4, indent)
-			linesInVerse := strings.Count(wrappedText, "\n") + 1
-
-			if !nextIsHighlighted {
-				// End of highlighted range: wrapped content + 2 border
-				// lines (top + bottom) + 1 trailing blank.
-				verseTotalLines = linesInVerse + 2 + 1
-			} else {
-				// Middle of highlighted range
-				verseTotalLines = linesInVerse + 1
-			}
-		} else {
-			wrappedText := wrapTextWithIndent(text, textWidth, indent)
-			linesInVerse := strings.Count(wrappedText, "\n") + 1
-			verseTotalLines = linesInVerse + 1
-		}
-
-		// If the current line + verse lines exceeds yOffset, this is our verse
-		if currentLine+verseTotalLines > yOffset {
-			return verse.Verse
-		}
-
-		currentLine += verseTotalLines
+	// The verse at the top of the viewport.
+	if v := m.verseAtLine(m.viewport.YOffset()); v > 0 {
+		return v
 	}
 
 	// If we've scrolled past all verses, return the last one
-	if len(m.currentVerses) > 0 {
-		return m.currentVerses[len(m.currentVerses)-1].Verse
-	}
-
-	return 1
+	return m.currentVerses[len(m.currentVerses)-1].Verse
 }
 
 func (m *Model) scrollToHighlightedVerse() {
@@ -2370,78 +3123,30 @@ func (m *Model) scrollToHighlightedVerse() {
 		return
 	}
 
-	// Calculate the line position of the highlighted verse
-	// This must match exactly how formatChapter renders verses
-	currentLine := 0
-
-	// Calculate text width the same way formatChapter does
-	textWidth := m.width - 6
-	if textWidth < 20 {
-		textWidth = 20
-	}
-	if textWidth > m.width-2 {
-		textWidth = m.width - 2
-	}
-
-	for i, verse := range m.currentVerses {
-		if verse.Verse == m.highlightedVerseStart {
-			// Found the verse, scroll to it
-			// Keep it near the top of the viewport (with some padding)
-			targetOffset := currentLine
-			if targetOffset < 0 {
-				targetOffset = 0
-			}
-
-			// Get the total number of lines in content
-			totalLines := strings.Count(m.content, "\n") + 1
-			maxOffset := totalLines - m.viewport.Height()
-			if maxOffset < 0 {
-				maxOffset = 0
-			}
-
-			if targetOffset > maxOffset {
-				targetOffset = maxOffset
-			}
-
-			m.viewport.SetYOffset(targetOffset)
-			return
-		}
-
-		// Calculate lines for this verse - must match formatChapter logic
-		text := stripHTMLTags(verse.Text)
-		indent := 6 // verse number width + 2 spaces
-
-		// Check if this verse is highlighted (which would add a border)
-		isHighlighted := m.highlightedVerseStart > 0 && verse.Verse >= m.highlightedVerseStart && verse.Verse <= m.highlightedVerseEnd
-
-		// Check if next verse is also highlighted
-		nextIsHighlighted := false
-		if i+1 < len(m.currentVerses) {
-			nextVerse := m.currentVerses[i+1]
-			nextIsHighlighted = m.highlightedVerseStart > 0 && nextVerse.Verse >= m.highlightedVerseStart && nextVerse.Verse <= m.highlightedVerseEnd
+	totalLines := strings.Count(m.content, "\n") + 1
+	for i, o := range m.verseOffsets {
+		if o.verse != m.highlightedVerseStart {
+			continue
 		}
-
-		if isHighlighted {
-			// Highlighted verses use narrower width due to border padding
-			wrappedText := wrapTextWithIndent(text, textWidth-4, indent)
-			linesInVerse := strings.Count(wrappedText, "\n") + 1
-
-			if !nextIsHighlighted {
-				// End of highlighted range - border adds 2 lines (top + bottom)
-				// Plus verse lines, plus blank line after
-				verseTotalLines := linesInVerse + 2 + 2 // border top/bottom + blank line + content
-				currentLine += verseTotalLines
-			} else {
-				// Middle of highlighted range - just the verse text + blank line within border
-				verseTotalLines := linesInVerse + 1 // +1 for blank line between verses in border
-				currentLine += verseTotalLines
+		// Keep it at the top of the viewport (or centered in typewriter
+		// mode), unless that would scroll past either end of the content.
+		maxOffset := totalLines - m.viewport.Height()
+		if maxOffset < 0 {
+			maxOffset = 0
+		}
+		offset := o.line
+		if i == 0 {
+			// Keep anything above the first verse (the outline) in view.
+			offset = 0
+		} else if m.typewriter {
+			end := totalLines
+			if i+1 < len(m.verseOffsets) {
+				end = m.verseOffsets[i+1].line - m.verseGap()
 			}
-		} else {
-			wrappedText := wrapTextWithIndent(text, textWidth, indent)
-			linesInVerse := strings.Count(wrappedText, "\n") + 1
-			verseTotalLines := linesInVerse + 1 // +1 for blank line after verse
-			currentLine += verseTotalLines
+			offset = o.line - (m.viewport.Height()-(end-o.line))/2
 		}
+		m.viewport.SetYOffset(max(0, min(offset, maxOffset)))
+		return
 	}
 }
 
@@ -2453,129 +3158,49 @@ func (m *Model) applyMillerFilter() {
 		return
 	}
 
-	filterLower := strings.ToLower(m.millerFilter)
+	filterLower := textnorm.Fold(m.millerFilter)
 
 	// Filter books based on current column
 	if m.millerColumn == 0 && m.books != nil {
 		m.millerFilteredBooks = []api.Book{}
 		for _, book := range m.books {
-			if strings.Contains(strings.ToLower(book.Name), filterLower) {
+			if strings.Contains(textnorm.Fold(book.Name), filterLower) {
 				m.millerFilteredBooks = append(m.millerFilteredBooks, book)
 			}
 		}
 	}
 
 	// Filter verses based on current column
-	if m.millerColumn == 2 && m.currentVerses != nil {
+	if verses := m.millerVerses(); m.millerColumn == 2 && verses != nil {
 		m.millerFilteredVerses = []api.Verse{}
-		for _, verse := range m.currentVerses {
+		for _, verse := range verses {
 			verseText := stripHTMLTags(verse.Text)
 			verseNumStr := fmt.Sprintf("%d", verse.Verse)
-			if strings.Contains(strings.ToLower(verseText), filterLower) || strings.Contains(verseNumStr, m.millerFilter) {
+			if strings.Contains(textnorm.Fold(verseText), filterLower) || strings.Contains(verseNumStr, m.millerFilter) {
 				m.millerFilteredVerses = append(m.millerFilteredVerses, verse)
 			}
 		}
 	}
 }
 
-// dimContent applies a dimming effect to content by reducing color intensity
-// and adding a lighter overlay for a subtle fog/shadow effect
-func dimContent(content string) string {
-	lines := strings.Split(content, "\n")
-	dimmedLines := make([]string, len(lines))
-
-	// Create a dim style with lighter gray to keep text readable
-	// Using Faint() for dimming while maintaining visibility
-	dimStyle := lipgloss.NewStyle().
-		Faint(true).
-		Foreground(lipgloss.Color("#888888")) // Lighter gray for better visibility
-
-	for i, line := range lines {
-		// Apply faint style to dim the line
-		dimmedLines[i] = dimStyle.Render(line)
-	}
-
-	return strings.Join(dimmedLines, "\n")
-}
-
-func overlayContent(base, overlay string, width, height int) string {
-	// Dim the base content to create a focus effect
-	base = dimContent(base)
-
-	// Split both strings into lines
-	baseLines := strings.Split(base, "\n")
-	overlayLines := strings.Split(overlay, "\n")
-
-	// Ensure we have enough base lines
-	for len(baseLines) < height {
-		baseLines = append(baseLines, "")
-	}
-
-	// Overlay the sidebar lines onto the base lines
-	for i := 0; i < len(overlayLines) && i < len(baseLines); i++ {
-		overlayLine := overlayLines[i]
-		baseLine := baseLines[i]
-
-		// Get the visual width of the overlay line (accounting for ANSI codes)
-		overlayWidth := lipgloss.Width(overlayLine)
-
-		// Pad base line to full width if needed
-		baseWidth := lipgloss.Width(baseLine)
-		if baseWidth < width {
-			baseLine += strings.Repeat(" ", width-baseWidth)
-		}
-
-		// Replace the beginning of the base line with the overlay line
-		if overlayWidth > 0 {
-			// For lines with ANSI codes, we need to be careful
-			// Just replace the visual portion
-			if len(overlayLine) > 0 {
-				// Simple approach: trim base line and prepend overlay
-				baseRunes := []rune(baseLine)
-				visualPos := 0
-				runePos := 0
-				inAnsi := false
-
-				// Count runes until we reach the visual width
-				for runePos < len(baseRunes) && visualPos < overlayWidth {
-					if baseRunes[runePos] == '\x1b' {
-						inAnsi = true
-					}
-					if !inAnsi {
-						visualPos++
-					}
-					if inAnsi && baseRunes[runePos] == 'm' {
-						inAnsi = false
-					}
-					runePos++
-				}
-
-				// Combine overlay with remaining base content
-				if runePos < len(baseRunes) {
-					baseLines[i] = overlayLine + string(baseRunes[runePos:])
-				} else {
-					baseLines[i] = overlayLine
-				}
-			}
-		}
-	}
-
-	return strings.Join(baseLines, "\n")
-}
-
 func (m Model) renderMillerColumns() string {
 	columnWidth := 30
+	// The columns and their hint line fill the body, between the header
+	// and the status bar.
+	columnHeight := m.height - headerOuterHeight - statusOuterHeight - 1
 
 	columnStyle := lipgloss.NewStyle().
 		Width(columnWidth).
-		Height(m.height - 2).
+		Height(columnHeight).
+		MaxHeight(columnHeight).
 		Border(lipgloss.NormalBorder()).
 		BorderForeground(m.currentTheme.Border).
 		Padding(1)
 
 	activeColumnStyle := lipgloss.NewStyle().
 		Width(columnWidth).
-		Height(m.height - 2).
+		Height(columnHeight).
+		MaxHeight(columnHeight).
 		Border(lipgloss.NormalBorder()).
 		BorderForeground(m.currentTheme.BorderActive).
 		Background(m.currentTheme.Background).
@@ -2584,7 +3209,7 @@ func (m Model) renderMillerColumns() string {
 	selectedStyle := lipgloss.NewStyle().
 		Foreground(m.currentTheme.Accent).
 		Background(m.currentTheme.Background).
-		Bold(true).
+		Bold(m.styled()).
 		Padding(0, 1)
 
 	normalStyle := lipgloss.NewStyle().
@@ -2594,13 +3219,13 @@ func (m Model) renderMillerColumns() string {
 	headerStyle := lipgloss.NewStyle().
 		Foreground(m.currentTheme.Success).
 		Background(m.currentTheme.Background).
-		Bold(true).
+		Bold(m.styled()).
 		Padding(0, 1).
 		Width(columnWidth - 2)
 
 	// Column 1: Books
 	var booksContent strings.Builder
-	booksContent.WriteString(headerStyle.Render("BOOKS") + "\n")
+	booksContent.WriteString(headerStyle.Render(locale.T("BOOKS")) + "\n")
 
 	// Show filter input if in books column
 	if m.millerColumn == 0 && m.millerFilterMode {
@@ -2613,13 +3238,13 @@ func (m Model) renderMillerColumns() string {
 	}
 
 	// Use filtered books if filter is active, otherwise use all books
-	booksToDisplay := m.books
+	booksToDisplay := m.millerBooks()
 	if m.millerColumn == 0 && m.millerFilter != "" && m.millerFilteredBooks != nil {
 		booksToDisplay = m.millerFilteredBooks
 	}
 
 	if booksToDisplay != nil {
-		visibleItems := m.height - 8
+		visibleItems := columnHeight - 6
 		if visibleItems < 5 {
 			visibleItems = 5
 		}
@@ -2641,6 +3266,7 @@ func (m Model) renderMillerColumns() string {
 			booksContent.WriteString(normalStyle.Render(fmt.Sprintf("... (%d)\n", startIdx)))
 		}
 
+		favorites := len(m.favoriteBooks())
 		for i := startIdx; i < endIdx && i < len(booksToDisplay); i++ {
 			book := booksToDisplay[i]
 			name := book.Name
@@ -2648,6 +3274,9 @@ func (m Model) renderMillerColumns() string {
 				name = name[:23] + "..."
 			}
 
+			if m.millerFilter == "" && i < favorites {
+				name = "★ " + name
+			}
 			if i == m.millerBookIdx {
 				booksContent.WriteString(selectedStyle.Render("> "+name) + "\n")
 			} else {
@@ -2669,10 +3298,10 @@ func (m Model) renderMillerColumns() string {
 
 	// Column 2: Chapters
 	var chaptersContent strings.Builder
-	chaptersContent.WriteString(headerStyle.Render("CHAPTERS") + "\n\n")
+	chaptersContent.WriteString(headerStyle.Render(locale.T("CHAPTERS")) + "\n\n")
 
-	if m.books != nil && m.millerBookIdx < len(m.books) {
-		selectedBook := m.books[m.millerBookIdx]
+	if millerBooks := m.millerBooks(); m.millerBookIdx < len(millerBooks) {
+		selectedBook := millerBooks[m.millerBookIdx]
 		for i := 0; i < selectedBook.Chapters; i++ {
 			chapterNum := fmt.Sprintf("Chapter %d", i+1)
 			if i == m.millerChapterIdx {
@@ -2692,7 +3321,7 @@ func (m Model) renderMillerColumns() string {
 
 	// Column 3: Verses
 	var versesContent strings.Builder
-	versesContent.WriteString(headerStyle.Render("VERSES") + "\n")
+	versesContent.WriteString(headerStyle.Render(locale.T("VERSES")) + "\n")
 
 	// Show filter input if in verses column
 	if m.millerColumn == 2 && m.millerFilterMode {
@@ -2705,13 +3334,13 @@ func (m Model) renderMillerColumns() string {
 	}
 
 	// Use filtered verses if filter is active, otherwise use all verses
-	versesToDisplay := m.currentVerses
+	versesToDisplay := m.millerVerses()
 	if m.millerColumn == 2 && m.millerFilter != "" && m.millerFilteredVerses != nil {
 		versesToDisplay = m.millerFilteredVerses
 	}
 
 	if versesToDisplay != nil {
-		visibleItems := m.height - 8
+		visibleItems := columnHeight - 6
 		if visibleItems < 5 {
 			visibleItems = 5
 		}
@@ -2740,6 +3369,9 @@ func (m Model) renderMillerColumns() string {
 				text = text[:20] + "..."
 			}
 			verseLabel := fmt.Sprintf("%d. %s", verse.Verse, text)
+			if text == "" {
+				verseLabel = fmt.Sprintf("%d.", verse.Verse)
+			}
 
 			if i == m.millerVerseIdx {
 				versesContent.WriteString(selectedStyle.Render("> "+verseLabel) + "\n")
@@ -2827,7 +3459,7 @@ func (m Model) renderSidebar() string {
 	selectedStyle := lipgloss.NewStyle().
 		Foreground(m.currentTheme.Accent).
 		Background(m.currentTheme.Background).
-		Bold(true).
+		Bold(m.styled()).
 		Padding(0, 1)
 
 	normalStyle := lipgloss.NewStyle().
@@ -2837,13 +3469,13 @@ func (m Model) renderSidebar() string {
 	sectionHeaderStyle := lipgloss.NewStyle().
 		Foreground(m.currentTheme.Success).
 		Background(m.currentTheme.Background).
-		Bold(true).
+		Bold(m.styled()).
 		Padding(0, 1).
 		Width(28)
 
 	moreStyle := lipgloss.NewStyle().
 		Foreground(m.currentTheme.Muted).
-		Italic(true).
+		Italic(m.styled()).
 		Padding(0, 1)
 
 	var sb strings.Builder
@@ -2863,27 +3495,15 @@ func (m Model) renderSidebar() string {
 
 		var entries []bookEntry
 
-		// Old Testament header
-		entries = append(entries, bookEntry{isHeader: true, headerText: "OLD TESTAMENT"})
-
-		// Old Testament books
-		for i, book := range m.books {
-			if book.BookID > 39 {
-				break
+		// Each testament's books under its header, in book_order
+		for n, sec := range m.bookSections() {
+			if n > 0 {
+				entries = append(entries, bookEntry{isHeader: true, headerText: ""}) // blank line
 			}
-			entries = append(entries, bookEntry{isHeader: false, bookIndex: i, book: book})
-		}
-
-		// New Testament header (with spacing)
-		entries = append(entries, bookEntry{isHeader: true, headerText: ""}) // blank line
-		entries = append(entries, bookEntry{isHeader: true, headerText: "NEW TESTAMENT"})
-
-		// New Testament books
-		for i, book := range m.books {
-			if book.BookID < 40 {
-				continue
+			entries = append(entries, bookEntry{isHeader: true, headerText: sec.title})
+			for _, i := range sec.books {
+				entries = append(entries, bookEntry{isHeader: false, bookIndex: i, book: m.books[i]})
 			}
-			entries = append(entries, bookEntry{isHeader: false, bookIndex: i, book: book})
 		}
 
 		// Find the entry index for the selected book
@@ -2990,72 +3610,18 @@ func (m Model) renderTranslationSelect() string {
 		BorderForeground(m.currentTheme.BorderActive).
 		BorderBackground(bg).
 		Background(bg).
-		Width(56).
-		Padding(1, 2)
-
-	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(true)
-
-	selectedStyle := lipgloss.NewStyle().
-		Foreground(bg).
-		Background(m.currentTheme.Accent).
-		Bold(true).
-		Padding(0, 1)
-
-	normalStyle := lipgloss.NewStyle().
-		Foreground(m.currentTheme.Primary).
-		Background(bg).
-		Padding(0, 1)
+		Width(m.panelWidth(56)).
+		Padding(m.panelPadding())
 
-	currentStyle := lipgloss.NewStyle().
-		Foreground(m.currentTheme.Success).
-		Background(bg).
-		Padding(0, 1)
+	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(m.styled())
 
 	var content strings.Builder
-	content.WriteString(titleStyle.Render("Select Translation") + "\n\n")
+	content.WriteString(titleStyle.Render(locale.T("Select Translation")) + m.panelTitleGap())
 
 	if m.translations != nil {
-		// Show at most 16 translations centered on selection.
-		const window = 16
-		start, end := 0, len(m.translations)
-		if len(m.translations) > window {
-			start = m.translationSelected - window/2
-			if start < 0 {
-				start = 0
-			}
-			end = start + window
-			if end > len(m.translations) {
-				end = len(m.translations)
-				start = end - window
-			}
-		}
-		mutedStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(true)
-		if start > 0 {
-			content.WriteString(mutedStyle.Render(fmt.Sprintf("  ↑ %d more\n", start)))
-		}
-		for i := start; i < end; i++ {
-			trans := m.translations[i]
-			prefix := "  "
-			style := normalStyle
-			suffix := ""
-			isCurrent := trans.ShortName == m.selectedTranslation
-			if i == m.translationSelected {
-				prefix = "▸ "
-				style = selectedStyle
-			} else if isCurrent {
-				style = currentStyle
-			}
-			name := fmt.Sprintf("%-6s · %s", trans.ShortName, trans.FullName)
-			if isCurrent && i != m.translationSelected {
-				suffix = "  ●"
-			}
-			content.WriteString(style.Render(prefix+name+suffix) + "\n")
-		}
-		if end < len(m.translations) {
-			content.WriteString(mutedStyle.Render(fmt.Sprintf("  ↓ %d more", len(m.translations)-end)))
-		}
+		content.WriteString(m.translationPicker.View(m.styles, m.translationItems(), 0))
 	} else {
-		content.WriteString(normalStyle.Render("  Loading translations..."))
+		content.WriteString(m.styles.text.Padding(0, 1).Render("  Loading translations..."))
 	}
 
 	return containerStyle.Render(content.String())
@@ -3069,80 +3635,19 @@ func (m Model) renderCacheManager() string {
 		BorderForeground(m.currentTheme.BorderActive).
 		BorderBackground(bg).
 		Background(bg).
-		Width(56).
-		Padding(1, 2)
+		Width(m.panelWidth(56)).
+		Padding(m.panelPadding())
 
-	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(true)
-	mutedStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(true)
-
-	selectedStyle := lipgloss.NewStyle().
-		Foreground(bg).
-		Background(m.currentTheme.Accent).
-		Bold(true).
-		Padding(0, 1)
-	normalStyle := lipgloss.NewStyle().
-		Foreground(m.currentTheme.Primary).
-		Background(bg).
-		Padding(0, 1)
-	cachedStyle := lipgloss.NewStyle().
-		Foreground(m.currentTheme.Success).
-		Background(bg).
-		Padding(0, 1)
-	downloadingStyle := lipgloss.NewStyle().
-		Foreground(m.currentTheme.Warning).
-		Background(bg).
-		Padding(0, 1)
+	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(m.styled())
+	mutedStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(m.styled())
 
 	var content strings.Builder
-	content.WriteString(titleStyle.Render("Download Translations") + "\n\n")
+	content.WriteString(titleStyle.Render(locale.T("Download Translations")) + m.panelTitleGap())
 
 	if m.translations != nil {
-		const window = 14
-		start, end := 0, len(m.translations)
-		if len(m.translations) > window {
-			start = m.cacheSelected - window/2
-			if start < 0 {
-				start = 0
-			}
-			end = start + window
-			if end > len(m.translations) {
-				end = len(m.translations)
-				start = end - window
-			}
-		}
-		if start > 0 {
-			content.WriteString(mutedStyle.Render(fmt.Sprintf("  ↑ %d more\n", start)))
-		}
-		for i := start; i < end; i++ {
-			trans := m.translations[i]
-			prefix := "  "
-			style := normalStyle
-			suffix := ""
-			isCached := m.cache != nil && m.cache.IsCached(trans.ShortName)
-			isDownloading := m.downloadingTranslation == trans.ShortName
-			if i == m.cacheSelected {
-				prefix = "▸ "
-				style = selectedStyle
-			}
-			name := fmt.Sprintf("%-6s · %s", trans.ShortName, trans.FullName)
-			if isDownloading {
-				suffix = "  ⟳ downloading"
-				if i != m.cacheSelected {
-					style = downloadingStyle
-				}
-			} else if isCached {
-				suffix = "  ✓"
-				if i != m.cacheSelected {
-					style = cachedStyle
-				}
-			}
-			content.WriteString(style.Render(prefix+name+suffix) + "\n")
-		}
-		if end < len(m.translations) {
-			content.WriteString(mutedStyle.Render(fmt.Sprintf("  ↓ %d more", len(m.translations)-end)))
-		}
+		content.WriteString(m.cachePicker.View(m.styles, m.cacheItems(), 0))
 	} else {
-		content.WriteString(normalStyle.Render("  Loading translations..."))
+		content.WriteString(m.styles.text.Padding(0, 1).Render("  Loading translations..."))
 	}
 
 	// Live download progress bar, rendered just inside the panel below
@@ -3150,9 +3655,15 @@ func (m Model) renderCacheManager() string {
 	// is asked for a static view via ViewAs(p) so it doesn't animate
 	// independently of our poll-driven m.downloadProgress.
 	if m.downloadingTranslation != "" {
+		// The bar spans the panel's inside, whatever width it has now.
+		inner := containerStyle.GetWidth() - containerStyle.GetHorizontalFrameSize()
 		bar := m.progressBar
-		bar.SetWidth(48)
-		content.WriteString("\n\n" + mutedStyle.Render(fmt.Sprintf("Downloading %s", m.downloadingTranslation)) + "\n")
+		bar.SetWidth(inner)
+		label := fmt.Sprintf("Downloading %s", m.downloadingTranslation)
+		if size := m.downloadSize(); size != "" {
+			label += " · " + size
+		}
+		content.WriteString("\n\n" + mutedStyle.Render(clipText(label, inner)) + "\n")
 		content.WriteString(bar.ViewAs(m.downloadProgress))
 	}
 
@@ -3166,11 +3677,11 @@ func (m Model) renderCacheManager() string {
 }
 
 func (m Model) renderThemeSelect() string {
-	// The picker uses the CURRENTLY APPLIED theme for its own chrome
-	// (title, list, container border) so the picker keeps a stable look
-	// while the user is arrowing through options. The PREVIEW pane uses
-	// the FOCUSED theme (themes[themeSelected]) so the user can see what
-	// they'd be committing to without pressing Enter.
+	// Moving through the list applies the focused theme to the whole
+	// reader behind the picker (previewTheme), so its chrome and the
+	// PREVIEW pane both show what Enter would commit to; the pane uses
+	// themes[themePicker.selected] directly so it follows the cursor
+	// even while the filter hides the selection.
 	chromeBg := m.currentTheme.Background
 
 	themes := theme.AllThemes()
@@ -3184,24 +3695,17 @@ func (m Model) renderThemeSelect() string {
 		BorderBackground(chromeBg).
 		Background(chromeBg).
 		Width(innerW + 4). // +2 padding +2 border
-		Padding(1, 2)
+		Padding(m.panelPadding())
 
 	titleStyle := lipgloss.NewStyle().
 		Foreground(m.currentTheme.Accent).
 		Background(chromeBg).
-		Bold(true)
+		Bold(m.styled())
 
 	// --- Left column: theme list ---
-	listSelectedStyle := lipgloss.NewStyle().
-		Foreground(chromeBg).
-		Background(m.currentTheme.Accent).
-		Bold(true)
 	listNormalStyle := lipgloss.NewStyle().
 		Foreground(m.currentTheme.Primary).
 		Background(chromeBg)
-	listCurrentStyle := lipgloss.NewStyle().
-		Foreground(m.currentTheme.Success).
-		Background(chromeBg)
 
 	padRow := func(s string) string {
 		w := lipgloss.Width(s)
@@ -3216,33 +3720,14 @@ func (m Model) renderThemeSelect() string {
 	// JoinHorizontal (which has been mis-pairing rows here when the
 	// preview contains multi-row primitives like the highlight box).
 	var listRows []string
-	listRows = append(listRows, listNormalStyle.Render(padRow(titleStyle.Render("Select Theme"))))
-	listRows = append(listRows, listNormalStyle.Render(padRow("")))
-	for i, thm := range themes {
-		label := thm.Name
-		if lipgloss.Width(label) > listWidth-4 {
-			label = label[:listWidth-4]
-		}
-		isCurrent := thm.Name == m.currentTheme.Name
-		isFocused := i == m.themeSelected
-		var row string
-		switch {
-		case isFocused:
-			text := "▸ " + label
-			if isCurrent {
-				text += " ●"
-			}
-			row = listSelectedStyle.Render(padRow(text))
-		case isCurrent:
-			row = listCurrentStyle.Render(padRow("  " + label + " ●"))
-		default:
-			row = listNormalStyle.Render(padRow("  " + label))
-		}
-		listRows = append(listRows, row)
+	listRows = append(listRows, listNormalStyle.Render(padRow(titleStyle.Render(locale.T("Select Theme")))))
+	if !m.compact() {
+		listRows = append(listRows, listNormalStyle.Render(padRow("")))
 	}
+	listRows = append(listRows, strings.Split(m.themePicker.View(m.styles, m.themeItems(), listWidth), "\n")...)
 
 	// --- Right column: live preview using the focused theme ---
-	focused := themes[m.themeSelected]
+	focused := themes[m.themePicker.selected]
 	previewRows := strings.Split(m.themePreview(focused, previewWidth), "\n")
 	// Drop a trailing empty row that strings.Split produces when the
 	// preview ends with a newline.
@@ -3291,14 +3776,14 @@ func (m Model) themePreview(th theme.Theme, w int) string {
 		inner = 14
 	}
 
-	titleStyle := lipgloss.NewStyle().Foreground(th.Accent).Background(bg).Bold(true)
-	bookStyle := lipgloss.NewStyle().Foreground(th.Success).Background(bg).Bold(true)
-	verseNumStyle := lipgloss.NewStyle().Foreground(th.Warning).Background(bg).Bold(true).Width(4).Align(lipgloss.Right)
+	titleStyle := lipgloss.NewStyle().Foreground(th.Accent).Background(bg).Bold(m.styled())
+	bookStyle := lipgloss.NewStyle().Foreground(th.Success).Background(bg).Bold(m.styled())
+	verseNumStyle := lipgloss.NewStyle().Foreground(th.Warning).Background(bg).Bold(m.styled()).Width(4).Align(lipgloss.Right)
 	textStyle := lipgloss.NewStyle().Foreground(th.Primary).Background(bg)
-	mutedStyle := lipgloss.NewStyle().Foreground(th.Muted).Background(bg).Italic(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(th.Muted).Background(bg).Italic(m.styled())
 
-	hlVerseNumStyle := lipgloss.NewStyle().Foreground(th.Accent).Background(hbg).Bold(true).Width(4).Align(lipgloss.Right)
-	hlTextStyle := lipgloss.NewStyle().Foreground(th.Primary).Background(hbg).Bold(true)
+	hlVerseNumStyle := lipgloss.NewStyle().Foreground(th.Accent).Background(hbg).Bold(m.styled()).Width(4).Align(lipgloss.Right)
+	hlTextStyle := lipgloss.NewStyle().Foreground(th.Primary).Background(hbg).Bold(m.styled())
 	hlBoxStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(th.BorderActive).
@@ -3369,22 +3854,51 @@ func (m Model) themePreview(th theme.Theme, w int) string {
 	return card
 }
 
-func (m Model) formatChapter(verses []api.Verse, bookName string, chapter int, width int, highlightedVerseStart, highlightedVerseEnd int) string {
+// verseOffset records the content line at which a verse's rendering
+// starts. The verse then covers every line up to the next offset, blank
+// separators and box borders included.
+type verseOffset struct {
+	verse int
+	line  int
+}
+
+// formatChapter renders verses for the reader viewport and returns, along
+// with the text, where each verse starts so that mouse hit-testing and
+// scrolling never have to repeat the wrapping math.
+func (m Model) formatChapter(verses []api.Verse, bookName string, chapter int, width int, highlightedVerseStart, highlightedVerseEnd int) (string, []verseOffset) {
+	numbers := make([]int, len(verses))
+	for i, v := range verses {
+		numbers[i] = v.Verse
+	}
+	gutter := m.showGutter(numbers)
+	if gutter {
+		width -= gutterWidth
+	}
+	// markAt maps the first text line of each verse to the verse, for the
+	// annotation gutter.
+	markAt := map[int]int{}
+
 	bg := m.currentTheme.Background
 	hbg := m.currentTheme.Highlight
 
+	numWidth := m.verseNumberWidth()
+	numColor, highlightedNumColor := m.currentTheme.Warning, m.currentTheme.Accent
+	if m.verseNumbers == versenum.Dimmed {
+		numColor, highlightedNumColor = m.currentTheme.Muted, m.currentTheme.Muted
+	}
+
 	verseStyle := lipgloss.NewStyle().
-		Foreground(m.currentTheme.Warning).
+		Foreground(numColor).
 		Background(bg).
-		Bold(true).
-		Width(4).
+		Bold(m.styled() && m.verseNumbers != versenum.Dimmed).
+		Width(numWidth).
 		Align(lipgloss.Right)
 
 	highlightedVerseStyle := lipgloss.NewStyle().
-		Foreground(m.currentTheme.Accent).
+		Foreground(highlightedNumColor).
 		Background(hbg).
-		Bold(true).
-		Width(4).
+		Bold(m.styled() && m.verseNumbers != versenum.Dimmed).
+		Width(numWidth).
 		Align(lipgloss.Right)
 
 	textStyle := lipgloss.NewStyle().
@@ -3394,7 +3908,7 @@ func (m Model) formatChapter(verses []api.Verse, bookName string, chapter int, w
 	highlightedTextStyle := lipgloss.NewStyle().
 		Foreground(m.currentTheme.Primary).
 		Background(hbg).
-		Bold(true)
+		Bold(m.styled())
 
 	highlightedContainerStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
@@ -3418,13 +3932,17 @@ func (m Model) formatChapter(verses []api.Verse, bookName string, chapter int, w
 	}
 
 	var sb strings.Builder
+	lines := 0
+	offsets := make([]verseOffset, 0, len(verses))
+	gap := m.verseGap()
 
 	// Calculate available width for text. Verse number is right-aligned
-	// in 4 chars + 2 spaces = 6 chars total. We leave an extra 2 cells of
-	// safety so the highlighted-verse rounded box (which costs 6 cells of
+	// in numWidth chars + 2 spaces. We leave an extra 2 cells of safety so
+	// the highlighted-verse rounded box (which costs 6 cells of
 	// border+padding around the inner text) doesn't equal viewport width
 	// exactly (lipgloss wraps on exact-width matches).
-	textWidth := width - 8
+	indent := numWidth + 2
+	textWidth := width - indent - 2
 	if textWidth < 20 {
 		textWidth = 20 // Minimum width for readability
 	}
@@ -3432,14 +3950,51 @@ func (m Model) formatChapter(verses []api.Verse, bookName string, chapter int, w
 		textWidth = width - 2
 	}
 
+	// The chapter outline, if any, sits above the first verse.
+	if intro := m.outlineLines(); len(intro) > 0 {
+		for _, ln := range intro {
+			sb.WriteString(padToWidth(ln) + "\n")
+		}
+		sb.WriteString(blankLine + "\n")
+		lines += len(intro) + 1
+	}
+
+	// Right-to-left translations are set flush right, every line in the
+	// text column, clear of the verse numbers.
+	rtl := m.translationRTL(m.selectedTranslation)
+	wrap := func(text string, w int) string {
+		if rtl {
+			return alignRight(wrapText(text, w), w)
+		}
+		return wrapTextWithIndent(text, w, indent)
+	}
+	blankHNum := highlightedVerseStyle.Render("")
+
 	// Track if we're currently in a highlighted range
 	inHighlightedRange := false
 	var highlightedContent strings.Builder
+	boxStart := 0
+	letters := m.acrosticLetters()
 
 	for i, v := range verses {
-		// Remove HTML tags
-		text := stripHTMLTags(v.Text)
-		verseNumStr := fmt.Sprintf("%d", v.Verse)
+		// An acrostic's stanzas are headed by their Hebrew letter, inside
+		// the box when the range around it is highlighted.
+		if l, ok := letters[v.Verse]; ok {
+			if inHighlightedRange {
+				highlightedContent.WriteString(m.acrosticHeading(l, textWidth-4+indent, hbg) + "\n" + strings.Repeat("\n", gap))
+			} else {
+				sb.WriteString(padToWidth(m.acrosticHeading(l, width-2, bg)) + "\n")
+				lines++
+				for range gap {
+					sb.WriteString(blankLine + "\n")
+					lines++
+				}
+			}
+		}
+
+		// Remove HTML tags, noting musical directions such as Selah
+		text, directions := verseDirections(v.Text)
+		verseNumStr := m.verseNumbers.Label(v.Verse)
 
 		// Check if this verse is in the highlighted range
 		isHighlighted := highlightedVerseStart > 0 && v.Verse >= highlightedVerseStart && v.Verse <= highlightedVerseEnd
@@ -3456,22 +4011,36 @@ func (m Model) formatChapter(verses []api.Verse, bookName string, chapter int, w
 				// Start of highlighted range
 				inHighlightedRange = true
 				highlightedContent.Reset()
+				boxStart = lines
+				offsets = append(offsets, verseOffset{v.Verse, boxStart})
+				markAt[boxStart+1] = v.Verse
+			} else {
+				// Inside the box: below its top border plus the rows
+				// already written for earlier verses of the range.
+				offsets = append(offsets, verseOffset{v.Verse, boxStart + 1 + strings.Count(highlightedContent.String(), "\n")})
+				markAt[boxStart+1+strings.Count(highlightedContent.String(), "\n")] = v.Verse
 			}
 
 			verseNum := highlightedVerseStyle.Render(verseNumStr)
 
-			// Calculate indent for wrapped lines (verse number width + 2 spaces)
-			indent := 6
 			// Account for border padding (2 chars on each side)
-			wrappedText := wrapTextWithIndent(text, textWidth-4, indent)
+			wrappedText := wrap(text, textWidth-4)
 			// Apply color with width set to prevent terminal wrapping
 			verseText := highlightedTextStyle.Width(textWidth - 4).Render(wrappedText)
+			if m.wordSelect && v.Verse == m.highlightedVerseStart {
+				verseText = m.renderWordSelection(wrappedText, textWidth-4, highlightedTextStyle)
+			} else if directions != nil {
+				verseText = m.renderDirections(wrappedText, textWidth-4, highlightedTextStyle, directions)
+			}
+			if rtl {
+				verseText = strings.ReplaceAll(verseText, "\n", "\n"+blankHNum+hsep)
+			}
 
 			highlightedContent.WriteString(verseNum + hsep + verseText)
 
 			// If next verse is also highlighted, add spacing within the border
 			if nextIsHighlighted {
-				highlightedContent.WriteString("\n\n")
+				highlightedContent.WriteString("\n" + strings.Repeat("\n", gap))
 			} else {
 				// End of highlighted range - render the border, then pad
 				// each rendered row out to width so the right edge meets
@@ -3479,36 +4048,62 @@ func (m Model) formatChapter(verses []api.Verse, bookName string, chapter int, w
 				borderedVerse := highlightedContainerStyle.Render(highlightedContent.String())
 				for _, ln := range strings.Split(borderedVerse, "\n") {
 					sb.WriteString(padToWidth(ln) + "\n")
+					lines++
+				}
+				for range gap {
+					sb.WriteString(blankLine + "\n")
+					lines++
 				}
-				sb.WriteString(blankLine + "\n")
 				inHighlightedRange = false
 			}
 		} else {
-			verseNum := verseStyle.Render(verseNumStr)
+			// A highlighted verse is tinted in its color.
+			numStyle, txtStyle, vsep, pad := verseStyle, textStyle, sep, padToWidth
+			if tint := m.verseBackground(m.highlightColorOf(v.Verse)); tint != nil {
+				numStyle, txtStyle = verseStyle.Background(tint), textStyle.Background(tint)
+				vsep = lipgloss.NewStyle().Background(tint).Render("  ")
+				pad = func(line string) string {
+					return line + lipgloss.NewStyle().Background(tint).Render(strings.Repeat(" ", max(width-lipgloss.Width(line), 0)))
+				}
+			}
+			verseNum := numStyle.Render(verseNumStr)
 
-			// Calculate indent for wrapped lines (verse number width + 2 spaces)
-			indent := 6
-			wrappedText := wrapTextWithIndent(text, textWidth, indent)
-			verseText := textStyle.Width(textWidth).Render(wrappedText)
+			wrappedText := wrap(text, textWidth)
+			verseText := txtStyle.Width(textWidth).Render(wrappedText)
+			if directions != nil {
+				verseText = m.renderDirections(wrappedText, textWidth, txtStyle, directions)
+			}
 
-			// Each wrapped line of the verse is verseNum (4) + sep (2) +
+			// Each wrapped line of the verse is verseNum + sep (2) +
 			// verseText (textWidth). The continuation lines already carry
 			// their leading indent inside wrappedText (from wrapTextWithIndent),
 			// so we only prepend the verse-number block on the first line.
 			// padToWidth then fills the right edge with bg for every row.
+			offsets = append(offsets, verseOffset{v.Verse, lines})
+			markAt[lines] = v.Verse
 			textLines := strings.Split(verseText, "\n")
+			lines += len(textLines)
 			for idx, ln := range textLines {
-				if idx == 0 {
-					sb.WriteString(padToWidth(verseNum+sep+ln) + "\n")
-				} else {
-					sb.WriteString(padToWidth(ln) + "\n")
+				switch {
+				case idx == 0:
+					sb.WriteString(pad(verseNum+vsep+ln) + "\n")
+				case rtl:
+					sb.WriteString(pad(numStyle.Render("")+vsep+ln) + "\n")
+				default:
+					sb.WriteString(pad(ln) + "\n")
 				}
 			}
-			sb.WriteString(blankLine + "\n")
+			for range gap {
+				sb.WriteString(blankLine + "\n")
+				lines++
+			}
 		}
 	}
 
-	return sb.String()
+	if gutter {
+		return m.addGutter(sb.String(), markAt), offsets
+	}
+	return sb.String(), offsets
 }
 
 func wrapText(text string, width int) string {
@@ -3522,7 +4117,7 @@ func wrapText(text string, width int) string {
 
 	words := strings.Fields(text)
 	for i, word := range words {
-		wordLen := len(word)
+		wordLen := lipgloss.Width(word)
 
 		// If adding this word would exceed width, start a new line
 		if currentLength > 0 && currentLength+1+wordLen > width {
@@ -3562,7 +4157,7 @@ func wrapTextWithIndent(text string, width int, indent int) string {
 
 	words := strings.Fields(text)
 	for i, word := range words {
-		wordLen := len(word)
+		wordLen := lipgloss.Width(word)
 
 		// If adding this word would exceed width, start a new line
 		if currentLength > 0 && currentLength+1+wordLen > width {
@@ -3597,145 +4192,51 @@ func wrapTextWithIndent(text string, width int, indent int) string {
 	return result.String()
 }
 
-func (m Model) formatParallelVerses(versesMap map[string][]api.Verse, translations []string, bookName string, chapter int, width int) string {
-	if len(translations) == 0 {
-		return ""
-	}
-
-	bg := m.currentTheme.Background
-
-	headerStyle := lipgloss.NewStyle().
-		Foreground(m.currentTheme.Accent).
-		Background(bg).
-		Bold(true)
-	verseNumStyle := lipgloss.NewStyle().
-		Foreground(m.currentTheme.Warning).
-		Background(bg).
-		Bold(true)
-	textStyle := lipgloss.NewStyle().
-		Foreground(m.currentTheme.Primary).
-		Background(bg)
-	separatorStyle := lipgloss.NewStyle().
-		Foreground(m.currentTheme.Border).
-		Background(bg)
-	bgPad := lipgloss.NewStyle().Background(bg)
-
-	// Column geometry: split the available width across N translations,
-	// leaving a 1-cell gutter between each pair.
-	n := len(translations)
-	gaps := n - 1
-	colWidth := (width - gaps) / n
-	if colWidth < 20 {
-		colWidth = 20
-	}
-	// Inner text width allows for a "NN " verse number prefix (4 cells).
-	textWidth := colWidth - 4
-	if textWidth < 12 {
-		textWidth = 12
-	}
-
-	// padCol pads a logical column line to colWidth using bg-styled
-	// spaces so the rows merge into a clean grid regardless of theme.
-	padCol := func(s string) string {
-		w := lipgloss.Width(s)
-		if w >= colWidth {
-			return s
-		}
-		return s + bgPad.Render(strings.Repeat(" ", colWidth-w))
+// yankText is what y copies: the highlighted verses, or the whole chapter
+// when nothing is highlighted, headed by the reference.
+func (m Model) yankText() string {
+	if m.cfg.ShareFormat == shareThread {
+		return m.shareText()
 	}
+	var textToCopy strings.Builder
 
-	// maxVerses across all translations.
-	maxVerses := 0
-	for _, vs := range versesMap {
-		if len(vs) > maxVerses {
-			maxVerses = len(vs)
+	// If verses are highlighted, only copy those
+	if m.highlightedVerseStart > 0 {
+		if m.highlightedVerseStart == m.highlightedVerseEnd {
+			textToCopy.WriteString(fmt.Sprintf("%s %s %d:%d\n\n", m.translationName(m.selectedTranslation), m.currentBookName, m.currentChapter, m.highlightedVerseStart))
+		} else {
+			textToCopy.WriteString(fmt.Sprintf("%s %s %d:%d-%d\n\n", m.translationName(m.selectedTranslation), m.currentBookName, m.currentChapter, m.highlightedVerseStart, m.highlightedVerseEnd))
 		}
-	}
 
-	// Build the header row: one column per translation, padded to colWidth.
-	// "▾" hints that the header opens a translation picker on click.
-	headerCells := make([]string, n)
-	for j, trans := range translations {
-		label := trans + " ▾"
-		if lipgloss.Width(label) > colWidth {
-			label = label[:colWidth]
+		for _, v := range m.currentVerses {
+			if v.Verse >= m.highlightedVerseStart && v.Verse <= m.highlightedVerseEnd {
+				text := stripHTMLTags(v.Text)
+				textToCopy.WriteString(m.yankVerse(v.Verse, text))
+			}
 		}
-		headerCells[j] = padCol(headerStyle.Render(label))
-	}
-	gutter := bgPad.Render(" ")
-	header := strings.Join(headerCells, gutter)
-
-	// Separator row under the header.
-	sepRow := padCol(separatorStyle.Render(strings.Repeat("─", colWidth)))
-	separator := strings.Join(repeatString(sepRow, n), gutter)
-
-	// For each verse number, build a row of columns and JoinHorizontal
-	// them. JoinHorizontal automatically pads shorter columns at the
-	// bottom so all rows in a verse stay aligned.
-	var rows []string
-	rows = append(rows, header, separator)
+	} else {
+		// Copy entire chapter
+		textToCopy.WriteString(fmt.Sprintf("%s %s %d\n\n", m.translationName(m.selectedTranslation), m.currentBookName, m.currentChapter))
 
-	for i := 1; i <= maxVerses; i++ {
-		cells := make([]string, n)
-		for j, trans := range translations {
-			verses := versesMap[trans]
-			var text string
-			for _, v := range verses {
-				if v.Verse == i {
-					text = stripHTMLTags(v.Text)
-					break
-				}
-			}
-			if text == "" {
-				cells[j] = padCol("")
-				continue
-			}
-			// First line: "N  text…", continuation lines indent under
-			// the text so the verse number stays as a visual anchor.
-			wrapped := wrapTextWithIndent(text, textWidth, 4)
-			lines := strings.Split(wrapped, "\n")
-			styled := make([]string, len(lines))
-			for k, ln := range lines {
-				if k == 0 {
-					styled[k] = padCol(verseNumStyle.Render(fmt.Sprintf("%-3d", i)) + bgPad.Render(" ") + textStyle.Render(ln))
-				} else {
-					styled[k] = padCol(textStyle.Render(ln))
-				}
-			}
-			cells[j] = strings.Join(styled, "\n")
+		for _, v := range m.currentVerses {
+			text := stripHTMLTags(v.Text)
+			textToCopy.WriteString(m.yankVerse(v.Verse, text))
 		}
-		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, intersperse(cells, gutter)...))
-		// Blank row between verses, styled in bg so it covers the full
-		// width and the grid stays painted.
-		blankRow := padCol("")
-		rows = append(rows, strings.Join(repeatString(blankRow, n), gutter))
 	}
 
-	return strings.Join(rows, "\n")
-}
-
-func repeatString(s string, n int) []string {
-	out := make([]string, n)
-	for i := range out {
-		out[i] = s
+	if m.cfg.ShareFormat == shareParagraph {
+		return strings.TrimRight(textToCopy.String(), " ") + "\n"
 	}
-	return out
+	return textToCopy.String()
 }
 
-// intersperse returns ss with sep placed between each consecutive pair.
-// "a","b","c" + "|" → "a","|","b","|","c"
-func intersperse(ss []string, sep string) []string {
-	if len(ss) == 0 {
-		return ss
-	}
-	out := make([]string, 0, 2*len(ss)-1)
-	for i, s := range ss {
-		if i > 0 {
-			out = append(out, sep)
-		}
-		out = append(out, s)
+// yankVerse is one verse as yankText copies it: numbered on its own
+// line, or run on with the next when share_format is "paragraph".
+func (m Model) yankVerse(verse int, text string) string {
+	if m.cfg.ShareFormat == shareParagraph {
+		return text + " "
 	}
-	return out
+	return m.verseNumbers.Prefix(verse, fmt.Sprintf("%d. ", verse)) + text + "\n\n"
 }
 
 func stripHTMLTags(s string) string {
@@ -3794,254 +4295,6 @@ func stripHTMLTags(s string) string {
 	return s
 }
 
-// fuzzyMatchBook attempts to match a book name or abbreviation to a book ID
-func fuzzyMatchBook(query string, books []api.Book) (int, string, bool) {
-	query = strings.ToLower(strings.TrimSpace(query))
-
-	// Book name abbreviations mapping
-	bookAbbrevs := map[string][]string{
-		"genesis":         {"gen", "ge", "gn"},
-		"exodus":          {"exo", "ex", "exod"},
-		"leviticus":       {"lev", "le", "lv"},
-		"numbers":         {"num", "nu", "nm", "nb"},
-		"deuteronomy":     {"deut", "de", "dt"},
-		"joshua":          {"josh", "jos", "jsh"},
-		"judges":          {"judg", "jdg", "jg", "jdgs"},
-		"ruth":            {"rut", "ru", "rth"},
-		"1 samuel":        {"1sam", "1sa", "1samuel", "1 sam", "1 sa", "1s"},
-		"2 samuel":        {"2sam", "2sa", "2samuel", "2 sam", "2 sa", "2s"},
-		"1 kings":         {"1king", "1kgs", "1ki", "1k", "1 kings", "1 kgs"},
-		"2 kings":         {"2king", "2kgs", "2ki", "2k", "2 kings", "2 kgs"},
-		"1 chronicles":    {"1chron", "1chr", "1ch", "1 chronicles", "1 chr"},
-		"2 chronicles":    {"2chron", "2chr", "2ch", "2 chronicles", "2 chr"},
-		"ezra":            {"ezr", "ez"},
-		"nehemiah":        {"neh", "ne"},
-		"esther":          {"est", "es"},
-		"job":             {"jb"},
-		"psalms":          {"psalm", "psa", "ps", "pss"},
-		"proverbs":        {"prov", "pro", "pr", "prv"},
-		"ecclesiastes":    {"eccl", "ecc", "ec", "qoh"},
-		"song of solomon": {"song", "sos", "so", "canticle", "canticles", "song of songs"},
-		"isaiah":          {"isa", "is"},
-		"jeremiah":        {"jer", "je", "jr"},
-		"lamentations":    {"lam", "la"},
-		"ezekiel":         {"ezek", "eze", "ezk"},
-		"daniel":          {"dan", "da", "dn"},
-		"hosea":           {"hos", "ho"},
-		"joel":            {"joe", "jl"},
-		"amos":            {"amo", "am"},
-		"obadiah":         {"obad", "ob"},
-		"jonah":           {"jon", "jnh"},
-		"micah":           {"mic", "mi"},
-		"nahum":           {"nah", "na"},
-		"habakkuk":        {"hab", "hb"},
-		"zephaniah":       {"zeph", "zep", "zp"},
-		"haggai":          {"hag", "hg"},
-		"zechariah":       {"zech", "zec", "zc"},
-		"malachi":         {"mal", "ml"},
-		"matthew":         {"matt", "mat", "mt"},
-		"mark":            {"mar", "mrk", "mk", "mr"},
-		"luke":            {"luk", "lk"},
-		"john":            {"joh", "jhn", "jn"},
-		"acts":            {"act", "ac"},
-		"romans":          {"rom", "ro", "rm"},
-		"1 corinthians":   {"1cor", "1co", "1 corinthians", "1 cor"},
-		"2 corinthians":   {"2cor", "2co", "2 corinthians", "2 cor"},
-		"galatians":       {"gal", "ga"},
-		"ephesians":       {"eph", "ephes"},
-		"philippians":     {"phil", "php", "pp"},
-		"colossians":      {"col", "co"},
-		"1 thessalonians": {"1thess", "1th", "1 thessalonians", "1 thess"},
-		"2 thessalonians": {"2thess", "2th", "2 thessalonians", "2 thess"},
-		"1 timothy":       {"1tim", "1ti", "1 timothy", "1 tim"},
-		"2 timothy":       {"2tim", "2ti", "2 timothy", "2 tim"},
-		"titus":           {"tit", "ti"},
-		"philemon":        {"philem", "phm", "pm"},
-		"hebrews":         {"heb", "he"},
-		"james":           {"jam", "jas", "jm"},
-		"1 peter":         {"1pet", "1pe", "1pt", "1p", "1 peter", "1 pet"},
-		"2 peter":         {"2pet", "2pe", "2pt", "2p", "2 peter", "2 pet"},
-		"1 john":          {"1john", "1jn", "1jo", "1j", "1 john"},
-		"2 john":          {"2john", "2jn", "2jo", "2j", "2 john"},
-		"3 john":          {"3john", "3jn", "3jo", "3j", "3 john"},
-		"jude":            {"jud", "jd"},
-		"revelation":      {"rev", "re", "rv"},
-	}
-
-	// Try exact match first
-	for _, book := range books {
-		if strings.ToLower(book.Name) == query {
-			return book.BookID, book.Name, true
-		}
-	}
-
-	// Try abbreviation match
-	for _, book := range books {
-		bookNameLower := strings.ToLower(book.Name)
-		if abbrevs, ok := bookAbbrevs[bookNameLower]; ok {
-			for _, abbrev := range abbrevs {
-				if query == abbrev {
-					return book.BookID, book.Name, true
-				}
-			}
-		}
-	}
-
-	// Try prefix match
-	for _, book := range books {
-		if strings.HasPrefix(strings.ToLower(book.Name), query) {
-			return book.BookID, book.Name, true
-		}
-	}
-
-	return 0, "", false
-}
-
-// parseReference accepts a wide variety of reference formats:
-//   - "John 3:16"       canonical
-//   - "john3:16"        no spaces
-//   - "john 3 16"       spaces instead of colon
-//   - "john 3:16-17"    range
-//   - "john 3 16-17"    range with spaces
-//   - "1 John 3:16"     book name starting with a digit
-//   - "1john3:16"       no spaces, book starts with digit
-//   - "1 1:1"           book by numeric id + chapter:verse
-//   - "gen 1"           book + chapter only
-//   - "gen"             book only (defaults to chapter 1)
-//
-// The split into book and the rest happens at the boundary between the
-// (optional digit-prefixed) word that names the book and the numeric
-// chapter/verse data that follows. Then any digit-runs in the rest are
-// pulled out in order as chapter / verse-start / verse-end, with a
-// hyphen interpreted as the range separator.
-func parseReference(ref string, books []api.Book) (book, chapter, verseStart, verseEnd int, err error) {
-	ref = strings.TrimSpace(ref)
-	if ref == "" {
-		return 0, 0, 0, 0, fmt.Errorf("empty reference")
-	}
-
-	// Book identifier alternatives, in order of specificity. Each
-	// letter run may be followed by ` letter-run` repeats so multi-word
-	// book names like "Song of Solomon" or "1 Samuel" stay intact.
-	//   1. digit + optional whitespace + letters (+ more words)  →  "1 John", "1john", "1 Samuel"
-	//   2. letters (+ more words)                                 →  "John", "rom", "Song of Solomon"
-	//   3. digit                                                  →  "1" (book id)
-	bookRe := regexp.MustCompile(`(?i)^(\d+\s*[a-z]+(?:\s+[a-z]+)*|[a-z]+(?:\s+[a-z]+)*|\d+)\s*(.*)$`)
-	m := bookRe.FindStringSubmatch(ref)
-	if m == nil {
-		return 0, 0, 0, 0, fmt.Errorf("invalid reference: %s", ref)
-	}
-	bookPart := strings.TrimSpace(m[1])
-	rest := m[2]
-
-	// Resolve the book identifier.
-	if id, perr := strconv.Atoi(bookPart); perr == nil {
-		book = id
-	} else if len(books) > 0 {
-		id, _, found := fuzzyMatchBook(bookPart, books)
-		if !found {
-			return 0, 0, 0, 0, fmt.Errorf("book not found: %s", bookPart)
-		}
-		book = id
-	} else {
-		return 0, 0, 0, 0, fmt.Errorf("no books loaded")
-	}
-
-	// Default chapter when none was supplied.
-	chapter = 1
-
-	if rest == "" {
-		return book, chapter, 0, 0, nil
-	}
-
-	// Split into before/after a hyphen so verse-range parsing is robust
-	// even with arbitrary separators around it ("3:16-17", "3 16 - 17",
-	// "3 16-17").
-	beforeDash, afterDash := rest, ""
-	if i := strings.IndexByte(rest, '-'); i >= 0 {
-		beforeDash = rest[:i]
-		afterDash = rest[i+1:]
-	}
-
-	numRe := regexp.MustCompile(`\d+`)
-	beforeNums := numRe.FindAllString(beforeDash, -1)
-	afterNums := numRe.FindAllString(afterDash, -1)
-
-	if len(beforeNums) >= 1 {
-		chapter, _ = strconv.Atoi(beforeNums[0])
-	}
-	if len(beforeNums) >= 2 {
-		verseStart, _ = strconv.Atoi(beforeNums[1])
-		verseEnd = verseStart
-	}
-	if len(afterNums) >= 1 {
-		verseEnd, _ = strconv.Atoi(afterNums[0])
-		if verseStart == 0 && len(beforeNums) >= 2 {
-			verseStart, _ = strconv.Atoi(beforeNums[1])
-		}
-	}
-
-	return book, chapter, verseStart, verseEnd, nil
-}
-
-func (m Model) renderAbout() string {
-	bg := m.currentTheme.Background
-
-	width := 64
-	if m.width-leftPaneOuterWidth-6 < width {
-		width = m.width - leftPaneOuterWidth - 6
-		if width < 40 {
-			width = 40
-		}
-	}
-
-	containerStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(m.currentTheme.BorderActive).
-		BorderBackground(bg).
-		Background(bg).
-		Width(width).
-		Padding(1, 2)
-
-	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(true)
-	sectionStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Primary).Background(bg)
-	labelStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Secondary).Background(bg).Bold(true)
-	valueStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Primary).Background(bg)
-	linkStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Underline(true)
-
-	var content strings.Builder
-	content.WriteString(titleStyle.Render("sword-tui") + "\n")
-	content.WriteString(sectionStyle.Render("A terminal-based Bible application") + "\n\n")
-
-	content.WriteString(labelStyle.Render("Version: ") + valueStyle.Render(version.Version) + "\n")
-	content.WriteString(labelStyle.Render("Build:   ") + valueStyle.Render(version.BuildNumber) + "\n\n")
-
-	content.WriteString(labelStyle.Render("Repo:    ") + linkStyle.Render("github.com/kmf/sword-tui") + "\n")
-	content.WriteString(labelStyle.Render("API:     ") + valueStyle.Render("bolls.life") + "\n")
-	content.WriteString(labelStyle.Render("License: ") + valueStyle.Render("GPL-2.0-or-later") + "\n\n")
-
-	content.WriteString(titleStyle.Render("Shortcuts") + "\n\n")
-	shortcuts := []struct{ key, desc string }{
-		{"tab", "switch focused pane"},
-		{"⏎", "open book / submit"},
-		{"n / p", "next / prev chapter"},
-		{"/", "go to verse"},
-		{"s", "search Bible"},
-		{"c", "compare translations"},
-		{"t", "select translation"},
-		{"T", "select theme"},
-		{"d", "download translations"},
-		{"y", "yank current verse"},
-		{"?", "about"},
-		{"q", "quit"},
-	}
-	for _, s := range shortcuts {
-		content.WriteString(labelStyle.Render(fmt.Sprintf("%-8s", s.key)) + sectionStyle.Render(s.desc) + "\n")
-	}
-
-	return containerStyle.Render(content.String())
-}
-
 func (m Model) renderWordSearch() string {
 	bg := m.currentTheme.Background
 
@@ -4064,14 +4317,14 @@ func (m Model) renderWordSearch() string {
 		BorderBackground(bg).
 		Background(bg).
 		Width(width).
-		Padding(1, 2)
+		Padding(m.panelPadding())
 
-	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(true)
+	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(m.styled())
 
 	selectedStyle := lipgloss.NewStyle().
 		Foreground(bg).
 		Background(m.currentTheme.Accent).
-		Bold(true)
+		Bold(m.styled())
 
 	normalStyle := lipgloss.NewStyle().
 		Foreground(m.currentTheme.Primary).
@@ -4080,27 +4333,32 @@ func (m Model) renderWordSearch() string {
 	bookNameStyle := lipgloss.NewStyle().
 		Foreground(m.currentTheme.Accent).
 		Background(bg).
-		Bold(true)
+		Bold(m.styled())
 
-	mutedStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(m.styled())
 
 	var content strings.Builder
-	content.WriteString(titleStyle.Render("Search Bible") + "\n\n")
+	content.WriteString(titleStyle.Render(locale.T("Search Bible")) + m.panelTitleGap())
 
 	if m.wordSearchResults == nil && !m.wordSearchLoading {
 		ti := m.wordSearchInput
 		ti.SetStyles(m.themedInputStyles())
 		ti.SetWidth(innerW - 2) // leave a couple of cells of breathing room
 		content.WriteString(ti.View() + "\n\n")
-		content.WriteString(mutedStyle.Render("Type a word or phrase, then ⏎"))
+		content.WriteString(mutedStyle.Render(locale.T("Type a word or phrase, then ⏎")))
 	} else if m.wordSearchLoading {
-		content.WriteString(mutedStyle.Render("Searching…"))
+		content.WriteString(mutedStyle.Render(locale.T("Searching…")))
 	} else if len(m.wordSearchResults) == 0 {
 		content.WriteString(normalStyle.Render(fmt.Sprintf("No results for \"%s\"", m.wordSearchQuery)) + "\n\n")
 		content.WriteString(mutedStyle.Render("esc to close"))
 	} else {
-		content.WriteString(mutedStyle.Render(fmt.Sprintf("%d results for \"%s\" — showing %d",
-			m.wordSearchTotal, m.wordSearchQuery, len(m.wordSearchResults))) + "\n\n")
+		summary := fmt.Sprintf("%d results for \"%s\" — showing %d", m.wordSearchTotal, m.wordSearchQuery, len(m.wordSearchResults))
+		if pages := m.searchPages(); pages > 1 {
+			first := (m.wordSearchPage-1)*api.SearchPageSize + 1
+			summary = fmt.Sprintf("%d results for \"%s\" — %d–%d, page %d of %d", m.wordSearchTotal, m.wordSearchQuery,
+				first, first+len(m.wordSearchResults)-1, m.wordSearchPage, pages)
+		}
+		content.WriteString(mutedStyle.Render(summary) + m.panelTitleGap())
 
 		// Row-based virtual scrolling: each result may wrap to multiple
 		// lines, so we budget by rendered row count instead of by item.
@@ -4108,8 +4366,13 @@ func (m Model) renderWordSearch() string {
 		// - status bar (3) - 2 cells of breathing room. Inside the panel
 		// we lose: border (2) + padding (2) + title (1) + blank (1) +
 		// "X results for…" (1) + blank (1) + the "↓ N more" trailer (1)
-		// = 9 rows of chrome. Anything left is for the wrapped items.
-		availRows := m.height - 3 - 3 - 2 - 9
+		// = 9 rows of chrome (5 when compact, without the padding and
+		// blanks). Anything left is for the wrapped items.
+		chrome := 9
+		if m.compact() {
+			chrome = 5
+		}
+		availRows := m.height - 3 - 3 - 2 - chrome
 		if availRows < 4 {
 			availRows = 4
 		}
@@ -4141,7 +4404,7 @@ func (m Model) renderWordSearch() string {
 				})
 			}
 			ref := fmt.Sprintf("%-7s", fmt.Sprintf("%d:%d", result.Chapter, result.Verse))
-			verseText := stripHTMLTags(result.Text)
+			verseText := searchSnippet(stripHTMLTags(result.Text), m.wordSearchQuery, 2*textWidth)
 			wrapped := wrapTextWithIndent(verseText, textWidth, 2+len(refTemplate))
 			wrappedLines := strings.Split(wrapped, "\n")
 
@@ -4263,4 +4526,3 @@ func (m Model) getBookName(bookID int) string {
 	}
 	return fmt.Sprintf("Book %d", bookID)
 }
-
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/locale"
+)
+
+// friendlyError turns a failure into a short message saying what went
+// wrong and what to try, instead of the raw error and response body.
+// service names the provider. Errors it doesn't recognise are returned as
+// they are.
+func friendlyError(err error, service string) string {
+	var status *api.StatusError
+	var dns *net.DNSError
+	var netErr net.Error
+	var syntax *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &status):
+		switch {
+		case status.Code == http.StatusNotFound:
+			return locale.Tf("not found on %s — try another translation (t)", service)
+		case status.Code == http.StatusTooManyRequests:
+			return locale.Tf("%s is rate limiting — wait a moment and retry", service)
+		case status.Code >= 500:
+			return locale.Tf("%s is having trouble (%d) — try later, or download the translation (d)", service, status.Code)
+		}
+		return locale.Tf("%s refused the request (%d)", service, status.Code)
+	case errors.As(err, &dns):
+		return locale.Tf("can't look up %s — check your connection (C to recheck)", service)
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return locale.Tf("%s timed out — check your connection (C to recheck)", service)
+	case errors.Is(err, syscall.ECONNREFUSED), errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, syscall.ENETUNREACH), errors.Is(err, syscall.EHOSTUNREACH):
+		return locale.Tf("can't reach %s — downloaded translations (d) still work offline", service)
+	case errors.As(err, &syntax), errors.As(err, &typeErr):
+		return locale.Tf("unexpected reply from %s — try again later", service)
+	}
+	return err.Error()
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"time"
+
+	"sword-tui/internal/theme"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// defaultNightLightStrength is used when night_light_strength is unset.
+const defaultNightLightStrength = 0.5
+
+// nightLight is the parsed night_light setting: when to warm the theme,
+// and by how much.
+type nightLight struct {
+	dailyWindow
+	strength float64
+}
+
+// parseNightLight reads the night_light window (see parseDailyWindow) and
+// strength.
+func parseNightLight(spec string, strength float64) (nightLight, error) {
+	w, err := parseDailyWindow("night_light", spec)
+	if err != nil {
+		return nightLight{}, err
+	}
+	if strength <= 0 {
+		strength = defaultNightLightStrength
+	}
+	return nightLight{w, strength}, nil
+}
+
+// nightLightMsg re-evaluates the schedule.
+type nightLightMsg struct{}
+
+func nightLightTick() tea.Cmd {
+	return tea.Tick(time.Minute, func(time.Time) tea.Msg { return nightLightMsg{} })
+}
+
+// applyNightLight re-derives currentTheme from the theme of the same name,
+// in the palette for the terminal's colors and warmed while the night
+// light is on, and the styles built from it. Call it whenever the theme
+// or the color profile changes. 16 colors are too few to warm.
+func (m *Model) applyNightLight() {
+	base := m.currentTheme
+	for _, th := range theme.AllThemes() {
+		if th.Name == base.Name {
+			base = th
+			break
+		}
+	}
+	base = base.ForProfile(m.colorProfile)
+	if m.nightLight.active(time.Now()) && m.tintable() {
+		base = base.Warm(m.nightLight.strength)
+	}
+	m.currentTheme = base
+	m.styles = newStyles(m.currentTheme, m.styled())
+}
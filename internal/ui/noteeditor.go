@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"sword-tui/internal/locale"
+	"sword-tui/internal/notes"
+
+	"charm.land/bubbles/v2/textarea"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// E opens a popup for writing a longer note on the highlighted verse than
+// the one-line input a gives: Enter starts a new line, ctrl+s saves the
+// note with the current time and esc throws it away. It is kept in the
+// chapter's Markdown file like any other note, its lines after the first
+// indented under the entry.
+
+// startNoteEditor opens the note editor on the highlighted verse.
+func (m *Model) startNoteEditor() tea.Cmd {
+	if m.highlightedVerseStart == 0 {
+		return m.flash("select a verse to note")
+	}
+	m.noteEditVerse = m.highlightedVerseStart
+	m.noteEditor.Reset()
+	m.pushOverlay(overlay{
+		name:   overlayNoteEditor,
+		place:  placeCenter,
+		dim:    true,
+		view:   Model.renderNoteEditor,
+		key:    Model.updateNoteEditor,
+		closed: func(m *Model) { m.noteEditor.Blur() },
+	})
+	return m.noteEditor.Focus()
+}
+
+// updateNoteEditor takes every key but esc while the editor is open.
+func (m Model) updateNoteEditor(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	switch msg.String() {
+	case "esc":
+		return m, nil, false
+	case "ctrl+s":
+		m.closeOverlay(overlayNoteEditor)
+		text := strings.TrimSpace(m.noteEditor.Value())
+		if text == "" {
+			return m, nil, true
+		}
+		if err := notes.Append(m.currentBookName, m.currentBook, m.currentChapter, m.noteEditVerse, text, time.Now()); err != nil {
+			m.err = err
+			return m, nil, true
+		}
+		m.chapterNotes, _ = notes.ForChapter(m.currentBook, m.currentChapter)
+		m.backlinks = indexBacklinks()
+		m.refreshContent()
+		return m, m.flash(locale.Tf("✎ noted %s %d:%d", m.currentBookName, m.currentChapter, m.noteEditVerse)), true
+	}
+	var cmd tea.Cmd
+	m.noteEditor, cmd = m.noteEditor.Update(msg)
+	return m, cmd, true
+}
+
+// renderNoteEditor draws the editor popup.
+func (m Model) renderNoteEditor() string {
+	bg := m.currentTheme.Background
+	w, h := m.popupSize()
+	_, padX := m.panelPadding()
+
+	containerStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.currentTheme.BorderActive).
+		BorderBackground(bg).
+		Background(bg).
+		Width(w + 2 + 2*padX).
+		Padding(m.panelPadding())
+	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(m.styled())
+	mutedStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(m.styled())
+
+	ta := m.noteEditor
+	ta.SetStyles(m.themedAreaStyles())
+	ta.SetWidth(w)
+	ta.SetHeight(min(h, 12))
+
+	title := fmt.Sprintf(locale.T("Note on %s %d:%d"), m.currentBookName, m.currentChapter, m.noteEditVerse)
+	body := titleStyle.Render(title) + m.panelTitleGap() +
+		ta.View() + "\n\n" + mutedStyle.Render(locale.T("ctrl+s save  ·  esc discard"))
+	return containerStyle.Render(body)
+}
+
+// themedAreaStyles styles the note editor to match the text inputs.
+func (m Model) themedAreaStyles() textarea.Styles {
+	bg := m.currentTheme.Background
+	base := lipgloss.NewStyle().Background(bg)
+	state := textarea.StyleState{
+		Base:        base,
+		Text:        lipgloss.NewStyle().Foreground(m.currentTheme.Primary).Background(bg),
+		Placeholder: lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(m.styled()),
+		Prompt:      lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg),
+		EndOfBuffer: lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg),
+		CursorLine:  base,
+	}
+	return textarea.Styles{
+		Focused: state,
+		Blurred: state,
+		Cursor: textarea.CursorStyle{
+			Color: m.currentTheme.Accent,
+			Shape: tea.CursorBlock,
+			Blink: true,
+		},
+	}
+}
@@ -0,0 +1,211 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/locale"
+	"sword-tui/internal/reference"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// The note popup (N) reads the notes on the highlighted verse with the
+// references in them picked out as links: tab moves between them and
+// Enter opens one, so notes can point at each other's passages. Any
+// book of the canon is a link, whatever the translation being read has.
+
+// openNotes opens the note popup on the highlighted verse.
+func (m *Model) openNotes() tea.Cmd {
+	if m.highlightedVerseStart == 0 {
+		return m.flash("select a verse to read its notes")
+	}
+	if len(m.chapterNotes[m.highlightedVerseStart]) == 0 {
+		return m.flash(locale.Tf("no notes on %s %d:%d — a adds one", m.currentBookName, m.currentChapter, m.highlightedVerseStart))
+	}
+	m.noteVerse = m.highlightedVerseStart
+	m.noteLink = 0
+	m.pushOverlay(overlay{
+		name:  overlayNotes,
+		place: placeCenter,
+		dim:   true,
+		view:  Model.renderNotes,
+		key:   Model.updateNotes,
+	})
+	return nil
+}
+
+// noteLinks returns the references in the popup's notes, in order.
+func (m Model) noteLinks() []string {
+	var refs []string
+	for _, n := range m.chapterNotes[m.noteVerse] {
+		for _, l := range reference.FindAll(n.Text, api.CanonicalBooks()) {
+			refs = append(refs, l.Ref)
+		}
+	}
+	return refs
+}
+
+// noteToken is a word of a note, or a whole reference kept on one line.
+type noteToken struct {
+	text  string
+	link  int  // index into noteLinks, or -1
+	space bool // preceded by a space, so a line may break before it
+}
+
+// noteTokens splits text into words and references; links numbers the
+// references from first.
+func (m Model) noteTokens(text string, first int) []noteToken {
+	var toks []noteToken
+	spaced := func(at int) bool {
+		return at > 0 && unicode.IsSpace(rune(text[at-1]))
+	}
+	words := func(from, to int) {
+		for i, w := range strings.Fields(text[from:to]) {
+			toks = append(toks, noteToken{w, -1, i > 0 || spaced(from+1)})
+		}
+	}
+	at := 0
+	for i, l := range reference.FindAll(text, api.CanonicalBooks()) {
+		words(at, l.Start)
+		toks = append(toks, noteToken{strings.Join(strings.Fields(text[l.Start:l.End]), " "), first + i, spaced(l.Start)})
+		at = l.End
+	}
+	words(at, len(text))
+	return toks
+}
+
+// noteLines renders the popup's notes width cells wide. linkLine gives
+// the line each reference is on, for scrolling to the selected one.
+func (m Model) noteLines(width int) (lines []string, linkLine []int) {
+	bg := m.currentTheme.Background
+	textStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Primary).Background(bg)
+	dateStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(m.styled())
+	linkStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Underline(m.styled())
+	selectedStyle := lipgloss.NewStyle().Foreground(bg).Background(m.currentTheme.Accent).Bold(m.styled())
+	pad := lipgloss.NewStyle().Background(bg).Width(width)
+
+	for i, n := range m.chapterNotes[m.noteVerse] {
+		if i > 0 {
+			lines = append(lines, pad.Render(""))
+		}
+		lines = append(lines, pad.Render(dateStyle.Render(n.Time.Format("2006-01-02 15:04"))))
+
+		var line strings.Builder
+		lineW := 0
+		flush := func() {
+			lines = append(lines, pad.Render(line.String()))
+			line.Reset()
+			lineW = 0
+		}
+		for _, tok := range m.noteTokens(n.Text, len(linkLine)) {
+			w := lipgloss.Width(tok.text)
+			if lineW > 0 && tok.space && lineW+1+w > width {
+				flush()
+			}
+			if lineW > 0 && tok.space {
+				line.WriteString(textStyle.Render(" "))
+				lineW++
+			}
+			switch {
+			case tok.link < 0:
+				line.WriteString(textStyle.Render(tok.text))
+			case tok.link == m.noteLink:
+				line.WriteString(selectedStyle.Render(tok.text))
+			default:
+				line.WriteString(linkStyle.Render(tok.text))
+			}
+			if tok.link >= 0 {
+				linkLine = append(linkLine, len(lines))
+			}
+			lineW += w
+		}
+		if lineW > 0 {
+			flush()
+		}
+	}
+	return lines, linkLine
+}
+
+// missingBook returns the name of ref's book when the translation being
+// read lacks it, as a personal translation may.
+func (m Model) missingBook(ref string) (string, bool) {
+	book, _, _, _, err := reference.Parse(ref, api.CanonicalBooks())
+	if err != nil || m.books == nil {
+		return "", false
+	}
+	for _, b := range m.books {
+		if b.BookID == book {
+			return "", false
+		}
+	}
+	b, _ := api.CanonicalBook(book)
+	return b.Name, true
+}
+
+func (m Model) updateNotes(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	links := m.noteLinks()
+	switch msg.String() {
+	case "tab", "down", "j":
+		if len(links) > 0 {
+			m.noteLink = (m.noteLink + 1) % len(links)
+		}
+	case "shift+tab", "up", "k":
+		if len(links) > 0 {
+			m.noteLink = (m.noteLink + len(links) - 1) % len(links)
+		}
+	case "enter":
+		if m.noteLink < len(links) {
+			ref := links[m.noteLink]
+			if name, ok := m.missingBook(ref); ok {
+				return m, m.flash(locale.Tf("%s is not in %s", name, m.selectedTranslation)), true
+			}
+			m.closeOverlay(overlayNotes)
+			next, cmd := m.gotoReference(ref)
+			return next, cmd, true
+		}
+		m.closeOverlay(overlayNotes)
+	case "q":
+		m.closeOverlay(overlayNotes)
+	case "esc":
+		return m, nil, false
+	}
+	// Everything else is swallowed while the popup has the focus.
+	return m, nil, true
+}
+
+func (m Model) renderNotes() string {
+	bg := m.currentTheme.Background
+	w, h := m.popupSize()
+	_, padX := m.panelPadding()
+
+	containerStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.currentTheme.BorderActive).
+		BorderBackground(bg).
+		Background(bg).
+		Width(w + 2 + 2*padX).
+		Padding(m.panelPadding())
+	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(m.styled())
+	mutedStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(m.styled())
+
+	lines, linkLine := m.noteLines(w)
+	top := 0
+	if m.noteLink < len(linkLine) {
+		top = linkLine[m.noteLink] - h/2
+	}
+	top = max(min(top, len(lines)-h), 0)
+	shown := lines[top:min(top+h, len(lines))]
+
+	title := fmt.Sprintf("Notes on %s %d:%d", m.currentBookName, m.currentChapter, m.noteVerse)
+	hint := "esc close"
+	if len(linkLine) > 0 {
+		hint = "tab next reference  ·  ⏎ open  ·  " + hint
+	}
+	body := titleStyle.Render(title) + m.panelTitleGap() +
+		strings.Join(shown, "\n") + "\n\n" + mutedStyle.Render(hint)
+	return containerStyle.Render(body)
+}
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"sword-tui/internal/outline"
+
+	"charm.land/lipgloss/v2"
+)
+
+// outlineLines renders the intro block shown above the first verse when
+// the chapter has an outline: a one-line summary while collapsed, or one
+// row per section once expanded with o. Lines are not yet padded to the
+// pane width.
+func (m Model) outlineLines() []string {
+	if m.hideOutlines {
+		return nil
+	}
+	sections, _ := outline.For(m.currentBook, m.currentChapter)
+	if len(sections) == 0 {
+		return nil
+	}
+
+	bg := m.currentTheme.Background
+	headStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Secondary).Background(bg).Bold(m.styled())
+	rangeStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg)
+	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Secondary).Background(bg).Italic(m.styled())
+
+	if !m.outlineExpanded {
+		return []string{
+			headStyle.Render("  ▸ Outline") + rangeStyle.Render(fmt.Sprintf(" · %d sections  (o to expand)", len(sections))),
+		}
+	}
+
+	lines := []string{headStyle.Render("  ▾ Outline")}
+	for _, s := range sections {
+		span := fmt.Sprintf("%d–%d", s.Start, s.End)
+		if s.Start == s.End {
+			span = fmt.Sprint(s.Start)
+		}
+		lines = append(lines, rangeStyle.Render(fmt.Sprintf("    %-7s ", span))+titleStyle.Render(s.Title))
+	}
+	return lines
+}
+
+// acrosticLetters are the stanza headings of the chapter on screen, by
+// the verse they go above, if it is an acrostic and outlines are on.
+func (m Model) acrosticLetters() map[int]outline.Letter {
+	if m.hideOutlines {
+		return nil
+	}
+	return outline.Acrostic(m.currentBook, m.currentChapter)
+}
+
+// acrosticHeading renders the separator above a stanza of an acrostic
+// chapter, "── ב Beth ───…", width cells wide on bg. Like the outline it
+// goes with hide_outlines.
+func (m Model) acrosticHeading(l outline.Letter, width int, bg color.Color) string {
+	style := lipgloss.NewStyle().Foreground(m.currentTheme.Secondary).Background(bg)
+	label := "── " + l.Glyph + " " + l.Name + " "
+	return style.Bold(m.styled()).Render(label) + style.Render(strings.Repeat("─", max(width-lipgloss.Width(label), 0)))
+}
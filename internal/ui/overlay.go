@@ -0,0 +1,203 @@
+package ui
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Popups that float over everything else — the Miller columns now,
+// footnotes, confirmations and info boxes later — live on a stack. The
+// top one has the focus: it sees keys first, esc and clicks outside it
+// dismiss it, and anything it marks dim is faded underneath it. The
+// mode overlays (pickers, search, …) stay modes and are drawn below the
+// stack.
+
+// overlayPlace is where a popup sits on screen.
+type overlayPlace int
+
+const (
+	placeCenter overlayPlace = iota // a modal centered over the reading pane
+	placeLeft                       // a panel over the books pane, below the header
+	placeBottom                     // a sheet just above the status bar
+)
+
+// overlay is one popup on the stack.
+type overlay struct {
+	name  string // identifies it to hasOverlay and closeOverlay
+	place overlayPlace
+	dim   bool // fade what is under it
+	view  func(m Model) string
+	// key, if set, sees keys first while the popup is on top. It reports
+	// whether it used the key; keys it doesn't use go on to the rest of
+	// Update, except esc, which dismisses the popup.
+	key func(m Model, msg tea.KeyMsg) (Model, tea.Cmd, bool)
+	// closed, if set, runs after the popup is dismissed.
+	closed func(m *Model)
+}
+
+// Names of the popups.
+const (
+	overlayMiller      = "miller"
+	overlayWhatsNew    = "whatsnew"
+	overlayNotes       = "notes"
+	overlayNoteEditor  = "noteeditor"
+	overlayBacklinks   = "backlinks"
+	overlayPlans       = "plans"
+	overlayDashboard   = "dashboard"
+	overlayComparisons = "comparisons"
+	overlayLanguages   = "languages"
+	// overlayUpdate is followed by the translation under review.
+	overlayUpdate = "update:"
+)
+
+// pushOverlay opens o on top of the stack, replacing any popup of the
+// same name.
+func (m *Model) pushOverlay(o overlay) {
+	m.overlays = append(m.removeOverlay(o.name), o)
+}
+
+// closeOverlay dismisses the popup called name, if open.
+func (m *Model) closeOverlay(name string) {
+	for _, o := range m.overlays {
+		if o.name == name {
+			m.overlays = m.removeOverlay(name)
+			if o.closed != nil {
+				o.closed(m)
+			}
+			return
+		}
+	}
+}
+
+// removeOverlay returns the stack without the popup called name. It
+// copies, so Model values sharing the old stack keep it.
+func (m Model) removeOverlay(name string) []overlay {
+	out := make([]overlay, 0, len(m.overlays)+1)
+	for _, o := range m.overlays {
+		if o.name != name {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+// hasOverlay reports whether the popup called name is open.
+func (m Model) hasOverlay(name string) bool {
+	for _, o := range m.overlays {
+		if o.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// topOverlay returns the popup with the focus.
+func (m Model) topOverlay() (overlay, bool) {
+	if len(m.overlays) == 0 {
+		return overlay{}, false
+	}
+	return m.overlays[len(m.overlays)-1], true
+}
+
+// updateOverlay gives a key to the popup on top. ok is false when the
+// key should go on to the rest of Update.
+func (m Model) updateOverlay(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	top, _ := m.topOverlay()
+	if top.key != nil {
+		if next, cmd, ok := top.key(m, msg); ok {
+			return next, cmd, true
+		}
+	}
+	if msg.String() == "esc" {
+		m.closeOverlay(top.name)
+		return m, nil, true
+	}
+	return m, nil, false
+}
+
+// overlayBounds returns the (x, y, width, height) of the popup panel
+// placed as place.
+func (m Model) overlayBounds(place overlayPlace, panel string) (int, int, int, int) {
+	w, h := lipgloss.Width(panel), lipgloss.Height(panel)
+	var x, y int
+	switch place {
+	case placeLeft:
+		x, y = 0, headerOuterHeight
+	case placeBottom:
+		x, y = (m.width-w)/2, m.height-statusOuterHeight-h
+	default:
+		// Center over the right pane.
+		rightX := leftPaneOuterWidth
+		x = rightX + (m.width-rightX-w)/2
+		y = (m.height-h)/2 + 1
+		x, y = max(x, 1), max(y, 1)
+	}
+	return x, y, w, h
+}
+
+// clickOverlay handles a click while a popup is open: one outside the
+// top popup dismisses it, one inside goes no further.
+func (m *Model) clickOverlay(x, y int) {
+	top, _ := m.topOverlay()
+	px, py, pw, ph := m.overlayBounds(top.place, top.view(*m))
+	if x < px || x >= px+pw || y < py || y >= py+ph {
+		m.closeOverlay(top.name)
+	}
+}
+
+// composeStack draws the popups over view, bottom to top, fading what
+// is under a popup that asks for it.
+func (m Model) composeStack(view string) string {
+	for _, o := range m.overlays {
+		panel := o.view(m)
+		if panel == "" {
+			continue
+		}
+		if o.dim {
+			view = m.dim(view)
+		}
+		x, y, _, _ := m.overlayBounds(o.place, panel)
+		canvas := lipgloss.NewCanvas(m.width, m.height)
+		canvas.Compose(lipgloss.NewCompositor(
+			lipgloss.NewLayer(view).X(0).Y(0).Z(0),
+			lipgloss.NewLayer(panel).X(x).Y(y).Z(1),
+		))
+		view = canvas.Render()
+	}
+	return view
+}
+
+// dim repaints view in the muted color, keeping its text.
+func (m Model) dim(view string) string {
+	style := lipgloss.NewStyle().
+		Foreground(m.currentTheme.Muted).
+		Background(m.currentTheme.Background)
+	lines := strings.Split(ansi.Strip(view), "\n")
+	for i, line := range lines {
+		lines[i] = style.Render(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// millerOverlay is the Miller-columns navigator (v): books, chapters and
+// verses side by side over the books pane. Its keys are handled in
+// Update's main switch; esc leaves the filter before closing it.
+func (m Model) millerOverlay() overlay {
+	return overlay{
+		name:  overlayMiller,
+		place: placeLeft,
+		dim:   true,
+		view:  Model.renderMillerColumns,
+		key: func(m Model, msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+			if msg.String() == "esc" && m.millerFilterMode {
+				m.millerFilterMode = false
+				m.millerFilterInput.Blur()
+				return m, nil, true
+			}
+			return m, nil, false
+		},
+	}
+}
@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"sword-tui/internal/versenum"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// defaultPager is used when $PAGER is unset.
+const defaultPager = "less -R"
+
+// pagerDoneMsg reports that the external pager exited.
+type pagerDoneMsg struct{ err error }
+
+// openInPager writes the chapter (or the comparison) on screen as plain
+// text to a temporary file and hands it to $PAGER. The TUI is suspended
+// until the pager exits.
+func (m Model) openInPager() tea.Cmd {
+	text := m.pagerText()
+	if text == "" {
+		return nil
+	}
+
+	f, err := os.CreateTemp("", "sword-tui-*.txt")
+	if err != nil {
+		return func() tea.Msg { return pagerDoneMsg{err} }
+	}
+	path := f.Name()
+	_, err = f.WriteString(text)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		return func() tea.Msg { return pagerDoneMsg{err} }
+	}
+
+	pager := strings.Fields(os.Getenv("PAGER"))
+	if len(pager) == 0 {
+		pager = strings.Fields(defaultPager)
+	}
+	c := exec.Command(pager[0], append(pager[1:], path)...)
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		os.Remove(path)
+		return pagerDoneMsg{err}
+	})
+}
+
+// pagerText renders the current view for a pager: no colors or padding,
+// wrapped to the terminal width (at most 100 columns) so it stays easy to
+// search.
+func (m Model) pagerText() string {
+	width := min(m.width, 100) - 2
+	if width < 40 {
+		width = 40
+	}
+
+	var sb strings.Builder
+	switch {
+	case m.mode == modeComparison && m.currentParallelVerses != nil:
+		fmt.Fprintf(&sb, "%s %d — %s\n\n", m.currentBookName, m.currentChapter, strings.Join(m.comparisonTranslations, " · "))
+
+		// Index every column by verse number; translations may differ
+		// in which verses they include.
+		byVerse := map[int]map[string]string{}
+		for trans, verses := range m.currentParallelVerses {
+			for _, v := range verses {
+				if byVerse[v.Verse] == nil {
+					byVerse[v.Verse] = map[string]string{}
+				}
+				byVerse[v.Verse][trans] = stripHTMLTags(v.Text)
+			}
+		}
+		numbers := make([]int, 0, len(byVerse))
+		for n := range byVerse {
+			numbers = append(numbers, n)
+		}
+		sort.Ints(numbers)
+
+		label := 0
+		for _, t := range m.comparisonTranslations {
+			label = max(label, len(t))
+		}
+		indent := label + 4
+		for _, n := range numbers {
+			fmt.Fprintf(&sb, "%d\n", n)
+			for _, t := range m.comparisonTranslations {
+				text, ok := byVerse[n][t]
+				if !ok {
+					continue
+				}
+				fmt.Fprintf(&sb, "  %-*s  %s\n", label, t, wrapTextWithIndent(text, width-indent, indent))
+			}
+			sb.WriteString("\n")
+		}
+	case m.currentVerses != nil:
+		fmt.Fprintf(&sb, "%s %d (%s)\n\n", m.currentBookName, m.currentChapter, m.translationName(m.selectedTranslation))
+		for _, v := range m.currentVerses {
+			text := stripHTMLTags(v.Text)
+			if m.verseNumbers == versenum.Hidden {
+				fmt.Fprintf(&sb, "%s\n", wrapText(text, width))
+			} else {
+				numWidth := m.verseNumberWidth()
+				fmt.Fprintf(&sb, "%*s  %s\n", numWidth, m.verseNumbers.Label(v.Verse), wrapTextWithIndent(text, width-numWidth-2, numWidth+2))
+			}
+			for range m.verseGap() {
+				sb.WriteString("\n")
+			}
+		}
+	}
+	return sb.String()
+}
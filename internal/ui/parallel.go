@@ -0,0 +1,254 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/theme"
+
+	"charm.land/lipgloss/v2"
+)
+
+// parallelMargin is how many lines above and below the visible part of
+// the comparison stay styled, so scrolling a little reuses them.
+const parallelMargin = 40
+
+// parallelCell is one column of a comparison line before styling.
+type parallelCell struct {
+	num  int // verse number, on the first line of a verse only
+	text string
+}
+
+// parallelLayout is the comparison view wrapped into lines but not yet
+// styled. A long chapter across three or more translations makes the
+// styled text enormous, so only the lines around the viewport are
+// rendered, when drawn. It is shared between Model copies, like
+// generations.
+type parallelLayout struct {
+	translations []string
+	colWidth     int
+	// rows are the content lines below the header and separator; nil
+	// rows are the blank lines between verses.
+	rows [][]parallelCell
+	// folds labels the nil rows that stand for verses the filter hides;
+	// shown counts the verses it doesn't, of total.
+	folds        map[int]string
+	shown, total int
+
+	// styled holds the rendered lines around the last window drawn, in
+	// the colors of theme.
+	styled map[int]string
+	theme  theme.Theme
+}
+
+// layoutParallel wraps the verses of each translation into side-by-side
+// columns across width: a header row naming each translation, a rule,
+// then each verse number's text with a blank line after it. Runs of
+// verses filter hides are folded into one line.
+func layoutParallel(versesMap map[string][]api.Verse, translations []string, width int, filter comparisonFilter) *parallelLayout {
+	l := &parallelLayout{translations: translations, folds: map[int]string{}}
+	if len(translations) == 0 {
+		return l
+	}
+
+	// Column geometry: split the available width across N translations,
+	// leaving a 1-cell gutter between each pair.
+	n := len(translations)
+	gaps := n - 1
+	l.colWidth = (width - gaps) / n
+	if l.colWidth < 20 {
+		l.colWidth = 20
+	}
+	// Inner text width allows for a "NN " verse number prefix (4 cells).
+	textWidth := l.colWidth - 4
+	if textWidth < 12 {
+		textWidth = 12
+	}
+
+	// The verses any translation has, which need not start at 1 when
+	// only some were fetched.
+	first, last := 0, 0
+	for _, vs := range versesMap {
+		for _, v := range vs {
+			if first == 0 || v.Verse < first {
+				first = v.Verse
+			}
+			last = max(last, v.Verse)
+		}
+	}
+
+	hidden := 0 // the first verse of the run being folded
+	fold := func(to int) {
+		if hidden > 0 {
+			l.folds[len(l.rows)] = filter.foldLabel(hidden, to)
+			l.rows = append(l.rows, nil, nil)
+			hidden = 0
+		}
+	}
+	for i := max(first, 1); i <= last; i++ {
+		texts := make([]string, n)
+		for j, trans := range translations {
+			for _, v := range versesMap[trans] {
+				if v.Verse == i {
+					texts[j] = stripHTMLTags(v.Text)
+					break
+				}
+			}
+		}
+		if filter.hides(texts) {
+			if hidden == 0 {
+				hidden = i
+			}
+			continue
+		}
+		fold(i - 1)
+		l.shown++
+
+		cols := make([][]string, n)
+		height := 1
+		for j, text := range texts {
+			// Continuation lines indent under the text so the verse
+			// number stays as a visual anchor.
+			if text != "" {
+				cols[j] = strings.Split(wrapTextWithIndent(text, textWidth, 4), "\n")
+			}
+			height = max(height, len(cols[j]))
+		}
+		for k := range height {
+			row := make([]parallelCell, n)
+			for j, lines := range cols {
+				if k < len(lines) {
+					row[j].text = lines[k]
+					if k == 0 {
+						row[j].num = i
+					}
+				}
+			}
+			l.rows = append(l.rows, row)
+		}
+		l.rows = append(l.rows, nil)
+	}
+	fold(last)
+	l.total = max(last-first+1, 0)
+	return l
+}
+
+// lines is the number of content lines in the comparison.
+func (l *parallelLayout) lines() int {
+	return len(l.rows) + 2
+}
+
+// placeholder stands in for the comparison in the viewport, which then
+// scrolls over the right number of lines; parallelView draws them.
+func (l *parallelLayout) placeholder() string {
+	return strings.Repeat("\n", l.lines()-1)
+}
+
+// window returns the styled lines from top on, height of them, styling
+// the ones not drawn recently plus a margin around them and forgetting
+// the rest.
+func (l *parallelLayout) window(m Model, top, height int) []string {
+	if l.styled == nil || l.theme != m.currentTheme {
+		l.styled = map[int]string{}
+		l.theme = m.currentTheme
+	}
+	from, to := max(top-parallelMargin, 0), min(top+height+parallelMargin, l.lines())
+	for i := range l.styled {
+		if i < from || i >= to {
+			delete(l.styled, i)
+		}
+	}
+
+	bg := m.currentTheme.Background
+	headerStyle := lipgloss.NewStyle().
+		Foreground(m.currentTheme.Accent).
+		Background(bg).
+		Bold(m.styled())
+	verseNumStyle := lipgloss.NewStyle().
+		Foreground(m.currentTheme.Warning).
+		Background(bg).
+		Bold(m.styled())
+	textStyle := lipgloss.NewStyle().
+		Foreground(m.currentTheme.Primary).
+		Background(bg)
+	separatorStyle := lipgloss.NewStyle().
+		Foreground(m.currentTheme.Border).
+		Background(bg)
+	foldStyle := lipgloss.NewStyle().
+		Foreground(m.currentTheme.Muted).
+		Background(bg).
+		Italic(m.styled())
+	bgPad := lipgloss.NewStyle().Background(bg)
+
+	// padCol pads a logical column line to colWidth using bg-styled
+	// spaces so the rows merge into a clean grid regardless of theme.
+	padCol := func(s string) string {
+		w := lipgloss.Width(s)
+		if w >= l.colWidth {
+			return s
+		}
+		return s + bgPad.Render(strings.Repeat(" ", l.colWidth-w))
+	}
+	gutter := bgPad.Render(" ")
+
+	render := func(i int) string {
+		cells := make([]string, len(l.translations))
+		switch {
+		case i == 0:
+			// "▾" hints that the header opens a translation picker on
+			// click.
+			for j, trans := range l.translations {
+				label := m.translationName(trans) + " ▾"
+				if lipgloss.Width(label) > l.colWidth {
+					label = label[:l.colWidth]
+				}
+				cells[j] = padCol(headerStyle.Render(label))
+			}
+		case i == 1:
+			for j := range cells {
+				cells[j] = padCol(separatorStyle.Render(strings.Repeat("─", l.colWidth)))
+			}
+		default:
+			if label, ok := l.folds[i-2]; ok {
+				width := len(cells)*(l.colWidth+1) - 1
+				return foldStyle.Render(label) + bgPad.Render(strings.Repeat(" ", max(width-lipgloss.Width(label), 0)))
+			}
+			row := l.rows[i-2]
+			for j := range cells {
+				switch {
+				case row == nil || row[j].text == "":
+					// Blank, styled in bg so the grid stays painted.
+					cells[j] = padCol("")
+				case row[j].num > 0:
+					cells[j] = padCol(verseNumStyle.Render(fmt.Sprintf("%-3d", row[j].num)) + bgPad.Render(" ") + textStyle.Render(row[j].text))
+				default:
+					cells[j] = padCol(textStyle.Render(row[j].text))
+				}
+			}
+		}
+		return strings.Join(cells, gutter)
+	}
+
+	for i := from; i < to; i++ {
+		if _, ok := l.styled[i]; !ok {
+			l.styled[i] = render(i)
+		}
+	}
+	out := make([]string, 0, height)
+	for i := top; i < min(top+height, l.lines()); i++ {
+		out = append(out, l.styled[i])
+	}
+	return out
+}
+
+// parallelView draws the visible part of the comparison in place of the
+// viewport's own (placeholder) content.
+func (m Model) parallelView() string {
+	w, h := m.viewport.Width(), m.viewport.Height()
+	if w == 0 || h == 0 {
+		return ""
+	}
+	lines := m.parallel.window(m, m.viewport.YOffset(), h)
+	return lipgloss.NewStyle().Width(w).Height(h).Render(strings.Join(lines, "\n"))
+}
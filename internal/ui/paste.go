@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"strings"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/reference"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// cleanPaste tidies a bracketed paste for the single-line inputs: line
+// breaks and runs of spaces from chat messages or PDFs become one space.
+// Into the reference box, a pasted sentence is cut down to the reference
+// it contains so it fits and parses.
+func (m Model) cleanPaste(msg tea.PasteMsg) tea.PasteMsg {
+	if m.hasOverlay(overlayNoteEditor) {
+		// The note editor keeps line breaks.
+		msg.Content = strings.ReplaceAll(msg.Content, "\r\n", "\n")
+		return msg
+	}
+	msg.Content = strings.Join(strings.Fields(msg.Content), " ")
+	if m.mode == modeSearch {
+		books := m.books
+		if books == nil {
+			books = api.CanonicalBooks()
+		}
+		if ref, ok := reference.Find(msg.Content, books); ok {
+			msg.Content = ref
+		}
+	}
+	return msg
+}
+
+// pasteIntoOverlay hands a paste to the input of the overlay or prompt
+// that owns the keyboard. It reports false when none does; the search and
+// filter inputs are then updated with the rest of the messages.
+func (m Model) pasteIntoOverlay(msg tea.PasteMsg) (Model, tea.Cmd, bool) {
+	var cmd tea.Cmd
+	switch {
+	case m.mode == modeWorkspace:
+		if m.workspaceEditing {
+			m.workspaceNoteInput, cmd = m.workspaceNoteInput.Update(msg)
+		}
+	case m.mode == modeAnnotations:
+		m.annotationQuery, cmd = m.annotationQuery.Update(msg)
+		m.annotationSelected = 0
+	case m.capturing:
+		m.captureInput, cmd = m.captureInput.Update(msg)
+	case m.comparisonFiltering:
+		m.comparisonFilterInput, cmd = m.comparisonFilterInput.Update(msg)
+		m.relayoutComparison()
+	case m.exporting:
+		m.exportInput, cmd = m.exportInput.Update(msg)
+	case m.hasOverlay(overlayNoteEditor):
+		m.noteEditor, cmd = m.noteEditor.Update(msg)
+	case m.overlayActive() && m.mode != modeSearch && m.mode != modeWordSearch:
+		// Pickers and lists have nothing to paste into.
+	default:
+		return m, nil, false
+	}
+	return m, cmd, true
+}
@@ -0,0 +1,258 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"sword-tui/internal/textnorm"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// pickerTone colors a picker row that isn't selected.
+type pickerTone int
+
+const (
+	toneNormal pickerTone = iota
+	toneGood              // the current choice, or downloaded
+	toneBusy              // being downloaded
+	toneHidden            // hidden, shown because the picker shows all
+	toneMarked            // marked to be downloaded
+	toneFailed            // its download failed
+)
+
+// pickerItem is one row of a picker.
+type pickerItem struct {
+	label string
+	badge string // shown after the label, e.g. "●" or "✓"
+	tone  pickerTone
+	// keywords are matched by the filter as well as the label, but not
+	// shown.
+	keywords string
+	// hidden items are left out unless the picker shows all.
+	hidden bool
+	// group heads a run of items, e.g. their language, when the items
+	// listed aren't all in the same one.
+	group string
+}
+
+// picker is a filterable, scrollable list. It holds only the selection
+// and the filter: each mode rebuilds its items (translationItems, …)
+// because their badges follow the model.
+type picker struct {
+	selected  int // index into the items
+	window    int // most rows shown at once; 0 shows them all
+	filter    string
+	filtering bool // "/" was pressed and typing edits the filter
+	showAll   bool // hidden items are listed too
+}
+
+// open clears the filter and selects item i.
+func (p *picker) open(i int) {
+	p.selected = max(i, 0)
+	p.filter = ""
+	p.filtering = false
+	p.showAll = false
+}
+
+// visible returns the indexes of the items matching the filter.
+func (p picker) visible(items []pickerItem) []int {
+	f := textnorm.Fold(p.filter)
+	var out []int
+	for i, it := range items {
+		if it.hidden && !p.showAll {
+			continue
+		}
+		if f == "" || strings.Contains(textnorm.Fold(it.label), f) || strings.Contains(textnorm.Fold(it.keywords), f) {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// current returns the selected item, unless the filter hides it.
+func (p picker) current(items []pickerItem) (int, bool) {
+	if slices.Contains(p.visible(items), p.selected) {
+		return p.selected, true
+	}
+	return 0, false
+}
+
+// move selects the visible item delta rows away, stopping at the ends.
+func (p *picker) move(items []pickerItem, delta int) {
+	vis := p.visible(items)
+	if len(vis) == 0 {
+		return
+	}
+	pos := slices.Index(vis, p.selected)
+	if pos < 0 {
+		p.selected = vis[0]
+		return
+	}
+	p.selected = vis[min(max(pos+delta, 0), len(vis)-1)]
+}
+
+// settle selects the nearest visible item after the selected one, or
+// before it, when the selection is no longer visible.
+func (p *picker) settle(items []pickerItem) {
+	vis := p.visible(items)
+	if len(vis) == 0 || slices.Contains(vis, p.selected) {
+		return
+	}
+	for _, i := range vis {
+		if i > p.selected {
+			p.selected = i
+			return
+		}
+	}
+	p.selected = vis[len(vis)-1]
+}
+
+// span returns which of the visible items are on screen: a window
+// centered on the selection.
+func (p picker) span(vis []int) (start, end int) {
+	start, end = 0, len(vis)
+	if p.window > 0 && len(vis) > p.window {
+		pos := max(slices.Index(vis, p.selected), 0)
+		start = min(max(pos-p.window/2, 0), len(vis)-p.window)
+		end = start + p.window
+	}
+	return start, end
+}
+
+// grouped reports whether the visible items are in more than one group,
+// so that each group is headed.
+func grouped(items []pickerItem, vis []int) bool {
+	for _, i := range vis {
+		if items[i].group != items[vis[0]].group {
+			return true
+		}
+	}
+	return false
+}
+
+// headed reports whether the visible item at pos starts a group that
+// gets a heading: the first of a window shows whose items it opens with.
+func headed(items []pickerItem, vis []int, start, pos int) bool {
+	return grouped(items, vis) && (pos == start || items[vis[pos]].group != items[vis[pos-1]].group)
+}
+
+// at returns the item drawn on list row (0 is the first row under the
+// title), for mouse clicks.
+func (p picker) at(items []pickerItem, row int) (int, bool) {
+	vis := p.visible(items)
+	start, end := p.span(vis)
+	if p.filtering || p.filter != "" {
+		row-- // the filter line
+	}
+	if start > 0 {
+		row-- // the "↑ N more" line
+	}
+	for pos := start; pos < end && row >= 0; pos++ {
+		if headed(items, vis, start, pos) {
+			row-- // the group heading
+		}
+		if row == 0 {
+			return vis[pos], true
+		}
+		row--
+	}
+	return 0, false
+}
+
+// key handles the keys that edit the filter and reports whether it used
+// msg. "/" starts filtering; then typing narrows the list, the arrows
+// and enter still work, and esc drops the filter.
+func (p *picker) key(items []pickerItem, msg tea.KeyMsg) bool {
+	if !p.filtering {
+		if msg.String() == "/" {
+			p.filtering = true
+			return true
+		}
+		return false
+	}
+	switch msg.String() {
+	case "up", "down", "enter", "ctrl+c":
+		return false
+	case "esc":
+		p.filtering = false
+		p.filter = ""
+	case "backspace":
+		r := []rune(p.filter)
+		if len(r) > 0 {
+			p.filter = string(r[:len(r)-1])
+		}
+	default:
+		p.filter += msg.Key().Text
+	}
+	if _, ok := p.current(items); !ok {
+		if vis := p.visible(items); len(vis) > 0 {
+			p.selected = vis[0]
+		}
+	}
+	return true
+}
+
+// View renders the rows, width cells wide (0 for as wide as each needs):
+// the filter if any, then the window of items with "more" markers.
+func (p picker) View(s styles, items []pickerItem, width int) string {
+	row := func(style lipgloss.Style, text string) string {
+		style = style.Padding(0, 1)
+		if width > 0 {
+			style = style.Width(width).MaxWidth(width)
+		}
+		return style.Render(text)
+	}
+
+	var lines []string
+	if p.filtering || p.filter != "" {
+		cursor := ""
+		if p.filtering {
+			cursor = "▏"
+		}
+		lines = append(lines, row(s.accent, "/ "+p.filter+cursor))
+	}
+	vis := p.visible(items)
+	if len(vis) == 0 {
+		lines = append(lines, row(s.dim, "no matches"))
+		return strings.Join(lines, "\n")
+	}
+	start, end := p.span(vis)
+	if start > 0 {
+		lines = append(lines, row(s.dim, fmt.Sprintf("↑ %d more", start)))
+	}
+	for pos := start; pos < end; pos++ {
+		i := vis[pos]
+		it := items[i]
+		if headed(items, vis, start, pos) {
+			lines = append(lines, row(s.accent, it.group))
+		}
+		text := "  " + it.label
+		if it.badge != "" {
+			text += "  " + it.badge
+		}
+		style := s.text
+		switch {
+		case i == p.selected:
+			text = "▸" + text[1:]
+			style = s.selected
+		case it.tone == toneGood:
+			style = s.success
+		case it.tone == toneBusy:
+			style = s.warning
+		case it.tone == toneHidden:
+			style = s.dim
+		case it.tone == toneMarked:
+			style = s.accent
+		case it.tone == toneFailed:
+			style = s.strongError
+		}
+		lines = append(lines, row(style, text))
+	}
+	if end < len(vis) {
+		lines = append(lines, row(s.dim, fmt.Sprintf("↓ %d more", len(vis)-end)))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func pickerFixture() []pickerItem {
+	return []pickerItem{
+		{label: "KJV", group: "English", tone: toneGood, badge: "✓"},
+		{label: "WEB", group: "English"},
+		{label: "RVR", group: "Español"},
+		{label: "NVI", group: "Español", keywords: "nueva"},
+		{label: "LBLA", group: "Español", hidden: true},
+	}
+}
+
+func TestPickerView(t *testing.T) {
+	tests := []struct {
+		name   string
+		picker picker
+		want   []string
+	}{
+		{"everything", picker{selected: 1}, []string{
+			"English", "  KJV  ✓", "▸ WEB", "Español", "  RVR", "  NVI",
+		}},
+		{"window", picker{selected: 2, window: 2}, []string{
+			"↑ 1 more", "English", "  WEB", "Español", "▸ RVR", "↓ 1 more",
+		}},
+		{"show all", picker{selected: 4, showAll: true, window: 1}, []string{
+			"↑ 4 more", "Español", "▸ LBLA",
+		}},
+		{"filter by keyword", picker{selected: 3, filter: "nueva", filtering: true}, []string{
+			"/ nueva▏", "▸ NVI",
+		}},
+		{"no matches", picker{filter: "zz"}, []string{
+			"/ zz", "no matches",
+		}},
+	}
+	for _, th := range barThemes {
+		for _, tt := range tests {
+			t.Run(th.Name+"/"+tt.name, func(t *testing.T) {
+				out := tt.picker.View(newStyles(th, true), pickerFixture(), 24)
+				lines := strings.Split(out, "\n")
+				if len(lines) != len(tt.want) {
+					t.Fatalf("picker has %d rows, want %d:\n%s", len(lines), len(tt.want), ansi.Strip(out))
+				}
+				for i, l := range lines {
+					if w := lipgloss.Width(l); w != 24 {
+						t.Errorf("row %d is %d cells wide, want 24", i, w)
+					}
+					if got := strings.TrimSpace(ansi.Strip(l)); got != strings.TrimSpace(tt.want[i]) {
+						t.Errorf("row %d is %q, want %q", i, got, strings.TrimSpace(tt.want[i]))
+					}
+				}
+			})
+		}
+	}
+}
+
+// TestPickerAt checks that a click lands on the item drawn on that row.
+func TestPickerAt(t *testing.T) {
+	items := pickerFixture()
+	for _, p := range []picker{{}, {selected: 2, window: 2}, {filter: "v", filtering: true}} {
+		lines := strings.Split(ansi.Strip(p.View(newStyles(barThemes[0], true), items, 24)), "\n")
+		hits := 0
+		for row, l := range lines {
+			i, ok := p.at(items, row)
+			if !ok {
+				continue
+			}
+			hits++
+			if label := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(l), "▸")); !strings.HasPrefix(label, items[i].label) {
+				t.Errorf("%+v: row %d %q is item %q", p, row, l, items[i].label)
+			}
+		}
+		if hits == 0 {
+			t.Errorf("%+v: no row is an item", p)
+		}
+	}
+}
+
+func TestPickerMove(t *testing.T) {
+	items := pickerFixture()
+	p := picker{selected: 0}
+	p.move(items, 10)
+	if p.selected != 3 {
+		t.Errorf("moving past the end selected %d, want the last visible item 3", p.selected)
+	}
+	p.move(items, -1)
+	if p.selected != 2 {
+		t.Errorf("moving up selected %d, want 2", p.selected)
+	}
+	p.showAll = true
+	p.move(items, 10)
+	if p.selected != 4 {
+		t.Errorf("moving past the end with all shown selected %d, want 4", p.selected)
+	}
+}
@@ -0,0 +1,338 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"sword-tui/internal/locale"
+	"sword-tui/internal/theme"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// Rows shown at once by the translation and download pickers.
+const (
+	translationWindow = 16
+	cacheWindow       = 14
+)
+
+// translationItems lists the translations for the translation picker,
+// marking the one being read and the personal ones.
+func (m Model) translationItems() []pickerItem {
+	items := make([]pickerItem, len(m.translations))
+	for i, t := range m.translations {
+		items[i].label = m.translationLabel(t.ShortName, t.FullName)
+		items[i].keywords = strings.Join(m.translationAliases(t.ShortName), " ") + " " + t.Language
+		items[i].group = languageLabel(t.Language)
+		switch {
+		case t.ShortName == m.selectedTranslation:
+			items[i].badge, items[i].tone = "●", toneGood
+		case m.translationHidden(t.ShortName):
+			items[i].hidden = true
+			items[i].badge, items[i].tone = "hidden", toneHidden
+		case !m.languageShown(t.Language):
+			items[i].hidden = true
+			items[i].tone = toneHidden
+		}
+		if t.Local {
+			items[i].badge = strings.TrimSpace(items[i].badge + "  ⌂ local")
+		}
+	}
+	return items
+}
+
+// cacheItems lists the translations for the download manager with what
+// is on disk.
+func (m Model) cacheItems() []pickerItem {
+	items := make([]pickerItem, len(m.translations))
+	for i, t := range m.translations {
+		items[i].label = m.translationLabel(t.ShortName, t.FullName)
+		items[i].keywords = strings.Join(m.translationAliases(t.ShortName), " ") + " " + t.Language
+		items[i].group = languageLabel(t.Language)
+		switch {
+		case m.downloadingTranslation == t.ShortName:
+			items[i].badge, items[i].tone = m.downloadBadge(), toneBusy
+		case t.Local:
+			items[i].badge, items[i].tone = "⌂ local", toneGood
+		case m.cache != nil && m.cache.IsCached(t.ShortName) && !m.queued(t.ShortName):
+			items[i].badge, items[i].tone = "✓", toneGood
+		default:
+			items[i].badge, items[i].tone = m.queueBadge(t.ShortName)
+		}
+		if m.downloadingTranslation != t.ShortName && m.translationHidden(t.ShortName) {
+			items[i].hidden = true
+			items[i].badge = strings.TrimSpace(items[i].badge + "  hidden")
+			items[i].tone = toneHidden
+		} else if m.downloadingTranslation != t.ShortName && !m.languageShown(t.Language) {
+			items[i].hidden = true
+			items[i].tone = toneHidden
+		}
+	}
+	return items
+}
+
+// downloadBadge marks the translation being downloaded with a bar of
+// its progress, or the bytes received when the server doesn't say how
+// many there are.
+func (m Model) downloadBadge() string {
+	const width = 10
+	switch {
+	case m.downloadRead == 0:
+		return "⟳ downloading"
+	case m.downloadRead == m.downloadTotal:
+		return "⟳ unpacking"
+	case m.downloadTotal < 0:
+		return "⟳ " + megabytes(m.downloadRead)
+	}
+	done := min(int(m.downloadProgress*width), width)
+	return fmt.Sprintf("⟳ %s%s %d%%", strings.Repeat("━", done), strings.Repeat("─", width-done), int(m.downloadProgress*100))
+}
+
+// downloadSize is how much of the download has arrived, "1.2 of 3.4
+// MB", or "" before anything has.
+func (m Model) downloadSize() string {
+	switch {
+	case m.downloadRead == 0:
+		return ""
+	case m.downloadTotal < 0:
+		return megabytes(m.downloadRead)
+	}
+	return fmt.Sprintf("%.1f of %s", float64(m.downloadRead)/(1024*1024), megabytes(m.downloadTotal))
+}
+
+func megabytes(n int64) string {
+	return fmt.Sprintf("%.1f MB", float64(n)/(1024*1024))
+}
+
+// themeItems lists the themes, marking the one in use before the
+// preview.
+func (m Model) themeItems() []pickerItem {
+	themes := theme.AllThemes()
+	items := make([]pickerItem, len(themes))
+	for i, th := range themes {
+		items[i].label = th.Name
+		if th.Name == m.committedTheme().Name {
+			items[i].badge, items[i].tone = "●", toneGood
+		}
+	}
+	return items
+}
+
+// activePicker returns the picker of the current mode and its items, or
+// nil outside the pickers.
+func (m *Model) activePicker() (*picker, []pickerItem) {
+	switch m.mode {
+	case modeTranslationSelect:
+		return &m.translationPicker, m.translationItems()
+	case modeThemeSelect:
+		return &m.themePicker, m.themeItems()
+	case modeCacheManager:
+		return &m.cachePicker, m.cacheItems()
+	}
+	return nil, nil
+}
+
+// translationIndex returns the position of short in m.translations, or 0.
+func (m Model) translationIndex(short string) int {
+	for i, t := range m.translations {
+		if t.ShortName == short {
+			return i
+		}
+	}
+	return 0
+}
+
+// updatePicker handles the keys of the translation, theme and download
+// pickers. Keys it doesn't use (esc, r, q, …) are left to the main
+// switch.
+func (m Model) updatePicker(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	p, items := m.activePicker()
+	if p.key(items, msg) {
+		m.previewTheme()
+		return m, nil, true
+	}
+	switch msg.String() {
+	case "up", "k":
+		p.move(items, -1)
+		m.previewTheme()
+	case "down", "j":
+		p.move(items, 1)
+		m.previewTheme()
+	case "enter":
+		if i, ok := p.current(items); ok {
+			cmd := m.pick(i)
+			return m, cmd, true
+		}
+	case "h":
+		if m.mode == modeThemeSelect {
+			return m, nil, false
+		}
+		if i, ok := p.current(items); ok {
+			cmd := m.toggleHidden(m.translations[i].ShortName)
+			p, items = m.activePicker()
+			p.settle(items)
+			return m, cmd, true
+		}
+	case "a":
+		if m.mode == modeThemeSelect {
+			return m, nil, false
+		}
+		p.showAll = !p.showAll
+		p.settle(items)
+	case "l":
+		if m.mode == modeThemeSelect {
+			return m, nil, false
+		}
+		return m, m.openLanguages(), true
+	case "x":
+		if m.mode != modeCacheManager {
+			return m, nil, false
+		}
+		if i, ok := p.current(items); ok {
+			if m.cancelDownload(i) {
+				return m, nil, true
+			}
+			cmd := m.removeDownload(i)
+			return m, cmd, true
+		}
+	case "u":
+		if m.mode != modeCacheManager {
+			return m, nil, false
+		}
+		if i, ok := p.current(items); ok {
+			cmd := m.queueUpdate(i)
+			return m, cmd, true
+		}
+	case "space":
+		if m.mode != modeCacheManager {
+			return m, nil, false
+		}
+		if i, ok := p.current(items); ok {
+			m.toggleMarked(i)
+			p.move(m.cacheItems(), 1)
+		}
+	default:
+		return m, nil, false
+	}
+	return m, nil, true
+}
+
+// pick acts on item i of the active picker, by Enter or a click.
+func (m *Model) pick(i int) tea.Cmd {
+	switch m.mode {
+	case modeTranslationSelect:
+		return m.chooseTranslation(i)
+	case modeThemeSelect:
+		m.themePicker.selected = i
+		m.currentTheme = theme.AllThemes()[i]
+		m.themeBefore = theme.Theme{}
+		m.applyNightLight()
+		m.themePinned = true
+		m.mode = modeReader
+	case modeCacheManager:
+		m.cachePicker.selected = i
+		return m.queueDownloads(i)
+	}
+	return nil
+}
+
+// chooseTranslation switches the reader, or the comparison column the
+// picker was opened from, to translation i.
+func (m *Model) chooseTranslation(i int) tea.Cmd {
+	m.translationPicker.selected = i
+	newTrans := m.translations[i].ShortName
+	// Picker was opened from a comparison column header: swap that
+	// column instead of changing the main reader.
+	if m.comparisonPickerColumn >= 0 && m.comparisonPickerColumn < len(m.comparisonTranslations) {
+		m.comparisonTranslations[m.comparisonPickerColumn] = newTrans
+		m.comparisonPickerColumn = -1
+		m.mode = modeComparison
+		m.loading = true
+		return loadParallelVerses(m.client, m.gen.nextContent(), m.comparisonTranslations, m.currentBook, m.currentChapter, m.comparisonVerseList())
+	}
+	m.mode = modeReader
+	if newTrans == m.selectedTranslation {
+		return nil
+	}
+	m.selectedTranslation = newTrans
+	m.loading = true
+	return tea.Batch(
+		loadBooks(m.client, m.gen.nextBooks(), m.selectedTranslation),
+		loadChapter(m.client, m.gen.nextContent(), m.selectedTranslation, m.currentBook, m.currentChapter),
+	)
+}
+
+// translationHidden reports whether short is left out of the pickers.
+func (m Model) translationHidden(short string) bool {
+	return slices.Contains(m.cfg.HiddenTranslations, short)
+}
+
+// toggleHidden hides short from the translation pickers, or shows it
+// again. The list is saved with the other settings on quit.
+func (m *Model) toggleHidden(short string) tea.Cmd {
+	if short == m.selectedTranslation {
+		return m.flash("can't hide the translation being read")
+	}
+	if i := slices.Index(m.cfg.HiddenTranslations, short); i >= 0 {
+		m.cfg.HiddenTranslations = slices.Delete(slices.Clone(m.cfg.HiddenTranslations), i, i+1)
+		return m.flash(locale.Tf("%s shown again", m.translationName(short)))
+	}
+	m.cfg.HiddenTranslations = append(slices.Clone(m.cfg.HiddenTranslations), short)
+	return m.flash(locale.Tf("hid %s · a shows all", m.translationName(short)))
+}
+
+// removeDownload deletes the downloaded copy of translation i.
+func (m *Model) removeDownload(i int) tea.Cmd {
+	trans := m.translations[i].ShortName
+	if m.cache != nil && m.cache.IsCached(trans) {
+		if err := m.cache.RemoveTranslation(trans); err == nil {
+			return loadCachedList(m.cache)
+		}
+	}
+	return nil
+}
+
+// committedTheme is the theme in use, not counting the one the picker
+// is previewing.
+func (m Model) committedTheme() theme.Theme {
+	if m.mode == modeThemeSelect && m.themeBefore.Name != "" {
+		return m.themeBefore
+	}
+	return m.currentTheme
+}
+
+// previewTheme applies the theme under the picker's cursor to the whole
+// reader, until enter keeps it or esc goes back.
+func (m *Model) previewTheme() {
+	if m.mode != modeThemeSelect {
+		return
+	}
+	i, ok := m.themePicker.current(m.themeItems())
+	if !ok || theme.AllThemes()[i].Name == m.currentTheme.Name {
+		return
+	}
+	m.currentTheme = theme.AllThemes()[i]
+	m.applyNightLight()
+}
+
+// revertThemePreview goes back to the theme in use before the picker
+// opened.
+func (m *Model) revertThemePreview() {
+	if m.mode != modeThemeSelect || m.themeBefore.Name == "" {
+		return
+	}
+	m.currentTheme = m.themeBefore
+	m.themeBefore = theme.Theme{}
+	m.applyNightLight()
+}
+
+// themeIndex returns the position of the theme called name, or 0.
+func themeIndex(name string) int {
+	for i, th := range theme.AllThemes() {
+		if th.Name == name {
+			return i
+		}
+	}
+	return 0
+}
@@ -0,0 +1,8 @@
+package ui
+
+// styled reports whether bold, italic and underline may be used. With the
+// plain_text setting on (for terminals that render those attributes
+// poorly) every style relies on color alone.
+func (m Model) styled() bool {
+	return !m.plainText
+}
@@ -0,0 +1,338 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"sword-tui/internal/locale"
+	"sword-tui/internal/plans"
+	"sword-tui/internal/settings"
+	"sword-tui/internal/visits"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// Reading plans are built with `sword-tui plan`. Opening a chapter ticks
+// it off in every plan that has it still to read, and R shows a plan's
+// schedule around today: Enter opens a day's reading, space marks the
+// whole day read or unread, tab moves to the next plan. When days have
+// been missed, c offers the ways to catch up, each with the load and end
+// date it would leave. On a shared machine r switches between readers,
+// who each have their own plans and reading log.
+
+// planWindow is how many days the plan popup lists at once.
+const planWindow = 12
+
+// tickPlans marks the chapter on screen read in the plans.
+func (m *Model) tickPlans() {
+	for _, p := range m.readingPlans {
+		if p.MarkRead(m.currentBook, m.currentChapter) {
+			if err := p.Save(); err != nil {
+				m.err = err
+			}
+		}
+	}
+}
+
+// openPlans opens the plan popup on today's reading.
+func (m *Model) openPlans() tea.Cmd {
+	others, _ := settings.Readers()
+	if len(m.readingPlans) == 0 && len(others) == 0 {
+		return m.flash("no reading plans — build one with sword-tui plan")
+	}
+	m.planIndex = max(min(m.planIndex, len(m.readingPlans)-1), 0)
+	m.planSelected = 0
+	if p := m.shownPlan(); p != nil {
+		m.planSelected = p.Today(time.Now())
+	}
+	m.planCatchUp = false
+	m.pushOverlay(overlay{
+		name:  overlayPlans,
+		place: placeCenter,
+		dim:   true,
+		view:  Model.renderPlans,
+		key:   Model.updatePlans,
+	})
+	return nil
+}
+
+// shownPlan is the plan the popup shows, or nil when the reader has
+// none.
+func (m Model) shownPlan() *plans.Plan {
+	if m.planIndex >= len(m.readingPlans) {
+		return nil
+	}
+	return m.readingPlans[m.planIndex]
+}
+
+// readerName is how a reader is shown.
+func readerName(reader string) string {
+	if reader == "" {
+		return "default reader"
+	}
+	return reader
+}
+
+// switchReader makes the next reader the one reading: the reading time
+// so far is credited to the last, and the new one's log and plans load.
+func (m *Model) switchReader() tea.Cmd {
+	others, err := settings.Readers()
+	if err != nil {
+		return m.alert(err.Error())
+	}
+	if len(others) == 0 {
+		return m.flash("no other readers — add one with sword-tui plan -reader NAME")
+	}
+	readers := append([]string{""}, others...)
+	next := readers[(slices.Index(readers, m.cfg.Reader)+1)%len(readers)]
+
+	if m.visitStore != nil {
+		m.creditReading()
+		if err := m.visitStore.Save(); err != nil {
+			m.err = err
+		}
+	}
+	seen, err := visits.Load(next)
+	if err != nil {
+		return m.alert(err.Error())
+	}
+	list, err := plans.List(next)
+	if err != nil {
+		return m.alert(err.Error())
+	}
+	m.cfg.Reader = next
+	m.visitStore = seen
+	m.readingPlans = list
+	m.planIndex = 0
+	m.planSelected = 0
+	if p := m.shownPlan(); p != nil {
+		m.planSelected = p.Today(time.Now())
+	}
+	return m.flash(locale.Tf("reading as %s", readerName(next)))
+}
+
+func (m Model) updatePlans(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	if msg.String() == "r" {
+		cmd := m.switchReader()
+		return m, cmd, true
+	}
+	p := m.shownPlan()
+	if p == nil {
+		if msg.String() == "esc" {
+			return m, nil, false
+		}
+		if msg.String() == "q" {
+			m.closeOverlay(overlayPlans)
+		}
+		return m, nil, true
+	}
+	if m.planCatchUp {
+		return m.updatePlanCatchUp(msg)
+	}
+	last := len(p.Days) - 1
+	switch msg.String() {
+	case "down", "j":
+		m.planSelected = min(m.planSelected+1, last)
+	case "up", "k":
+		m.planSelected = max(m.planSelected-1, 0)
+	case "pgdown":
+		m.planSelected = min(m.planSelected+planWindow, last)
+	case "pgup":
+		m.planSelected = max(m.planSelected-planWindow, 0)
+	case "c":
+		if p.Behind(time.Now()) == 0 {
+			return m, m.flash("nothing to catch up on"), true
+		}
+		m.planCatchUp = true
+	case "tab", "shift+tab":
+		step := 1
+		if msg.String() == "shift+tab" {
+			step = len(m.readingPlans) - 1
+		}
+		m.planIndex = (m.planIndex + step) % len(m.readingPlans)
+		m.planSelected = m.shownPlan().Today(time.Now())
+	case "space":
+		if m.planSelected <= last {
+			p.SetDay(m.planSelected, !p.Days[m.planSelected].Done())
+			if err := p.Save(); err != nil {
+				return m, m.alert(err.Error()), true
+			}
+		}
+	case "enter":
+		if m.planSelected > last {
+			break
+		}
+		day := p.Days[m.planSelected]
+		r := day.Readings[0]
+		for _, rd := range day.Readings {
+			if !rd.Read {
+				r = rd
+				break
+			}
+		}
+		if name, ok := m.missingBook(r.Reference()); ok {
+			return m, m.flash(locale.Tf("%s is not in %s", name, m.selectedTranslation)), true
+		}
+		m.closeOverlay(overlayPlans)
+		next, cmd := m.gotoReference(r.Reference())
+		return next, cmd, true
+	case "q":
+		m.closeOverlay(overlayPlans)
+	case "esc":
+		return m, nil, false
+	}
+	// Everything else is swallowed while the popup has the focus.
+	return m, nil, true
+}
+
+// updatePlanCatchUp picks a way to catch up, by its number.
+func (m Model) updatePlanCatchUp(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	p := m.shownPlan()
+	switch k := msg.String(); k {
+	case "1", "2", "3":
+		how := plans.CatchUps[k[0]-'1']
+		now := time.Now()
+		p.CatchUp(how, now)
+		m.planCatchUp = false
+		m.planSelected = p.Today(now)
+		if err := p.Save(); err != nil {
+			return m, m.alert(err.Error()), true
+		}
+		return m, m.flash(locale.Tf("%s rescheduled, ends %s", p.Name, p.End())), true
+	case "esc", "c", "q":
+		m.planCatchUp = false
+	}
+	return m, nil, true
+}
+
+// catchUpLabels describe the ways to catch up on the shown plan, with
+// the most chapters a day and the end date each would leave.
+func (m Model) catchUpLabels() []string {
+	now := time.Now()
+	names := map[string]string{
+		plans.Spread: "spread out",
+		plans.Double: "double up",
+		plans.Shift:  "push end back",
+	}
+	var out []string
+	for i, how := range plans.CatchUps {
+		c := m.shownPlan().Clone()
+		c.CatchUp(how, now)
+		end, _ := time.ParseInLocation(plans.DateLayout, c.End(), time.Local)
+		out = append(out, fmt.Sprintf("%d  %-14s up to %d a day, ends %s", i+1, names[how], c.MostInADay(now), end.Format("Mon 02 Jan")))
+	}
+	return out
+}
+
+func (m Model) renderPlans() string {
+	bg := m.currentTheme.Background
+	w, _ := m.popupSize()
+	_, padX := m.panelPadding()
+
+	containerStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.currentTheme.BorderActive).
+		BorderBackground(bg).
+		Background(bg).
+		Width(w + 2 + 2*padX).
+		Padding(m.panelPadding())
+	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(m.styled())
+	selectedStyle := lipgloss.NewStyle().Foreground(bg).Background(m.currentTheme.Accent).Bold(m.styled())
+	textStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Primary).Background(bg)
+	doneStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Success).Background(bg)
+	lateStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Error).Background(bg)
+	mutedStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(m.styled())
+
+	p := m.shownPlan()
+	now := time.Now()
+	today := now.Format(plans.DateLayout)
+	others, _ := settings.Readers()
+
+	var content strings.Builder
+	reader := ""
+	if len(others) > 0 {
+		reader = "  · " + readerName(m.cfg.Reader)
+	}
+	if p == nil {
+		content.WriteString(titleStyle.Render(locale.T("Reading plans")) + mutedStyle.Render(reader) + m.panelTitleGap())
+		content.WriteString(textStyle.Render(wrapText(fmt.Sprintf("No plans for %s yet. Build one with sword-tui plan -reader %s -books …",
+			readerName(m.cfg.Reader), cmp.Or(m.cfg.Reader, `""`)), w)) + "\n")
+		content.WriteString("\n" + mutedStyle.Render("r next reader  ·  esc close"))
+		return containerStyle.Render(content.String())
+	}
+	read, total := p.Progress()
+	title := "Reading plan  " + p.Name
+	if len(m.readingPlans) > 1 {
+		title += fmt.Sprintf("  (%d/%d)", m.planIndex+1, len(m.readingPlans))
+	}
+	content.WriteString(titleStyle.Render(title) + mutedStyle.Render(reader) + "\n")
+	status := fmt.Sprintf("%s · %d/%d chapters · ends %s", p.Selection, read, total, p.End())
+	if p.Remind != "" {
+		status += " · reminder " + p.Remind
+	}
+	late := ""
+	if behind := p.Behind(now); behind > 0 {
+		late = fmt.Sprintf(" · %d behind", behind)
+	}
+	content.WriteString(mutedStyle.Render(clipText(status, max(w-lipgloss.Width(late), 1))) + lateStyle.Render(late))
+	content.WriteString(m.panelTitleGap())
+
+	start := m.overlayWindowStart(m.planSelected, len(p.Days), planWindow)
+	end := min(start+planWindow, len(p.Days))
+	if start > 0 {
+		content.WriteString(mutedStyle.Render(fmt.Sprintf("  ↑ %d more", start)) + "\n")
+	}
+	for i := start; i < end; i++ {
+		d := p.Days[i]
+		date, _ := time.ParseInLocation(plans.DateLayout, d.Date, time.Local)
+		when := date.Format("Mon 02 Jan")
+		if d.Date == today {
+			when = "Today     "
+		}
+		mark, markStyle := " ", textStyle
+		switch {
+		case d.Done():
+			mark, markStyle = "✓", doneStyle
+		case d.Date < today:
+			mark, markStyle = "!", lateStyle
+		}
+		text := clipText(d.Summary(), max(w-lipgloss.Width(when)-6, 1))
+		if i == m.planSelected {
+			line := "▸ " + mark + " " + when + "  " + text
+			line += strings.Repeat(" ", max(w-lipgloss.Width(line), 0))
+			content.WriteString(selectedStyle.Render(line) + "\n")
+			continue
+		}
+		content.WriteString(textStyle.Render("  ") + markStyle.Render(mark) + textStyle.Render(" "+when+"  "+text) + "\n")
+	}
+	if end < len(p.Days) {
+		content.WriteString(mutedStyle.Render(fmt.Sprintf("  ↓ %d more", len(p.Days)-end)) + "\n")
+	}
+	if m.planSelected >= len(p.Days) {
+		content.WriteString(doneStyle.Render("  The plan's last day has passed.") + "\n")
+	}
+	if m.planCatchUp {
+		content.WriteString("\n" + titleStyle.Render(fmt.Sprintf("Catch up on %d missed chapters:", p.Behind(now))) + "\n")
+		for _, l := range m.catchUpLabels() {
+			content.WriteString(textStyle.Render(clipText("  "+l, w)) + "\n")
+		}
+		content.WriteString(mutedStyle.Render("1-3 choose  ·  esc cancel"))
+		return containerStyle.Render(content.String())
+	}
+	hint := "⏎ read  ·  space mark read"
+	if late != "" {
+		hint += "  ·  c catch up"
+	}
+	if len(m.readingPlans) > 1 {
+		hint += "  ·  tab next plan"
+	}
+	if len(others) > 0 {
+		hint += "  ·  r reader"
+	}
+	content.WriteString("\n" + mutedStyle.Render(clipText(hint+"  ·  esc close", w)))
+	return containerStyle.Render(content.String())
+}
@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"sword-tui/internal/timing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// playbackMsg reports how far audio playback of a chapter has got.
+type playbackMsg struct {
+	book, chapter int
+	pos           time.Duration
+}
+
+// PlaybackPosition returns a message telling a running Model that audio of
+// the given chapter has reached pos. When the chapter is on screen and has
+// verse timings (see package timing), the spoken verse is highlighted and
+// scrolled into view. An audio player should send it a few times a second,
+// through the PLAY request of -listen (see docs/editor-protocol.md).
+func PlaybackPosition(book, chapter int, pos time.Duration) tea.Msg {
+	return playbackMsg{book, chapter, pos}
+}
+
+// loadTimings reads the verse timings of the chapter on screen, if any,
+// unless they are read already. Only chapters audio is played for are
+// read.
+func (m *Model) loadTimings() {
+	key := fmt.Sprintf("%s %d:%d", m.selectedTranslation, m.currentBook, m.currentChapter)
+	if key == m.timingsFor {
+		return
+	}
+	m.timingsFor = key
+	m.timings, _ = timing.Load(m.selectedTranslation, m.currentBook, m.currentChapter)
+}
+
+// followPlayback highlights the verse being spoken.
+func (m *Model) followPlayback(msg playbackMsg) {
+	if m.mode != modeReader || m.wordSelect ||
+		msg.book != m.currentBook || msg.chapter != m.currentChapter {
+		return
+	}
+	m.loadTimings()
+	if m.timings == nil {
+		return
+	}
+	v := m.timings.VerseAt(msg.pos)
+	if v == 0 || (v == m.highlightedVerseStart && v == m.highlightedVerseEnd) {
+		return
+	}
+	m.highlightedVerseStart, m.highlightedVerseEnd = v, v
+	m.refreshContent()
+	m.scrollToHighlightedVerse()
+}
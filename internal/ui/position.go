@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"sword-tui/internal/locale"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// The reader remembers where it was in each chapter, the highlighted
+// verse and the verse at the top of the screen, in the reader's visit
+// log. Coming back to a chapter without asking for a verse resumes
+// there, so a long chapter like Psalm 119 picks up where it was left
+// rather than at the top. A chapter left on its first or last verse
+// starts at the top.
+
+// notePosition remembers where the reader is in the chapter on screen.
+// Update calls it before handling each message, while the state still
+// describes the chapter shown; after n or p it no longer does, until the
+// next chapter has loaded.
+func (m *Model) notePosition() {
+	if m.visitStore == nil || m.mode != modeReader || m.loading || m.currentVerses == nil ||
+		m.highlightedVerseStart == 0 || m.shownChapter != [2]int{m.currentBook, m.currentChapter} {
+		return
+	}
+	m.visitStore.SetPosition(m.currentBook, m.currentChapter, m.highlightedVerseStart, m.verseAtLine(m.viewport.YOffset()))
+}
+
+// resumePosition highlights and scrolls back to where the chapter on
+// screen was left, if anywhere.
+func (m *Model) resumePosition() tea.Cmd {
+	if m.visitStore == nil || len(m.currentVerses) == 0 {
+		return nil
+	}
+	verse, top := m.visitStore.Position(m.currentBook, m.currentChapter)
+	if verse <= m.currentVerses[0].Verse || verse >= m.currentVerses[len(m.currentVerses)-1].Verse {
+		return nil
+	}
+	m.highlightedVerseStart, m.highlightedVerseEnd = verse, verse
+	m.content, m.verseOffsets = m.layoutChapter(m.viewport.Width())
+	m.viewport.SetContent(m.content)
+	m.scrollToHighlightedVerse()
+
+	// Put the verse that was at the top back there too, as long as the
+	// highlighted one stays in view at this size.
+	var topLine, verseLine int
+	for _, o := range m.verseOffsets {
+		switch o.verse {
+		case top:
+			topLine = o.line
+		case verse:
+			verseLine = o.line
+		}
+	}
+	if top > 0 && top < verse && verseLine-topLine < m.viewport.Height() {
+		m.viewport.SetYOffset(topLine)
+	}
+	m.topVisibleVerse = m.verseAtLine(m.viewport.YOffset())
+	return m.flash(locale.Tf("back at verse %d", verse))
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"sword-tui/internal/api"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// adjacentChapters returns the chapters n and p would go to from the
+// current one, as book and chapter.
+func (m Model) adjacentChapters() [][2]int {
+	var out [][2]int
+	next := false
+	for _, b := range m.books {
+		if b.BookID == m.currentBook && m.currentChapter < b.Chapters {
+			out = append(out, [2]int{m.currentBook, m.currentChapter + 1})
+			next = true
+			break
+		}
+	}
+	if b, ok := m.adjacentBook(1); !next && ok {
+		out = append(out, [2]int{b.BookID, 1})
+	}
+	if m.currentChapter > 1 {
+		out = append(out, [2]int{m.currentBook, m.currentChapter - 1})
+	} else if b, ok := m.adjacentBook(-1); ok && b.Chapters > 0 {
+		out = append(out, [2]int{b.BookID, b.Chapters})
+	}
+	return out
+}
+
+// prefetchAdjacent fetches the chapters either side of the current one
+// in the background, so n and p don't wait on the network. Nothing is
+// fetched while offline.
+func (m Model) prefetchAdjacent() tea.Cmd {
+	if m.conn == connOffline {
+		return nil
+	}
+	return prefetchChapters(m.client, m.selectedTranslation, m.adjacentChapters())
+}
+
+func prefetchChapters(client *api.Client, translation string, chapters [][2]int) tea.Cmd {
+	return func() tea.Msg {
+		for _, c := range chapters {
+			client.Prefetch(translation, c[0], c[1])
+		}
+		return nil
+	}
+}
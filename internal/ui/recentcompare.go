@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/comparisons"
+	"sword-tui/internal/locale"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// Each comparison opened is remembered with the translations it was in.
+// ctrl+r, in the reader or the comparison, lists them newest first;
+// Enter opens one again.
+
+// recentComparisonWindow is how many comparisons the popup lists at once.
+const recentComparisonWindow = 10
+
+// rememberComparison records the comparison just loaded.
+func (m *Model) rememberComparison() {
+	if m.comparisonLog == nil {
+		return
+	}
+	m.comparisonLog.Add(comparisons.Comparison{
+		Book:         m.currentBook,
+		BookName:     m.currentBookName,
+		Chapter:      m.currentChapter,
+		VerseStart:   m.comparisonVerses[0],
+		VerseEnd:     m.comparisonVerses[1],
+		Translations: slices.Clone(m.comparisonTranslations),
+		When:         time.Now(),
+	})
+	if err := m.comparisonLog.Save(); err != nil {
+		m.err = err
+	}
+}
+
+// openRecentComparisons opens the popup listing past comparisons.
+func (m *Model) openRecentComparisons() tea.Cmd {
+	if m.comparisonLog == nil || len(m.comparisonLog.Entries) == 0 {
+		return m.flash("nothing compared yet — c compares translations")
+	}
+	m.recentComparisonSelected = 0
+	m.pushOverlay(overlay{
+		name:  overlayComparisons,
+		place: placeCenter,
+		dim:   true,
+		view:  Model.renderRecentComparisons,
+		key:   Model.updateRecentComparisons,
+	})
+	return nil
+}
+
+func (m Model) updateRecentComparisons(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	list := m.comparisonLog.Recent()
+	switch msg.String() {
+	case "down", "j", "tab":
+		m.recentComparisonSelected = min(m.recentComparisonSelected+1, len(list)-1)
+	case "up", "k", "shift+tab":
+		m.recentComparisonSelected = max(m.recentComparisonSelected-1, 0)
+	case "enter":
+		m.closeOverlay(overlayComparisons)
+		if m.recentComparisonSelected < len(list) {
+			return m, m.reopenComparison(list[m.recentComparisonSelected]), true
+		}
+	case "q", "ctrl+r":
+		m.closeOverlay(overlayComparisons)
+	case "esc":
+		return m, nil, false
+	}
+	// Everything else is swallowed while the popup has the focus.
+	return m, nil, true
+}
+
+// reopenComparison compares c's verses in its translations again.
+func (m *Model) reopenComparison(c comparisons.Comparison) tea.Cmd {
+	m.currentBook = c.Book
+	m.currentChapter = c.Chapter
+	m.currentBookName = c.BookName
+	if i := slices.IndexFunc(m.books, func(b api.Book) bool { return b.BookID == c.Book }); i >= 0 {
+		m.currentBookName = m.books[i].Name
+	}
+	m.highlightedVerseStart, m.highlightedVerseEnd = c.VerseStart, c.VerseEnd
+	m.comparisonTranslations = slices.Clone(c.Translations)
+	m.comparisonVerses = [2]int{c.VerseStart, c.VerseEnd}
+	m.comparisonFilterInput.SetValue("")
+	m.mode = modeComparison
+	m.loading = true
+	return loadParallelVerses(m.client, m.gen.nextContent(), m.comparisonTranslations, m.currentBook, m.currentChapter, m.comparisonVerseList())
+}
+
+func (m Model) renderRecentComparisons() string {
+	bg := m.currentTheme.Background
+	w, _ := m.popupSize()
+	_, padX := m.panelPadding()
+
+	containerStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.currentTheme.BorderActive).
+		BorderBackground(bg).
+		Background(bg).
+		Width(w + 2 + 2*padX).
+		Padding(m.panelPadding())
+	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(m.styled())
+	selectedStyle := lipgloss.NewStyle().Foreground(bg).Background(m.currentTheme.Accent).Bold(m.styled())
+	refStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Primary).Background(bg)
+	textStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Secondary).Background(bg)
+	mutedStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(m.styled())
+
+	list := m.comparisonLog.Recent()
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(locale.T("Recently compared")) +
+		mutedStyle.Render(fmt.Sprintf("  %d", len(list))) + m.panelTitleGap())
+
+	start := m.overlayWindowStart(m.recentComparisonSelected, len(list), recentComparisonWindow)
+	end := min(start+recentComparisonWindow, len(list))
+	if start > 0 {
+		content.WriteString(mutedStyle.Render(fmt.Sprintf("  ↑ %d more", start)) + "\n")
+	}
+	for i := start; i < end; i++ {
+		c := list[i]
+		ref := c.Reference()
+		names := make([]string, len(c.Translations))
+		for j, t := range c.Translations {
+			names[j] = m.translationName(t)
+		}
+		date := c.When.Format("2006-01-02")
+		textW := max(w-2-lipgloss.Width(ref)-2-len(date)-1, 1)
+		text := clipText(strings.Join(names, " · "), textW)
+		pad := strings.Repeat(" ", max(w-2-lipgloss.Width(ref)-2-lipgloss.Width(text)-len(date), 1))
+		if i == m.recentComparisonSelected {
+			line := "▸ " + ref + "  " + text + pad + date
+			line += strings.Repeat(" ", max(w-lipgloss.Width(line), 0))
+			content.WriteString(selectedStyle.Render(line) + "\n")
+			continue
+		}
+		content.WriteString(refStyle.Render("  "+ref+"  ") + textStyle.Render(text) + refStyle.Render(pad) + mutedStyle.Render(date) + "\n")
+	}
+	if end < len(list) {
+		content.WriteString(mutedStyle.Render(fmt.Sprintf("  ↓ %d more", len(list)-end)) + "\n")
+	}
+	content.WriteString("\n" + mutedStyle.Render(locale.T("↑↓ select  ·  ⏎ compare again  ·  esc close")))
+	return containerStyle.Render(content.String())
+}
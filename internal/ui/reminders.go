@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"sword-tui/internal/locale"
+	"sword-tui/internal/plans"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// While the reader is open, a reading plan with a reminder time (plan
+// -remind) says so in the status bar when the time comes and rings the
+// terminal bell — once a day, and only if the day's reading isn't done.
+// The reminders setting turns the bell ("flash") or both ("off") off;
+// quiet hours keep the bell still but not the message.
+
+// The reminders settings.
+const (
+	remindBell  = "bell" // status bar and bell, the default
+	remindFlash = "flash"
+	remindOff   = "off"
+)
+
+// checkReminders reports a reminders setting the reader doesn't know.
+func checkReminders(s string) error {
+	switch s {
+	case "", remindBell, remindFlash, remindOff:
+		return nil
+	}
+	return fmt.Errorf("reminders: %q is not %q, %q or %q", s, remindBell, remindFlash, remindOff)
+}
+
+type reminderTickMsg struct{ now time.Time }
+
+// reminderTick fires at the top of every minute.
+func reminderTick() tea.Cmd {
+	return tea.Every(time.Minute, func(t time.Time) tea.Msg { return reminderTickMsg{t} })
+}
+
+// remind tells of the plans whose reminder time is now and whose reading
+// for today is still to do.
+func (m *Model) remind(now time.Time) tea.Cmd {
+	if m.cfg.Reminders == remindOff {
+		return nil
+	}
+	today := now.Format(plans.DateLayout)
+	for _, p := range m.readingPlans {
+		at, err := time.Parse("15:04", p.Remind)
+		if err != nil || at.Hour() != now.Hour() || at.Minute() != now.Minute() {
+			continue
+		}
+		i := p.Today(now)
+		if i >= len(p.Days) || p.Days[i].Date != today || p.Days[i].Done() {
+			continue
+		}
+		if m.reminded[p.Name] == today {
+			continue
+		}
+		if m.reminded == nil {
+			m.reminded = map[string]string{}
+		}
+		m.reminded[p.Name] = today
+		cmd := m.alert(locale.Tf("⏰ time to read %s: %s (R)", p.Name, p.Days[i].Summary()))
+		if m.cfg.Reminders == remindFlash || m.quietHours.active(now) {
+			return cmd
+		}
+		return tea.Batch(cmd, tea.Raw("\a"))
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/reference"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/atotto/clipboard"
+)
+
+// gotoMsg asks the reader to jump to a reference typed by an external
+// tool (see --stdin-follow).
+type gotoMsg struct{ ref string }
+
+// Goto returns a message that makes a running Model jump to ref, e.g.
+// "John 3:16" or "rom 8:28-30". Send it with tea.Program.Send.
+func Goto(ref string) tea.Msg {
+	return gotoMsg{strings.TrimSpace(ref)}
+}
+
+// gotoReference navigates to ref, closing any overlay. Unparseable or
+// out-of-range references are reported in the status bar.
+func (m Model) gotoReference(ref string) (Model, tea.Cmd) {
+	if ref == "" {
+		return m, nil
+	}
+	books := m.books
+	if books == nil {
+		books = api.CanonicalBooks()
+	}
+	book, chapter, verseStart, verseEnd, translation, err := m.parseReference(ref, books)
+	if err != nil {
+		return m, m.flash(err.Error())
+	}
+	if problem := m.checkReference(book, chapter, verseStart); problem != "" {
+		return m, m.flash(problem)
+	}
+
+	m.currentBook = book
+	m.currentChapter = chapter
+	m.highlightedVerseStart = verseStart
+	m.highlightedVerseEnd = verseEnd
+	for _, b := range books {
+		if b.BookID == book {
+			m.currentBookName = b.Name
+			break
+		}
+	}
+	m.mode = modeReader
+	m.loading = true
+	if translation != m.selectedTranslation {
+		m.selectedTranslation = translation
+		return m, tea.Batch(
+			loadBooks(m.client, m.gen.nextBooks(), m.selectedTranslation),
+			loadChapter(m.client, m.gen.nextContent(), m.selectedTranslation, m.currentBook, m.currentChapter),
+		)
+	}
+	return m, loadChapter(m.client, m.gen.nextContent(), m.selectedTranslation, m.currentBook, m.currentChapter)
+}
+
+// translationQueryMsg asks the model which translation it is showing.
+type translationQueryMsg struct{ reply chan<- string }
+
+// CurrentTranslation asks the running program p which translation the
+// reader is showing. It returns "" if p does not answer within timeout.
+func CurrentTranslation(p *tea.Program, timeout time.Duration) string {
+	reply := make(chan string, 1)
+	p.Send(translationQueryMsg{reply})
+	select {
+	case t := <-reply:
+		return t
+	case <-time.After(timeout):
+		return ""
+	}
+}
+
+// PlainText strips the markup the API embeds in verse text.
+func PlainText(s string) string {
+	return stripHTMLTags(s)
+}
+
+// clipboardMsg carries the system clipboard's text, read for "V".
+type clipboardMsg struct {
+	text string
+	err  error
+}
+
+// readClipboard reads the clipboard off the update loop; the helper
+// programs it shells out to on some systems can be slow.
+func readClipboard() tea.Msg {
+	text, err := clipboard.ReadAll()
+	return clipboardMsg{text, err}
+}
+
+// gotoClipboard jumps to the first reference found in the clipboard text,
+// e.g. a verse someone pasted into a chat message.
+func (m Model) gotoClipboard(msg clipboardMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		return m, m.alert(clipboardUnreadable())
+	}
+	books := m.books
+	if books == nil {
+		books = api.CanonicalBooks()
+	}
+	ref, ok := reference.Find(msg.text, books)
+	if !ok {
+		return m, m.flash("no reference on the clipboard")
+	}
+	return m.gotoReference(ref)
+}
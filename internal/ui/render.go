@@ -0,0 +1,15 @@
+package ui
+
+import (
+	"sword-tui/internal/api"
+	"sword-tui/internal/theme"
+)
+
+// RenderChapter formats verses exactly as the reader pane would at the
+// given content width, using the default theme. It exists so the chapter
+// layout can be exercised without a terminal (see `sword-tui bench`).
+func RenderChapter(verses []api.Verse, bookName string, chapter, width, highlightStart, highlightEnd int) string {
+	m := Model{currentTheme: theme.CatppuccinMocha}
+	out, _ := m.formatChapter(verses, bookName, chapter, width, highlightStart, highlightEnd)
+	return out
+}
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"slices"
+
+	"sword-tui/internal/theme"
+	"sword-tui/internal/versenum"
+)
+
+// renderCacheSize is how many layouts of the chapter are kept: enough to
+// step back and forth between nearby verses without reformatting.
+const renderCacheSize = 16
+
+// renderKey identifies one layout of a chapter. Annotations aren't part
+// of it; changing them goes through refreshContent, which empties the
+// cache.
+type renderKey struct {
+	translation                  string
+	book, chapter, width         int
+	theme                        theme.Theme
+	highlightStart, highlightEnd int
+	density                      string
+	verseNumbers                 versenum.Style
+	outlineExpanded              bool
+}
+
+type renderedChapter struct {
+	content string
+	offsets []verseOffset
+}
+
+// renderCache memoizes formatted chapters, so moving the highlight, drag
+// selection and scrolling back over verses reuse strings already built.
+// It is shared between Model copies, like generations.
+type renderCache struct {
+	entries map[renderKey]renderedChapter
+	order   []renderKey // oldest first
+}
+
+func (c *renderCache) get(k renderKey) (renderedChapter, bool) {
+	r, ok := c.entries[k]
+	if ok {
+		i := slices.Index(c.order, k)
+		c.order = append(slices.Delete(c.order, i, i+1), k)
+	}
+	return r, ok
+}
+
+func (c *renderCache) put(k renderKey, r renderedChapter) {
+	if c.entries == nil {
+		c.entries = map[renderKey]renderedChapter{}
+	}
+	if _, ok := c.entries[k]; !ok {
+		if len(c.order) >= renderCacheSize {
+			delete(c.entries, c.order[0])
+			c.order = c.order[1:]
+		}
+		c.order = append(c.order, k)
+	}
+	c.entries[k] = r
+}
+
+func (c *renderCache) reset() {
+	if c == nil {
+		return
+	}
+	clear(c.entries)
+	c.order = c.order[:0]
+}
+
+// layoutChapter formats the current chapter at width with the current
+// highlight, reusing an identical earlier layout when there is one.
+func (m Model) layoutChapter(width int) (string, []verseOffset) {
+	if m.renders == nil || m.wordSelect {
+		return m.formatChapter(m.currentVerses, m.currentBookName, m.currentChapter, width, m.highlightedVerseStart, m.highlightedVerseEnd)
+	}
+	k := renderKey{
+		translation:     m.selectedTranslation,
+		book:            m.currentBook,
+		chapter:         m.currentChapter,
+		width:           width,
+		theme:           m.currentTheme,
+		highlightStart:  m.highlightedVerseStart,
+		highlightEnd:    m.highlightedVerseEnd,
+		density:         m.density,
+		verseNumbers:    m.verseNumbers,
+		outlineExpanded: m.outlineExpanded,
+	}
+	if r, ok := m.renders.get(k); ok {
+		return r.content, r.offsets
+	}
+	content, offsets := m.formatChapter(m.currentVerses, m.currentBookName, m.currentChapter, width, m.highlightedVerseStart, m.highlightedVerseEnd)
+	m.renders.put(k, renderedChapter{content, offsets})
+	return content, offsets
+}
@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"sword-tui/internal/api"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+const (
+	// maxRetries is how often a request is repeated before its error is
+	// shown.
+	maxRetries = 5
+	// maxBackoff caps the wait between retries when the server doesn't
+	// send Retry-After.
+	maxBackoff = time.Minute
+)
+
+// Request kinds, one per generation counter.
+const (
+	retryBooks   = "books"
+	retryContent = "content"
+	retrySearch  = "search"
+)
+
+// pendingRetry is a request waiting to be sent again.
+type pendingRetry struct {
+	kind string
+	gen  int
+	at   time.Time
+	cmd  tea.Cmd
+}
+
+// retryQueue holds requests the server asked us to repeat later. It is
+// shared between Model copies, like generations.
+type retryQueue struct {
+	pending  []pendingRetry
+	attempts map[string][2]int // kind -> generation, attempts so far
+	ticking  bool
+}
+
+// retryTickMsg counts down pending retries once a second.
+type retryTickMsg struct{}
+
+func retryTick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg { return retryTickMsg{} })
+}
+
+// backoff is how long to wait before retrying after err, and whether to
+// retry at all: only rate limiting and server errors are worth repeating.
+// Retry-After is honoured; otherwise the wait doubles from two seconds.
+func backoff(err error, attempt int) (time.Duration, bool) {
+	var status *api.StatusError
+	if !errors.As(err, &status) || !status.Temporary() || attempt >= maxRetries {
+		return 0, false
+	}
+	if status.RetryAfter > 0 {
+		return status.RetryAfter, true
+	}
+	return min(2*time.Second<<attempt, maxBackoff), true
+}
+
+// currentGen is the live generation for a request kind.
+func (m Model) currentGen(kind string) int {
+	switch kind {
+	case retryBooks:
+		return m.gen.books
+	case retrySearch:
+		return m.gen.search
+	}
+	return m.gen.content
+}
+
+// queueRetry schedules cmd, the request of generation gen that failed
+// with err, to be sent again, returning the countdown tick to start if
+// any. It reports false when err isn't worth retrying or the attempts are
+// used up; the caller then shows err.
+func (m *Model) queueRetry(kind string, gen int, err error, cmd tea.Cmd) (tea.Cmd, bool) {
+	q := m.retries
+	if q.attempts == nil {
+		q.attempts = map[string][2]int{}
+	}
+	attempt := 0
+	if a := q.attempts[kind]; a[0] == gen {
+		attempt = a[1]
+	}
+	wait, ok := backoff(err, attempt)
+	if !ok {
+		delete(q.attempts, kind)
+		return nil, false
+	}
+	q.attempts[kind] = [2]int{gen, attempt + 1}
+
+	p := pendingRetry{kind: kind, gen: gen, at: time.Now().Add(wait), cmd: cmd}
+	replaced := false
+	for i := range q.pending {
+		if q.pending[i].kind == kind {
+			q.pending[i], replaced = p, true
+		}
+	}
+	if !replaced {
+		q.pending = append(q.pending, p)
+	}
+	if q.ticking {
+		return nil, true
+	}
+	q.ticking = true
+	return retryTick(), true
+}
+
+// fireRetries sends the requests that are due, dropping any the user
+// has since moved on from, and keeps the countdown going while others
+// wait.
+func (m *Model) fireRetries(now time.Time) tea.Cmd {
+	q := m.retries
+	var cmds []tea.Cmd
+	waiting := q.pending[:0]
+	for _, p := range q.pending {
+		switch {
+		case p.gen != m.currentGen(p.kind):
+		case !now.Before(p.at):
+			cmds = append(cmds, p.cmd)
+		default:
+			waiting = append(waiting, p)
+		}
+	}
+	q.pending = waiting
+	if len(waiting) > 0 {
+		cmds = append(cmds, retryTick())
+	} else {
+		q.ticking = false
+	}
+	return tea.Batch(cmds...)
+}
+
+// renderRetry is the status-bar countdown to the next retry, or "".
+func (m Model) renderRetry(style lipgloss.Style) string {
+	var next time.Time
+	for _, p := range m.retries.pending {
+		if p.gen == m.currentGen(p.kind) && (next.IsZero() || p.at.Before(next)) {
+			next = p.at
+		}
+	}
+	if next.IsZero() {
+		return ""
+	}
+	secs := int(time.Until(next).Round(time.Second) / time.Second)
+	return style.Render(fmt.Sprintf("⏳ %s busy · retrying in %ds", m.client.Provider().Name(), max(secs, 0)))
+}
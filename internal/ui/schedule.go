@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+)
+
+// dailyWindow is a schedule setting: off, always on, or on between two
+// times of day.
+type dailyWindow struct {
+	always   bool
+	from, to int // minutes after midnight; from == to means off
+}
+
+// parseDailyWindow reads "" (off), "always", or a "21:00-06:30" window
+// that may wrap past midnight. setting names the option in errors.
+func parseDailyWindow(setting, spec string) (dailyWindow, error) {
+	switch spec {
+	case "":
+		return dailyWindow{}, nil
+	case "always":
+		return dailyWindow{always: true}, nil
+	}
+	var h1, m1, h2, m2 int
+	if _, err := fmt.Sscanf(spec, "%d:%d-%d:%d", &h1, &m1, &h2, &m2); err != nil ||
+		h1 > 23 || h2 > 23 || m1 > 59 || m2 > 59 || h1 < 0 || h2 < 0 || m1 < 0 || m2 < 0 {
+		return dailyWindow{}, fmt.Errorf("%s: want \"always\" or \"HH:MM-HH:MM\", got %q", setting, spec)
+	}
+	return dailyWindow{from: h1*60 + m1, to: h2*60 + m2}, nil
+}
+
+// scheduled reports whether the setting depends on the time of day.
+func (w dailyWindow) scheduled() bool {
+	return !w.always && w.from != w.to
+}
+
+func (w dailyWindow) active(now time.Time) bool {
+	if w.always {
+		return true
+	}
+	if w.from == w.to {
+		return false
+	}
+	t := now.Hour()*60 + now.Minute()
+	if w.from < w.to {
+		return t >= w.from && t < w.to
+	}
+	return t >= w.from || t < w.to
+}
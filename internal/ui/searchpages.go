@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"strings"
+	"unicode"
+
+	tea "charm.land/bubbletea/v2"
+
+	"sword-tui/internal/api"
+)
+
+// bolls.life answers a search api.SearchPageSize verses at a time; n and
+// p in the results step through the pages of a search that found more.
+
+// searchPages is how many pages the current search's results fill.
+func (m Model) searchPages() int {
+	return (m.wordSearchTotal + api.SearchPageSize - 1) / api.SearchPageSize
+}
+
+// searchPage loads the page delta away from the one shown, if there is
+// one.
+func (m *Model) searchPage(delta int) tea.Cmd {
+	page := m.wordSearchPage + delta
+	if m.wordSearchLoading || page < 1 || page > m.searchPages() {
+		return nil
+	}
+	m.wordSearchLoading = true
+	return loadSearchResults(m.client, m.gen.nextSearch(), m.selectedTranslation, m.wordSearchQuery, page)
+}
+
+// searchSnippet shortens a verse longer than limit runes to the part
+// around the first match of query, cut at spaces and marked with "…".
+func searchSnippet(text, query string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit || limit <= 0 {
+		return text
+	}
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+	at := 0
+	if q := strings.ToLower(strings.TrimSpace(query)); q != "" {
+		if i := strings.Index(string(lower), q); i > 0 {
+			at = len([]rune(string(lower)[:i]))
+		}
+	}
+	start := max(at-limit/3, 0)
+	end := min(start+limit, len(runes))
+	start = max(end-limit, 0)
+	for start > 0 && start < at && !unicode.IsSpace(runes[start-1]) {
+		start++
+	}
+	for end < len(runes) && end > at && !unicode.IsSpace(runes[end]) {
+		end--
+	}
+	snippet := strings.TrimSpace(string(runes[start:end]))
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(runes) {
+		snippet += "…"
+	}
+	return snippet
+}
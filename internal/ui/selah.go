@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+// Liturgical and musical directions in the Psalms and Habakkuk — Selah,
+// Higgaion, the NLT's Interlude — are not part of the sentence around
+// them, so the reader shows them muted. Translations that mark them up
+// (<span class="selah">, <selah>, …) are taken at their word; otherwise
+// the words themselves are recognised.
+
+// Private-use runes bracket a direction through stripHTMLTags, which
+// keeps them, so it can be found again among the words.
+const (
+	directionOpen  = "\uE000"
+	directionClose = "\uE001"
+)
+
+var (
+	// directionMarkup matches an element whose tag or class names it a
+	// direction, e.g. <span class="selah">Selah</span>.
+	directionMarkup = regexp.MustCompile(`(?is)<[^>]*\b(?:selah|interlude|musical)\b[^>]*>(.*?)</[^>]*>`)
+	// directionWord matches the directions English translations use.
+	directionWord = regexp.MustCompile(`\b(?:Selah|Higgaion|Interlude)\b`)
+)
+
+// verseDirections strips a verse's markup like stripHTMLTags and returns
+// which of its words, by index among strings.Fields, are directions.
+func verseDirections(raw string) (string, map[int]bool) {
+	if !directionMarkup.MatchString(raw) && !directionWord.MatchString(raw) {
+		return stripHTMLTags(raw), nil
+	}
+	marked := directionMarkup.ReplaceAllStringFunc(raw, func(el string) string {
+		return directionOpen + directionMarkup.FindStringSubmatch(el)[1] + directionClose
+	})
+	marked = directionWord.ReplaceAllStringFunc(marked, func(w string) string {
+		return directionOpen + w + directionClose
+	})
+
+	directions := map[int]bool{}
+	words := strings.Fields(stripHTMLTags(marked))
+	inside := false
+	for i, w := range words {
+		if strings.Contains(w, directionOpen) {
+			inside = true
+		}
+		if inside {
+			directions[i] = true
+		}
+		if strings.Contains(w, directionClose) {
+			inside = false
+		}
+		words[i] = strings.NewReplacer(directionOpen, "", directionClose, "").Replace(w)
+	}
+	return strings.Join(words, " "), directions
+}
+
+// renderDirections styles the words of wrapped text that are directions
+// muted, the rest with base, padding each line to width.
+func (m Model) renderDirections(wrapped string, width int, base lipgloss.Style, directions map[int]bool) string {
+	muted := base.Foreground(m.currentTheme.Muted).Bold(false).Italic(m.styled())
+	idx := 0
+	lines := strings.Split(wrapped, "\n")
+	for i, line := range lines {
+		words := strings.TrimLeft(line, " ")
+		var sb strings.Builder
+		sb.WriteString(base.Render(line[:len(line)-len(words)]))
+		for j, w := range strings.Fields(words) {
+			if j > 0 {
+				sb.WriteString(base.Render(" "))
+			}
+			style := base
+			if directions[idx] {
+				style = muted
+			}
+			sb.WriteString(style.Render(w))
+			idx++
+		}
+		if pad := width - lipgloss.Width(sb.String()); pad > 0 {
+			sb.WriteString(base.Render(strings.Repeat(" ", pad)))
+		}
+		lines[i] = sb.String()
+	}
+	return strings.Join(lines, "\n")
+}
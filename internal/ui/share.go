@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// With share_format set to "paragraph", y copies the verses without
+// their numbers, run together into one paragraph under the reference,
+// ready to paste into a document.
+//
+// With share_format set to "thread", y copies the verses ready to post on
+// a microblog: the quote and its citation, cut at word boundaries into
+// posts of at most share_limit characters, each marked (1/3), (2/3), …
+// and separated by blank lines, so they can be pasted one at a time.
+
+// The share_format values besides "", which numbers each verse.
+const (
+	shareParagraph = "paragraph" // one paragraph, no verse numbers
+	shareThread    = "thread"    // split into posts
+)
+
+// defaultShareLimit is the length of a post when share_limit is unset.
+const defaultShareLimit = 280
+
+// checkShareFormat reports a share_format the reader doesn't know.
+func checkShareFormat(format string) error {
+	switch format {
+	case "", shareParagraph, shareThread:
+		return nil
+	}
+	return fmt.Errorf("share_format: %q is not %q or %q", format, shareParagraph, shareThread)
+}
+
+// shareText renders the highlighted verses, or the chapter, as a thread.
+func (m Model) shareText() string {
+	ref := fmt.Sprintf("%s %d", m.currentBookName, m.currentChapter)
+	switch {
+	case m.highlightedVerseStart == 0:
+	case m.highlightedVerseStart == m.highlightedVerseEnd:
+		ref += fmt.Sprintf(":%d", m.highlightedVerseStart)
+	default:
+		ref += fmt.Sprintf(":%d-%d", m.highlightedVerseStart, m.highlightedVerseEnd)
+	}
+	var quote []string
+	for _, v := range m.currentVerses {
+		if m.highlightedVerseStart == 0 || v.Verse >= m.highlightedVerseStart && v.Verse <= m.highlightedVerseEnd {
+			quote = append(quote, stripHTMLTags(v.Text))
+		}
+	}
+	limit := m.cfg.ShareLimit
+	if limit <= 0 {
+		limit = defaultShareLimit
+	}
+	posts := threadPosts("“"+strings.Join(quote, " ")+"”", "— "+ref+" ("+m.translationName(m.selectedTranslation)+")", limit)
+	return strings.Join(posts, "\n\n") + "\n"
+}
+
+// threadPosts splits text followed by its citation into posts of at most
+// limit characters, breaking between words and keeping the citation
+// whole. When it takes more than one post each ends in " (i/n)".
+func threadPosts(text, citation string, limit int) []string {
+	words := append(strings.Fields(text), citation)
+	if posts := fill(words, limit); len(posts) == 1 {
+		return posts
+	}
+	// The markers take room from the posts, which may need another post
+	// and so a longer marker: settle on a count that holds.
+	n := 2
+	for {
+		marker := len(fmt.Sprintf(" (%d/%d)", n, n))
+		posts := fill(words, max(limit-marker, 1))
+		if len(posts) <= n {
+			for i := range posts {
+				posts[i] += fmt.Sprintf(" (%d/%d)", i+1, len(posts))
+			}
+			return posts
+		}
+		n = len(posts)
+	}
+}
+
+// fill packs words into lines of at most limit characters, cutting any
+// word longer than that.
+func fill(words []string, limit int) []string {
+	var posts []string
+	var cur strings.Builder
+	for _, w := range words {
+		for utf8.RuneCountInString(w) > limit {
+			if cur.Len() > 0 {
+				posts = append(posts, cur.String())
+				cur.Reset()
+			}
+			r := []rune(w)
+			posts = append(posts, string(r[:limit]))
+			w = string(r[limit:])
+		}
+		if cur.Len() > 0 && utf8.RuneCountInString(cur.String())+1+utf8.RuneCountInString(w) > limit {
+			posts = append(posts, cur.String())
+			cur.Reset()
+		}
+		if cur.Len() > 0 {
+			cur.WriteString(" ")
+		}
+		cur.WriteString(w)
+	}
+	if cur.Len() > 0 {
+		posts = append(posts, cur.String())
+	}
+	return posts
+}
@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"sword-tui/internal/locale"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// hint is a key and what it does, as listed in the status bar.
+type hint struct{ k, label string }
+
+// statusBar is the bar along the bottom: the current mode's key hints, or
+// the note prompt while one is open, and on the right the most pressing
+// state: a pending retry, loading, a message, an error or the connection.
+type statusBar struct {
+	hints []hint
+	// prompt, when set, draws the note input in place of the hints at
+	// the width left for them.
+	prompt func(width int) string
+	right  string
+}
+
+func (m Model) statusBar() statusBar {
+	s := m.styles
+	b := statusBar{hints: m.statusHints()}
+	if m.capturing {
+		b.prompt = func(width int) string { return m.renderCapture(s.accent, width) }
+	}
+	if m.comparisonFiltering {
+		b.prompt = func(width int) string { return m.renderComparisonFilter(s.accent, width) }
+	}
+	if m.exporting {
+		b.prompt = func(width int) string { return m.renderExport(s.accent, width) }
+	}
+
+	b.right = m.renderRetry(s.warning)
+	switch {
+	case b.right != "":
+		// The retry countdown explains any error until it runs out.
+	case m.loading:
+		b.right = s.strongWarning.Render(locale.T("● loading"))
+	case m.downloadingTranslation != "" && m.mode != modeCacheManager:
+		// The cache manager shows the download itself; elsewhere it
+		// goes on in the status bar.
+		b.right = fmt.Sprintf("⟳ %s %d%%", m.downloadingTranslation, int(m.downloadProgress*100))
+		if n := len(m.downloads.waiting); n > 0 {
+			b.right += fmt.Sprintf(" +%d", n)
+		}
+		b.right = s.warning.Render(b.right)
+	case m.statusMsg != "":
+		b.right = s.success.Render(m.statusMsg)
+	case m.err != nil:
+		b.right = s.strongError.Render("⚠ " + clipText(friendlyError(m.err, m.client.Provider().Name()), 72))
+	default:
+		b.right = m.renderConnStatus(s.muted)
+	}
+	return b
+}
+
+// View renders the status bar across width.
+func (b statusBar) View(s styles, width int) string {
+	innerWidth := width - 4 - 2 // -2 border -2 padding -2 safety
+	// A long message or error gives up its end before the bar wraps,
+	// keeping a cell for the hints and the gap before it.
+	right := ansi.Truncate(b.right, max(innerWidth-2, 1), "…")
+	rightW := lipgloss.Width(right)
+	leftW := innerWidth - rightW - 1
+	if leftW < 1 {
+		leftW = 1
+	}
+	left := renderHints(s, b.hints)
+	if b.prompt != nil {
+		left = b.prompt(leftW)
+	}
+	hintsSlot := s.bg.Width(leftW).MaxWidth(leftW).MaxHeight(1).Render(left)
+
+	return s.bar.Width(width - 2).Render(hintsSlot + s.bg.Render(" ") + right)
+}
+
+// statusHints lists the keys that matter in the current mode.
+func (m Model) statusHints() []hint {
+	var hs []hint
+	switch m.mode {
+	case modeTranslationSelect:
+		hs = []hint{{"↑↓", "navigate"}, {"⏎", "select"}, {"/", "filter"}, {"h", "hide"}, {"a", "all"}, {"l", "languages"}, {"esc", "close"}}
+	case modeThemeSelect:
+		hs = []hint{{"↑↓", "navigate"}, {"⏎", "select"}, {"/", "filter"}, {"esc", "close"}}
+	case modeCacheManager:
+		hs = []hint{{"↑↓", "navigate"}, {"⏎", "download"}, {"space", "mark"}, {"x", "remove"}, {"u", "update"}, {"h", "hide"}, {"a", "all"}, {"l", "languages"}, {"/", "filter"}, {"esc", "close"}}
+	case modeAbout:
+		hs = []hint{{"j/k", "scroll"}, {"[ ]", "section"}, {"n", "what's new"}, {"esc", "close"}}
+	case modeWordSearch:
+		if m.wordSearchResults != nil {
+			hs = []hint{{"↑↓", "navigate"}, {"⏎", "go to verse"}, {"esc", "close"}}
+			if m.searchPages() > 1 {
+				hs = append(hs[:2:2], hint{"n/p", "page"}, hs[2])
+			}
+		} else {
+			hs = []hint{{"⏎", "search"}, {"↑↓", "history"}, {"ctrl+r", "all"}, {"esc", "close"}}
+		}
+	case modeComparison:
+		hs = []hint{{"↑↓", "scroll"}, {"/", "filter"}, {"=", "differences"}, {"r", "reader"}, {"P", "pager"}, {"X", "export"}, {"esc", "back"}}
+		if m.comparisonFiltering {
+			hs = []hint{{"⏎", "done"}, {"esc", "clear"}}
+		}
+	case modeSearch:
+		hs = []hint{{"⏎", "go"}, {"↑↓", "history"}, {"ctrl+r", "all"}, {"esc", "cancel"}}
+	case modeHistory:
+		hs = []hint{{"↑↓", "navigate"}, {"⏎", "use"}, {"esc", "back"}}
+	case modeWorkspace:
+		if m.workspaceEditing {
+			hs = []hint{{"⏎", "save note"}, {"esc", "cancel"}}
+		} else {
+			hs = []hint{{"↑↓", "navigate"}, {"J/K", "reorder"}, {"e", "note"}, {"x", "remove"}, {"m", "export"}, {"⏎", "open"}, {"esc", "close"}}
+		}
+	case modeBookmarks:
+		hs = []hint{{"↑↓", "navigate"}, {"x", "remove"}, {"⏎", "open"}, {"esc", "close"}}
+	case modeAnnotations:
+		hs = []hint{{"type", "filter"}, {"↑↓", "navigate"}, {"⏎", "open"}, {"esc", "close"}}
+	case modeChapterGrid:
+		hs = []hint{{"0-9", "chapter"}, {"←→↑↓", "move"}, {"⏎", "open"}, {"esc", "close"}}
+	case modeActivity:
+		if m.weekReport != nil {
+			hs = []hint{{"←→", "week"}, {"m", "export"}, {"w", "heat list"}, {"esc", "close"}}
+		} else {
+			hs = []hint{{"↑↓", "navigate"}, {"tab", "chapters/books"}, {"w", "week"}, {"⏎", "open"}, {"esc", "close"}}
+		}
+	case modeReader:
+		if m.markPending == "m" {
+			hs = []hint{{"a-z", "set mark"}, {"esc", "cancel"}}
+			break
+		}
+		if m.markPending == "'" {
+			hs = []hint{{"a-z", "jump to mark"}, {"'", "back"}, {"esc", "cancel"}}
+			break
+		}
+		if m.wordSelect {
+			hs = []hint{{"h/l", "word"}, {"o", "other end"}, {"y", "yank phrase"}, {"esc", "done"}}
+			break
+		}
+		if l, ok := m.studyLayout(); ok && m.focus == paneStudy && m.studyFocus < len(l.Panes) && l.Panes[m.studyFocus] == studyCrossrefs {
+			x := hint{"x", "reader"}
+			if m.cfg.StudyLayout == crossrefsLayout {
+				x = hint{"x", "close"}
+			}
+			hs = []hint{{"j/k", "select"}, {"⏎", "go to verse"}, {"tab", "focus"}, x}
+			break
+		}
+		if m.yankAppend {
+			hs = append(hs, hint{"+", fmt.Sprintf("appending (%d)", len(m.yanked))})
+		}
+		fallthrough
+	default:
+		hs = append(hs, []hint{
+			{"tab", "focus"},
+			{"⏎", "open"},
+			{"n/p", "chapter"},
+			{"t", "translation"},
+			{"T", "theme"},
+			{"/", "verse"},
+			{"s", "search"},
+			{"?", "about"},
+			{"q", "quit"},
+		}...)
+	}
+
+	return hs
+}
+
+// renderHints joins hints as "key label  ·  key label". Each part
+// bundles the key + leading space + label into a single muted Render so
+// the gap between the styled key and label gets the pane background (raw
+// spaces between two styled spans would inherit the terminal default and
+// show through as black blocks).
+func renderHints(s styles, hs []hint) string {
+	var parts []string
+	for _, h := range hs {
+		parts = append(parts, s.accent.Render(h.k)+s.muted.Render(" "+locale.T(h.label)))
+	}
+	return strings.Join(parts, s.muted.Render("  ·  "))
+}
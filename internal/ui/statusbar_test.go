@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"sword-tui/internal/locale"
+)
+
+func TestStatusBarView(t *testing.T) {
+	hints := []hint{{"t", "translation"}, {"q", "quit"}}
+	tests := []struct {
+		name   string
+		bar    statusBar
+		width  int
+		want   []string
+		unwant []string
+	}{
+		{"hints and state", statusBar{hints: hints, right: "online"}, 80, []string{"t translation  ·  q quit", "online"}, nil},
+		{"hints give way first", statusBar{hints: hints, right: "online"}, 20, []string{"online"}, []string{"quit"}},
+		{"long message", statusBar{hints: hints, right: strings.Repeat("slow ", 30)}, 80, []string{"slow slow", "…"}, nil},
+		{"long message, narrowest terminal", statusBar{hints: hints, right: strings.Repeat("slow ", 30)}, 60, []string{"…"}, nil},
+		{"prompt", statusBar{hints: hints, prompt: func(int) string { return "note: " }, right: "online"}, 80, []string{"note:", "online"}, []string{"quit"}},
+	}
+	for _, th := range barThemes {
+		for _, tt := range tests {
+			t.Run(th.Name+"/"+tt.name, func(t *testing.T) {
+				out := tt.bar.View(newStyles(th, true), tt.width)
+				checkBar(t, out, th, tt.width)
+				row := middleRow(out)
+				for _, w := range tt.want {
+					if !strings.Contains(row, w) {
+						t.Errorf("status bar %q doesn't show %q", row, w)
+					}
+				}
+				for _, w := range tt.unwant {
+					if strings.Contains(row, w) {
+						t.Errorf("status bar %q shows %q", row, w)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestStatusBarPromptWidth(t *testing.T) {
+	got := 0
+	b := statusBar{prompt: func(width int) string { got = width; return "" }, right: "online"}
+	b.View(newStyles(barThemes[0], true), 80)
+	// 80 less the cell either side, the border, the padding and the
+	// safety margin, less "online" and the gap before it.
+	if want := 80 - 6 - len("online") - 1; got != want {
+		t.Errorf("prompt got %d cells, want %d", got, want)
+	}
+}
+
+func TestStatusBarHintsTranslated(t *testing.T) {
+	if err := locale.Set("es"); err != nil {
+		t.Fatal(err)
+	}
+	defer locale.Set("")
+	b := statusBar{hints: []hint{{"q", "quit"}}}
+	if row := middleRow(b.View(newStyles(barThemes[0], true), 80)); !strings.Contains(row, "q salir") {
+		t.Errorf("status bar %q isn't in Spanish", row)
+	}
+}
@@ -0,0 +1,509 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/commentary"
+	"sword-tui/internal/crossref"
+	"sword-tui/internal/locale"
+	"sword-tui/internal/settings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// The study view (|) adds a column of panes beside the reader: cross
+// references, the notes and the commentary on the highlighted verse. Tab
+// cycles the focus through the books, the reader and each pane; a focused
+// pane scrolls with j/k, and Enter follows a cross reference or opens
+// the notes. Which panes show, and how wide the column is, comes from
+// a named layout in settings; | steps through the layouts and then off.
+// x opens the cross references on their own.
+
+// The study panes, as named in layouts.
+const (
+	studyCrossrefs  = "crossrefs"
+	studyNotes      = "notes"
+	studyCommentary = "commentary"
+)
+
+// studyPaneKinds are the panes a layout may use.
+var studyPaneKinds = []string{studyCrossrefs, studyNotes, studyCommentary}
+
+// defaultStudyLayout is the built-in layout, with every pane.
+const defaultStudyLayout = "study"
+
+// crossrefsLayout is the built-in layout x opens: the cross references
+// alone. It isn't among the layouts | steps through.
+const crossrefsLayout = "crossrefs"
+
+// Bounds of a layout's width, in percent of the reading area.
+const (
+	studyMinWidth     = 20
+	studyMaxWidth     = 70
+	studyDefaultWidth = 40
+)
+
+// crossrefsLoadedMsg delivers the cross-reference list, read in the
+// background the first time the study view opens.
+type crossrefsLoadedMsg struct {
+	index *crossref.Index
+	err   error
+}
+
+func loadCrossrefs() tea.Cmd {
+	return func() tea.Msg {
+		idx, err := crossref.Load()
+		return crossrefsLoadedMsg{idx, err}
+	}
+}
+
+// studyLayouts returns the layouts in cfg with the built-in one.
+func studyLayouts(cfg settings.Settings) map[string]settings.StudyLayout {
+	all := map[string]settings.StudyLayout{
+		defaultStudyLayout: {Panes: studyPaneKinds},
+		crossrefsLayout:    {Panes: []string{studyCrossrefs}, Width: 30},
+	}
+	for name, l := range cfg.StudyLayouts {
+		all[name] = l
+	}
+	return all
+}
+
+// studyLayoutNames returns the layout names in the order | steps
+// through them: the built-in one, then the rest by name.
+func studyLayoutNames(cfg settings.Settings) []string {
+	names := []string{defaultStudyLayout}
+	for name := range cfg.StudyLayouts {
+		if name != defaultStudyLayout {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// studyLayout returns the layout on screen, or false when the study view
+// is off.
+func (m Model) studyLayout() (settings.StudyLayout, bool) {
+	if m.cfg.StudyLayout == "" {
+		return settings.StudyLayout{}, false
+	}
+	l, ok := studyLayouts(m.cfg)[m.cfg.StudyLayout]
+	if !ok {
+		return settings.StudyLayout{}, false
+	}
+	var panes []string
+	for _, p := range l.Panes {
+		if slices.Contains(studyPaneKinds, p) && !slices.Contains(panes, p) {
+			panes = append(panes, p)
+		}
+	}
+	l.Panes = panes
+	if l.Width < studyMinWidth || l.Width > studyMaxWidth {
+		l.Width = studyDefaultWidth
+	}
+	return l, len(panes) > 0
+}
+
+// studyWidth is the outer width of the study column, 0 when it is off.
+func (m Model) studyWidth() int {
+	l, ok := m.studyLayout()
+	if !ok {
+		return 0
+	}
+	right := m.width - leftPaneOuterWidth
+	return max(right*l.Width/100, 24)
+}
+
+// cycleStudyLayout steps to the next layout, or turns the study view
+// off after the last one.
+func (m *Model) cycleStudyLayout() tea.Cmd {
+	names := studyLayoutNames(m.cfg)
+	next := ""
+	if i := slices.Index(names, m.cfg.StudyLayout); i < 0 {
+		next = names[0]
+	} else if i+1 < len(names) {
+		next = names[i+1]
+	}
+	m.cfg.StudyLayout = next
+	if m.focus == paneStudy {
+		m.focus = paneContent
+	}
+	m.studyFocus = 0
+	m.resizeReader()
+
+	if next == "" {
+		return m.flash("study view off")
+	}
+	var cmd tea.Cmd
+	if m.crossrefs == nil && m.crossrefErr == nil {
+		cmd = loadCrossrefs()
+	}
+	return tea.Batch(cmd, m.flash(locale.Tf("study layout: %s", next)))
+}
+
+// toggleCrossrefs shows the cross references of the highlighted verse
+// in a side panel and gives it the focus, so j/k pick one and Enter
+// jumps there. A layout already on screen with them is used as it is.
+// Pressed again, x hands the focus back to the reader and closes the
+// panel it opened.
+func (m *Model) toggleCrossrefs() tea.Cmd {
+	l, ok := m.studyLayout()
+	i := slices.Index(l.Panes, studyCrossrefs)
+	if ok && i >= 0 && m.focus == paneStudy && m.studyFocus == i {
+		m.focus = paneContent
+		if m.cfg.StudyLayout == crossrefsLayout {
+			m.cfg.StudyLayout = m.layoutBeforeCrossrefs
+			m.studyFocus = 0
+			m.resizeReader()
+		}
+		return nil
+	}
+	if m.highlightedVerseStart == 0 {
+		return m.flash("select a verse to see its cross references")
+	}
+	if !ok || i < 0 {
+		m.layoutBeforeCrossrefs = m.cfg.StudyLayout
+		m.cfg.StudyLayout = crossrefsLayout
+		i = 0
+		m.resizeReader()
+	}
+	m.focus = paneStudy
+	m.studyFocus = i
+	if m.crossrefs == nil && m.crossrefErr == nil {
+		return loadCrossrefs()
+	}
+	return nil
+}
+
+// cycleFocus moves the focus on through the books, the reader and the
+// study panes, by dir (1 or -1).
+func (m *Model) cycleFocus(dir int) {
+	var stops []int // -2 books, -1 reader, i study pane i
+	stops = append(stops, -2, -1)
+	if l, ok := m.studyLayout(); ok {
+		for i := range l.Panes {
+			stops = append(stops, i)
+		}
+	}
+	cur := -1
+	switch m.focus {
+	case paneBooks:
+		cur = -2
+	case paneStudy:
+		cur = m.studyFocus
+	}
+	i := (slices.Index(stops, cur) + dir + len(stops)) % len(stops)
+	switch next := stops[i]; next {
+	case -2:
+		m.focus = paneBooks
+		if m.books != nil && m.sidebarSelected == 0 {
+			for i, book := range m.books {
+				if book.BookID == m.currentBook {
+					m.sidebarSelected = i
+					break
+				}
+			}
+		}
+	case -1:
+		m.focus = paneContent
+	default:
+		m.focus = paneStudy
+		m.studyFocus = next
+	}
+}
+
+// studyVerse is the verse the panes are about.
+func (m Model) studyVerse() verseKey {
+	return verseKey{m.currentBook, m.currentChapter, m.highlightedVerseStart}
+}
+
+// studyPosition returns the cross reference selected and how far each
+// pane is scrolled, all zero once the highlighted verse has moved on.
+func (m Model) studyPosition() (int, [3]int) {
+	if m.studyAt != m.studyVerse() {
+		return 0, [3]int{}
+	}
+	return m.studySelected, m.studyScroll
+}
+
+// updateStudy handles keys while a study pane has the focus. Keys it
+// doesn't use go on to the main switch.
+func (m Model) updateStudy(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	l, ok := m.studyLayout()
+	if !ok || m.studyFocus >= len(l.Panes) {
+		m.focus = paneContent
+		return m, nil, false
+	}
+	m.studySelected, m.studyScroll = m.studyPosition()
+	m.studyAt = m.studyVerse()
+	pane := l.Panes[m.studyFocus]
+	kind := slices.Index(studyPaneKinds, pane)
+
+	step := 0
+	switch msg.String() {
+	case "down", "j":
+		step = 1
+	case "up", "k":
+		step = -1
+	case "pgdown", "space":
+		step = 10
+	case "pgup":
+		step = -10
+	case "enter":
+		switch pane {
+		case studyCrossrefs:
+			refs := m.crossrefs.For(m.currentBook, m.currentChapter, m.highlightedVerseStart)
+			if m.studySelected < len(refs) {
+				ref := crossrefText(refs[m.studySelected])
+				if name, ok := m.missingBook(ref); ok {
+					return m, m.flash(locale.Tf("%s is not in %s", name, m.selectedTranslation)), true
+				}
+				next, cmd := m.gotoReference(ref)
+				next.focus = paneContent
+				return next, cmd, true
+			}
+		case studyNotes:
+			cmd := m.openNotes()
+			return m, cmd, true
+		}
+		return m, nil, true
+	default:
+		return m, nil, false
+	}
+
+	w, h := m.studyPaneSize(m.studyFocus)
+	lines, _ := m.studyPaneLines(pane, w, true)
+	if pane == studyCrossrefs {
+		m.studySelected = max(min(m.studySelected+step, len(lines)-1), 0)
+	} else {
+		m.studyScroll[kind] = max(min(m.studyScroll[kind]+step, len(lines)-h), 0)
+	}
+	return m, nil, true
+}
+
+// crossrefText formats a cross reference so gotoReference accepts it.
+func crossrefText(r crossref.Ref) string {
+	name := fmt.Sprint(r.Book)
+	if b, ok := api.CanonicalBook(r.Book); ok {
+		name = b.Name
+	}
+	if r.VerseEnd > r.VerseStart {
+		return fmt.Sprintf("%s %d:%d-%d", name, r.Chapter, r.VerseStart, r.VerseEnd)
+	}
+	return fmt.Sprintf("%s %d:%d", name, r.Chapter, r.VerseStart)
+}
+
+// renderStudyColumn draws the study panes stacked in a column outerW
+// wide and outerH tall.
+func (m Model) renderStudyColumn(outerW, outerH int) string {
+	l, _ := m.studyLayout()
+	n := len(l.Panes)
+	var panes []string
+	for i, pane := range l.Panes {
+		h := outerH / n
+		if i == n-1 {
+			h = outerH - (n-1)*(outerH/n)
+		}
+		panes = append(panes, m.renderStudyPane(i, pane, outerW, h))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, panes...)
+}
+
+// studyPaneSize returns the content width and height of study pane i:
+// inside the border and padding, below the title.
+func (m Model) studyPaneSize(i int) (int, int) {
+	l, _ := m.studyLayout()
+	n := max(len(l.Panes), 1)
+	bodyH := max(m.height-headerOuterHeight-statusOuterHeight, 5)
+	h := bodyH / n
+	if i == n-1 {
+		h = bodyH - (n-1)*(bodyH/n)
+	}
+	return max(m.studyWidth()-2-2, 8), max(h-2-1, 1)
+}
+
+func (m Model) renderStudyPane(i int, pane string, outerW, outerH int) string {
+	bg := m.currentTheme.Background
+	focused := m.focus == paneStudy && m.studyFocus == i && !m.overlayActive()
+	border := m.currentTheme.Border
+	if focused {
+		border = m.currentTheme.BorderActive
+	}
+	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Bold(m.styled())
+	if focused {
+		titleStyle = titleStyle.Foreground(m.currentTheme.Accent)
+	}
+
+	innerW, innerH := m.studyPaneSize(i)
+	lines, title := m.studyPaneLines(pane, innerW, focused)
+
+	selected, scroll := m.studyPosition()
+	top := 0
+	if pane == studyCrossrefs {
+		top = m.overlayWindowStart(selected, len(lines), innerH)
+	} else {
+		top = min(scroll[slices.Index(studyPaneKinds, pane)], max(len(lines)-innerH, 0))
+	}
+	shown := lines[min(top, len(lines)):min(top+innerH, len(lines))]
+
+	pad := lipgloss.NewStyle().Background(bg).Width(innerW).MaxWidth(innerW)
+	for i, line := range shown {
+		shown[i] = pad.Render(line)
+	}
+	body := pad.Render(titleStyle.Render(title)) + "\n" + strings.Join(shown, "\n")
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(border).
+		BorderBackground(bg).
+		Background(bg).
+		Width(outerW).
+		Height(outerH).
+		MaxHeight(outerH).
+		Padding(0, 1).
+		Render(body)
+}
+
+// studyPaneLines renders a pane's content width cells wide, with its
+// title.
+func (m Model) studyPaneLines(pane string, width int, focused bool) ([]string, string) {
+	bg := m.currentTheme.Background
+	textStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Primary).Background(bg)
+	mutedStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(m.styled())
+	selectedStyle := lipgloss.NewStyle().Foreground(bg).Background(m.currentTheme.Accent).Bold(m.styled())
+	verse := m.highlightedVerseStart
+	here := fmt.Sprintf("%d:%d", m.currentChapter, verse)
+
+	wrapped := func(text string, style lipgloss.Style) []string {
+		var out []string
+		for _, para := range strings.Split(text, "\n") {
+			for _, l := range strings.Split(wrapText(para, width), "\n") {
+				out = append(out, style.Render(l))
+			}
+		}
+		return out
+	}
+	if verse == 0 {
+		return wrapped("Select a verse.", mutedStyle), paneTitle(pane, "")
+	}
+
+	switch pane {
+	case studyCrossrefs:
+		switch {
+		case m.crossrefErr != nil && os.IsNotExist(m.crossrefErr):
+			p, _ := crossref.Path()
+			return wrapped("No cross references. Save OpenBible.info's "+crossref.FileName+" as "+p+".", mutedStyle), paneTitle(pane, here)
+		case m.crossrefErr != nil:
+			return wrapped(m.crossrefErr.Error(), mutedStyle), paneTitle(pane, here)
+		case m.crossrefs == nil:
+			return wrapped(locale.T("Loading…"), mutedStyle), paneTitle(pane, here)
+		}
+		refs := m.crossrefs.For(m.currentBook, m.currentChapter, verse)
+		if len(refs) == 0 {
+			return wrapped("None for this verse.", mutedStyle), paneTitle(pane, here)
+		}
+		selected, _ := m.studyPosition()
+		var lines []string
+		for i, r := range refs {
+			ref := clipText(crossrefText(r), width-2)
+			if focused && i == selected {
+				line := "▸ " + ref
+				lines = append(lines, selectedStyle.Render(line+strings.Repeat(" ", max(width-lipgloss.Width(line), 0))))
+				continue
+			}
+			lines = append(lines, textStyle.Render("  "+ref))
+		}
+		return lines, paneTitle(pane, fmt.Sprintf("%s · %d", here, len(refs)))
+
+	case studyNotes:
+		var lines []string
+		for i, n := range m.chapterNotes[verse] {
+			if i > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, mutedStyle.Render(n.Time.Format("2006-01-02 15:04")))
+			lines = append(lines, wrapped(n.Text, textStyle)...)
+		}
+		if k := len(m.backlinksHere()); k > 0 {
+			if len(lines) > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, mutedStyle.Render(fmt.Sprintf("↩ mentioned in %d other notes (L)", k)))
+		}
+		if len(lines) == 0 {
+			return wrapped("No notes — a adds one.", mutedStyle), paneTitle(pane, here)
+		}
+		return lines, paneTitle(pane, here)
+
+	case studyCommentary:
+		c := m.chapterCommentary
+		if s := c.For(verse); s != nil {
+			span := fmt.Sprintf("%d:%d", m.currentChapter, s.Start)
+			if s.End > s.Start {
+				span += fmt.Sprintf("-%d", s.End)
+			}
+			return wrapped(s.Text, textStyle), paneTitle(pane, span)
+		}
+		if c.Intro != "" {
+			return wrapped(c.Intro, textStyle), paneTitle(pane, fmt.Sprintf("%s %d", m.currentBookName, m.currentChapter))
+		}
+		dir, _ := commentary.Dir()
+		file := filepath.Join(dir, fmt.Sprintf("%02d-%03d.md", m.currentBook, m.currentChapter))
+		return wrapped("No commentary here. Write some in "+file+".", mutedStyle), paneTitle(pane, here)
+	}
+	return nil, pane
+}
+
+// paneTitle is a study pane's title line, e.g. "Cross references 3:16".
+func paneTitle(pane, what string) string {
+	names := map[string]string{
+		studyCrossrefs:  "Cross references",
+		studyNotes:      "Notes",
+		studyCommentary: "Commentary",
+	}
+	if what == "" {
+		return names[pane]
+	}
+	return names[pane] + "  " + what
+}
+
+// resizeReader sizes the viewport to the reading area and lays the
+// chapter out again, after the window or the study column changes.
+func (m *Model) resizeReader() {
+	vpW, vpH := m.viewportSize()
+	m.viewport.SetWidth(vpW)
+	m.viewport.SetHeight(vpH)
+	if m.currentVerses != nil {
+		m.content, m.verseOffsets = m.layoutChapter(vpW)
+	} else if m.currentParallelVerses != nil {
+		m.parallel = layoutParallel(m.currentParallelVerses, m.comparisonTranslations, vpW, m.comparisonFilter())
+		m.content = m.parallel.placeholder()
+	}
+	m.viewport.SetContent(m.content)
+	if m.mode == modeAbout {
+		m.layoutAbout()
+	}
+}
+
+// studyPaneAt returns the study pane under screen row y.
+func (m Model) studyPaneAt(y int) (int, bool) {
+	l, ok := m.studyLayout()
+	if !ok {
+		return 0, false
+	}
+	bodyH := max(m.height-headerOuterHeight-statusOuterHeight, 5)
+	n := len(l.Panes)
+	i := (y - headerOuterHeight) / max(bodyH/n, 1)
+	if y < headerOuterHeight || y >= headerOuterHeight+bodyH {
+		return 0, false
+	}
+	return min(i, n-1), true
+}
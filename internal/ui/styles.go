@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"sword-tui/internal/theme"
+
+	"charm.land/lipgloss/v2"
+)
+
+// styles are the lipgloss styles the bars and panes share. They are built
+// once per theme (see applyNightLight) rather than on every frame.
+type styles struct {
+	bg      lipgloss.Style // the pane background, for gaps and padding
+	text    lipgloss.Style
+	muted   lipgloss.Style
+	dim     lipgloss.Style // italic muted: asides such as "↑ 3 more"
+	accent  lipgloss.Style // bold: titles, logo, key names
+	success lipgloss.Style
+	warning lipgloss.Style
+	// selected is the highlighted row of a list.
+	selected lipgloss.Style
+
+	strongSuccess lipgloss.Style
+	strongWarning lipgloss.Style
+	strongError   lipgloss.Style
+
+	// activeBar frames the header, bar the status bar. Both still need
+	// a width.
+	activeBar lipgloss.Style
+	bar       lipgloss.Style
+}
+
+// newStyles builds the shared styles for th. Bold is dropped when styled
+// is false (see plain_text).
+func newStyles(th theme.Theme, styled bool) styles {
+	bg := lipgloss.NewStyle().Background(th.Background)
+	bar := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(th.Border).
+		BorderBackground(th.Background).
+		Background(th.Background).
+		Padding(0, 1)
+	return styles{
+		bg:            bg,
+		text:          bg.Foreground(th.Primary),
+		muted:         bg.Foreground(th.Muted),
+		dim:           bg.Foreground(th.Muted).Italic(styled),
+		accent:        bg.Foreground(th.Accent).Bold(styled),
+		success:       bg.Foreground(th.Success),
+		warning:       bg.Foreground(th.Warning),
+		selected:      lipgloss.NewStyle().Foreground(th.Background).Background(th.Accent).Bold(styled),
+		strongSuccess: bg.Foreground(th.Success).Bold(styled),
+		strongWarning: bg.Foreground(th.Warning).Bold(styled),
+		strongError:   bg.Foreground(th.Error).Bold(styled),
+		activeBar:     bar.BorderForeground(th.BorderActive),
+		bar:           bar,
+	}
+}
@@ -0,0 +1,104 @@
+package ui
+
+import "slices"
+
+// The testament headers in the books sidebar fold away: Enter or space on
+// one hides its books behind it, and again shows them. Which are folded
+// is kept in collapsed_testaments and saved with the other settings on
+// quit.
+
+// The testaments, as collapsed_testaments names them (with
+// testamentFavorites).
+const (
+	testamentOld = "old"
+	testamentNew = "new"
+)
+
+// sidebarRow is one line of the books sidebar: a section's header, or
+// one of its books.
+type sidebarRow struct {
+	header  bool
+	section int // index into bookSections
+	bookIdx int // index into m.books, for a book
+}
+
+// testamentCollapsed reports whether the testament's books are folded
+// away.
+func (m Model) testamentCollapsed(testament string) bool {
+	return slices.Contains(m.cfg.CollapsedTestaments, testament)
+}
+
+// sidebarRows lists the sidebar's lines, leaving out folded books.
+func (m Model) sidebarRows() []sidebarRow {
+	var rows []sidebarRow
+	for n, sec := range m.bookSections() {
+		rows = append(rows, sidebarRow{header: true, section: n})
+		if m.testamentCollapsed(sec.testament) {
+			continue
+		}
+		for _, i := range sec.books {
+			rows = append(rows, sidebarRow{section: n, bookIdx: i})
+		}
+	}
+	return rows
+}
+
+// sidebarCursor returns the index in rows of the selected line. A book
+// listed twice, as a favorite and in its testament, is found in the
+// section it was selected in; a selected book that is folded away
+// selects its header.
+func (m Model) sidebarCursor(rows []sidebarRow) int {
+	for i, r := range rows {
+		if r.header == m.sidebarOnHeader && r.section == m.sidebarSection && (r.header || r.bookIdx == m.sidebarSelected) {
+			return i
+		}
+	}
+	if m.sidebarOnHeader {
+		return -1
+	}
+	for i, r := range rows {
+		if !r.header && r.bookIdx == m.sidebarSelected {
+			return i
+		}
+	}
+	for n, sec := range m.bookSections() {
+		if slices.Contains(sec.books, m.sidebarSelected) {
+			return slices.IndexFunc(rows, func(r sidebarRow) bool { return r.header && r.section == n })
+		}
+	}
+	return -1
+}
+
+// moveSidebar moves the sidebar selection delta lines, headers included.
+func (m *Model) moveSidebar(delta int) {
+	rows := m.sidebarRows()
+	if len(rows) == 0 {
+		return
+	}
+	i := min(max(m.sidebarCursor(rows)+delta, 0), len(rows)-1)
+	m.selectSidebarRow(rows[i])
+}
+
+// selectSidebarRow selects r in the sidebar.
+func (m *Model) selectSidebarRow(r sidebarRow) {
+	m.sidebarOnHeader = r.header
+	m.sidebarSection = r.section
+	if !r.header {
+		m.sidebarSelected = r.bookIdx
+	}
+}
+
+// toggleTestament folds the selected header's testament away, or opens
+// it again.
+func (m *Model) toggleTestament() {
+	secs := m.bookSections()
+	if !m.sidebarOnHeader || m.sidebarSection >= len(secs) {
+		return
+	}
+	t := secs[m.sidebarSection].testament
+	if i := slices.Index(m.cfg.CollapsedTestaments, t); i >= 0 {
+		m.cfg.CollapsedTestaments = slices.Delete(slices.Clone(m.cfg.CollapsedTestaments), i, i+1)
+		return
+	}
+	m.cfg.CollapsedTestaments = append(slices.Clone(m.cfg.CollapsedTestaments), t)
+}
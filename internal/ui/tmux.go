@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// defaultTmuxTarget is tmux's name for the previously active pane, which
+// is usually the editor next to the reader.
+const defaultTmuxTarget = "{last}"
+
+// tmuxSentMsg reports the outcome of sendToTmux.
+type tmuxSentMsg struct {
+	target string
+	err    error
+}
+
+// sendToTmux types text into the tmux pane target (the previously active
+// pane when empty) with `tmux send-keys -l`, so it lands wherever that
+// pane's cursor is. Trailing newlines are dropped so nothing is submitted.
+func sendToTmux(target, text string) tea.Cmd {
+	if target == "" {
+		target = defaultTmuxTarget
+	}
+	return func() tea.Msg {
+		if os.Getenv("TMUX") == "" {
+			return tmuxSentMsg{target, errors.New("not running inside tmux")}
+		}
+		text := strings.TrimRight(text, "\n")
+		out, err := exec.Command("tmux", "send-keys", "-t", target, "-l", "--", text).CombinedOutput()
+		if err != nil {
+			if msg := strings.TrimSpace(string(out)); msg != "" {
+				err = fmt.Errorf("%s", msg)
+			}
+		}
+		return tmuxSentMsg{target, err}
+	}
+}
@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/reference"
+)
+
+// translation_names shows translations under names of the reader's
+// choosing ("NASB 2020" for NASB) in the header, the comparison columns,
+// the pickers and copied text. translation_aliases are abbreviations for
+// them: the picker filter finds a translation by its aliases, and a
+// reference typed with / can name one before or after it ("n john 3:16",
+// "john 3:16 kjv") to switch to it.
+
+// translationName is how the translation short is shown.
+func (m Model) translationName(short string) string {
+	if name := m.cfg.TranslationNames[short]; name != "" {
+		return name
+	}
+	return short
+}
+
+// translationAliases lists the aliases of the translation short.
+func (m Model) translationAliases(short string) []string {
+	var out []string
+	for alias, t := range m.cfg.TranslationAliases {
+		if strings.EqualFold(t, short) {
+			out = append(out, alias)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// findTranslation resolves a word to a translation short name, by alias
+// or by short name, ignoring case.
+func (m Model) findTranslation(word string) (string, bool) {
+	for alias, t := range m.cfg.TranslationAliases {
+		if strings.EqualFold(alias, word) {
+			return m.canonicalTranslation(t), true
+		}
+	}
+	for _, t := range m.translations {
+		if strings.EqualFold(t.ShortName, word) {
+			return t.ShortName, true
+		}
+	}
+	for _, t := range m.cachedTranslations {
+		if strings.EqualFold(t, word) {
+			return t, true
+		}
+	}
+	return "", false
+}
+
+// canonicalTranslation spells short as the translation list does.
+func (m Model) canonicalTranslation(short string) string {
+	for _, t := range m.translations {
+		if strings.EqualFold(t.ShortName, short) {
+			return t.ShortName
+		}
+	}
+	for _, t := range m.cachedTranslations {
+		if strings.EqualFold(t, short) {
+			return t
+		}
+	}
+	return short
+}
+
+// splitTranslation takes a translation named at either end of a typed
+// reference off it. Words that are numbers are never translations, so
+// "john 3 16" stays whole.
+func (m Model) splitTranslation(ref string) (string, string) {
+	fields := strings.Fields(ref)
+	if len(fields) < 2 {
+		return ref, ""
+	}
+	isNumber := func(s string) bool { return strings.IndexFunc(s, func(r rune) bool { return !unicode.IsDigit(r) }) < 0 }
+	if last := fields[len(fields)-1]; !isNumber(last) {
+		if t, ok := m.findTranslation(last); ok {
+			return strings.Join(fields[:len(fields)-1], " "), t
+		}
+	}
+	if first := fields[0]; !isNumber(first) {
+		if t, ok := m.findTranslation(first); ok {
+			return strings.Join(fields[1:], " "), t
+		}
+	}
+	return ref, ""
+}
+
+// parseReference parses a typed reference, taking off a translation
+// named at either end ("john 3:16 kjv") when what's left still parses.
+// The translation is the current one when none is named.
+func (m Model) parseReference(ref string, books []api.Book) (book, chapter, verseStart, verseEnd int, translation string, err error) {
+	if rest, t := m.splitTranslation(ref); t != "" {
+		if book, chapter, verseStart, verseEnd, err = reference.Parse(rest, books); err == nil {
+			return book, chapter, verseStart, verseEnd, t, nil
+		}
+	}
+	book, chapter, verseStart, verseEnd, err = reference.Parse(ref, books)
+	return book, chapter, verseStart, verseEnd, m.selectedTranslation, err
+}
+
+// translationLabel is a translation's line in the pickers.
+func (m Model) translationLabel(short, full string) string {
+	if name := m.translationName(short); name != short {
+		full = fmt.Sprintf("%s (%s)", name, full)
+	}
+	return fmt.Sprintf("%-6s · %s", short, full)
+}
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// O opens the comparison on the highlighted verses (or the chapter, with
+// none highlighted) in the study_trio translations: by default the
+// original language, a literal and a dynamic translation side by side.
+// An entry "A/B" reads A in the Old Testament and B in the New, so one
+// preset serves both the Hebrew and the Greek.
+
+// defaultStudyTrio is the trio when study_trio isn't set.
+var defaultStudyTrio = []string{"WLC/TR", "YLT", "NLT"}
+
+// checkStudyTrio reports a study_trio entry that names no translation.
+func checkStudyTrio(trio []string) error {
+	var errs []error
+	for _, t := range trio {
+		parts := strings.Split(t, "/")
+		if len(parts) > 2 || slices.ContainsFunc(parts, func(p string) bool { return strings.TrimSpace(p) == "" }) {
+			errs = append(errs, fmt.Errorf("study_trio: %q is not a translation or an Old/New Testament pair like \"WLC/TR\"", t))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// studyTrio resolves study_trio for book, by alias as well as short
+// name.
+func (m Model) studyTrio(book int) []string {
+	trio := m.cfg.StudyTrio
+	if len(trio) == 0 {
+		trio = defaultStudyTrio
+	}
+	out := make([]string, 0, len(trio))
+	for _, t := range trio {
+		ot, nt, pair := strings.Cut(t, "/")
+		t = ot
+		if pair && book >= 40 {
+			t = nt
+		}
+		t = strings.TrimSpace(t)
+		if short, ok := m.findTranslation(t); ok {
+			t = short
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
+// openStudyTrio compares the highlighted verses in the study trio.
+func (m *Model) openStudyTrio() tea.Cmd {
+	m.comparisonTranslations = m.studyTrio(m.currentBook)
+	m.comparisonVerses = [2]int{m.highlightedVerseStart, max(m.highlightedVerseEnd, m.highlightedVerseStart)}
+	m.comparisonFilterInput.SetValue("")
+	m.mode = modeComparison
+	m.loading = true
+	return loadParallelVerses(m.client, m.gen.nextContent(), m.comparisonTranslations, m.currentBook, m.currentChapter, m.comparisonVerseList())
+}
@@ -0,0 +1,204 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"sword-tui/internal/cache"
+	"sword-tui/internal/locale"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// u in the cache manager downloads a translation again, through the
+// same queue as new downloads. The new copy waits beside the old one
+// while a popup lists the chapters that differ; enter swaps it in and
+// esc keeps the old text.
+
+// updateReadyMsg is an update downloaded and compared with the copy on
+// disk.
+type updateReadyMsg struct {
+	translation string
+	changes     []cache.ChapterChange
+}
+
+func updateTranslation(c CacheInterface, translation string) tea.Cmd {
+	return func() tea.Msg {
+		changes, err := c.UpdateTranslation(translation)
+		if err != nil {
+			return downloadErrorMsg{translation, err}
+		}
+		return updateReadyMsg{translation, changes}
+	}
+}
+
+// queueUpdate queues translation i, downloaded already, to be
+// downloaded again.
+func (m *Model) queueUpdate(i int) tea.Cmd {
+	t := m.translations[i]
+	if m.cache == nil || t.Local || !m.cache.IsCached(t.ShortName) || m.queued(t.ShortName) {
+		return nil
+	}
+	if m.downloads.updates == nil {
+		m.downloads.updates = map[string]bool{}
+	}
+	m.downloads.updates[t.ShortName] = true
+	m.downloads.waiting = append(m.downloads.waiting, t.ShortName)
+	if m.downloadingTranslation != "" {
+		return nil
+	}
+	return m.nextDownload()
+}
+
+// updateReady settles an update that changes nothing, or opens the
+// popup reviewing it.
+func (m *Model) updateReady(msg updateReadyMsg) tea.Cmd {
+	if len(msg.changes) == 0 {
+		if err := m.cache.ApplyUpdate(msg.translation); err != nil {
+			m.err = err
+			return nil
+		}
+		return m.flash(locale.Tf("%s is up to date", msg.translation))
+	}
+	m.updateTop = 0
+	m.pushOverlay(overlay{
+		name:  overlayUpdate + msg.translation,
+		place: placeCenter,
+		dim:   true,
+		view: func(m Model) string {
+			return m.renderUpdate(msg.translation, msg.changes)
+		},
+		key: func(m Model, key tea.KeyMsg) (Model, tea.Cmd, bool) {
+			return m.updateReview(msg.translation, msg.changes, key)
+		},
+		closed: func(m *Model) {
+			// Kept the old text, unless enter applied the update first.
+			m.cache.DiscardUpdate(msg.translation)
+		},
+	})
+	return nil
+}
+
+func (m Model) updateReview(translation string, changes []cache.ChapterChange, msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	w, h := m.popupSize()
+	h = max(h-2, 1)
+	last := max(len(m.updateLines(changes, w))-h, 0)
+	switch msg.String() {
+	case "down", "j":
+		m.updateTop = min(m.updateTop+1, last)
+	case "up", "k":
+		m.updateTop = max(m.updateTop-1, 0)
+	case "pgdown", "space":
+		m.updateTop = min(m.updateTop+h, last)
+	case "pgup":
+		m.updateTop = max(m.updateTop-h, 0)
+	case "enter", "y":
+		err := m.cache.ApplyUpdate(translation)
+		m.closeOverlay(overlayUpdate + translation)
+		if err != nil {
+			m.err = err
+			return m, nil, true
+		}
+		cmds := []tea.Cmd{m.flash(locale.Tf("updated %s", translation)), loadCachedList(m.cache)}
+		if translation == m.selectedTranslation {
+			m.loading = true
+			cmds = append(cmds, loadChapter(m.client, m.gen.nextContent(), m.selectedTranslation, m.currentBook, m.currentChapter))
+		}
+		return m, tea.Batch(cmds...), true
+	case "n":
+		m.closeOverlay(overlayUpdate + translation)
+	case "esc":
+		return m, nil, false
+	}
+	// Everything else is swallowed while the popup has the focus.
+	return m, nil, true
+}
+
+// updateSummary counts the chapters and books that changed and the
+// verses reworded.
+func updateSummary(changes []cache.ChapterChange) string {
+	books := map[int]bool{}
+	verses := 0
+	for _, c := range changes {
+		books[c.Book] = true
+		verses += c.Changed
+	}
+	return fmt.Sprintf(locale.T("%d chapters in %d books differ, %d verses reworded"), len(changes), len(books), verses)
+}
+
+// chapterChange describes how one chapter changed: "3", or "3 (new)",
+// "3 (gone)", "3 (25→26 verses)".
+func chapterChange(c cache.ChapterChange) string {
+	switch {
+	case c.Before == 0:
+		return fmt.Sprintf(locale.T("%d (new)"), c.Chapter)
+	case c.After == 0:
+		return fmt.Sprintf(locale.T("%d (gone)"), c.Chapter)
+	case c.Before != c.After:
+		return fmt.Sprintf(locale.T("%d (%d→%d verses)"), c.Chapter, c.Before, c.After)
+	}
+	return fmt.Sprint(c.Chapter)
+}
+
+// updateLines renders the changes width cells wide, a book to a line.
+func (m Model) updateLines(changes []cache.ChapterChange, width int) []string {
+	bg := m.currentTheme.Background
+	bookStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Success).Background(bg).Bold(m.styled())
+	textStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Primary).Background(bg)
+	pad := lipgloss.NewStyle().Background(bg).Width(width)
+
+	var lines []string
+	for i := 0; i < len(changes); {
+		book := changes[i].Book
+		var chapters []string
+		for ; i < len(changes) && changes[i].Book == book; i++ {
+			chapters = append(chapters, chapterChange(changes[i]))
+		}
+		name := canonicalName(book)
+		if name == "" {
+			name = fmt.Sprintf(locale.T("Book %d"), book)
+		}
+		wrapped := strings.Split(wrapTextWithIndent(name+" "+strings.Join(chapters, ", "), width, 2), "\n")
+		for j, l := range wrapped {
+			if j == 0 {
+				l = bookStyle.Render(name) + textStyle.Render(strings.TrimPrefix(l, name))
+			} else {
+				l = textStyle.Render(l)
+			}
+			lines = append(lines, pad.Render(l))
+		}
+	}
+	return lines
+}
+
+func (m Model) renderUpdate(translation string, changes []cache.ChapterChange) string {
+	bg := m.currentTheme.Background
+	w, h := m.popupSize()
+	_, padX := m.panelPadding()
+	h = max(h-2, 1) // the summary and its gap
+
+	containerStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.currentTheme.BorderActive).
+		BorderBackground(bg).
+		Background(bg).
+		Width(w + 2 + 2*padX).
+		Padding(m.panelPadding())
+	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(m.styled())
+	textStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Primary).Background(bg)
+	mutedStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(m.styled())
+
+	lines := m.updateLines(changes, w)
+	top := min(m.updateTop, max(len(lines)-h, 0))
+	shown := lines[top:min(top+h, len(lines))]
+
+	hint := locale.T("⏎ replace  ·  esc keep old")
+	if len(lines) > h {
+		hint = "j/k scroll  ·  " + hint
+	}
+	body := titleStyle.Render(locale.T("Update to")+" "+translation) + m.panelTitleGap() +
+		textStyle.Render(clipText(updateSummary(changes), w)) + "\n\n" +
+		strings.Join(shown, "\n") + "\n\n" + mutedStyle.Render(hint)
+	return containerStyle.Render(body)
+}
@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"strings"
+
+	"sword-tui/internal/locale"
+	"sword-tui/internal/settings"
+	"sword-tui/internal/version"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// noticeUpgrade shows what's new, once, when the version differs from
+// the one that last ran. A first run has nothing to compare with, unless
+// settings were saved by a version from before LastVersion existed.
+func (m *Model) noticeUpgrade(haveSettings bool) {
+	prev := m.cfg.LastVersion
+	if prev == version.Version {
+		return
+	}
+	m.cfg.LastVersion = version.Version
+	if prev == "" && !haveSettings {
+		return
+	}
+	if notes := version.Since(prev); notes != "" {
+		m.showWhatsNew(notes)
+		_ = settings.Save(m.cfg)
+	}
+}
+
+// showWhatsNew opens the changelog popup on notes, Markdown from
+// CHANGELOG.md.
+func (m *Model) showWhatsNew(notes string) {
+	m.whatsNew = notes
+	m.whatsNewTop = 0
+	m.pushOverlay(overlay{
+		name:  overlayWhatsNew,
+		place: placeCenter,
+		dim:   true,
+		view:  Model.renderWhatsNew,
+		key:   Model.updateWhatsNew,
+	})
+}
+
+// whatsNewLines renders the notes width cells wide.
+func (m Model) whatsNewLines(width int) []string {
+	bg := m.currentTheme.Background
+	headingStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Success).Background(bg).Bold(m.styled())
+	textStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Primary).Background(bg)
+	pad := lipgloss.NewStyle().Background(bg).Width(width)
+
+	// Join each bullet's continuation lines so it can be rewrapped.
+	var items []string
+	for _, line := range strings.Split(m.whatsNew, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			items = append(items, "")
+		case strings.HasPrefix(line, "## "), strings.HasPrefix(trimmed, "- "):
+			items = append(items, trimmed)
+		case len(items) > 0 && items[len(items)-1] != "":
+			items[len(items)-1] += " " + trimmed
+		default:
+			items = append(items, trimmed)
+		}
+	}
+
+	var lines []string
+	for _, it := range items {
+		it = strings.ReplaceAll(it, "`", "")
+		switch {
+		case strings.HasPrefix(it, "## "):
+			lines = append(lines, pad.Render(headingStyle.Render(strings.TrimPrefix(it, "## "))))
+		case strings.HasPrefix(it, "- "):
+			wrapped := wrapTextWithIndent("• "+strings.TrimPrefix(it, "- "), width, 2)
+			for _, l := range strings.Split(wrapped, "\n") {
+				lines = append(lines, pad.Render(textStyle.Render(l)))
+			}
+		default:
+			for _, l := range strings.Split(wrapText(it, width), "\n") {
+				lines = append(lines, pad.Render(textStyle.Render(l)))
+			}
+		}
+	}
+	return lines
+}
+
+// popupSize returns the inner width of a text popup such as what's new
+// and how many lines of text fit in it.
+func (m Model) popupSize() (int, int) {
+	padY, padX := m.panelPadding()
+	width := min(max(m.width-leftPaneOuterWidth-6, 40), 64)
+	// Border, padding, the title with its gap and the hint line.
+	chrome := 2 + 2*padY + strings.Count(m.panelTitleGap(), "\n") + 2
+	height := max(m.height-headerOuterHeight-statusOuterHeight-4-chrome, 3)
+	return width - 2 - 2*padX, height
+}
+
+func (m Model) updateWhatsNew(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	w, h := m.popupSize()
+	last := max(len(m.whatsNewLines(w))-h, 0)
+	switch msg.String() {
+	case "down", "j":
+		m.whatsNewTop = min(m.whatsNewTop+1, last)
+	case "up", "k":
+		m.whatsNewTop = max(m.whatsNewTop-1, 0)
+	case "pgdown", "space":
+		m.whatsNewTop = min(m.whatsNewTop+h, last)
+	case "pgup":
+		m.whatsNewTop = max(m.whatsNewTop-h, 0)
+	case "enter", "q":
+		m.closeOverlay(overlayWhatsNew)
+	case "esc":
+		return m, nil, false
+	}
+	// Everything else is swallowed while the popup has the focus.
+	return m, nil, true
+}
+
+func (m Model) renderWhatsNew() string {
+	bg := m.currentTheme.Background
+	w, h := m.popupSize()
+	_, padX := m.panelPadding()
+
+	containerStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.currentTheme.BorderActive).
+		BorderBackground(bg).
+		Background(bg).
+		Width(w + 2 + 2*padX).
+		Padding(m.panelPadding())
+	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(m.styled())
+	mutedStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(m.styled())
+
+	lines := m.whatsNewLines(w)
+	top := min(m.whatsNewTop, max(len(lines)-h, 0))
+	shown := lines[top:min(top+h, len(lines))]
+
+	hint := "⏎ close"
+	if len(lines) > h {
+		hint = "j/k scroll  ·  " + hint
+	}
+	body := titleStyle.Render(locale.T("What's new in sword-tui")) + m.panelTitleGap() +
+		strings.Join(shown, "\n") + "\n\n" + mutedStyle.Render(hint)
+	return containerStyle.Render(body)
+}
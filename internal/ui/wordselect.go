@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"sword-tui/internal/locale"
+
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// Word selection narrows the highlighted verse down to a phrase. h/l move
+// the cursor end of the selection a word at a time, o swaps which end
+// moves (as in vim's visual mode), and y copies the phrase with its
+// reference.
+
+// selectedVerseWords returns the words of the highlighted verse, split the
+// same way wrapTextWithIndent splits them.
+func (m Model) selectedVerseWords() []string {
+	for _, v := range m.currentVerses {
+		if v.Verse == m.highlightedVerseStart {
+			return strings.Fields(stripHTMLTags(v.Text))
+		}
+	}
+	return nil
+}
+
+// wordSelection is the selected word range, inclusive and in order.
+func (m Model) wordSelection() (int, int) {
+	lo, hi := m.wordAnchor, m.wordCursor
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	return lo, hi
+}
+
+// startWordSelect enters word selection on the first word of the
+// highlighted verse, narrowing a highlighted range to its first verse.
+func (m *Model) startWordSelect() {
+	if m.highlightedVerseStart == 0 || len(m.selectedVerseWords()) == 0 {
+		return
+	}
+	m.highlightedVerseEnd = m.highlightedVerseStart
+	m.wordSelect = true
+	m.wordAnchor, m.wordCursor = 0, 0
+	m.refreshContent()
+}
+
+// updateWordSelect handles keys while selecting words.
+func (m Model) updateWordSelect(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	n := len(m.selectedVerseWords())
+	switch msg.String() {
+	case "esc", "e":
+		m.wordSelect = false
+	case "left", "h":
+		if m.wordCursor > 0 {
+			m.wordCursor--
+		}
+	case "right", "l":
+		if m.wordCursor < n-1 {
+			m.wordCursor++
+		}
+	case "0", "home":
+		m.wordCursor = 0
+	case "$", "end":
+		m.wordCursor = n - 1
+	case "o":
+		m.wordAnchor, m.wordCursor = m.wordCursor, m.wordAnchor
+	case "y":
+		m.wordSelect = false
+		m.refreshContent()
+		phrase, ref := m.selectedPhrase()
+		if phrase == "" {
+			return m, nil
+		}
+		if n := m.copyYank(fmt.Sprintf("“%s” — %s (%s)", phrase, ref, m.translationName(m.selectedTranslation))); m.yankAppend {
+			return m, m.yankAppended(n)
+		}
+		return m, m.flash(locale.Tf("copied phrase from %s", ref))
+	default:
+		return m, nil
+	}
+	m.refreshContent()
+	return m, nil
+}
+
+// selectedPhrase returns the selected words and the verse reference.
+func (m Model) selectedPhrase() (string, string) {
+	words := m.selectedVerseWords()
+	lo, hi := m.wordSelection()
+	if hi >= len(words) {
+		return "", ""
+	}
+	ref := fmt.Sprintf("%s %d:%d", m.currentBookName, m.currentChapter, m.highlightedVerseStart)
+	return strings.Join(words[lo:hi+1], " "), ref
+}
+
+// refreshContent re-lays out the current chapter after a display change.
+func (m *Model) refreshContent() {
+	if m.currentVerses == nil {
+		return
+	}
+	m.renders.reset()
+	m.content, m.verseOffsets = m.layoutChapter(m.viewport.Width())
+	m.viewport.SetContent(m.content)
+}
+
+// renderWordSelection styles text already wrapped by wrapTextWithIndent,
+// picking out the selected words, and pads each line to width. It is
+// used in place of base.Width(width).Render(wrapped) so the line breaks
+// stay exactly where they were.
+func (m Model) renderWordSelection(wrapped string, width int, base lipgloss.Style) string {
+	sel := lipgloss.NewStyle().
+		Foreground(m.currentTheme.Background).
+		Background(m.currentTheme.Accent).
+		Bold(m.styled())
+	lo, hi := m.wordSelection()
+	inSel := func(i int) bool { return i >= lo && i <= hi }
+
+	idx := 0
+	lines := strings.Split(wrapped, "\n")
+	for i, line := range lines {
+		words := strings.TrimLeft(line, " ")
+		var sb strings.Builder
+		sb.WriteString(base.Render(line[:len(line)-len(words)]))
+		for j, w := range strings.Fields(words) {
+			if j > 0 {
+				space := base
+				if inSel(idx-1) && inSel(idx) {
+					space = sel
+				}
+				sb.WriteString(space.Render(" "))
+			}
+			style := base
+			if inSel(idx) {
+				style = sel
+			}
+			sb.WriteString(style.Render(w))
+			idx++
+		}
+		if pad := width - lipgloss.Width(sb.String()); pad > 0 {
+			sb.WriteString(base.Render(strings.Repeat(" ", pad)))
+		}
+		lines[i] = sb.String()
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,250 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"sword-tui/internal/locale"
+	"sword-tui/internal/workspace"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// workspaceWindow is how many pinned passages the workspace panel lists
+// at once.
+const workspaceWindow = 10
+
+// pinToWorkspace adds the highlighted verse range (or the whole chapter
+// when nothing is highlighted) to the study workspace.
+func (m *Model) pinToWorkspace() tea.Cmd {
+	if m.currentVerses == nil || m.workspace == nil {
+		return nil
+	}
+	p := workspace.Passage{
+		Translation: m.selectedTranslation,
+		Book:        m.currentBook,
+		BookName:    m.currentBookName,
+		Chapter:     m.currentChapter,
+		VerseStart:  m.highlightedVerseStart,
+		VerseEnd:    m.highlightedVerseEnd,
+	}
+	for _, v := range m.currentVerses {
+		if p.VerseStart > 0 && (v.Verse < p.VerseStart || v.Verse > p.VerseEnd) {
+			continue
+		}
+		p.Verses = append(p.Verses, workspace.Verse{Number: v.Verse, Text: stripHTMLTags(v.Text)})
+	}
+	m.workspace.Add(p)
+	if err := m.workspace.Save(); err != nil {
+		m.err = err
+		return nil
+	}
+	return m.flash(locale.Tf("📌 pinned %s (%d in workspace)", p.Reference(), len(m.workspace.Passages)))
+}
+
+// updateWorkspace handles keys while the workspace panel is open.
+func (m Model) updateWorkspace(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	ws := m.workspace
+	n := len(ws.Passages)
+
+	if m.workspaceEditing {
+		switch msg.String() {
+		case "enter":
+			if m.workspaceSelected < n {
+				ws.Passages[m.workspaceSelected].Note = m.workspaceNoteInput.Value()
+				if err := ws.Save(); err != nil {
+					m.err = err
+				}
+			}
+			m.workspaceEditing = false
+			m.workspaceNoteInput.Blur()
+			return m, nil
+		case "esc":
+			m.workspaceEditing = false
+			m.workspaceNoteInput.Blur()
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.workspaceNoteInput, cmd = m.workspaceNoteInput.Update(msg)
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "esc", "W", "q":
+		m.mode = modeReader
+	case "up", "k":
+		if m.workspaceSelected > 0 {
+			m.workspaceSelected--
+		}
+	case "down", "j":
+		if m.workspaceSelected < n-1 {
+			m.workspaceSelected++
+		}
+	case "K", "shift+up":
+		if n > 0 {
+			m.workspaceSelected = ws.Move(m.workspaceSelected, -1)
+			return m, m.saveWorkspace()
+		}
+	case "J", "shift+down":
+		if n > 0 {
+			m.workspaceSelected = ws.Move(m.workspaceSelected, +1)
+			return m, m.saveWorkspace()
+		}
+	case "x":
+		if n > 0 {
+			ws.Remove(m.workspaceSelected)
+			if m.workspaceSelected >= len(ws.Passages) && m.workspaceSelected > 0 {
+				m.workspaceSelected--
+			}
+			return m, m.saveWorkspace()
+		}
+	case "e":
+		if m.workspaceSelected < n {
+			m.workspaceNoteInput.SetValue(ws.Passages[m.workspaceSelected].Note)
+			m.workspaceNoteInput.CursorEnd()
+			m.workspaceEditing = true
+			return m, m.workspaceNoteInput.Focus()
+		}
+	case "m":
+		if n == 0 {
+			return m, m.flash("workspace is empty")
+		}
+		dir, err := os.Getwd()
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		out, err := ws.Export(dir, m.verseNumbers)
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		return m, m.flash(locale.Tf("exported %s", out))
+	case "enter":
+		if m.workspaceSelected < n {
+			p := ws.Passages[m.workspaceSelected]
+			m.mode = modeReader
+			m.currentBook = p.Book
+			m.currentBookName = p.BookName
+			m.currentChapter = p.Chapter
+			m.highlightedVerseStart = p.VerseStart
+			m.highlightedVerseEnd = p.VerseEnd
+			m.loading = true
+			// Open it in the translation it was pinned from.
+			if p.Translation != "" && p.Translation != m.selectedTranslation {
+				m.selectedTranslation = p.Translation
+				return m, tea.Batch(
+					loadBooks(m.client, m.gen.nextBooks(), m.selectedTranslation),
+					loadChapter(m.client, m.gen.nextContent(), m.selectedTranslation, m.currentBook, m.currentChapter),
+				)
+			}
+			return m, loadChapter(m.client, m.gen.nextContent(), m.selectedTranslation, m.currentBook, m.currentChapter)
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) saveWorkspace() tea.Cmd {
+	if err := m.workspace.Save(); err != nil {
+		m.err = err
+	}
+	return nil
+}
+
+func (m Model) renderWorkspace() string {
+	bg := m.currentTheme.Background
+
+	maxAvail := m.width - leftPaneOuterWidth - 8
+	width := maxAvail
+	if width > 90 {
+		width = 90
+	}
+	if width < 40 {
+		width = 40
+	}
+	innerW := width - 6
+
+	containerStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.currentTheme.BorderActive).
+		BorderBackground(bg).
+		Background(bg).
+		Width(width).
+		Padding(m.panelPadding())
+
+	titleStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Accent).Background(bg).Bold(m.styled())
+	selectedStyle := lipgloss.NewStyle().Foreground(bg).Background(m.currentTheme.Accent).Bold(m.styled())
+	normalStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Primary).Background(bg)
+	mutedStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Muted).Background(bg).Italic(m.styled())
+	verseNumStyle := lipgloss.NewStyle().Foreground(m.currentTheme.Warning).Background(bg).Bold(m.styled())
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(locale.T("Workspace")) + m.panelTitleGap())
+
+	passages := m.workspace.Passages
+	if len(passages) == 0 {
+		content.WriteString(mutedStyle.Render(locale.T("Nothing pinned yet — press w in the reader to pin a passage.")))
+		return containerStyle.Render(content.String())
+	}
+
+	start := m.overlayWindowStart(m.workspaceSelected, len(passages), workspaceWindow)
+	end := start + workspaceWindow
+	if end > len(passages) {
+		end = len(passages)
+	}
+	if start > 0 {
+		content.WriteString(mutedStyle.Render(fmt.Sprintf("  ↑ %d more", start)) + "\n")
+	}
+	for i := start; i < end; i++ {
+		p := passages[i]
+		label := fmt.Sprintf("%d. %s (%s)", i+1, p.Reference(), p.Translation)
+		if p.Note != "" {
+			label += "  ✎"
+		}
+		label = ansi.Truncate(label, innerW-2, "…")
+		if i == m.workspaceSelected {
+			line := "▸ " + label
+			if w := lipgloss.Width(line); w < innerW {
+				line += strings.Repeat(" ", innerW-w)
+			}
+			content.WriteString(selectedStyle.Render(line) + "\n")
+		} else {
+			content.WriteString(normalStyle.Render("  "+label) + "\n")
+		}
+	}
+	if end < len(passages) {
+		content.WriteString(mutedStyle.Render(fmt.Sprintf("  ↓ %d more", len(passages)-end)) + "\n")
+	}
+
+	// Detail of the selected passage: a few lines of text plus its note.
+	if m.workspaceSelected < len(passages) {
+		p := passages[m.workspaceSelected]
+		content.WriteString("\n")
+		shown := 0
+		for _, v := range p.Verses {
+			if shown == 3 {
+				content.WriteString(mutedStyle.Render(fmt.Sprintf("… %d more verses", len(p.Verses)-shown)) + "\n")
+				break
+			}
+			text := wrapTextWithIndent(v.Text, innerW-5, 5)
+			content.WriteString(verseNumStyle.Render(fmt.Sprintf("%4d ", v.Number)) + normalStyle.Render(text) + "\n")
+			shown++
+		}
+		content.WriteString("\n")
+		if m.workspaceEditing {
+			ti := m.workspaceNoteInput
+			ti.SetStyles(m.themedInputStyles())
+			ti.SetWidth(innerW - 2)
+			content.WriteString(ti.View())
+		} else if p.Note != "" {
+			content.WriteString(normalStyle.Render(wrapText("✎ "+p.Note, innerW)))
+		} else {
+			content.WriteString(mutedStyle.Render("no note — press e to add one"))
+		}
+	}
+
+	return containerStyle.Render(content.String())
+}
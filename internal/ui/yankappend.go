@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"strings"
+
+	"sword-tui/internal/locale"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// + turns on append mode: each yank, of verses or of a phrase, is added
+// to what the earlier ones put on the clipboard instead of replacing it,
+// so several passages can be gathered with their references and pasted
+// together. + again turns it off and forgets them.
+
+// toggleYankAppend turns append mode on or off.
+func (m *Model) toggleYankAppend() tea.Cmd {
+	m.yankAppend = !m.yankAppend
+	m.yanked = nil
+	if m.yankAppend {
+		return m.flash("appending yanks — + again to stop")
+	}
+	return m.flash("yanks replace the clipboard again")
+}
+
+// copyYank puts text on the clipboard, after the earlier yanks in append
+// mode. It returns how many passages the clipboard holds.
+func (m *Model) copyYank(text string) int {
+	if !m.yankAppend {
+		writeClipboard(text)
+		return 1
+	}
+	m.yanked = append(m.yanked, strings.TrimRight(text, "\n"))
+	writeClipboard(strings.Join(m.yanked, "\n\n") + "\n")
+	return len(m.yanked)
+}
+
+// yankAppended says how many passages append mode has gathered.
+func (m *Model) yankAppended(n int) tea.Cmd {
+	if n == 1 {
+		return m.flash("appended · 1 passage on the clipboard")
+	}
+	return m.flash(locale.Tf("appended · %d passages on the clipboard", n))
+}
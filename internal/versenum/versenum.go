@@ -0,0 +1,73 @@
+// Package versenum formats verse numbers in the styles offered by the
+// "verse_numbers" setting.
+package versenum
+
+import (
+	"strconv"
+	"strings"
+)
+
+type Style string
+
+const (
+	Plain       Style = "plain"
+	Superscript Style = "superscript"
+	Brackets    Style = "brackets"
+	Dimmed      Style = "dimmed"
+	Hidden      Style = "hidden"
+)
+
+// Styles lists every style in the order the reader cycles through them.
+var Styles = []Style{Plain, Superscript, Brackets, Dimmed, Hidden}
+
+// Parse returns the named style, or Plain for "" and unknown names.
+func Parse(name string) Style {
+	for _, s := range Styles {
+		if string(s) == name {
+			return s
+		}
+	}
+	return Plain
+}
+
+// Next is the style after s in Styles, wrapping around.
+func (s Style) Next() Style {
+	for i, o := range Styles {
+		if o == s {
+			return Styles[(i+1)%len(Styles)]
+		}
+	}
+	return Plain
+}
+
+var superscripts = strings.NewReplacer(
+	"0", "⁰", "1", "¹", "2", "²", "3", "³", "4", "⁴",
+	"5", "⁵", "6", "⁶", "7", "⁷", "8", "⁸", "9", "⁹",
+)
+
+// Label is the verse number as shown in the reader: "16", "¹⁶", "[16]",
+// or "" when hidden. Dimmed differs from Plain only in color.
+func (s Style) Label(n int) string {
+	num := strconv.Itoa(n)
+	switch s {
+	case Superscript:
+		return superscripts.Replace(num)
+	case Brackets:
+		return "[" + num + "]"
+	case Hidden:
+		return ""
+	}
+	return num
+}
+
+// Prefix is what precedes a verse's text in copied or exported text. Plain
+// and Dimmed use the format's own plain prefix (such as "16. ").
+func (s Style) Prefix(n int, plain string) string {
+	switch s {
+	case Plain, Dimmed:
+		return plain
+	case Hidden:
+		return ""
+	}
+	return s.Label(n) + " "
+}
@@ -0,0 +1,34 @@
+package version
+
+import (
+	_ "embed"
+	"strings"
+)
+
+// Changelog is the bundled CHANGELOG.md.
+//
+//go:embed CHANGELOG.md
+var Changelog string
+
+// Since returns the changelog entries for the releases after prev, newest
+// first, as the Markdown they are written in. It returns the whole
+// changelog when prev isn't listed, and "" when prev is this version.
+func Since(prev string) string {
+	if prev == Version {
+		return ""
+	}
+	var out []string
+	in := false
+	for _, line := range strings.Split(Changelog, "\n") {
+		if heading, ok := strings.CutPrefix(line, "## "); ok {
+			if strings.TrimSpace(heading) == prev {
+				break
+			}
+			in = true
+		}
+		if in {
+			out = append(out, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
@@ -1,7 +1,15 @@
 package version
 
+import "strings"
+
 // Version is the current version of sword-tui
 const Version = "v2.0.0"
 
 // BuildNumber is set during compilation via -ldflags
 var BuildNumber = "dev"
+
+// UserAgent is what sword-tui calls itself in HTTP requests, so the
+// services it talks to can tell its traffic apart and know where to turn.
+func UserAgent() string {
+	return "sword-tui/" + strings.TrimPrefix(Version, "v") + " (build " + BuildNumber + "; +https://github.com/kmf/sword-tui)"
+}
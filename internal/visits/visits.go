@@ -0,0 +1,180 @@
+// Package visits counts how often each chapter is opened, and keeps a
+// daily log of chapters read and time spent reading, per reader (see
+// settings.ReaderDir). The activity overlay and weekly report are built
+// from it.
+package visits
+
+import (
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"sword-tui/internal/jsonfile"
+	"sword-tui/internal/settings"
+)
+
+// Visit is how often a chapter was opened, and when last. Verse and Top
+// are where the reader was when leaving it: the highlighted verse and
+// the verse at the top of the screen.
+type Visit struct {
+	Book    int       `json:"book"`
+	Chapter int       `json:"chapter"`
+	Count   int       `json:"count"`
+	Last    time.Time `json:"last"`
+	Verse   int       `json:"verse,omitempty"`
+	Top     int       `json:"top,omitempty"`
+}
+
+// Day is the reading done on one date.
+type Day struct {
+	Date     string   `json:"date"`     // local date, YYYY-MM-DD
+	Chapters [][2]int `json:"chapters"` // book, chapter; first visit order
+	Seconds  int      `json:"seconds"`  // time spent reading
+}
+
+// dateLayout is how Day.Date is written.
+const dateLayout = "2006-01-02"
+
+// Store holds a visit per chapter in canonical order, and the daily log
+// oldest first.
+type Store struct {
+	Chapters []Visit `json:"chapters"`
+	Days     []Day   `json:"days,omitempty"`
+
+	reader string // whose visits these are
+	file   jsonfile.File
+}
+
+func path(reader string) (string, error) {
+	dir, err := settings.ReaderDir(reader)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "visits.json"), nil
+}
+
+// Load reads a reader's saved visits. A missing file is an empty store;
+// one that can't be read is an empty store that won't be saved.
+func Load(reader string) (*Store, error) {
+	s := &Store{reader: reader}
+	p, err := path(reader)
+	if err != nil {
+		return s, err
+	}
+	if s.file, err = jsonfile.Load(p, s); err != nil {
+		return &Store{reader: reader, file: s.file}, err
+	}
+	return s, nil
+}
+
+func (s *Store) Save() error {
+	p, err := path(s.reader)
+	if err != nil {
+		return err
+	}
+	return s.file.Save(p, s)
+}
+
+// day returns the log entry for at's date, adding it if needed.
+func (s *Store) day(at time.Time) *Day {
+	date := at.Format(dateLayout)
+	i := sort.Search(len(s.Days), func(i int) bool { return s.Days[i].Date >= date })
+	if i == len(s.Days) || s.Days[i].Date != date {
+		s.Days = slices.Insert(s.Days, i, Day{Date: date})
+	}
+	return &s.Days[i]
+}
+
+// Record counts a visit to a chapter and logs it as read that day.
+func (s *Store) Record(book, chapter int, at time.Time) {
+	d := s.day(at)
+	logged := false
+	for _, c := range d.Chapters {
+		if c == [2]int{book, chapter} {
+			logged = true
+			break
+		}
+	}
+	if !logged {
+		d.Chapters = append(d.Chapters, [2]int{book, chapter})
+	}
+
+	for i := range s.Chapters {
+		if v := &s.Chapters[i]; v.Book == book && v.Chapter == chapter {
+			v.Count++
+			v.Last = at
+			return
+		}
+	}
+	s.Chapters = append(s.Chapters, Visit{Book: book, Chapter: chapter, Count: 1, Last: at})
+	sort.SliceStable(s.Chapters, func(i, j int) bool {
+		a, b := s.Chapters[i], s.Chapters[j]
+		if a.Book != b.Book {
+			return a.Book < b.Book
+		}
+		return a.Chapter < b.Chapter
+	})
+}
+
+// SetPosition remembers where the reader is in a visited chapter.
+func (s *Store) SetPosition(book, chapter, verse, top int) {
+	for i := range s.Chapters {
+		if v := &s.Chapters[i]; v.Book == book && v.Chapter == chapter {
+			v.Verse, v.Top = verse, top
+			return
+		}
+	}
+}
+
+// Position returns where the reader was in a chapter, or zeros.
+func (s *Store) Position(book, chapter int) (verse, top int) {
+	for _, v := range s.Chapters {
+		if v.Book == book && v.Chapter == chapter {
+			return v.Verse, v.Top
+		}
+	}
+	return 0, 0
+}
+
+// AddReading adds time spent reading to the log for at's date.
+func (s *Store) AddReading(d time.Duration, at time.Time) {
+	if d <= 0 {
+		return
+	}
+	s.day(at).Seconds += int(d / time.Second)
+}
+
+// Between returns the logged days from from up to, but not including, to.
+func (s *Store) Between(from, to time.Time) []Day {
+	lo, hi := from.Format(dateLayout), to.Format(dateLayout)
+	var out []Day
+	for _, d := range s.Days {
+		if d.Date >= lo && d.Date < hi {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// Streak counts the days in a row, up to now's date, on which chapters
+// were read. A day with nothing read yet doesn't break it until the day
+// is over.
+func (s *Store) Streak(now time.Time) int {
+	read := map[string]bool{}
+	for _, d := range s.Days {
+		if len(d.Chapters) > 0 {
+			read[d.Date] = true
+		}
+	}
+	day := now
+	if !read[day.Format(dateLayout)] {
+		day = day.AddDate(0, 0, -1)
+	}
+	n := 0
+	for read[day.Format(dateLayout)] {
+		n++
+		day = day.AddDate(0, 0, -1)
+	}
+	return n
+}
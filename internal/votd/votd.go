@@ -0,0 +1,48 @@
+// Package votd picks the verse of the day from a bundled list of
+// well-loved verses, the same one all day and on every machine.
+package votd
+
+import (
+	"time"
+
+	"sword-tui/internal/api"
+	"sword-tui/internal/reference"
+)
+
+// verses are the references the verse of the day cycles through.
+var verses = []string{
+	"Genesis 1:1", "Joshua 1:9", "Numbers 6:24-26", "Deuteronomy 31:8",
+	"1 Samuel 16:7", "Nehemiah 8:10", "Job 19:25", "Psalms 1:1-2",
+	"Psalms 16:11", "Psalms 19:14", "Psalms 23:1", "Psalms 27:1",
+	"Psalms 34:8", "Psalms 37:4", "Psalms 46:10", "Psalms 51:10",
+	"Psalms 90:12", "Psalms 103:2", "Psalms 118:24", "Psalms 119:105",
+	"Psalms 121:1-2", "Psalms 139:14", "Proverbs 3:5-6", "Proverbs 16:3",
+	"Ecclesiastes 3:1", "Isaiah 26:3", "Isaiah 40:31", "Isaiah 41:10",
+	"Isaiah 53:5", "Jeremiah 29:11", "Lamentations 3:22-23", "Micah 6:8",
+	"Zephaniah 3:17", "Matthew 5:14", "Matthew 6:33", "Matthew 11:28",
+	"Matthew 28:20", "Mark 10:27", "Luke 1:37", "John 1:14",
+	"John 3:16", "John 8:12", "John 11:25", "John 14:6",
+	"John 15:5", "John 16:33", "Acts 1:8", "Romans 5:8",
+	"Romans 8:28", "Romans 12:2", "Romans 15:13", "1 Corinthians 13:4",
+	"2 Corinthians 5:17", "2 Corinthians 12:9", "Galatians 2:20", "Galatians 5:22-23",
+	"Ephesians 2:8", "Ephesians 3:20", "Philippians 4:6", "Philippians 4:13",
+	"Colossians 3:23", "1 Thessalonians 5:16-18", "2 Timothy 1:7", "Hebrews 4:12",
+	"Hebrews 11:1", "Hebrews 12:1", "James 1:5", "1 Peter 5:7",
+	"1 John 1:9", "1 John 4:19", "Revelation 3:20", "Revelation 21:4",
+}
+
+// Verse is a passage of the day.
+type Verse struct {
+	Ref                  string // e.g. "John 3:16"
+	Book, Chapter        int
+	VerseStart, VerseEnd int
+}
+
+// For returns the verse of the day of t's date.
+func For(t time.Time) Verse {
+	y, m, d := t.Date()
+	days := time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / (24 * 60 * 60)
+	ref := verses[days%int64(len(verses))]
+	book, chapter, start, end, _ := reference.Parse(ref, api.CanonicalBooks())
+	return Verse{Ref: ref, Book: book, Chapter: chapter, VerseStart: start, VerseEnd: max(end, start)}
+}
@@ -0,0 +1,139 @@
+// Package wallpaper draws a verse onto a desktop background: the quote
+// centered in the theme's text color on its background, with the
+// citation under it in the accent color, in the Go fonts.
+package wallpaper
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+
+	"sword-tui/internal/theme"
+)
+
+// Options say what to draw and how big.
+type Options struct {
+	Width, Height int
+	Theme         theme.Theme
+	Quote         string // the verse text
+	Citation      string // e.g. "John 3:16 (KJV)"
+}
+
+// The quote fills at most this much of the width and height; the rest
+// is margin.
+const (
+	textWidth  = 0.7
+	textHeight = 0.6
+)
+
+// Render draws the wallpaper and writes it to w as a PNG.
+func Render(w io.Writer, o Options) error {
+	regular, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		return err
+	}
+	bold, err := opentype.Parse(gobold.TTF)
+	if err != nil {
+		return err
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, o.Width, o.Height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(orBlack(o.Theme.Background)), image.Point{}, draw.Src)
+
+	// The largest size at which the quote fits, from a twelfth of the
+	// height down.
+	maxW := int(float64(o.Width) * textWidth)
+	maxH := int(float64(o.Height) * textHeight)
+	var face font.Face
+	var lines []string
+	for size := float64(o.Height) / 12; ; size *= 0.9 {
+		if face != nil {
+			face.Close()
+		}
+		if face, err = opentype.NewFace(regular, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull}); err != nil {
+			return err
+		}
+		lines = wrap(face, o.Quote, maxW)
+		if len(lines)*lineHeight(face) <= maxH || size < 8 {
+			break
+		}
+	}
+	defer face.Close()
+	citeSize := float64(face.Metrics().Height.Ceil()) * 0.6
+	cite, err := opentype.NewFace(bold, &opentype.FaceOptions{Size: citeSize, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return err
+	}
+	defer cite.Close()
+
+	lh := lineHeight(face)
+	total := len(lines)*lh + lh/2 + lineHeight(cite)
+	y := (o.Height-total)/2 + face.Metrics().Ascent.Ceil()
+	text := image.NewUniform(orWhite(o.Theme.Primary))
+	for _, l := range lines {
+		drawCentered(img, face, text, l, y)
+		y += lh
+	}
+	// Half a line below the last one.
+	y += face.Metrics().Descent.Ceil() - lh + lh/2 + cite.Metrics().Ascent.Ceil()
+	drawCentered(img, cite, image.NewUniform(orWhite(o.Theme.Accent)), o.Citation, y)
+
+	return png.Encode(w, img)
+}
+
+// wrap breaks s into lines no wider than width in face.
+func wrap(face font.Face, s string, width int) []string {
+	var lines []string
+	cur := ""
+	for _, word := range strings.Fields(s) {
+		next := word
+		if cur != "" {
+			next = cur + " " + word
+		}
+		if cur != "" && font.MeasureString(face, next).Ceil() > width {
+			lines = append(lines, cur)
+			next = word
+		}
+		cur = next
+	}
+	if cur != "" {
+		lines = append(lines, cur)
+	}
+	return lines
+}
+
+func lineHeight(face font.Face) int {
+	return face.Metrics().Height.Ceil() * 5 / 4
+}
+
+// drawCentered draws s centered across img with its baseline at y.
+func drawCentered(img draw.Image, face font.Face, src image.Image, s string, y int) {
+	d := font.Drawer{Dst: img, Src: src, Face: face}
+	x := (img.Bounds().Dx() - d.MeasureString(s).Ceil()) / 2
+	d.Dot = fixed.P(x, y)
+	d.DrawString(s)
+}
+
+// Themes leave no color unset, but an image needs one regardless.
+func orBlack(c color.Color) color.Color {
+	if c == nil {
+		return color.Black
+	}
+	return c
+}
+
+func orWhite(c color.Color) color.Color {
+	if c == nil {
+		return color.White
+	}
+	return c
+}
@@ -0,0 +1,136 @@
+// Package workspace holds the passages a user has pinned for a study
+// session, together with their notes, and can export the lot as a single
+// Markdown document.
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"sword-tui/internal/jsonfile"
+	"sword-tui/internal/settings"
+	"sword-tui/internal/versenum"
+)
+
+// Passage is one pinned reference with the text captured at pin time.
+type Passage struct {
+	Translation string    `json:"translation"`
+	Book        int       `json:"book"`
+	BookName    string    `json:"book_name"`
+	Chapter     int       `json:"chapter"`
+	VerseStart  int       `json:"verse_start"` // 0 means the whole chapter
+	VerseEnd    int       `json:"verse_end"`
+	Verses      []Verse   `json:"verses"`
+	Note        string    `json:"note,omitempty"`
+	Added       time.Time `json:"added"`
+}
+
+type Verse struct {
+	Number int    `json:"number"`
+	Text   string `json:"text"`
+}
+
+// Reference renders the passage as "John 3:16-18".
+func (p Passage) Reference() string {
+	switch {
+	case p.VerseStart == 0:
+		return fmt.Sprintf("%s %d", p.BookName, p.Chapter)
+	case p.VerseStart == p.VerseEnd:
+		return fmt.Sprintf("%s %d:%d", p.BookName, p.Chapter, p.VerseStart)
+	default:
+		return fmt.Sprintf("%s %d:%d-%d", p.BookName, p.Chapter, p.VerseStart, p.VerseEnd)
+	}
+}
+
+type Workspace struct {
+	Passages []Passage `json:"passages"`
+
+	file jsonfile.File
+}
+
+func path() (string, error) {
+	dir, err := settings.AnnotationsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "workspace.json"), nil
+}
+
+// Load reads the saved workspace. A missing file is an empty workspace;
+// one that can't be read is an empty workspace that won't be saved.
+func Load() (*Workspace, error) {
+	ws := &Workspace{}
+	p, err := path()
+	if err != nil {
+		return ws, err
+	}
+	if ws.file, err = jsonfile.Load(p, ws); err != nil {
+		return &Workspace{file: ws.file}, err
+	}
+	return ws, nil
+}
+
+func (w *Workspace) Save() error {
+	p, err := path()
+	if err != nil {
+		return err
+	}
+	return w.file.Save(p, w)
+}
+
+func (w *Workspace) Add(p Passage) {
+	if p.Added.IsZero() {
+		p.Added = time.Now()
+	}
+	w.Passages = append(w.Passages, p)
+}
+
+func (w *Workspace) Remove(i int) {
+	if i < 0 || i >= len(w.Passages) {
+		return
+	}
+	w.Passages = append(w.Passages[:i], w.Passages[i+1:]...)
+}
+
+// Move shifts passage i by delta places and returns its new index.
+func (w *Workspace) Move(i, delta int) int {
+	j := i + delta
+	if i < 0 || i >= len(w.Passages) || j < 0 || j >= len(w.Passages) {
+		return i
+	}
+	w.Passages[i], w.Passages[j] = w.Passages[j], w.Passages[i]
+	return j
+}
+
+// Markdown renders the workspace as a single study document: one section
+// per passage with its text as a blockquote followed by the note. Verse
+// numbers are written in the given style.
+func (w *Workspace) Markdown(numbers versenum.Style) string {
+	var sb strings.Builder
+	sb.WriteString("# Study Workspace\n\n")
+	sb.WriteString(fmt.Sprintf("_Exported %s_\n", time.Now().Format("2006-01-02 15:04")))
+
+	for i, p := range w.Passages {
+		sb.WriteString(fmt.Sprintf("\n## %d. %s (%s)\n\n", i+1, p.Reference(), p.Translation))
+		for _, v := range p.Verses {
+			sb.WriteString("> " + numbers.Prefix(v.Number, fmt.Sprintf("**%d** ", v.Number)) + v.Text + "\n")
+		}
+		if strings.TrimSpace(p.Note) != "" {
+			sb.WriteString("\n" + strings.TrimSpace(p.Note) + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// Export writes the Markdown document into dir and returns its path.
+func (w *Workspace) Export(dir string, numbers versenum.Style) (string, error) {
+	name := fmt.Sprintf("workspace-%s.md", time.Now().Format("2006-01-02"))
+	out := filepath.Join(dir, name)
+	if err := os.WriteFile(out, []byte(w.Markdown(numbers)), 0o644); err != nil {
+		return "", err
+	}
+	return out, nil
+}